@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/cbergoon/merkletree"
 	"github.com/filefilego/filefilego/common/hexutil"
@@ -17,6 +18,13 @@ import (
 
 const maxBlockDataSizeBytes = 300000
 
+// SignatureDomainActivationHeight is the block height from which the verifiers sign the block hash prefixed by a domain tag.
+// the tag separates the block signatures from the other signatures of the verifier key.
+const SignatureDomainActivationHeight = ScheduleActivationHeight
+
+// blockSignatureDomain is the domain tag of the block signatures.
+var blockSignatureDomain = []byte("filefilego/block/v1")
+
 // Block represents a block.
 type Block struct {
 	Hash       []byte
@@ -28,6 +36,8 @@ type Block struct {
 	PreviousBlockHash []byte
 	Transactions      []transaction.Transaction
 	Number            uint64
+	// StateRoot is the merkle root of the address states and node items after the block is applied.
+	StateRoot []byte
 }
 
 // GetCoinbaseTransaction gets the coinbase transaction.
@@ -118,6 +128,7 @@ func (b Block) GetBlockHash() ([]byte, error) {
 			b.PreviousBlockHash,
 			b.MerkleHash,
 			blockNumberBytes,
+			b.StateRoot,
 		},
 		[]byte{},
 	)
@@ -126,6 +137,18 @@ func (b Block) GetBlockHash() ([]byte, error) {
 	return hash[:], nil
 }
 
+// SigningHash returns the digest of the block hash which is signed by the verifier.
+func (b Block) SigningHash() []byte {
+	if b.Number < SignatureDomainActivationHeight {
+		return b.Hash
+	}
+
+	data := make([]byte, 0, len(blockSignatureDomain)+len(b.Hash))
+	data = append(data, blockSignatureDomain...)
+	data = append(data, b.Hash...)
+	return ffgcrypto.Sha256(data)
+}
+
 // Sign signs a block with a private key.
 func (b *Block) Sign(key crypto.PrivKey) error {
 	blockMerkleHash, err := b.GetMerkleHash()
@@ -143,7 +166,7 @@ func (b *Block) Sign(key crypto.PrivKey) error {
 	b.Hash = make([]byte, len(hash))
 	copy(b.Hash, hash)
 
-	sig, err := key.Sign(b.Hash)
+	sig, err := key.Sign(b.SigningHash())
 	if err != nil {
 		return fmt.Errorf("failed to sign block: %w", err)
 	}
@@ -158,7 +181,7 @@ func (b *Block) Sign(key crypto.PrivKey) error {
 // The coinbase transaction always includes the public Key of the verifier.
 // This public key is used to verify the block.
 func (b Block) VerifyWithPublicKey(key crypto.PubKey) error {
-	ok, err := key.Verify(b.Hash, b.Signature)
+	ok, err := key.Verify(b.SigningHash(), b.Signature)
 	if err != nil {
 		return fmt.Errorf("failed to verify block: %w", err)
 	}
@@ -218,13 +241,22 @@ func (b Block) Validate() (bool, error) {
 		return false, errors.New("block is altered and doesn't match the hash")
 	}
 
+	if err := b.validateTimestamp(time.Now().Unix()); err != nil {
+		return false, err
+	}
+
 	verifierAddr, err := ffgcrypto.RawPublicToAddress(coinbase.PublicKey)
 	if err != nil {
 		return false, errors.New("failed to get verifier's address")
 	}
 
-	if !IsValidVerifier(verifierAddr) {
-		return false, errors.New("block was signed by a non-verifier")
+	isVerifier, err := IsValidVerifier(verifierAddr, b.Number)
+	if err != nil {
+		return false, err
+	}
+
+	if !isVerifier {
+		return false, ErrUnknownVerifier
 	}
 
 	return true, nil
@@ -266,6 +298,10 @@ func ToProtoBlock(block Block) *ProtoBlock {
 	copy(pblock.Signature, block.Signature)
 	copy(pblock.Data, block.Data)
 	copy(pblock.PreviousBlockHash, block.PreviousBlockHash)
+	if len(block.StateRoot) > 0 {
+		pblock.StateRoot = make([]byte, len(block.StateRoot))
+		copy(pblock.StateRoot, block.StateRoot)
+	}
 	for _, t := range block.Transactions {
 		pblock.Transactions = append(pblock.Transactions, transaction.ToProtoTransaction(t))
 	}
@@ -291,6 +327,10 @@ func ProtoBlockToBlock(pblock *ProtoBlock) Block {
 	copy(block.Signature, pblock.Signature)
 	copy(block.Data, pblock.Data)
 	copy(block.PreviousBlockHash, pblock.PreviousBlockHash)
+	if len(pblock.StateRoot) > 0 {
+		block.StateRoot = make([]byte, len(pblock.StateRoot))
+		copy(block.StateRoot, pblock.StateRoot)
+	}
 	for _, t := range pblock.Transactions {
 		block.Transactions = append(block.Transactions, transaction.ProtoTransactionToTransaction(t))
 	}
@@ -93,6 +93,16 @@ func TestSignAndVerifyBlock(t *testing.T) {
 	assert.NotEmpty(t, block.Signature)
 	err = block.VerifyWithPublicKey(keypair.PublicKey)
 	assert.NoError(t, err)
+	assert.Equal(t, block.Hash, block.SigningHash())
+
+	// from the activation height the signed hash is prefixed by the domain tag
+	block.Number = SignatureDomainActivationHeight
+	assert.NoError(t, block.Sign(keypair.PrivateKey))
+	assert.NotEqual(t, block.Hash, block.SigningHash())
+	assert.NoError(t, block.VerifyWithPublicKey(keypair.PublicKey))
+	ok, err := keypair.PublicKey.Verify(block.Hash, block.Signature)
+	assert.NoError(t, err)
+	assert.False(t, ok)
 
 	// sign an invalid block
 	block2, _ := validBlock(t)
@@ -304,6 +314,15 @@ func TestProtoBlockMethods(t *testing.T) {
 
 	derivedBlock := ProtoBlockToBlock(protoBlock)
 	assert.Equal(t, *block, derivedBlock)
+
+	// the state root is part of the block hash
+	hash := block.Hash
+	block.StateRoot = []byte{1, 2}
+	err = block.Sign(kp.PrivateKey)
+	assert.NoError(t, err)
+	assert.NotEqual(t, hash, block.Hash)
+	derivedBlock = ProtoBlockToBlock(ToProtoBlock(*block))
+	assert.Equal(t, *block, derivedBlock)
 }
 
 func equalTransactions(ptx, derivedTx *transaction.ProtoTransaction, t *testing.T) {
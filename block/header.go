@@ -0,0 +1,175 @@
+package block
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+
+	ffgcrypto "github.com/filefilego/filefilego/crypto"
+)
+
+// ErrUnknownVerifier is returned when a header is signed by a key which is not a verifier at the height of the header.
+// the verifier set of a future height may not be known before the blocks changing it are applied.
+var ErrUnknownVerifier = errors.New("block was signed by a non-verifier")
+
+// Header contains the fields of a block which are needed to verify its hash and signature without the transactions.
+type Header struct {
+	Hash              []byte
+	MerkleHash        []byte
+	Signature         []byte
+	Timestamp         int64
+	Data              []byte
+	PreviousBlockHash []byte
+	Number            uint64
+	StateRoot         []byte
+	// VerifierPublicKey is the public key of the coinbase transaction which signed the block.
+	VerifierPublicKey []byte
+}
+
+// Header returns the header of the block.
+func (b Block) Header() (Header, error) {
+	if len(b.Transactions) == 0 {
+		return Header{}, errors.New("block doesn't contain any transaction")
+	}
+
+	return Header{
+		Hash:              b.Hash,
+		MerkleHash:        b.MerkleHash,
+		Signature:         b.Signature,
+		Timestamp:         b.Timestamp,
+		Data:              b.Data,
+		PreviousBlockHash: b.PreviousBlockHash,
+		Number:            b.Number,
+		StateRoot:         b.StateRoot,
+		VerifierPublicKey: b.Transactions[0].PublicKey,
+	}, nil
+}
+
+// Validate checks the hash and the signature of the header.
+func (h Header) Validate() error {
+	if len(h.Hash) == 0 {
+		return errors.New("hash is empty")
+	}
+
+	if len(h.PreviousBlockHash) == 0 {
+		return errors.New("previousBlockHash is empty")
+	}
+
+	if len(h.Data) > maxBlockDataSizeBytes {
+		return fmt.Errorf("data with size %d is greater than %d bytes", len(h.Data), maxBlockDataSizeBytes)
+	}
+
+	blck := h.block()
+	hash, err := blck.GetBlockHash()
+	if err != nil {
+		return fmt.Errorf("failed to get block hash: %w", err)
+	}
+
+	if !bytes.Equal(h.Hash, hash) {
+		return errors.New("header is altered and doesn't match the hash")
+	}
+
+	pubKey, err := ffgcrypto.PublicKeyFromBytes(h.VerifierPublicKey)
+	if err != nil {
+		return fmt.Errorf("failed to derive public key of verifier: %w", err)
+	}
+
+	if err := blck.VerifyWithPublicKey(pubKey); err != nil {
+		return err
+	}
+
+	if err := blck.validateTimestamp(time.Now().Unix()); err != nil {
+		return err
+	}
+
+	verifierAddr, err := ffgcrypto.RawPublicToAddress(h.VerifierPublicKey)
+	if err != nil {
+		return errors.New("failed to get verifier's address")
+	}
+
+	isVerifier, err := IsValidVerifier(verifierAddr, h.Number)
+	if err != nil {
+		return err
+	}
+
+	if !isVerifier {
+		return ErrUnknownVerifier
+	}
+
+	return nil
+}
+
+// MatchesBlock checks that the block has the same header.
+func (h Header) MatchesBlock(b Block) error {
+	header, err := b.Header()
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(h.Hash, header.Hash) {
+		return errors.New("block hash doesn't match the header")
+	}
+
+	merkleHash, err := b.GetMerkleHash()
+	if err != nil {
+		return fmt.Errorf("failed to get block's merkle hash: %w", err)
+	}
+
+	if !bytes.Equal(h.MerkleHash, merkleHash) {
+		return errors.New("block transactions don't match the header")
+	}
+
+	if !bytes.Equal(h.VerifierPublicKey, header.VerifierPublicKey) {
+		return errors.New("block verifier doesn't match the header")
+	}
+
+	ok, err := b.Validate()
+	if err != nil || !ok {
+		return fmt.Errorf("failed to validate block: %w", err)
+	}
+	return nil
+}
+
+func (h Header) block() Block {
+	return Block{
+		Hash:              h.Hash,
+		MerkleHash:        h.MerkleHash,
+		Signature:         h.Signature,
+		Timestamp:         h.Timestamp,
+		Data:              h.Data,
+		PreviousBlockHash: h.PreviousBlockHash,
+		Number:            h.Number,
+		StateRoot:         h.StateRoot,
+	}
+}
+
+// ToProtoBlockHeader returns a proto representation of a header.
+func ToProtoBlockHeader(h Header) *ProtoBlockHeader {
+	return &ProtoBlockHeader{
+		Hash:              h.Hash,
+		MerkleHash:        h.MerkleHash,
+		Signature:         h.Signature,
+		Timestamp:         h.Timestamp,
+		Data:              h.Data,
+		PreviousBlockHash: h.PreviousBlockHash,
+		Number:            h.Number,
+		StateRoot:         h.StateRoot,
+		VerifierPublicKey: h.VerifierPublicKey,
+	}
+}
+
+// ProtoBlockHeaderToHeader returns a domain header.
+func ProtoBlockHeaderToHeader(ph *ProtoBlockHeader) Header {
+	return Header{
+		Hash:              ph.Hash,
+		MerkleHash:        ph.MerkleHash,
+		Signature:         ph.Signature,
+		Timestamp:         ph.Timestamp,
+		Data:              ph.Data,
+		PreviousBlockHash: ph.PreviousBlockHash,
+		Number:            ph.Number,
+		StateRoot:         ph.StateRoot,
+		VerifierPublicKey: ph.VerifierPublicKey,
+	}
+}
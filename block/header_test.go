@@ -0,0 +1,114 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeaderValidate(t *testing.T) {
+	t.Parallel()
+	cases := map[string]struct {
+		when   func() Header
+		expErr string
+	}{
+		"empty hash": {
+			expErr: "hash is empty",
+			when: func() Header {
+				header := signedHeader(t, true)
+				header.Hash = nil
+				return header
+			},
+		},
+		"empty previous hash": {
+			expErr: "previousBlockHash is empty",
+			when: func() Header {
+				header := signedHeader(t, true)
+				header.PreviousBlockHash = nil
+				return header
+			},
+		},
+		"altered header": {
+			expErr: "header is altered and doesn't match the hash",
+			when: func() Header {
+				header := signedHeader(t, true)
+				header.Number = 3
+				return header
+			},
+		},
+		"missing signature": {
+			expErr: "failed to verify block: malformed signature: too short: 0 < 8",
+			when: func() Header {
+				header := signedHeader(t, true)
+				header.Signature = nil
+				return header
+			},
+		},
+		"unknown verifier": {
+			expErr: ErrUnknownVerifier.Error(),
+			when: func() Header {
+				return signedHeader(t, false)
+			},
+		},
+		"success": {
+			when: func() Header {
+				return signedHeader(t, true)
+			},
+		},
+	}
+
+	for name, tt := range cases {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.when().Validate()
+			if tt.expErr != "" {
+				assert.EqualError(t, err, tt.expErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestHeaderMatchesBlock(t *testing.T) {
+	block, kp := validBlock(t)
+	SetBlockVerifiers(Verifier{Address: kp.Address})
+	err := block.Sign(kp.PrivateKey)
+	assert.NoError(t, err)
+
+	header, err := block.Header()
+	assert.NoError(t, err)
+	assert.Equal(t, block.Transactions[0].PublicKey, header.VerifierPublicKey)
+	assert.Equal(t, header, ProtoBlockHeaderToHeader(ToProtoBlockHeader(header)))
+	assert.NoError(t, header.MatchesBlock(*block))
+
+	// a block with other transactions doesn't match the header
+	otherTx, _ := validTransaction(t)
+	altered := *block
+	altered.Transactions = append(altered.Transactions, *otherTx)
+	assert.EqualError(t, header.MatchesBlock(altered), "block transactions don't match the header")
+
+	// a block with another hash doesn't match the header
+	other, otherKp := validBlock(t)
+	err = other.Sign(otherKp.PrivateKey)
+	assert.NoError(t, err)
+	assert.EqualError(t, header.MatchesBlock(*other), "block hash doesn't match the header")
+
+	_, err = Block{}.Header()
+	assert.EqualError(t, err, "block doesn't contain any transaction")
+}
+
+// signedHeader returns the header of a signed block, registering its signer as a verifier if needed.
+func signedHeader(t *testing.T, verifier bool) Header {
+	block, kp := validBlock(t)
+	if verifier {
+		SetBlockVerifiers(Verifier{Address: kp.Address})
+	}
+	err := block.Sign(kp.PrivateKey)
+	assert.NoError(t, err)
+
+	header, err := block.Header()
+	assert.NoError(t, err)
+	return header
+}
@@ -0,0 +1,154 @@
+package block
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	ffgcrypto "github.com/filefilego/filefilego/crypto"
+)
+
+// Schedule defines the turns of the verifiers.
+// the verifier at index height % len(verifiers) is in turn and can seal a block once the period has passed
+// since the previous block. the other verifiers can seal only after an additional delay for each position
+// they are away from the in-turn verifier, so they take over only when the in-turn verifier is offline.
+type Schedule struct {
+	// Period is the minimum time between two blocks.
+	Period time.Duration
+	// OutOfTurnDelay is the back-off delay of an out-of-turn verifier for each position away from the in-turn verifier.
+	OutOfTurnDelay time.Duration
+	// ActivationHeight is the first block height which is sealed in turns.
+	ActivationHeight uint64
+}
+
+// ScheduleActivationHeight is the block height from which the verifiers seal blocks in turns.
+// the blocks before it were sealed on a fixed 10 seconds timer by whichever verifier was first,
+// so they are not checked against the schedule. it's the height of the network upgrade which introduced the turns.
+const ScheduleActivationHeight uint64 = 15000000
+
+// DefaultSchedule is the schedule of the network.
+var DefaultSchedule = Schedule{
+	Period:           10 * time.Second,
+	OutOfTurnDelay:   5 * time.Second,
+	ActivationHeight: ScheduleActivationHeight,
+}
+
+// ErrFutureTimestamp is returned when a block is sealed ahead of the local time.
+// the local clock may be behind, so it doesn't prove that the block is invalid.
+var ErrFutureTimestamp = errors.New("block timestamp is too far in the future")
+
+// schedule is disabled until it's set, so every verifier can seal at any time.
+var schedule Schedule
+
+// SetSchedule sets the schedule which is enforced while sealing and validating blocks.
+func SetSchedule(s Schedule) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	schedule = s
+}
+
+// GetSchedule returns the current schedule.
+func GetSchedule() Schedule {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	return schedule
+}
+
+// Enabled returns true if the schedule has any delay.
+func (s Schedule) Enabled() bool {
+	return s.Period > 0 || s.OutOfTurnDelay > 0
+}
+
+// EnabledAt returns true if the blocks at the given height are sealed in turns.
+func (s Schedule) EnabledAt(height uint64) bool {
+	return s.Enabled() && height >= s.ActivationHeight
+}
+
+// InTurnVerifier returns the verifier which is in turn at the given block height.
+func InTurnVerifier(height uint64) (Verifier, error) {
+	verifiers, err := GetBlockVerifiersAtHeight(height)
+	if err != nil {
+		return Verifier{}, err
+	}
+
+	if len(verifiers) == 0 {
+		return Verifier{}, errors.New("no verifiers available")
+	}
+	return verifiers[height%uint64(len(verifiers))], nil
+}
+
+// SealingDelay returns the number of seconds the verifier should wait after the previous block before sealing a block at the given height.
+// before the activation height of the schedule every verifier waits for the period only.
+func SealingDelay(address string, height uint64) (int64, error) {
+	verifiers, err := GetBlockVerifiersAtHeight(height)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(verifiers) == 0 {
+		return 0, errors.New("no verifiers available")
+	}
+
+	index := -1
+	for i, v := range verifiers {
+		if v.Address == address {
+			index = i
+			break
+		}
+	}
+	if index == -1 {
+		return 0, fmt.Errorf("%s is not a verifier at block %d", address, height)
+	}
+
+	s := GetSchedule()
+	if !s.EnabledAt(height) {
+		return int64(s.Period / time.Second), nil
+	}
+
+	total := uint64(len(verifiers))
+	distance := (uint64(index) + total - height%total) % total
+	delay := s.Period + time.Duration(distance)*s.OutOfTurnDelay
+	return int64(delay / time.Second), nil
+}
+
+// ValidateSealingTime checks that the block was sealed by its verifier after the delay of the schedule.
+func (b Block) ValidateSealingTime(previousBlockTimestamp int64) error {
+	if !GetSchedule().EnabledAt(b.Number) {
+		return nil
+	}
+
+	coinbase, err := b.GetAndValidateCoinbaseTransaction()
+	if err != nil {
+		return fmt.Errorf("failed to get coinbase transaction: %w", err)
+	}
+
+	verifierAddr, err := ffgcrypto.RawPublicToAddress(coinbase.PublicKey)
+	if err != nil {
+		return fmt.Errorf("failed to get verifier's address: %w", err)
+	}
+
+	delay, err := SealingDelay(verifierAddr, b.Number)
+	if err != nil {
+		return err
+	}
+
+	if b.Timestamp < previousBlockTimestamp+delay {
+		return fmt.Errorf("block %d was sealed by %s %d seconds before its turn", b.Number, verifierAddr, previousBlockTimestamp+delay-b.Timestamp)
+	}
+	return nil
+}
+
+// validateTimestamp rejects blocks which are sealed ahead of the local time to skip the schedule delay.
+func (b Block) validateTimestamp(now int64) error {
+	s := GetSchedule()
+	if !s.EnabledAt(b.Number) {
+		return nil
+	}
+
+	if b.Timestamp > now+int64(s.Period/time.Second) {
+		return fmt.Errorf("%w: %d", ErrFutureTimestamp, b.Timestamp)
+	}
+	return nil
+}
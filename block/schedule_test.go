@@ -0,0 +1,95 @@
+package block
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchedule(t *testing.T) {
+	blck, kp := validBlock(t)
+	blck.Number = 5
+	assert.NoError(t, blck.Sign(kp.PrivateKey))
+
+	verifiers := []Verifier{{Address: "0x01"}, {Address: kp.Address}, {Address: "0x03"}}
+	SetVerifierSetProvider(&verifierSetProviderStub{
+		height: 4,
+		sets:   map[uint64][]Verifier{4: verifiers, 5: verifiers},
+	})
+	t.Cleanup(func() {
+		SetVerifierSetProvider(nil)
+		SetSchedule(Schedule{})
+	})
+
+	// a disabled schedule doesn't delay any verifier
+	assert.False(t, GetSchedule().Enabled())
+	assert.NoError(t, blck.ValidateSealingTime(blck.Timestamp))
+
+	SetSchedule(Schedule{Period: 10 * time.Second, OutOfTurnDelay: 5 * time.Second})
+	inTurn, err := InTurnVerifier(5)
+	assert.NoError(t, err)
+	assert.Equal(t, "0x03", inTurn.Address)
+	inTurn, err = InTurnVerifier(4)
+	assert.NoError(t, err)
+	assert.Equal(t, kp.Address, inTurn.Address)
+
+	cases := map[string]struct {
+		address  string
+		height   uint64
+		expDelay int64
+		expErr   string
+	}{
+		"in turn": {
+			address:  "0x03",
+			height:   5,
+			expDelay: 10,
+		},
+		"one position away": {
+			address:  "0x01",
+			height:   5,
+			expDelay: 15,
+		},
+		"two positions away": {
+			address:  kp.Address,
+			height:   5,
+			expDelay: 20,
+		},
+		"not a verifier": {
+			address: "0x04",
+			height:  5,
+			expErr:  "0x04 is not a verifier at block 5",
+		},
+	}
+
+	for name, tt := range cases {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			delay, err := SealingDelay(tt.address, tt.height)
+			if tt.expErr != "" {
+				assert.EqualError(t, err, tt.expErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expDelay, delay)
+			}
+		})
+	}
+
+	// the block is sealed by an out-of-turn verifier before its back-off delay
+	err = blck.ValidateSealingTime(blck.Timestamp - 15)
+	assert.EqualError(t, err, "block 5 was sealed by "+kp.Address+" 5 seconds before its turn")
+	assert.NoError(t, blck.ValidateSealingTime(blck.Timestamp-20))
+
+	// blocks can't be sealed ahead of time to skip the delay
+	assert.NoError(t, blck.validateTimestamp(blck.Timestamp-10))
+	assert.EqualError(t, blck.validateTimestamp(blck.Timestamp-11), fmt.Sprintf("block timestamp is too far in the future: %d", blck.Timestamp))
+
+	// blocks before the activation height were sealed on a fixed timer by any verifier
+	SetSchedule(Schedule{Period: 10 * time.Second, OutOfTurnDelay: 5 * time.Second, ActivationHeight: 6})
+	assert.NoError(t, blck.ValidateSealingTime(blck.Timestamp))
+	assert.NoError(t, blck.validateTimestamp(blck.Timestamp-11))
+	delay, err := SealingDelay(kp.Address, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), delay)
+}
@@ -1,6 +1,7 @@
 package block
 
 import (
+	"fmt"
 	sync "sync"
 
 	"github.com/libp2p/go-libp2p/core/crypto"
@@ -8,6 +9,14 @@ import (
 
 var mu sync.RWMutex
 
+// VerifierSetProvider provides the verifier set which is active at a block height.
+type VerifierSetProvider interface {
+	GetVerifiersAtHeight(height uint64) ([]Verifier, error)
+	GetHeight() uint64
+}
+
+var verifierSetProvider VerifierSetProvider
+
 var blockVerifiers = []Verifier{
 	{
 		Address:      "0xdd9a374e8dce9d656073ec153580301b7d2c3850",
@@ -91,14 +100,44 @@ var blockVerifiers = []Verifier{
 	},
 }
 
-// GetBlockVerifiers returns a list of verifiers.
-func GetBlockVerifiers() []Verifier {
+// GetGenesisBlockVerifiers returns the verifiers which are active before any change to the verifier set.
+func GetGenesisBlockVerifiers() []Verifier {
 	mu.RLock()
 	defer mu.RUnlock()
 
 	return blockVerifiers
 }
 
+// GetBlockVerifiers returns a list of verifiers which are active for the next block.
+func GetBlockVerifiers() ([]Verifier, error) {
+	mu.RLock()
+	provider := verifierSetProvider
+	mu.RUnlock()
+
+	if provider == nil {
+		return GetGenesisBlockVerifiers(), nil
+	}
+
+	return GetBlockVerifiersAtHeight(provider.GetHeight() + 1)
+}
+
+// GetBlockVerifiersAtHeight returns a list of verifiers which are active at the given block height.
+func GetBlockVerifiersAtHeight(height uint64) ([]Verifier, error) {
+	mu.RLock()
+	provider := verifierSetProvider
+	mu.RUnlock()
+
+	if provider == nil {
+		return GetGenesisBlockVerifiers(), nil
+	}
+
+	verifiers, err := provider.GetVerifiersAtHeight(height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get verifiers at height %d: %w", height, err)
+	}
+	return verifiers, nil
+}
+
 // SetBlockVerifiers adds a verifier to the block verifiers.
 func SetBlockVerifiers(v Verifier) {
 	mu.Lock()
@@ -107,6 +146,14 @@ func SetBlockVerifiers(v Verifier) {
 	blockVerifiers = append(blockVerifiers, v)
 }
 
+// SetVerifierSetProvider sets the provider of the verifier set which is active at a block height.
+func SetVerifierSetProvider(provider VerifierSetProvider) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	verifierSetProvider = provider
+}
+
 // Verifier represents a block verifier/sealer
 type Verifier struct {
 	Address         string `json:"address"`
@@ -115,14 +162,17 @@ type Verifier struct {
 	PublicKeyCrypto crypto.PubKey
 }
 
-// IsValidVerifier verifies if an address is a validator
-func IsValidVerifier(address string) bool {
-	mu.RLock()
-	defer mu.RUnlock()
-	for _, v := range blockVerifiers {
+// IsValidVerifier verifies if an address is a validator at the given block height.
+func IsValidVerifier(address string, height uint64) (bool, error) {
+	verifiers, err := GetBlockVerifiersAtHeight(height)
+	if err != nil {
+		return false, err
+	}
+
+	for _, v := range verifiers {
 		if v.Address == address {
-			return true
+			return true, nil
 		}
 	}
-	return false
+	return false, nil
 }
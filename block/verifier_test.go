@@ -1,12 +1,73 @@
 package block
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 )
 
 func TestIsValidVerifier(t *testing.T) {
-	assert.True(t, IsValidVerifier(GetBlockVerifiers()[0].Address))
-	assert.False(t, IsValidVerifier(""))
+	verifiers, err := GetBlockVerifiers()
+	assert.NoError(t, err)
+	isVerifier, err := IsValidVerifier(verifiers[0].Address, 1)
+	assert.NoError(t, err)
+	assert.True(t, isVerifier)
+	isVerifier, err = IsValidVerifier("", 1)
+	assert.NoError(t, err)
+	assert.False(t, isVerifier)
+}
+
+func TestVerifierSetProvider(t *testing.T) {
+	genesisVerifier := GetGenesisBlockVerifiers()[0]
+	provider := &verifierSetProviderStub{
+		height: 9,
+		sets: map[uint64][]Verifier{
+			10: {{Address: "0x01"}},
+		},
+	}
+	SetVerifierSetProvider(provider)
+	t.Cleanup(func() {
+		SetVerifierSetProvider(nil)
+	})
+
+	// the set of the next block is returned
+	verifiers, err := GetBlockVerifiers()
+	assert.NoError(t, err)
+	assert.Equal(t, []Verifier{{Address: "0x01"}}, verifiers)
+	isVerifier, err := IsValidVerifier("0x01", 10)
+	assert.NoError(t, err)
+	assert.True(t, isVerifier)
+	isVerifier, err = IsValidVerifier(genesisVerifier.Address, 10)
+	assert.NoError(t, err)
+	assert.False(t, isVerifier)
+
+	// the genesis verifiers are not used if the provider fails
+	_, err = IsValidVerifier(genesisVerifier.Address, 11)
+	assert.EqualError(t, err, "failed to get verifiers at height 11: not found")
+
+	// blocks can't be validated if the provider fails
+	blck, kp := validBlock(t)
+	blck.Number = 11
+	assert.NoError(t, blck.Sign(kp.PrivateKey))
+	ok, err := blck.Validate()
+	assert.False(t, ok)
+	assert.EqualError(t, err, "failed to get verifiers at height 11: not found")
+}
+
+type verifierSetProviderStub struct {
+	height uint64
+	sets   map[uint64][]Verifier
+}
+
+func (s *verifierSetProviderStub) GetVerifiersAtHeight(height uint64) ([]Verifier, error) {
+	set, ok := s.sets[height]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return set, nil
+}
+
+func (s *verifierSetProviderStub) GetHeight() uint64 {
+	return s.height
 }
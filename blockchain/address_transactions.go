@@ -0,0 +1,251 @@
+package blockchain
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/filefilego/filefilego/block"
+	"github.com/filefilego/filefilego/common/hexutil"
+	"github.com/filefilego/filefilego/transaction"
+	"github.com/syndtr/goleveldb/leveldb/util"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// defaultAddressTransactionsLimit is the page size used when no limit is given.
+	defaultAddressTransactionsLimit = 100
+	// maxAddressTransactionsLimit is the maximum page size.
+	maxAddressTransactionsLimit = 1000
+	// addressTransactionCursorLength is the length of the block number and transaction index of an index key.
+	addressTransactionCursorLength = 16
+)
+
+// TransactionFlow filters the transactions of an address by the side of the transfer.
+type TransactionFlow int
+
+const (
+	// AllTransactions returns the sent and received transactions.
+	AllTransactions TransactionFlow = iota
+	// SentTransactions returns the transactions sent from the address.
+	SentTransactions
+	// ReceivedTransactions returns the transactions sent to the address.
+	ReceivedTransactions
+)
+
+// AddressTransactionsQuery represents the filters and pagination of an address transactions query.
+type AddressTransactionsQuery struct {
+	// Limit is the maximum number of transactions returned.
+	Limit int
+	// Cursor continues from the last transaction of a previous page.
+	Cursor []byte
+	// Descending returns the newest transactions first.
+	Descending bool
+	// FromBlock is the lowest block number included.
+	FromBlock uint64
+	// ToBlock is the highest block number included, zero means there is no upper bound.
+	ToBlock uint64
+	// Flow returns only the sent or the received transactions.
+	Flow TransactionFlow
+	// DataType returns only the transactions with the given data payload type.
+	DataType *transaction.DataType
+}
+
+// AddressTransactionsPage is a page of the transactions of an address.
+type AddressTransactionsPage struct {
+	Transactions []transaction.Transaction
+	BlockNumbers []uint64
+	// NextCursor is used to get the next page, it's empty when there are no more transactions.
+	NextCursor []byte
+}
+
+// QueryAddressTransactions iterates the transactions of an address using a cursor.
+func (b *Blockchain) QueryAddressTransactions(address []byte, query AddressTransactionsQuery) (AddressTransactionsPage, error) {
+	page := AddressTransactionsPage{
+		Transactions: make([]transaction.Transaction, 0),
+		BlockNumbers: make([]uint64, 0),
+	}
+
+	if len(query.Cursor) > 0 && len(query.Cursor) != addressTransactionCursorLength {
+		return page, errors.New("invalid cursor")
+	}
+
+	if query.ToBlock > 0 && query.ToBlock < query.FromBlock {
+		return page, errors.New("from block is greater than to block")
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultAddressTransactionsLimit
+	}
+	if limit > maxAddressTransactionsLimit {
+		limit = maxAddressTransactionsLimit
+	}
+
+	iter := b.db.NewIterator(addressTransactionsRange(address, query), nil)
+	defer iter.Release()
+
+	next := iter.Next
+	first := iter.First
+	if query.Descending {
+		next = iter.Prev
+		first = iter.Last
+	}
+
+	// the transactions of a block are next to each other in the index, so the last block is reused.
+	var blck *block.Block
+	full := false
+	for ok := first(); ok; ok = next() {
+		if len(page.Transactions) == limit {
+			full = true
+			break
+		}
+
+		cursor := append([]byte{}, iter.Key()[len(iter.Key())-addressTransactionCursorLength:]...)
+		blockNumber := binary.BigEndian.Uint64(cursor[:8])
+		txIndex := binary.BigEndian.Uint64(cursor[8:])
+		if blck == nil || blck.Number != blockNumber {
+			found, err := b.GetBlockByNumber(blockNumber)
+			if err != nil {
+				return page, fmt.Errorf("failed to get block number %d in get transactions by address: %w", blockNumber, err)
+			}
+			blck = found
+		}
+
+		if txIndex >= uint64(len(blck.Transactions)) {
+			return page, fmt.Errorf("transaction %d not found in block %d", txIndex, blockNumber)
+		}
+
+		tx := blck.Transactions[txIndex]
+		if !matchesAddressTransactionsQuery(tx, address, query) {
+			continue
+		}
+
+		page.Transactions = append(page.Transactions, tx)
+		page.BlockNumbers = append(page.BlockNumbers, blockNumber)
+		page.NextCursor = cursor
+	}
+
+	if err := iter.Error(); err != nil {
+		return page, fmt.Errorf("iterator error while getting transactions: %w", err)
+	}
+
+	if !full {
+		page.NextCursor = nil
+	}
+	return page, nil
+}
+
+// addressTransactionsRange returns the index keys range of the query.
+func addressTransactionsRange(address []byte, query AddressTransactionsQuery) *util.Range {
+	prefix := append([]byte(addressTransactionPrefix), address...)
+	rng := util.BytesPrefix(prefix)
+
+	rng.Start = appendBlockNumber(prefix, query.FromBlock)
+	if query.ToBlock > 0 && query.ToBlock < ^uint64(0) {
+		rng.Limit = appendBlockNumber(prefix, query.ToBlock+1)
+	}
+
+	if len(query.Cursor) == 0 {
+		return rng
+	}
+
+	cursorKey := append(append([]byte{}, prefix...), query.Cursor...)
+	if query.Descending {
+		if rng.Limit == nil || bytes.Compare(cursorKey, rng.Limit) < 0 {
+			rng.Limit = cursorKey
+		}
+		return rng
+	}
+
+	// the key right after the cursor key
+	startKey := append(cursorKey, 0)
+	if bytes.Compare(startKey, rng.Start) > 0 {
+		rng.Start = startKey
+	}
+	return rng
+}
+
+func appendBlockNumber(prefix []byte, blockNumber uint64) []byte {
+	blockNumberBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(blockNumberBytes, blockNumber)
+	return append(append([]byte{}, prefix...), blockNumberBytes...)
+}
+
+// matchesAddressTransactionsQuery checks the flow and data type filters of a query.
+func matchesAddressTransactionsQuery(tx transaction.Transaction, address []byte, query AddressTransactionsQuery) bool {
+	switch query.Flow {
+	case SentTransactions:
+		from, err := hexutil.Decode(tx.From)
+		if err != nil || !bytes.Equal(from, address) {
+			return false
+		}
+	case ReceivedTransactions:
+		if !isTransactionRecipient(tx, address) {
+			return false
+		}
+	}
+
+	if query.DataType == nil {
+		return true
+	}
+
+	dataPayload := transaction.DataPayload{}
+	if len(tx.Data) == 0 || proto.Unmarshal(tx.Data, &dataPayload) != nil {
+		return false
+	}
+	return dataPayload.Type == *query.DataType
+}
+
+// transactionAddresses returns the addresses a transaction is indexed by.
+// the recipients of a batch transfer are included.
+func transactionAddresses(tx transaction.Transaction) [][]byte {
+	candidates := []string{tx.From, tx.To}
+	if tx.IsBatchTransfer() {
+		transfers, err := tx.BatchTransfers()
+		if err == nil {
+			for _, t := range transfers {
+				candidates = append(candidates, t.To)
+			}
+		}
+	}
+
+	addresses := make([][]byte, 0, len(candidates))
+	seen := make(map[string]struct{}, len(candidates))
+	for _, candidate := range candidates {
+		addr, err := hexutil.Decode(candidate)
+		if err != nil {
+			continue
+		}
+
+		if _, ok := seen[string(addr)]; ok {
+			continue
+		}
+		seen[string(addr)] = struct{}{}
+		addresses = append(addresses, addr)
+	}
+	return addresses
+}
+
+// isTransactionRecipient checks if the address receives a transfer from the transaction.
+func isTransactionRecipient(tx transaction.Transaction, address []byte) bool {
+	// a batch transfer is sent to the sender's address, only the transfers have recipients
+	if !tx.IsBatchTransfer() {
+		to, err := hexutil.Decode(tx.To)
+		return err == nil && bytes.Equal(to, address)
+	}
+
+	transfers, err := tx.BatchTransfers()
+	if err != nil {
+		return false
+	}
+
+	for _, t := range transfers {
+		recipient, err := hexutil.Decode(t.To)
+		if err == nil && bytes.Equal(recipient, address) {
+			return true
+		}
+	}
+	return false
+}
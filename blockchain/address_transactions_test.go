@@ -0,0 +1,140 @@
+package blockchain
+
+import (
+	"os"
+	"testing"
+
+	"github.com/filefilego/filefilego/block"
+	"github.com/filefilego/filefilego/common/hexutil"
+	"github.com/filefilego/filefilego/database"
+	"github.com/filefilego/filefilego/search"
+	"github.com/filefilego/filefilego/transaction"
+	"github.com/stretchr/testify/assert"
+	"github.com/syndtr/goleveldb/leveldb"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestQueryAddressTransactions(t *testing.T) {
+	genesisblockValid, err := block.GetGenesisBlock()
+	assert.NoError(t, err)
+	db, err := leveldb.OpenFile("addresstransactions.db", nil)
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		db.Close()
+		os.RemoveAll("addresstransactions.db")
+	})
+
+	driver, err := database.New(db)
+	assert.NoError(t, err)
+	bchain, err := New(driver, &search.Search{}, genesisblockValid.Hash)
+	assert.NoError(t, err)
+
+	addr := "0x0101010101010101010101010101010101010101"
+	other := "0x0202020202020202020202020202020202020202"
+	addrBytes, err := hexutil.Decode(addr)
+	assert.NoError(t, err)
+
+	createNodeData, err := proto.Marshal(&transaction.DataPayload{Type: transaction.DataType_CREATE_NODE})
+	assert.NoError(t, err)
+
+	// blocks 1 to 5 have a sent and a received transaction of the address
+	// block 3 has a transaction of other addresses only
+	for i := byte(1); i <= 5; i++ {
+		blck := block.Block{
+			Hash:   []byte{i},
+			Number: uint64(i),
+			Transactions: []transaction.Transaction{
+				{Hash: []byte{i, 1}, From: addr, To: other, Data: createNodeData},
+				{Hash: []byte{i, 2}, From: other, To: addr},
+			},
+		}
+		if i == 3 {
+			blck.Transactions = []transaction.Transaction{{Hash: []byte{i, 3}, From: other, To: other}}
+		}
+		assert.NoError(t, bchain.SaveBlockInDB(blck))
+		assert.NoError(t, bchain.indexBlockHashByBlockNumber(bchain.db, blck.Hash, blck.Number))
+		assert.NoError(t, bchain.indexTransactionsByAddresses(bchain.db, blck))
+	}
+
+	createNode := transaction.DataType_CREATE_NODE
+	updateNode := transaction.DataType_UPDATE_NODE
+	cases := map[string]struct {
+		query     AddressTransactionsQuery
+		expHashes [][]byte
+		expErr    string
+	}{
+		"invalid cursor": {
+			query:  AddressTransactionsQuery{Cursor: []byte{1}},
+			expErr: "invalid cursor",
+		},
+		"invalid block range": {
+			query:  AddressTransactionsQuery{FromBlock: 3, ToBlock: 2},
+			expErr: "from block is greater than to block",
+		},
+		"ascending": {
+			query:     AddressTransactionsQuery{Limit: 3},
+			expHashes: [][]byte{{1, 1}, {1, 2}, {2, 1}},
+		},
+		"descending": {
+			query:     AddressTransactionsQuery{Limit: 3, Descending: true},
+			expHashes: [][]byte{{5, 2}, {5, 1}, {4, 2}},
+		},
+		"block range": {
+			query:     AddressTransactionsQuery{FromBlock: 2, ToBlock: 4},
+			expHashes: [][]byte{{2, 1}, {2, 2}, {4, 1}, {4, 2}},
+		},
+		"sent": {
+			query:     AddressTransactionsQuery{Flow: SentTransactions},
+			expHashes: [][]byte{{1, 1}, {2, 1}, {4, 1}, {5, 1}},
+		},
+		"received in descending order": {
+			query:     AddressTransactionsQuery{Flow: ReceivedTransactions, Descending: true, Limit: 2},
+			expHashes: [][]byte{{5, 2}, {4, 2}},
+		},
+		"data type": {
+			query:     AddressTransactionsQuery{DataType: &createNode, FromBlock: 4},
+			expHashes: [][]byte{{4, 1}, {5, 1}},
+		},
+		"no matching data type": {
+			query:     AddressTransactionsQuery{DataType: &updateNode},
+			expHashes: [][]byte{},
+		},
+	}
+
+	for name, tt := range cases {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			page, err := bchain.QueryAddressTransactions(addrBytes, tt.query)
+			if tt.expErr != "" {
+				assert.EqualError(t, err, tt.expErr)
+				return
+			}
+			assert.NoError(t, err)
+			hashes := make([][]byte, 0)
+			for _, tx := range page.Transactions {
+				hashes = append(hashes, tx.Hash)
+			}
+			assert.Equal(t, tt.expHashes, hashes)
+			assert.Len(t, page.BlockNumbers, len(page.Transactions))
+		})
+	}
+
+	// all the pages are retrieved with the cursor in both directions
+	for _, descending := range []bool{false, true} {
+		query := AddressTransactionsQuery{Limit: 3, Descending: descending}
+		total := 0
+		pages := 0
+		for {
+			page, err := bchain.QueryAddressTransactions(addrBytes, query)
+			assert.NoError(t, err)
+			total += len(page.Transactions)
+			pages++
+			if len(page.NextCursor) == 0 {
+				break
+			}
+			query.Cursor = page.NextCursor
+		}
+		assert.Equal(t, 8, total)
+		assert.Equal(t, 3, pages)
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/filefilego/filefilego/common/hexutil"
 	"github.com/filefilego/filefilego/crypto"
 	"github.com/filefilego/filefilego/database"
+	"github.com/filefilego/filefilego/mempool"
 	"github.com/filefilego/filefilego/node/protocols/messages"
 	"github.com/filefilego/filefilego/search"
 	"github.com/filefilego/filefilego/transaction"
@@ -38,6 +39,15 @@ const (
 	nodeNodesPrefix           = "nn"
 	channelPrefix             = "ch"
 	channelsCountPrefix       = "channels_count"
+	undoPrefix                = "ud"
+	verifierSetPrefix         = "vs"
+	evidencePrefix            = "ev"
+	finalizedCheckpointPrefix = "finalized_checkpoint"
+	snapshotImportPrefix      = "snapshot_import"
+	escrowPrefix              = "es"
+	escrowDeadlinePrefix      = "ed"
+	stateTreePrefix           = "sm"
+	stateTreeBuiltPrefix      = "state_tree_built"
 )
 
 var (
@@ -47,14 +57,22 @@ var (
 	RemainingChannelOperationFeesMiliFFG = int64(50)
 )
 
+// StateRootActivationHeight is the block height from which every block must commit to the state root.
+// the state root was introduced by the same network upgrade as the verifier turns, so it's activated at the same height.
+// the blocks before it were sealed without a state root, which is only checked if it's present.
+const StateRootActivationHeight = block.ScheduleActivationHeight
+
 // Interface wraps the functionality of a blockchain.
 type Interface interface {
 	GetBlocksFromPool() []block.Block
 	PutBlockPool(block block.Block) error
+	PutBlockPoolAndWait(block block.Block) error
 	DeleteFromBlockPool(block block.Block) error
 	PutMemPool(tx transaction.Transaction) error
 	DeleteFromMemPool(tx transaction.Transaction) error
 	GetTransactionsFromPool() []transaction.Transaction
+	GetPendingTransactionsFromPool() []transaction.Transaction
+	GetFutureTransactionsFromPool() []transaction.Transaction
 	SaveBlockInDB(blck block.Block) error
 	GetBlockByHash(blockHash []byte) (block.Block, error)
 	GetNounceFromMemPool(address []byte) uint64
@@ -67,8 +85,17 @@ type Interface interface {
 	PerformStateUpdateFromBlock(validBlock block.Block) error
 	GetBlockByNumber(blockNumber uint64) (*block.Block, error)
 	GetLastBlockUpdatedAt() int64
+	GetLowestBlockNumber() uint64
+	CalculateStateRoot(blck block.Block) ([]byte, error)
+	SimulateBlock(blck block.Block) (*BlockSimulation, error)
+	GetDoubleSignEvidence(address string) ([]*DoubleSignEvidenceProto, error)
+	AddCheckpointAttestation(attestation *messages.CheckpointAttestationProto) (bool, error)
+	GetFinalizedCheckpoint() (uint64, []byte)
+	IsFinalized(blockNumber uint64) bool
+	IsPruned() bool
 	GetTransactionByHash(hash []byte) ([]transaction.Transaction, []uint64, error)
 	GetAddressTransactions(address []byte) ([]transaction.Transaction, []uint64, error)
+	QueryAddressTransactions(address []byte, query AddressTransactionsQuery) (AddressTransactionsPage, error)
 	GetChannels(limit, offset int) ([]*NodeItem, error)
 	GetChannelsCount() uint64
 	GetChildNodeItems(nodeHash []byte) ([]*NodeItem, error)
@@ -76,6 +103,7 @@ type Interface interface {
 	GetParentNodeItem(nodeHash []byte) (*NodeItem, error)
 	GetDownloadContractInTransactionDataTransactionHash(contractHash []byte) ([]DownloadContractInTransactionDataTxHash, error)
 	GetReleasedFeesOfDownloadContractInTransactionData(contractHash []byte) ([]DownloadContractInTransactionDataTxHash, error)
+	GetContractEscrow(contractHash []byte) (*ContractEscrowProto, error)
 	GetNodeFileItemFromFileHash(fileHash []byte) ([]*NodeItem, error)
 	GetFilesFromEntryOrFolderRecursively(entryOrFolderHash []byte) ([]FileMetadata, error)
 }
@@ -87,18 +115,38 @@ type Blockchain struct {
 	blockPool map[string]block.Block
 	bmu       sync.RWMutex
 
-	memPool map[string]transaction.Transaction
+	memPool *mempool.Pool
 	tmu     sync.RWMutex
 
 	height uint64
 	hmu    sync.RWMutex
 
-	genesisBlockHash           []byte
-	updatingBlockchainStateMux sync.RWMutex
-	updatingBlockchainState    bool
+	genesisBlockHash []byte
+	// blockPoolMu is held while the blocks of the pool are applied.
+	blockPoolMu sync.Mutex
 	// lastBlockUpdateAt used to trigger syncing
 	lastBlockUpdateAt int64
 	lastBlockUpdateMu sync.RWMutex
+
+	// stateMu serializes the operations which apply or revert blocks.
+	stateMu   sync.Mutex
+	journal   *undoJournal
+	journalMu sync.Mutex
+
+	// attestations are the pending checkpoint attestations by block number and block hash.
+	attestations    map[uint64]map[string]checkpointAttestations
+	finalizedNumber uint64
+	finalizedHash   []byte
+	finalityMu      sync.RWMutex
+
+	// keepBlocks is the number of latest blocks kept by a pruned node.
+	keepBlocks uint64
+	pruneMu    sync.RWMutex
+
+	// stateRootActivationHeight is the block height from which the state root is required.
+	stateRootActivationHeight uint64
+	// contractEscrowActivationHeight is the block height from which the contract fees are locked in escrows.
+	contractEscrowActivationHeight uint64
 }
 
 // New creates a new blockchain instance.
@@ -119,17 +167,34 @@ func New(db database.Database, search search.IndexSearcher, genesisBlockHash []b
 		db:               db,
 		search:           search,
 		blockPool:        make(map[string]block.Block),
-		memPool:          make(map[string]transaction.Transaction),
+		attestations:     make(map[uint64]map[string]checkpointAttestations),
 		genesisBlockHash: make([]byte, len(genesisBlockHash)),
+
+		stateRootActivationHeight:      StateRootActivationHeight,
+		contractEscrowActivationHeight: ContractEscrowActivationHeight,
 	}
 
 	copy(b.genesisBlockHash, genesisBlockHash)
 
+	err := b.SetMemPoolConfig(mempool.DefaultConfig())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create mempool: %w", err)
+	}
+
 	return b, nil
 }
 
 // InitOrLoad intializes or loads the blockchain from the database.
 func (b *Blockchain) InitOrLoad(verifyAllBlocks bool) error {
+	// an unfinished snapshot import leaves a partial state which can't be used.
+	if err := b.discardSnapshotImport(); err != nil {
+		return fmt.Errorf("failed to discard snapshot import: %w", err)
+	}
+
+	if err := b.loadFinalizedCheckpoint(); err != nil {
+		return err
+	}
+
 	lastBlockHash := b.GetLastBlockHash()
 	if len(lastBlockHash) == 0 {
 		// reset height
@@ -146,7 +211,16 @@ func (b *Blockchain) InitOrLoad(verifyAllBlocks bool) error {
 		if err != nil {
 			return fmt.Errorf("failed to perform block state update: %w", err)
 		}
-		return nil
+		return b.setStateTreeBuilt()
+	}
+
+	if err := b.buildStateTreeIfMissing(); err != nil {
+		return fmt.Errorf("failed to build state tree: %w", err)
+	}
+
+	// the search index is updated after a block is committed, so the nodes of the last block are indexed again.
+	if err := b.reindexBlockNodes(lastBlockHash); err != nil {
+		log.Warnf("failed to reindex nodes of the last block: %v", err)
 	}
 
 	if !verifyAllBlocks {
@@ -155,10 +229,12 @@ func (b *Blockchain) InitOrLoad(verifyAllBlocks bool) error {
 			return fmt.Errorf("failed to get block: %v with error: %w", hexutil.Encode(lastBlockHash), err)
 		}
 		b.SetHeight(foundBlock.Number)
-		return nil
+		return b.pruneBlocks()
 	}
 
 	// load blockchain and verify
+	// blocks older than the lowest block are not available on pruned nodes.
+	lowestBlockNumber := b.GetLowestBlockNumber()
 	for {
 		foundBlock, err := b.GetBlockByHash(lastBlockHash)
 		if err != nil {
@@ -169,6 +245,11 @@ func (b *Blockchain) InitOrLoad(verifyAllBlocks bool) error {
 			return fmt.Errorf("failed to verify block: %s : %w", hexutil.Encode(lastBlockHash), err)
 		}
 
+		if lowestBlockNumber > 0 && foundBlock.Number == lowestBlockNumber {
+			b.IncrementHeightBy(foundBlock.Number)
+			break
+		}
+
 		lastBlockHash = make([]byte, len(foundBlock.PreviousBlockHash))
 		copy(lastBlockHash, foundBlock.PreviousBlockHash)
 
@@ -179,7 +260,7 @@ func (b *Blockchain) InitOrLoad(verifyAllBlocks bool) error {
 		b.IncrementHeightBy(1)
 	}
 
-	return nil
+	return b.pruneBlocks()
 }
 
 // GetLastBlockUpdatedAt returns the timestamp of the last blockchain update from a block.
@@ -200,7 +281,12 @@ func (b *Blockchain) SetHeight(h uint64) {
 
 // SetLastBlockHash sets the last block hash.
 func (b *Blockchain) SetLastBlockHash(data []byte) error {
-	if err := b.db.Put([]byte(lastBlockPrefix), data); err != nil {
+	return b.setLastBlockHash(b.db, data)
+}
+
+// setLastBlockHash sets the last block hash in the given db.
+func (b *Blockchain) setLastBlockHash(db database.Database, data []byte) error {
+	if err := db.Put([]byte(lastBlockPrefix), data); err != nil {
 		return fmt.Errorf("failed to update last block hash in db: %w", err)
 	}
 	return nil
@@ -245,11 +331,11 @@ func (b *Blockchain) GetBlocksFromPool() []block.Block {
 }
 
 // indexBlockHashByBlockNumber indexes the blockHash by the block number so we can query db by block numbers.
-func (b *Blockchain) indexBlockHashByBlockNumber(blockHash []byte, blockNumber uint64) error {
+func (b *Blockchain) indexBlockHashByBlockNumber(db database.Database, blockHash []byte, blockNumber uint64) error {
 	blockNumberBytes := make([]byte, 8)
 	binary.BigEndian.PutUint64(blockNumberBytes, blockNumber)
 
-	if err := b.db.Put(append([]byte(blockNumberPrefix), blockNumberBytes...), blockHash); err != nil {
+	if err := db.Put(append([]byte(blockNumberPrefix), blockNumberBytes...), blockHash); err != nil {
 		return fmt.Errorf("failed to save block number and hash into db: %w", err)
 	}
 	return nil
@@ -258,7 +344,7 @@ func (b *Blockchain) indexBlockHashByBlockNumber(blockHash []byte, blockNumber u
 // indexBlockTransactions indexes the transactions of blocks so they can be retrieved by hash.
 // block number is included in the key and the value is an empty byte.
 // use an iterator to find the transaction hashes, in this way its possible to index coinbase txs.
-func (b *Blockchain) indexBlockTransactions(validBlock block.Block) error {
+func (b *Blockchain) indexBlockTransactions(db database.Database, validBlock block.Block) error {
 	blockNumberBytes := make([]byte, 8)
 	binary.BigEndian.PutUint64(blockNumberBytes, validBlock.Number)
 	batch := new(leveldb.Batch)
@@ -266,7 +352,7 @@ func (b *Blockchain) indexBlockTransactions(validBlock block.Block) error {
 		prefixWithTransactionHash := append([]byte(transactionPrefix), v.Hash...)
 		batch.Put(append(prefixWithTransactionHash, blockNumberBytes...), []byte{})
 	}
-	err := b.db.Write(batch, nil)
+	err := db.Write(batch, nil)
 	if err != nil {
 		return fmt.Errorf("failed to write batch of block transactions: %w", err)
 	}
@@ -316,7 +402,7 @@ func (b *Blockchain) GetTransactionByHash(hash []byte) ([]transaction.Transactio
 
 // indexTransactionsByAddress indexes the transaction by the addresses "from" and "to".
 // indexing is based on the following key: prefix_address_blocknumber_transactionIndex
-func (b *Blockchain) indexTransactionsByAddresses(validBlock block.Block) error {
+func (b *Blockchain) indexTransactionsByAddresses(db database.Database, validBlock block.Block) error {
 	blockNumberBytes := make([]byte, 8)
 	binary.BigEndian.PutUint64(blockNumberBytes, validBlock.Number)
 
@@ -325,21 +411,14 @@ func (b *Blockchain) indexTransactionsByAddresses(validBlock block.Block) error
 		indexBytes := make([]byte, 8)
 		binary.BigEndian.PutUint64(indexBytes, uint64(i))
 
-		fromAddr, _ := hexutil.Decode(v.From)
-		toAddr, _ := hexutil.Decode(v.To)
-
-		prefixWithFromAddress := append([]byte(addressTransactionPrefix), fromAddr...)
-		prefixWithToAddress := append([]byte(addressTransactionPrefix), toAddr...)
-
-		// nolint:gocritic
-		prefixWithFromAddressBlocknumber := append(prefixWithFromAddress, blockNumberBytes...)
-		// nolint:gocritic
-		prefixWithToAddressBlocknumber := append(prefixWithToAddress, blockNumberBytes...)
-
-		batch.Put(append(prefixWithFromAddressBlocknumber, indexBytes...), v.Hash)
-		batch.Put(append(prefixWithToAddressBlocknumber, indexBytes...), v.Hash)
+		for _, addr := range transactionAddresses(v) {
+			prefixWithAddress := append([]byte(addressTransactionPrefix), addr...)
+			// nolint:gocritic
+			prefixWithAddressBlocknumber := append(prefixWithAddress, blockNumberBytes...)
+			batch.Put(append(prefixWithAddressBlocknumber, indexBytes...), v.Hash)
+		}
 	}
-	err := b.db.Write(batch, nil)
+	err := db.Write(batch, nil)
 	if err != nil {
 		return fmt.Errorf("failed to write batch of address and transactions: %w", err)
 	}
@@ -402,75 +481,114 @@ func (b *Blockchain) GetBlockByNumber(blockNumber uint64) (*block.Block, error)
 	return &foundBlock, nil
 }
 
-func (b *Blockchain) setUpdatingBlockchainState(updating bool) {
-	b.updatingBlockchainStateMux.Lock()
-	defer b.updatingBlockchainStateMux.Unlock()
+// PutBlockPool adds a block to blockPool.
+// A block which conflicts with a known block signed by the same verifier is recorded as double sign evidence.
+// The blocks of the pool which extend the chain are applied. If a branch in the pool is preferred over
+// the current chain, the chain is reverted to the common ancestor and the branch is applied.
+// If the pool is already being applied by another goroutine, it returns right away and the block is left to it.
+func (b *Blockchain) PutBlockPool(block block.Block) error {
+	if !b.addToBlockPool(block) {
+		return nil
+	}
+
+	// make other goroutines to return while update operation is being performed.
+	if !b.blockPoolMu.TryLock() {
+		return nil
+	}
+	defer b.blockPoolMu.Unlock()
 
-	b.updatingBlockchainState = updating
+	return b.applyBlockPool()
 }
 
-func (b *Blockchain) getUpdatingBlockchainState() bool {
-	b.updatingBlockchainStateMux.Lock()
-	defer b.updatingBlockchainStateMux.Unlock()
+// PutBlockPoolAndWait adds a block to blockPool and applies the pool like PutBlockPool.
+// If the pool is already being applied, it waits and applies the pool again, so the block
+// is part of the chain when it returns if it extends the chain.
+func (b *Blockchain) PutBlockPoolAndWait(block block.Block) error {
+	if !b.addToBlockPool(block) {
+		return nil
+	}
+
+	b.blockPoolMu.Lock()
+	defer b.blockPoolMu.Unlock()
 
-	return b.updatingBlockchainState
+	return b.applyBlockPool()
 }
 
-// PutBlockPool adds a block to blockPool.
-func (b *Blockchain) PutBlockPool(block block.Block) error {
+// addToBlockPool adds a block to blockPool unless it's outside of the reorg window.
+func (b *Blockchain) addToBlockPool(block block.Block) bool {
+	b.detectDoubleSign(block)
+
 	currentHeight := b.GetHeight()
-	if block.Number < currentHeight {
-		return nil
+	if isOutsideReorgWindow(block.Number, currentHeight) {
+		return false
 	}
 	b.bmu.Lock()
 	blockHash := hexutil.Encode(block.Hash)
 	b.blockPool[blockHash] = block
 	b.bmu.Unlock()
+	return true
+}
 
-	// make other goroutines to return while update operation is being performed.
-	if b.getUpdatingBlockchainState() {
-		return nil
-	}
-
-	b.setUpdatingBlockchainState(true)
-	defer b.setUpdatingBlockchainState(false)
+// applyBlockPool applies the blocks of the pool which extend the chain and reorganizes to a preferred branch.
+// blockPoolMu must be held.
+func (b *Blockchain) applyBlockPool() error {
 	for {
-		nextBlockFound := false
 		lastBlockHash := b.GetLastBlockHash()
 		if lastBlockHash == nil {
 			return errors.New("last block hash is nil")
 		}
 
-		blocskPool := b.GetBlocksFromPool()
-		for _, blck := range blocskPool {
-			// remove old blocks from pool
-			if blck.Number < currentHeight {
-				if err := b.DeleteFromBlockPool(blck); err != nil {
-					log.Errorf("failed to delete block %s from blockpool: %v", hexutil.Encode(blck.Hash), err)
-				}
-				continue
+		nextBlock, nextBlockFound := b.nextBlockFromPool(lastBlockHash, b.GetHeight())
+		if nextBlockFound {
+			if err := b.PerformStateUpdateFromBlock(nextBlock); err != nil {
+				log.Errorf("failed to perform blockchain update from block %s : %v", hexutil.Encode(nextBlock.Hash), err)
 			}
 
-			if bytes.Equal(blck.PreviousBlockHash, lastBlockHash) {
-				nextBlockFound = true
-				if err := b.PerformStateUpdateFromBlock(blck); err != nil {
-					log.Errorf("failed to perform blockchain update from block %s : %v", hexutil.Encode(blck.Hash), err)
-				}
-
-				if err := b.DeleteFromBlockPool(blck); err != nil {
-					log.Errorf("failed to delete block %s from blockpool: %v", hexutil.Encode(blck.Hash), err)
-				}
+			if err := b.DeleteFromBlockPool(nextBlock); err != nil {
+				log.Errorf("failed to delete block %s from blockpool: %v", hexutil.Encode(nextBlock.Hash), err)
 			}
+			continue
 		}
 
-		if !nextBlockFound {
+		ancestor, branch, ok := b.findBestBranch()
+		if !ok {
 			break
 		}
+
+		if err := b.reorganize(ancestor, branch); err != nil {
+			log.Errorf("failed to reorganize chain at block %d: %v", ancestor.Number, err)
+		}
 	}
 
 	return nil
 }
 
+// nextBlockFromPool removes stale blocks from the pool and returns the preferred block which extends the last block.
+func (b *Blockchain) nextBlockFromPool(lastBlockHash []byte, currentHeight uint64) (block.Block, bool) {
+	found := false
+	next := block.Block{}
+	for _, blck := range b.GetBlocksFromPool() {
+		// remove old blocks, blocks which conflict with a finalized block and blocks which are already part of the chain from pool
+		if isOutsideReorgWindow(blck.Number, currentHeight) || b.IsFinalized(blck.Number) || (blck.Number <= currentHeight && b.isCanonicalBlock(blck)) {
+			if err := b.DeleteFromBlockPool(blck); err != nil {
+				log.Errorf("failed to delete block %s from blockpool: %v", hexutil.Encode(blck.Hash), err)
+			}
+			continue
+		}
+
+		if bytes.Equal(blck.PreviousBlockHash, lastBlockHash) && (!found || isPreferredTip(blck, next)) {
+			found = true
+			next = blck
+		}
+	}
+	return next, found
+}
+
+// isOutsideReorgWindow checks if a block number is too old to be part of a chain reorganization.
+func isOutsideReorgWindow(blockNumber, currentHeight uint64) bool {
+	return currentHeight > maxReorgDepth && blockNumber < currentHeight-maxReorgDepth
+}
+
 // DeleteFromBlockPool deletes a block from mempool.
 func (b *Blockchain) DeleteFromBlockPool(block block.Block) error {
 	b.bmu.Lock()
@@ -482,12 +600,12 @@ func (b *Blockchain) DeleteFromBlockPool(block block.Block) error {
 }
 
 // addBalanceTo adds balance to address.
-func (b *Blockchain) addBalanceTo(address []byte, amount *big.Int) error {
+func (b *Blockchain) addBalanceTo(db database.Database, address []byte, amount *big.Int) error {
 	zeroBig := big.NewInt(0)
 	if amount.Cmp(zeroBig) == -1 {
 		return errors.New("amount is negative")
 	}
-	state, err := b.GetAddressState(address)
+	state, err := b.getAddressState(db, address)
 	// address has no balance
 	if err != nil {
 		state.SetBalance(big.NewInt(0))
@@ -501,7 +619,7 @@ func (b *Blockchain) addBalanceTo(address []byte, amount *big.Int) error {
 
 	state.SetBalance(balance.Add(balance, amount))
 
-	err = b.UpdateAddressState(address, state)
+	err = b.updateAddressState(db, address, state)
 	if err != nil {
 		return fmt.Errorf("failed to update balance state: %w", err)
 	}
@@ -510,12 +628,12 @@ func (b *Blockchain) addBalanceTo(address []byte, amount *big.Int) error {
 }
 
 // subBalanceFrom subtracts balance from address.
-func (b *Blockchain) subBalanceFrom(address []byte, amount *big.Int, nounce uint64) error {
+func (b *Blockchain) subBalanceFrom(db database.Database, address []byte, amount *big.Int, nounce uint64) error {
 	zeroBig := big.NewInt(0)
 	if amount.Cmp(zeroBig) == -1 {
 		return errors.New("amount is negative")
 	}
-	state, err := b.GetAddressState(address)
+	state, err := b.getAddressState(db, address)
 	// address has no balance
 	if err != nil {
 		return fmt.Errorf("address has no balance: %w", err)
@@ -533,7 +651,7 @@ func (b *Blockchain) subBalanceFrom(address []byte, amount *big.Int, nounce uint
 	state.SetBalance(balance.Sub(balance, amount))
 	state.SetNounce(nounce)
 
-	err = b.UpdateAddressState(address, state)
+	err = b.updateAddressState(db, address, state)
 	if err != nil {
 		return fmt.Errorf("failed to update balance state: %w", err)
 	}
@@ -545,6 +663,17 @@ func (b *Blockchain) subBalanceFrom(address []byte, amount *big.Int, nounce uint
 // This function should be able to rollback to previous state in case of failure.
 // APPLYING OPERATIONS ON BIG INTS MODIFIES THE UNDERLYING DATA.
 func (b *Blockchain) PerformAddressStateUpdate(transaction transaction.Transaction, verifierAddr []byte, isCoinbase bool) error {
+	b.stateMu.Lock()
+	defer b.stateMu.Unlock()
+
+	return b.updateCommittedState(func(db database.Database) error {
+		return b.performAddressStateUpdate(db, transaction, verifierAddr, isCoinbase, false)
+	})
+}
+
+// performAddressStateUpdate performs the state update of a transaction.
+// a failed data payload update is only logged unless strictDataPayload is set.
+func (b *Blockchain) performAddressStateUpdate(db database.Database, transaction transaction.Transaction, verifierAddr []byte, isCoinbase, strictDataPayload bool) error {
 	ok, err := transaction.Validate()
 	if err != nil || !ok {
 		return fmt.Errorf("failed to validate transaction: %w", err)
@@ -559,12 +688,12 @@ func (b *Blockchain) PerformAddressStateUpdate(transaction transaction.Transacti
 		return fmt.Errorf("failed to decode from address: %w", err)
 	}
 
-	fromState, err := b.GetAddressState(fromAddrBytes)
+	fromState, err := b.getAddressState(db, fromAddrBytes)
 	if err != nil {
 		// if from is not available, create a zero state
 		fromState.SetNounce(0)
 		fromState.SetBalance(big.NewInt(0))
-		if err := b.UpdateAddressState(fromAddrBytes, fromState); err != nil {
+		if err := b.updateAddressState(db, fromAddrBytes, fromState); err != nil {
 			return fmt.Errorf("failed to initialize zero state for `from` address: %w", err)
 		}
 	}
@@ -574,6 +703,23 @@ func (b *Blockchain) PerformAddressStateUpdate(transaction transaction.Transacti
 		return fmt.Errorf("failed to get nounce of address state: %w", err)
 	}
 
+	// the transfers of a batch are validated before any balance is changed, so they are applied all together or not at all
+	batchTransfers, err := batchTransfersOf(transaction, isCoinbase)
+	if err != nil {
+		return fmt.Errorf("failed to validate batch transfer: %w", err)
+	}
+
+	// the fees of a download contract are locked until the verifier releases them or they are refunded after the deadline
+	escrow, err := b.contractEscrowOf(db, transaction, isCoinbase)
+	if err != nil {
+		return fmt.Errorf("failed to validate contract escrow: %w", err)
+	}
+
+	releasedEscrows, err := b.contractEscrowsReleasedBy(db, transaction, isCoinbase)
+	if err != nil {
+		return fmt.Errorf("failed to validate contract escrow release: %w", err)
+	}
+
 	// if not coinbase tx, then subtract the amount from the account
 	if !isCoinbase {
 		fromAddressNounceTX := hexutil.DecodeBigFromBytesToUint64(transaction.Nounce)
@@ -587,7 +733,7 @@ func (b *Blockchain) PerformAddressStateUpdate(transaction transaction.Transacti
 		}
 		totalFees := txValue.Add(txValue, txFees)
 
-		err = b.subBalanceFrom(fromAddrBytes, totalFees, fromAddressNounceTX)
+		err = b.subBalanceFrom(db, fromAddrBytes, totalFees, fromAddressNounceTX)
 		if err != nil {
 			return fmt.Errorf("failed to subtract total value from address: %w", err)
 		}
@@ -603,29 +749,80 @@ func (b *Blockchain) PerformAddressStateUpdate(transaction transaction.Transacti
 		return fmt.Errorf("failed to decode transaction value: %w", err)
 	}
 
-	err = b.addBalanceTo(toAddrBytes, txValue)
-	if err != nil {
-		return fmt.Errorf("failed to add amount to balance: %w", err)
+	if batchTransfers != nil {
+		err = b.applyBatchTransfers(db, batchTransfers)
+		if err != nil {
+			return err
+		}
+	} else if escrow != nil {
+		err = b.lockContractEscrow(db, escrow)
+		if err != nil {
+			return err
+		}
+	} else {
+		err = b.addBalanceTo(db, toAddrBytes, txValue)
+		if err != nil {
+			return fmt.Errorf("failed to add amount to balance: %w", err)
+		}
 	}
 
-	err = b.addBalanceTo(verifierAddr, txFees)
+	err = b.addBalanceTo(db, verifierAddr, txFees)
 	if err != nil {
 		return fmt.Errorf("failed to add amount to verifier's balance: %w", err)
 	}
 
-	err = b.performStateUpdateFromDataPayload(&transaction)
+	for _, released := range releasedEscrows {
+		err = b.releaseContractEscrow(db, released)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = b.performStateUpdateFromDataPayload(db, &transaction)
 	if err != nil {
+		if strictDataPayload {
+			return fmt.Errorf("failed to perform state update from tx data payload: %w", err)
+		}
 		log.Errorf("failed to perform state update from tx data payload: %v", err)
 	}
 
 	return nil
 }
 
+// batchTransfersOf returns the transfers of a batch transfer transaction or nil for the other transactions.
+func batchTransfersOf(tx transaction.Transaction, isCoinbase bool) ([]transaction.BatchTransfer, error) {
+	if isCoinbase || !tx.IsBatchTransfer() {
+		return nil, nil
+	}
+	return tx.BatchTransfers()
+}
+
+// applyBatchTransfers adds the amounts of validated batch transfers to the recipients.
+func (b *Blockchain) applyBatchTransfers(db database.Database, transfers []transaction.BatchTransfer) error {
+	for _, t := range transfers {
+		to, err := hexutil.Decode(t.To)
+		if err != nil {
+			return fmt.Errorf("failed to decode batch transfer address: %w", err)
+		}
+
+		value, err := hexutil.DecodeBig(t.Value)
+		if err != nil {
+			return fmt.Errorf("failed to decode batch transfer value: %w", err)
+		}
+
+		err = b.addBalanceTo(db, to, value)
+		if err != nil {
+			return fmt.Errorf("failed to add batch transfer amount to balance: %w", err)
+		}
+	}
+	return nil
+}
+
 // performStateUpdateFromDataPayload performs updates from the transaction data.
 // operations allowed are related to updating blockchain settings and channel operations.
 // there could be arbitrary data in the transaction data field so trying to unmarshal first and
 // if failed then just return without any error.
-func (b *Blockchain) performStateUpdateFromDataPayload(tx *transaction.Transaction) error {
+func (b *Blockchain) performStateUpdateFromDataPayload(db database.Database, tx *transaction.Transaction) error {
 	dataPayload := transaction.DataPayload{}
 	err := proto.Unmarshal(tx.Data, &dataPayload)
 	if err != nil {
@@ -641,7 +838,7 @@ func (b *Blockchain) performStateUpdateFromDataPayload(tx *transaction.Transacti
 		}
 
 		for _, v := range downloadContracts.Contracts {
-			err = b.saveContractFromTransactionDataPayload(v, tx.Hash)
+			err = b.saveContractFromTransactionDataPayload(db, v, tx.Hash)
 			if err != nil {
 				return fmt.Errorf("failed to save contract in db: %w", err)
 			}
@@ -657,7 +854,7 @@ func (b *Blockchain) performStateUpdateFromDataPayload(tx *transaction.Transacti
 		}
 
 		for _, v := range downloadContracts.Contracts {
-			err = b.releaseFeesContractFromTransactionDataPayload(v, tx.Hash)
+			err = b.releaseFeesContractFromTransactionDataPayload(db, v, tx.Hash)
 			if err != nil {
 				return fmt.Errorf("failed to save release fees contract in db: %w", err)
 			}
@@ -706,11 +903,11 @@ func (b *Blockchain) performStateUpdateFromDataPayload(tx *transaction.Transacti
 				node.ParentHash = []byte{}
 				node.NodeHash = crypto.Sha256(data)
 
-				err = b.saveNode(node)
+				err = b.saveNode(db, node)
 				if err != nil {
 					return fmt.Errorf("failed to create channel node: %w", err)
 				}
-				err = b.saveAsChannel(node.NodeHash)
+				err = b.saveAsChannel(db, node.NodeHash)
 				if err != nil {
 					return fmt.Errorf("failed add to channel list: %w", err)
 				}
@@ -730,7 +927,7 @@ func (b *Blockchain) performStateUpdateFromDataPayload(tx *transaction.Transacti
 				node.NodeHash = crypto.Sha256(data)
 
 				// get parent
-				parentNode, err := b.GetNodeItem(node.ParentHash)
+				parentNode, err := b.getNodeItem(db, node.ParentHash)
 				if err != nil {
 					return fmt.Errorf("failed to get parent of node %s : %w", hexutil.Encode(node.ParentHash), err)
 				}
@@ -747,7 +944,7 @@ func (b *Blockchain) performStateUpdateFromDataPayload(tx *transaction.Transacti
 					rootNodeItem = parentNode
 				} else {
 					// traverse back to find root
-					rootItem, err := b.GetRootNodeItem(parentNode.NodeHash)
+					rootItem, err := b.getRootNodeItem(db, parentNode.NodeHash)
 					if err != nil {
 						return fmt.Errorf("failed to get root node item: %w", err)
 					}
@@ -769,29 +966,65 @@ func (b *Blockchain) performStateUpdateFromDataPayload(tx *transaction.Transacti
 					return errors.New("poster can't create channel and subchannel")
 				}
 
-				err = b.saveNode(node)
+				err = b.saveNode(db, node)
 				if err != nil {
 					return fmt.Errorf("failed to create node: %w", err)
 				}
-				err = b.saveNodeAsChildNode(parentNode.NodeHash, node.NodeHash)
+				err = b.saveNodeAsChildNode(db, parentNode.NodeHash, node.NodeHash)
 				if err != nil {
 					return fmt.Errorf("failed to save node child: %w", err)
 				}
 			}
 
-			nodeDescription := ""
-			if node.Description != nil {
-				nodeDescription = *node.Description
+			err = b.indexNodeItem(node)
+			if err != nil {
+				return fmt.Errorf("failed to index item into search engine: %w", err)
 			}
+		}
+	}
+
+	// change the verifier set
+	if dataPayload.Type == transaction.DataType_UPDATE_BLOCKCHAIN_SETTINGS {
+		err := b.updateBlockchainSettings(db, dataPayload.Payload)
+		if err != nil {
+			return fmt.Errorf("failed to update blockchain settings: %w", err)
+		}
+	}
+
+	// record the evidence of a verifier which signed two different blocks
+	if dataPayload.Type == transaction.DataType_DOUBLE_SIGN_EVIDENCE {
+		err := b.commitDoubleSignEvidence(db, dataPayload.Payload)
+		if err != nil {
+			return fmt.Errorf("failed to commit double sign evidence: %w", err)
+		}
+	}
+
+	// support updating multiple nodes
+	if dataPayload.Type == transaction.DataType_UPDATE_NODE {
+		nodesEnvelope := NodeItems{}
+		err := proto.Unmarshal(dataPayload.Payload, &nodesEnvelope)
+		if err != nil {
+			return nil
+		}
+
+		txFees, err := hexutil.DecodeBig(tx.TransactionFees)
+		if err != nil {
+			return fmt.Errorf("failed to get the transaction fee value while updating tx data payload: %w", err)
+		}
+
+		totalActionsFees := CalculateChannelUpdateFees(nodesEnvelope.Nodes)
+		if txFees.Cmp(totalActionsFees) == -1 {
+			return fmt.Errorf("total cost of channel actions (%s) are higher than the supplied transaction fee (%s)", totalActionsFees.Text(10), txFees.Text(10))
+		}
 
-			indexItem := search.IndexItem{
-				Hash:        hexutil.Encode(node.NodeHash),
-				Type:        int32(node.NodeType),
-				Name:        node.Name,
-				Description: nodeDescription,
+		fromBytes, _ := hexutil.Decode(tx.From)
+		for _, update := range nodesEnvelope.Nodes {
+			node, err := b.updateNode(db, update, fromBytes)
+			if err != nil {
+				return fmt.Errorf("failed to update node: %w", err)
 			}
 
-			err = b.search.Index(indexItem)
+			err = b.indexNodeItem(node)
 			if err != nil {
 				return fmt.Errorf("failed to index item into search engine: %w", err)
 			}
@@ -803,6 +1036,18 @@ func (b *Blockchain) performStateUpdateFromDataPayload(tx *transaction.Transacti
 
 // PerformStateUpdateFromBlock performs updates from a block.
 func (b *Blockchain) PerformStateUpdateFromBlock(validBlock block.Block) error {
+	b.stateMu.Lock()
+	defer b.stateMu.Unlock()
+
+	return b.performStateUpdateFromBlock(validBlock)
+}
+
+// performStateUpdateFromBlock applies a block and saves the undo log which can be used to revert it.
+func (b *Blockchain) performStateUpdateFromBlock(validBlock block.Block) error {
+	if b.snapshotImportInProgress() {
+		return errors.New("blocks can't be applied while a snapshot is imported")
+	}
+
 	_, err := b.GetBlockByHash(validBlock.Hash)
 	if err == nil {
 		return errors.New("block is already within the blockchain")
@@ -828,6 +1073,10 @@ func (b *Blockchain) PerformStateUpdateFromBlock(validBlock block.Block) error {
 		if validBlock.Timestamp < previousBlock.Timestamp {
 			return fmt.Errorf("previous block timestamp %d is bigger than the current block %d", previousBlock.Timestamp, validBlock.Timestamp)
 		}
+
+		if err := validBlock.ValidateSealingTime(previousBlock.Timestamp); err != nil {
+			return fmt.Errorf("failed to validate sealing time: %w", err)
+		}
 	}
 
 	coinbaseTx, err := validBlock.GetAndValidateCoinbaseTransaction()
@@ -840,56 +1089,78 @@ func (b *Blockchain) PerformStateUpdateFromBlock(validBlock block.Block) error {
 		return fmt.Errorf("failed to get address of verifier: %w", err)
 	}
 
-	for _, tx := range validBlock.Transactions {
-		isCoinbase, err := coinbaseTx.Equals(tx)
-		if err != nil {
-			return fmt.Errorf("failed to compare coinbase transaction: %w", err)
-		}
+	// all the state changes of the block are staged and committed in a single batch.
+	// the staged changes are only visible to the block being applied.
+	overlay, err := b.beginStateOverlay()
+	if err != nil {
+		return err
+	}
+	b.startJournal(validBlock.Number)
+	defer b.stopJournal()
 
-		err = b.PerformAddressStateUpdate(tx, verifierAddr, isCoinbase)
-		if err != nil {
-			log.Errorf("failed to update the state of blockchain: %v", err)
-			_ = b.DeleteFromMemPool(tx)
-			continue
-		}
+	err = b.applyBlockTransactions(overlay, validBlock, coinbaseTx, verifierAddr, true)
+	if err != nil {
+		return err
+	}
 
-		if !isCoinbase {
-			err = b.DeleteFromMemPool(tx)
-			if err != nil {
-				log.Warnf("failed to delete transaction from mempool: %v", err)
-			}
-		}
+	// the state tree is always kept up to date, blocks which commit to a state root must lead to the same state on every node
+	stateRoot, err := b.updateStateTree(overlay)
+	if err != nil {
+		return fmt.Errorf("failed to update state tree: %w", err)
 	}
 
-	if !isGenesisBlock {
-		b.IncrementHeightBy(1)
+	if err := b.validateStateRoot(validBlock, stateRoot); err != nil {
+		return err
 	}
 
-	err = b.SetLastBlockHash(validBlock.Hash)
+	err = b.setLastBlockHash(overlay, validBlock.Hash)
 	if err != nil {
 		return fmt.Errorf("failed to update last block hash in db: %w", err)
 	}
 
-	err = b.SaveBlockInDB(validBlock)
+	err = b.saveBlock(overlay, validBlock)
 	if err != nil {
 		return fmt.Errorf("failed to save genesis block in DB: %w", err)
 	}
 
-	err = b.indexBlockHashByBlockNumber(validBlock.Hash, validBlock.Number)
+	err = b.indexBlockHashByBlockNumber(overlay, validBlock.Hash, validBlock.Number)
 	if err != nil {
 		return fmt.Errorf("failed to index block hash by block number: %w", err)
 	}
 
-	err = b.indexTransactionsByAddresses(validBlock)
+	err = b.indexTransactionsByAddresses(overlay, validBlock)
 	if err != nil {
 		return fmt.Errorf("failed to index transactions by address: %w", err)
 	}
 
-	err = b.indexBlockTransactions(validBlock)
+	err = b.indexBlockTransactions(overlay, validBlock)
 	if err != nil {
 		return fmt.Errorf("failed to index block transactions: %w", err)
 	}
 
+	journal := b.stopJournal()
+	undoBatch, err := b.undoLogBatch(overlay, validBlock.Hash, journal)
+	if err != nil {
+		return fmt.Errorf("failed to create undo log: %w", err)
+	}
+
+	err = overlay.Commit(undoBatch)
+	if err != nil {
+		return fmt.Errorf("failed to commit block state: %w", err)
+	}
+
+	if !isGenesisBlock {
+		b.IncrementHeightBy(1)
+	}
+
+	b.indexNodeItems(journal.indexedNodes)
+	b.pruneMemPool()
+	b.finalizePendingCheckpoints()
+
+	if err := b.pruneBlocks(); err != nil {
+		log.Warnf("failed to prune blocks: %v", err)
+	}
+
 	b.lastBlockUpdateMu.Lock()
 	b.lastBlockUpdateAt = time.Now().Unix()
 	b.lastBlockUpdateMu.Unlock()
@@ -897,79 +1168,183 @@ func (b *Blockchain) PerformStateUpdateFromBlock(validBlock block.Block) error {
 	return nil
 }
 
-// GetNounceFromMemPool get the nounce of an address from mempool.
-func (b *Blockchain) GetNounceFromMemPool(address []byte) uint64 {
-	b.tmu.RLock()
-	defer b.tmu.RUnlock()
+// beginStateOverlay creates an overlay which stages the state changes of a block on top of the committed state.
+// the overlay is passed to the state updates of the block, every other reader uses the committed state.
+func (b *Blockchain) beginStateOverlay() (*database.Overlay, error) {
+	overlay, err := database.NewOverlay(b.db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create database overlay: %w", err)
+	}
 
-	tmp := uint64(0)
-	for _, v := range b.memPool {
-		if v.From == hexutil.Encode(address) {
-			nounce := hexutil.DecodeBigFromBytesToUint64(v.Nounce)
-			if nounce > tmp {
-				tmp = nounce
-			}
-		}
+	err = overlay.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin staging block state: %w", err)
 	}
-	return tmp
+	return overlay, nil
 }
 
-// PutMemPool adds a transaction to mempool.
-// validation of transaction should be done outside this function.
-func (b *Blockchain) PutMemPool(tx transaction.Transaction) error {
-	b.tmu.Lock()
-	defer b.tmu.Unlock()
+// updateCommittedState applies a state change outside of a block and commits it together with the state tree.
+func (b *Blockchain) updateCommittedState(update func(db database.Database) error) error {
+	overlay, err := b.beginStateOverlay()
+	if err != nil {
+		return err
+	}
 
-	for idx, transaction := range b.memPool {
-		// transaction is already in mempool with this nounce
-		// pick the one with higher fee
-		if bytes.Equal(transaction.Nounce, tx.Nounce) && transaction.From == tx.From {
-			txFees, err := hexutil.DecodeBig(tx.TransactionFees)
-			if err != nil {
-				return fmt.Errorf("failed to decode transaction fees: %w", err)
+	if err := update(overlay); err != nil {
+		overlay.Discard()
+		return err
+	}
+
+	if _, err := b.updateStateTree(overlay); err != nil {
+		overlay.Discard()
+		return fmt.Errorf("failed to update state tree: %w", err)
+	}
+
+	if err := overlay.Commit(nil); err != nil {
+		return fmt.Errorf("failed to commit state update: %w", err)
+	}
+	return nil
+}
+
+// applyBlockTransactions updates the state with the transactions of a block.
+// invalid transactions are skipped and removed from the mempool if updateMemPool is set.
+// a transaction outside of its validity window invalidates the whole block.
+// the staged changes of a transaction which fails are reverted, so it doesn't leave a partial update behind.
+func (b *Blockchain) applyBlockTransactions(overlay *database.Overlay, validBlock block.Block, coinbaseTx transaction.Transaction, verifierAddr []byte, updateMemPool bool) error {
+	err := b.refundExpiredContractEscrows(overlay, validBlock.Number)
+	if err != nil {
+		return fmt.Errorf("failed to refund expired contract escrows: %w", err)
+	}
+
+	for _, tx := range validBlock.Transactions {
+		isCoinbase, err := coinbaseTx.Equals(tx)
+		if err != nil {
+			return fmt.Errorf("failed to compare coinbase transaction: %w", err)
+		}
+
+		// a block can't contain a transaction outside of its validity window
+		if err := tx.ValidateValidityWindow(validBlock.Number); err != nil {
+			return fmt.Errorf("transaction %s can't be included in block %d: %w", hexutil.Encode(tx.Hash), validBlock.Number, err)
+		}
+
+		checkpoint := overlay.Checkpoint()
+		journalCheckpoint := b.journalCheckpoint()
+		err = b.performAddressStateUpdate(overlay, tx, verifierAddr, isCoinbase, false)
+		if err != nil {
+			log.Errorf("failed to update the state of blockchain: %v", err)
+			if rerr := overlay.RevertToCheckpoint(checkpoint); rerr != nil {
+				return fmt.Errorf("failed to revert transaction state: %w", rerr)
 			}
-			txFeesInMempool, err := hexutil.DecodeBig(transaction.TransactionFees)
-			if err != nil {
-				return fmt.Errorf("failed to decode transaction fees from mempool: %w", err)
+			b.revertJournal(journalCheckpoint)
+			if updateMemPool {
+				_ = b.DeleteFromMemPool(tx)
 			}
+			continue
+		}
 
-			if txFees.Cmp(txFeesInMempool) == 1 {
-				b.memPool[idx] = tx
-				return nil
+		if !isCoinbase && updateMemPool {
+			err = b.DeleteFromMemPool(tx)
+			if err != nil {
+				log.Warnf("failed to delete transaction from mempool: %v", err)
 			}
 		}
 	}
-
-	txHash := hexutil.Encode(tx.Hash)
-	b.memPool[txHash] = tx
 	return nil
 }
 
-// DeleteFromMemPool deletes a transaction from mempool.
-func (b *Blockchain) DeleteFromMemPool(tx transaction.Transaction) error {
+// SetMemPoolConfig replaces the mempool with an empty mempool which uses the given limits.
+// it should be called before the blockchain starts receiving transactions.
+func (b *Blockchain) SetMemPoolConfig(config mempool.Config) error {
+	pool, err := mempool.New(config, b.getStateNounce)
+	if err != nil {
+		return err
+	}
+
 	b.tmu.Lock()
 	defer b.tmu.Unlock()
 
-	txHash := hexutil.Encode(tx.Hash)
-	delete(b.memPool, txHash)
+	b.memPool = pool
 	return nil
 }
 
-// GetTransactionsFromPool get all the transactions from mempool.
-func (b *Blockchain) GetTransactionsFromPool() []transaction.Transaction {
+func (b *Blockchain) getMemPool() *mempool.Pool {
 	b.tmu.RLock()
 	defer b.tmu.RUnlock()
 
-	txs := make([]transaction.Transaction, 0, len(b.memPool))
-	for _, tx := range b.memPool {
-		txs = append(txs, tx)
+	return b.memPool
+}
+
+// getStateNounce returns the nounce of an address in the current state.
+func (b *Blockchain) getStateNounce(address string) uint64 {
+	addr, err := hexutil.Decode(address)
+	if err != nil {
+		return 0
+	}
+
+	state, err := b.GetAddressState(addr)
+	if err != nil {
+		return 0
+	}
+
+	nounce, err := state.GetNounce()
+	if err != nil {
+		return 0
+	}
+	return nounce
+}
+
+// GetNounceFromMemPool get the nounce of an address from mempool.
+func (b *Blockchain) GetNounceFromMemPool(address []byte) uint64 {
+	return b.getMemPool().HighestNounce(hexutil.Encode(address))
+}
+
+// PutMemPool adds a transaction to mempool.
+// validation of transaction should be done outside this function.
+// expired transactions are rejected.
+func (b *Blockchain) PutMemPool(tx transaction.Transaction) error {
+	if tx.IsExpired(b.GetHeight() + 1) {
+		return fmt.Errorf("transaction expired at block %d", tx.ValidUntil)
 	}
+	return b.getMemPool().Add(tx)
+}
+
+// DeleteFromMemPool deletes a transaction from mempool.
+func (b *Blockchain) DeleteFromMemPool(tx transaction.Transaction) error {
+	b.getMemPool().Remove(tx)
+	return nil
+}
 
-	return txs
+// GetTransactionsFromPool get all the transactions from mempool.
+func (b *Blockchain) GetTransactionsFromPool() []transaction.Transaction {
+	return b.getMemPool().Transactions()
+}
+
+// GetPendingTransactionsFromPool returns the executable transactions of the mempool ordered by fees per byte.
+// the transactions of an address are kept in nounce order.
+func (b *Blockchain) GetPendingTransactionsFromPool() []transaction.Transaction {
+	return b.getMemPool().Pending()
+}
+
+// GetFutureTransactionsFromPool returns the transactions of the mempool which have a nounce gap.
+func (b *Blockchain) GetFutureTransactionsFromPool() []transaction.Transaction {
+	return b.getMemPool().Future()
+}
+
+// pruneMemPool removes the expired transactions and the transactions which nounce is already used.
+// transactions which can't be included in the next block because of their validity window are expired too.
+func (b *Blockchain) pruneMemPool() {
+	if removed := b.getMemPool().Prune(b.GetHeight() + 1); removed > 0 {
+		log.Debugf("removed %d stale transactions from mempool", removed)
+	}
 }
 
 // SaveBlockInDB saves a block into the database.
 func (b *Blockchain) SaveBlockInDB(blck block.Block) error {
+	return b.saveBlock(b.db, blck)
+}
+
+// saveBlock saves a block into the given db.
+func (b *Blockchain) saveBlock(db database.Database, blck block.Block) error {
 	if len(blck.Hash) == 0 {
 		return errors.New("blockhash is empty")
 	}
@@ -978,7 +1353,7 @@ func (b *Blockchain) SaveBlockInDB(blck block.Block) error {
 	if err != nil {
 		return fmt.Errorf("failed to marshal protoblock: %w", err)
 	}
-	err = b.db.Put(append([]byte(blockPrefix), blck.Hash...), data)
+	err = db.Put(append([]byte(blockPrefix), blck.Hash...), data)
 	if err != nil {
 		return fmt.Errorf("failed to save data into db: %w", err)
 	}
@@ -1006,7 +1381,12 @@ func (b *Blockchain) GetBlockByHash(blockHash []byte) (block.Block, error) {
 
 // GetAddressState returns the state of the address from the db.
 func (b *Blockchain) GetAddressState(address []byte) (AddressState, error) {
-	data, err := b.db.Get(append([]byte(addressPrefix), address...))
+	return b.getAddressState(b.db, address)
+}
+
+// getAddressState returns the state of the address from the given db.
+func (b *Blockchain) getAddressState(db database.Database, address []byte) (AddressState, error) {
+	data, err := db.Get(append([]byte(addressPrefix), address...))
 	if err != nil {
 		return AddressState{}, fmt.Errorf("failed to get address state: %w", err)
 	}
@@ -1019,6 +1399,16 @@ func (b *Blockchain) GetAddressState(address []byte) (AddressState, error) {
 
 // UpdateAddressState updates the state of the address in the db.
 func (b *Blockchain) UpdateAddressState(address []byte, state AddressState) error {
+	b.stateMu.Lock()
+	defer b.stateMu.Unlock()
+
+	return b.updateCommittedState(func(db database.Database) error {
+		return b.updateAddressState(db, address, state)
+	})
+}
+
+// updateAddressState updates the state of the address in the given db.
+func (b *Blockchain) updateAddressState(db database.Database, address []byte, state AddressState) error {
 	if len(address) == 0 {
 		return errors.New("address is empty")
 	}
@@ -1029,7 +1419,7 @@ func (b *Blockchain) UpdateAddressState(address []byte, state AddressState) erro
 		return fmt.Errorf("failed to marshal address state: %w", err)
 	}
 
-	err = b.db.Put(append([]byte(addressPrefix), address...), data)
+	err = db.Put(append([]byte(addressPrefix), address...), data)
 	if err != nil {
 		return fmt.Errorf("failed to put to database: %w", err)
 	}
@@ -1051,17 +1441,17 @@ func (b *Blockchain) GetChannelsCount() uint64 {
 	return binary.BigEndian.Uint64(channelsCountBytes)
 }
 
-func (b *Blockchain) saveAsChannel(nodeHash []byte) error {
-	err := b.db.Put(append([]byte(channelPrefix), nodeHash...), []byte{})
+func (b *Blockchain) saveAsChannel(db database.Database, nodeHash []byte) error {
+	err := db.Put(append([]byte(channelPrefix), nodeHash...), []byte{})
 	if err != nil {
 		return fmt.Errorf("failed to insert node to channels: %w", err)
 	}
 
-	channelsCountBytes, err := b.db.Get([]byte(channelsCountPrefix))
+	channelsCountBytes, err := db.Get([]byte(channelsCountPrefix))
 	if err != nil || channelsCountBytes == nil {
 		channelsUint64 := make([]byte, 8)
 		binary.BigEndian.PutUint64(channelsUint64, 1)
-		err := b.db.Put([]byte(channelsCountPrefix), channelsUint64)
+		err := db.Put([]byte(channelsCountPrefix), channelsUint64)
 		if err != nil {
 			return fmt.Errorf("failed to insert to channels count: %w", err)
 		}
@@ -1072,7 +1462,7 @@ func (b *Blockchain) saveAsChannel(nodeHash []byte) error {
 	num++
 	channelsUint64 := make([]byte, 8)
 	binary.BigEndian.PutUint64(channelsUint64, num)
-	err = b.db.Put([]byte(channelsCountPrefix), channelsUint64)
+	err = db.Put([]byte(channelsCountPrefix), channelsUint64)
 	if err != nil {
 		return fmt.Errorf("failed to update channels count: %w", err)
 	}
@@ -1104,25 +1494,25 @@ func (b *Blockchain) GetNodeFileItemFromFileHash(fileHash []byte) ([]*NodeItem,
 	return items, nil
 }
 
-func (b *Blockchain) saveNode(node *NodeItem) error {
+func (b *Blockchain) saveNode(db database.Database, node *NodeItem) error {
 	nodeData, err := proto.Marshal(node)
 	if err != nil {
 		return fmt.Errorf("failed to marshal node item: %w", err)
 	}
 
-	_, err = b.GetNodeItem(node.NodeHash)
+	_, err = b.getNodeItem(db, node.NodeHash)
 	if err == nil {
 		return fmt.Errorf("node with this hash already exists in db %s", hexutil.Encode(node.NodeHash))
 	}
 
-	err = b.db.Put(append([]byte(nodePrefix), node.NodeHash...), nodeData)
+	err = db.Put(append([]byte(nodePrefix), node.NodeHash...), nodeData)
 	if err != nil {
 		return fmt.Errorf("failed to insert node item into db: %w", err)
 	}
 
 	if node.NodeType == NodeItemType_FILE {
 		prefixWithFileHash := append([]byte(fileNodePrefix), node.FileHash...)
-		err = b.db.Put(append(prefixWithFileHash, node.NodeHash...), []byte{})
+		err = db.Put(append(prefixWithFileHash, node.NodeHash...), []byte{})
 		if err != nil {
 			return fmt.Errorf("failed to insert file hash into db: %w", err)
 		}
@@ -1131,6 +1521,96 @@ func (b *Blockchain) saveNode(node *NodeItem) error {
 	return nil
 }
 
+// updateNode replaces the mutable fields of an existing node with the values of the update.
+// The name, type, owner and parent of a node can't be changed since they define its hash and position.
+func (b *Blockchain) updateNode(db database.Database, update *NodeItem, fromAddr []byte) (*NodeItem, error) {
+	node, err := b.getNodeItem(db, update.NodeHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node %s: %w", hexutil.Encode(update.NodeHash), err)
+	}
+
+	rootNodeItem := node
+	if node.NodeType != NodeItemType_CHANNEL {
+		rootNodeItem, err = b.getRootNodeItem(db, node.NodeHash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get root node item: %w", err)
+		}
+	}
+
+	owner, admin, poster := b.GetPermissionFromRootNode(rootNodeItem, fromAddr)
+	err = checkNodeUpdatePermissions(node, update, fromAddr, owner, admin, poster)
+	if err != nil {
+		return nil, err
+	}
+
+	node.Enabled = update.Enabled
+	node.Description = update.Description
+	node.ContentType = update.ContentType
+	node.Attributes = update.Attributes
+	node.Admins = update.Admins
+	node.Posters = update.Posters
+
+	nodeData, err := proto.Marshal(node)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal node item: %w", err)
+	}
+
+	err = db.Put(append([]byte(nodePrefix), node.NodeHash...), nodeData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update node item in db: %w", err)
+	}
+
+	return node, nil
+}
+
+// checkNodeUpdatePermissions checks if an address with the given channel permissions can apply the update to a node.
+func checkNodeUpdatePermissions(node, update *NodeItem, fromAddr []byte, owner, admin, poster bool) error {
+	adminsChanged := !equalAddresses(node.Admins, update.Admins)
+	postersChanged := !equalAddresses(node.Posters, update.Posters)
+
+	if node.NodeType == NodeItemType_CHANNEL {
+		if !owner && !admin {
+			return errors.New("only the owner and admins can update a channel")
+		}
+
+		if adminsChanged && !owner {
+			return errors.New("only the owner can change the admins of a channel")
+		}
+		return nil
+	}
+
+	if owner || admin {
+		return nil
+	}
+
+	if !bytes.Equal(node.Owner, fromAddr) {
+		return errors.New("posters and guests can only update their own nodes")
+	}
+
+	if !poster && node.NodeType != NodeItemType_OTHER {
+		return errors.New("only `other` nodes can be updated by guest")
+	}
+
+	if adminsChanged || postersChanged {
+		return errors.New("only the owner and admins can change the admins and posters")
+	}
+
+	return nil
+}
+
+func equalAddresses(a, b [][]byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if !bytes.Equal(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
 // DownloadContractInTransactionDataTxHash represents a contract metadata and a tx hash.
 type DownloadContractInTransactionDataTxHash struct {
 	TxHash                                 []byte
@@ -1169,14 +1649,14 @@ func (b *Blockchain) GetDownloadContractInTransactionDataTransactionHash(contrac
 }
 
 // saveContractFromTransactionDataPayload saves a contract in the blockchain when a transaction is updating the blockchain state.
-func (b *Blockchain) saveContractFromTransactionDataPayload(contractInfo *messages.DownloadContractInTransactionDataProto, txHash []byte) error {
+func (b *Blockchain) saveContractFromTransactionDataPayload(db database.Database, contractInfo *messages.DownloadContractInTransactionDataProto, txHash []byte) error {
 	contactInfoBytes, err := proto.Marshal(contractInfo)
 	if err != nil {
 		return fmt.Errorf("failed to marshal contract info: %w", err)
 	}
 
 	prefixWithContractHash := append([]byte(contractPrefix), contractInfo.ContractHash...)
-	err = b.db.Put(append(prefixWithContractHash, txHash...), contactInfoBytes)
+	err = db.Put(append(prefixWithContractHash, txHash...), contactInfoBytes)
 	if err != nil {
 		return fmt.Errorf("failed to insert contract into db: %w", err)
 	}
@@ -1216,14 +1696,14 @@ func (b *Blockchain) GetReleasedFeesOfDownloadContractInTransactionData(contract
 }
 
 // releaseFeesContractFromTransactionDataPayload releases the contract fees to the file hoster in the blockchain when a transaction is updating the blockchain state.
-func (b *Blockchain) releaseFeesContractFromTransactionDataPayload(contractInfo *messages.DownloadContractInTransactionDataProto, txHash []byte) error {
+func (b *Blockchain) releaseFeesContractFromTransactionDataPayload(db database.Database, contractInfo *messages.DownloadContractInTransactionDataProto, txHash []byte) error {
 	contactInfoBytes, err := proto.Marshal(contractInfo)
 	if err != nil {
 		return fmt.Errorf("failed to marshal contract info: %w", err)
 	}
 
 	prefixWithContractHash := append([]byte(contractFeesReleasePrefix), contractInfo.ContractHash...)
-	err = b.db.Put(append(prefixWithContractHash, txHash...), contactInfoBytes)
+	err = db.Put(append(prefixWithContractHash, txHash...), contactInfoBytes)
 	if err != nil {
 		return fmt.Errorf("failed to insert contract into db: %w", err)
 	}
@@ -1231,9 +1711,9 @@ func (b *Blockchain) releaseFeesContractFromTransactionDataPayload(contractInfo
 	return nil
 }
 
-func (b *Blockchain) saveNodeAsChildNode(parentHash, childHash []byte) error {
+func (b *Blockchain) saveNodeAsChildNode(db database.Database, parentHash, childHash []byte) error {
 	prefixWithNodeNodes := append([]byte(nodeNodesPrefix), parentHash...)
-	err := b.db.Put(append(prefixWithNodeNodes, childHash...), []byte{})
+	err := db.Put(append(prefixWithNodeNodes, childHash...), []byte{})
 	if err != nil {
 		return fmt.Errorf("failed to insert child node item under parent node: %w", err)
 	}
@@ -1339,7 +1819,12 @@ func (b *Blockchain) GetChannels(limit, offset int) ([]*NodeItem, error) {
 
 // GetNodeItem get a node.
 func (b *Blockchain) GetNodeItem(nodeHash []byte) (*NodeItem, error) {
-	nodeData, err := b.db.Get(append([]byte(nodePrefix), nodeHash...))
+	return b.getNodeItem(b.db, nodeHash)
+}
+
+// getNodeItem gets a node from the given db.
+func (b *Blockchain) getNodeItem(db database.Database, nodeHash []byte) (*NodeItem, error) {
+	nodeData, err := db.Get(append([]byte(nodePrefix), nodeHash...))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get node item from database: %w", err)
 	}
@@ -1353,12 +1838,17 @@ func (b *Blockchain) GetNodeItem(nodeHash []byte) (*NodeItem, error) {
 
 // GetParentNodeItem get a node.
 func (b *Blockchain) GetParentNodeItem(nodeHash []byte) (*NodeItem, error) {
-	nodeItem, err := b.GetNodeItem(nodeHash)
+	return b.getParentNodeItem(b.db, nodeHash)
+}
+
+// getParentNodeItem gets the parent of a node from the given db.
+func (b *Blockchain) getParentNodeItem(db database.Database, nodeHash []byte) (*NodeItem, error) {
+	nodeItem, err := b.getNodeItem(db, nodeHash)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find node: %w", err)
 	}
 
-	parentItem, err := b.GetNodeItem(nodeItem.ParentHash)
+	parentItem, err := b.getNodeItem(db, nodeItem.ParentHash)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find parent node: %w", err)
 	}
@@ -1367,11 +1857,16 @@ func (b *Blockchain) GetParentNodeItem(nodeHash []byte) (*NodeItem, error) {
 
 // GetRootNodeItem traverse back until root node is reached and its a channel node.
 func (b *Blockchain) GetRootNodeItem(nodeHash []byte) (*NodeItem, error) {
+	return b.getRootNodeItem(b.db, nodeHash)
+}
+
+// getRootNodeItem traverses back the given db until the channel of the node is reached.
+func (b *Blockchain) getRootNodeItem(db database.Database, nodeHash []byte) (*NodeItem, error) {
 	var lastFoundNodeItem *NodeItem
 	nodeHashToFind := make([]byte, len(nodeHash))
 	copy(nodeHashToFind, nodeHash)
 	for {
-		nodeItem, err := b.GetParentNodeItem(nodeHashToFind)
+		nodeItem, err := b.getParentNodeItem(db, nodeHashToFind)
 		if err != nil {
 			break
 		}
@@ -1461,3 +1956,10 @@ func CalculateChannelActionsFees(nodes []*NodeItem) *big.Int {
 
 	return totalFees
 }
+
+// CalculateChannelUpdateFees given a list of node item updates it calculates the amount of fees required.
+func CalculateChannelUpdateFees(nodes []*NodeItem) *big.Int {
+	oneMiliFFG := currency.MiliFFG()
+	fees := oneMiliFFG.Mul(oneMiliFFG, big.NewInt(RemainingChannelOperationFeesMiliFFG))
+	return fees.Mul(fees, big.NewInt(int64(len(nodes))))
+}
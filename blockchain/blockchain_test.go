@@ -3,6 +3,7 @@ package blockchain
 import (
 	"bytes"
 	"context"
+	"errors"
 	"math/big"
 	"os"
 	"testing"
@@ -18,6 +19,7 @@ import (
 	"github.com/filefilego/filefilego/transaction"
 	"github.com/stretchr/testify/assert"
 	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -441,7 +443,7 @@ func TestMemPoolBlockPoolMethods(t *testing.T) {
 
 	accountAddr := []byte{12}
 	amount := big.NewInt(10)
-	err = blockchain.addBalanceTo(accountAddr, amount)
+	err = blockchain.addBalanceTo(blockchain.db, accountAddr, amount)
 	assert.NoError(t, err)
 	newState, err := blockchain.GetAddressState(accountAddr)
 	assert.NoError(t, err)
@@ -452,11 +454,11 @@ func TestMemPoolBlockPoolMethods(t *testing.T) {
 	assert.Equal(t, "10", newStateBalance.String())
 
 	// subtract a bigger amount than what is in db
-	err = blockchain.subBalanceFrom(accountAddr, amount.Add(amount, amount), 10)
+	err = blockchain.subBalanceFrom(blockchain.db, accountAddr, amount.Add(amount, amount), 10)
 	assert.EqualError(t, err, "failed to subtract: amount is greater than balance")
 
 	// subtract the right amount
-	err = blockchain.subBalanceFrom(accountAddr, big.NewInt(10), 11)
+	err = blockchain.subBalanceFrom(blockchain.db, accountAddr, big.NewInt(10), 11)
 	assert.NoError(t, err)
 
 	// balance should be zero
@@ -471,7 +473,7 @@ func TestMemPoolBlockPoolMethods(t *testing.T) {
 
 	// add a negative big int
 	negativeBig := big.NewInt(-10)
-	err = blockchain.addBalanceTo(accountAddr, negativeBig)
+	err = blockchain.addBalanceTo(blockchain.db, accountAddr, negativeBig)
 	assert.EqualError(t, err, "amount is negative")
 	newState, err = blockchain.GetAddressState(accountAddr)
 	assert.NoError(t, err)
@@ -480,13 +482,352 @@ func TestMemPoolBlockPoolMethods(t *testing.T) {
 	assert.Equal(t, "0", newBalance.String())
 
 	// subtract negative
-	err = blockchain.subBalanceFrom(accountAddr, negativeBig, 12)
+	err = blockchain.subBalanceFrom(blockchain.db, accountAddr, negativeBig, 12)
 	assert.EqualError(t, err, "amount is negative")
 
 	err = blockchain.CloseDB()
 	assert.NoError(t, err)
 }
 
+func TestPutBlockPoolReorganization(t *testing.T) {
+	genesisblockValid, err := block.GetGenesisBlock()
+	assert.NoError(t, err)
+	db, err := leveldb.OpenFile("reorg.db", nil)
+	assert.NoError(t, err)
+
+	driver, err := database.New(db)
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		db.Close()
+		os.RemoveAll("reorg.db")
+	})
+
+	blockchain, err := New(driver, &search.Search{}, genesisblockValid.Hash)
+	assert.NoError(t, err)
+
+	err = blockchain.InitOrLoad(true)
+	assert.NoError(t, err)
+
+	signBlock := func(blck *block.Block, previous []byte, kp crypto.KeyPair) {
+		blck.PreviousBlockHash = make([]byte, len(previous))
+		copy(blck.PreviousBlockHash, previous)
+		err := blck.Sign(kp.PrivateKey)
+		assert.NoError(t, err)
+		pubKeyBytes, err := kp.PublicKey.Raw()
+		assert.NoError(t, err)
+		block.SetBlockVerifiers(block.Verifier{
+			Address:   kp.Address,
+			PublicKey: hexutil.Encode(pubKeyBytes),
+		})
+	}
+
+	// chain A: genesis -> a1
+	a1, kpA, _ := validBlock(t, 1)
+	signBlock(a1, genesisblockValid.Hash, kpA)
+	err = blockchain.PutBlockPool(*a1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), blockchain.GetHeight())
+	assert.EqualValues(t, a1.Hash, blockchain.GetLastBlockHash())
+
+	addrA, err := hexutil.Decode(kpA.Address)
+	assert.NoError(t, err)
+	_, err = blockchain.GetAddressState(addrA)
+	assert.NoError(t, err)
+
+	// chain B: genesis -> b1 -> b2
+	b1, kpB, _ := validBlock(t, 1)
+	signBlock(b1, genesisblockValid.Hash, kpB)
+	b2, _, _ := validBlock(t, 2)
+	b2.Transactions[0].PublicKey, err = kpB.PublicKey.Raw()
+	assert.NoError(t, err)
+	b2.Transactions[0].From = kpB.Address
+	b2.Transactions[0].To = kpB.Address
+	err = b2.Transactions[0].Sign(kpB.PrivateKey)
+	assert.NoError(t, err)
+	signBlock(b2, b1.Hash, kpB)
+
+	// b2 can't be applied without its parent
+	err = blockchain.PutBlockPool(*b2)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), blockchain.GetHeight())
+	assert.Len(t, blockchain.GetBlocksFromPool(), 1)
+
+	// chain B is longer, so the chain is reorganized
+	err = blockchain.PutBlockPool(*b1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), blockchain.GetHeight())
+	assert.EqualValues(t, b2.Hash, blockchain.GetLastBlockHash())
+
+	blockByNumber, err := blockchain.GetBlockByNumber(1)
+	assert.NoError(t, err)
+	assert.EqualValues(t, b1.Hash, blockByNumber.Hash)
+
+	// state of a1 was reverted
+	_, err = blockchain.GetAddressState(addrA)
+	assert.Error(t, err)
+	txs, _, err := blockchain.GetTransactionByHash(a1.Transactions[1].Hash)
+	assert.NoError(t, err)
+	assert.Len(t, txs, 0)
+	_, err = blockchain.db.Get(append([]byte(undoPrefix), a1.Hash...))
+	assert.Error(t, err)
+
+	// a1 is kept in the block pool and its transaction is back in the mempool
+	blocks := blockchain.GetBlocksFromPool()
+	assert.Len(t, blocks, 1)
+	assert.EqualValues(t, a1.Hash, blocks[0].Hash)
+	transactions := blockchain.GetTransactionsFromPool()
+	assert.Len(t, transactions, 1)
+	assert.EqualValues(t, a1.Transactions[1].Hash, transactions[0].Hash)
+
+	// an old block is dropped once the chain moves on
+	err = blockchain.PutBlockPool(*b1)
+	assert.NoError(t, err)
+	assert.Len(t, blockchain.GetBlocksFromPool(), 1)
+}
+
+func TestIsPreferredTip(t *testing.T) {
+	lower, _, _ := validBlock(t, 1)
+	higher, _, _ := validBlock(t, 2)
+	assert.True(t, isPreferredTip(*higher, *lower))
+	assert.False(t, isPreferredTip(*lower, *higher))
+
+	a, _, _ := validBlock(t, 1)
+	b, _, _ := validBlock(t, 1)
+	if bytes.Compare(blockVerifierAddress(*a), blockVerifierAddress(*b)) > 0 {
+		a, b = b, a
+	}
+	assert.True(t, isPreferredTip(*a, *b))
+	assert.False(t, isPreferredTip(*b, *a))
+
+	// same verifier, lower hash wins
+	c := *a
+	c.Hash = []byte{0}
+	a.Hash = []byte{1}
+	assert.True(t, isPreferredTip(c, *a))
+	assert.False(t, isPreferredTip(*a, c))
+}
+
+func TestPerformStateUpdateFromBlockIsAtomic(t *testing.T) {
+	genesisblockValid, err := block.GetGenesisBlock()
+	assert.NoError(t, err)
+	db, err := leveldb.OpenFile("atomic.db", nil)
+	assert.NoError(t, err)
+
+	driver, err := database.New(db)
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		db.Close()
+		os.RemoveAll("atomic.db")
+	})
+
+	failingDB := &failingWriteDB{Database: driver}
+	blockchain, err := New(failingDB, &search.Search{}, genesisblockValid.Hash)
+	assert.NoError(t, err)
+
+	err = blockchain.InitOrLoad(true)
+	assert.NoError(t, err)
+
+	validBlock, kp, _ := validBlock(t, 1)
+	validBlock.PreviousBlockHash = make([]byte, len(genesisblockValid.Hash))
+	copy(validBlock.PreviousBlockHash, genesisblockValid.Hash)
+	err = validBlock.Sign(kp.PrivateKey)
+	assert.NoError(t, err)
+	pubKeyBytes, err := kp.PublicKey.Raw()
+	assert.NoError(t, err)
+	block.SetBlockVerifiers(block.Verifier{
+		Address:   kp.Address,
+		PublicKey: hexutil.Encode(pubKeyBytes),
+	})
+
+	// the commit fails so nothing of the block is written
+	failingDB.fail = true
+	err = blockchain.PerformStateUpdateFromBlock(*validBlock)
+	assert.ErrorContains(t, err, "failed to commit block state")
+	assert.Equal(t, uint64(0), blockchain.GetHeight())
+	assert.EqualValues(t, genesisblockValid.Hash, blockchain.GetLastBlockHash())
+	_, err = blockchain.GetBlockByHash(validBlock.Hash)
+	assert.Error(t, err)
+	addr, err := hexutil.Decode(kp.Address)
+	assert.NoError(t, err)
+	_, err = blockchain.GetAddressState(addr)
+	assert.Error(t, err)
+
+	// the block can be applied once the database works again
+	failingDB.fail = false
+	err = blockchain.PerformStateUpdateFromBlock(*validBlock)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), blockchain.GetHeight())
+	assert.EqualValues(t, validBlock.Hash, blockchain.GetLastBlockHash())
+	_, err = blockchain.GetAddressState(addr)
+	assert.NoError(t, err)
+	_, err = driver.Get(append([]byte(undoPrefix), validBlock.Hash...))
+	assert.NoError(t, err)
+}
+
+func TestPerformStateUpdateFromBlockRevertsFailedTransaction(t *testing.T) {
+	genesisblockValid, err := block.GetGenesisBlock()
+	assert.NoError(t, err)
+	db, err := leveldb.OpenFile("revertedtx.db", nil)
+	assert.NoError(t, err)
+	driver, err := database.New(db)
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		db.Close()
+		os.RemoveAll("revertedtx.db")
+	})
+	blockchain, err := New(driver, &search.Search{}, genesisblockValid.Hash)
+	assert.NoError(t, err)
+	err = blockchain.InitOrLoad(true)
+	assert.NoError(t, err)
+
+	// the recipient address is empty, so the transaction fails after the sender was debited
+	validBlock, kp, _ := validBlock(t, 1)
+	validBlock.PreviousBlockHash = genesisblockValid.Hash
+	validBlock.Transactions[1].To = "0x"
+	assert.NoError(t, validBlock.Transactions[1].Sign(kp.PrivateKey))
+	assert.NoError(t, validBlock.Sign(kp.PrivateKey))
+	pubKeyBytes, err := kp.PublicKey.Raw()
+	assert.NoError(t, err)
+	block.SetBlockVerifiers(block.Verifier{
+		Address:   kp.Address,
+		PublicKey: hexutil.Encode(pubKeyBytes),
+	})
+
+	err = blockchain.PerformStateUpdateFromBlock(*validBlock)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), blockchain.GetHeight())
+
+	// only the coinbase transaction changed the state of the sender
+	addr, err := hexutil.Decode(kp.Address)
+	assert.NoError(t, err)
+	state, err := blockchain.GetAddressState(addr)
+	assert.NoError(t, err)
+	balance, err := state.GetBalance()
+	assert.NoError(t, err)
+	assert.Equal(t, validBlock.Transactions[0].Value, hexutil.EncodeBig(balance))
+	nounce, err := state.GetNounce()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), nounce)
+}
+
+func TestPerformStateUpdateFromBlockRejectsTransactionOutsideOfValidityWindow(t *testing.T) {
+	genesisblockValid, err := block.GetGenesisBlock()
+	assert.NoError(t, err)
+	db, err := leveldb.OpenFile("validitywindow.db", nil)
+	assert.NoError(t, err)
+	driver, err := database.New(db)
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		db.Close()
+		os.RemoveAll("validitywindow.db")
+	})
+	blockchain, err := New(driver, &search.Search{}, genesisblockValid.Hash)
+	assert.NoError(t, err)
+	err = blockchain.InitOrLoad(true)
+	assert.NoError(t, err)
+
+	validBlock, kp, _ := validBlock(t, 1)
+	validBlock.PreviousBlockHash = genesisblockValid.Hash
+	validBlock.Transactions[1].ValidAfter = 1
+	assert.NoError(t, validBlock.Transactions[1].Sign(kp.PrivateKey))
+	assert.NoError(t, validBlock.Sign(kp.PrivateKey))
+	pubKeyBytes, err := kp.PublicKey.Raw()
+	assert.NoError(t, err)
+	block.SetBlockVerifiers(block.Verifier{
+		Address:   kp.Address,
+		PublicKey: hexutil.Encode(pubKeyBytes),
+	})
+
+	err = blockchain.PerformStateUpdateFromBlock(*validBlock)
+	assert.ErrorContains(t, err, "can't be included in block 1: transaction is not valid before block 2")
+	assert.Equal(t, uint64(0), blockchain.GetHeight())
+	addr, err := hexutil.Decode(kp.Address)
+	assert.NoError(t, err)
+	_, err = blockchain.GetAddressState(addr)
+	assert.Error(t, err)
+}
+
+func TestPutBlockPoolAndWait(t *testing.T) {
+	genesisblockValid, err := block.GetGenesisBlock()
+	assert.NoError(t, err)
+	db, err := leveldb.OpenFile("blockpoolwait.db", nil)
+	assert.NoError(t, err)
+	driver, err := database.New(db)
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		db.Close()
+		os.RemoveAll("blockpoolwait.db")
+	})
+	blockchain, err := New(driver, &search.Search{}, genesisblockValid.Hash)
+	assert.NoError(t, err)
+	err = blockchain.InitOrLoad(true)
+	assert.NoError(t, err)
+
+	validBlock, kp, _ := validBlock(t, 1)
+	validBlock.PreviousBlockHash = genesisblockValid.Hash
+	assert.NoError(t, validBlock.Sign(kp.PrivateKey))
+	pubKeyBytes, err := kp.PublicKey.Raw()
+	assert.NoError(t, err)
+	block.SetBlockVerifiers(block.Verifier{
+		Address:   kp.Address,
+		PublicKey: hexutil.Encode(pubKeyBytes),
+	})
+
+	// while the pool is being applied, the block is only added to the pool
+	blockchain.blockPoolMu.Lock()
+	assert.NoError(t, blockchain.PutBlockPool(*validBlock))
+	assert.Equal(t, uint64(0), blockchain.GetHeight())
+	assert.Len(t, blockchain.GetBlocksFromPool(), 1)
+
+	// the block is applied once the pool is released
+	done := make(chan error)
+	go func() {
+		done <- blockchain.PutBlockPoolAndWait(*validBlock)
+	}()
+	blockchain.blockPoolMu.Unlock()
+	assert.NoError(t, <-done)
+	assert.Equal(t, uint64(1), blockchain.GetHeight())
+	assert.Empty(t, blockchain.GetBlocksFromPool())
+}
+
+func TestStagedStateIsOnlyVisibleToTheOverlay(t *testing.T) {
+	genesisblockValid, err := block.GetGenesisBlock()
+	assert.NoError(t, err)
+	db, err := leveldb.OpenFile("stagedstate.db", nil)
+	assert.NoError(t, err)
+	driver, err := database.New(db)
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		db.Close()
+		os.RemoveAll("stagedstate.db")
+	})
+	blockchain, err := New(driver, &search.Search{}, genesisblockValid.Hash)
+	assert.NoError(t, err)
+
+	overlay, err := blockchain.beginStateOverlay()
+	assert.NoError(t, err)
+	err = blockchain.addBalanceTo(overlay, []byte{1}, big.NewInt(10))
+	assert.NoError(t, err)
+
+	// the staged balance is only visible through the overlay
+	_, err = blockchain.GetAddressState([]byte{1})
+	assert.Error(t, err)
+	state, err := blockchain.getAddressState(overlay, []byte{1})
+	assert.NoError(t, err)
+	balance, err := state.GetBalance()
+	assert.NoError(t, err)
+	assert.Equal(t, "10", balance.String())
+
+	// readers see the balance once it's committed
+	err = overlay.Commit(nil)
+	assert.NoError(t, err)
+	state, err = blockchain.GetAddressState([]byte{1})
+	assert.NoError(t, err)
+	balance, err = state.GetBalance()
+	assert.NoError(t, err)
+	assert.Equal(t, "10", balance.String())
+}
+
 func TestChannelFunctionality(t *testing.T) {
 	genesisblockValid, err := block.GetGenesisBlock()
 	assert.NoError(t, err)
@@ -520,11 +861,11 @@ func TestChannelFunctionality(t *testing.T) {
 		Admins:   [][]byte{fromAddrAdmin},
 	}
 	// saveNode
-	err = blockchain.saveNode(&channelNode)
+	err = blockchain.saveNode(blockchain.db, &channelNode)
 	assert.NoError(t, err)
 
 	// saveAsChannel
-	err = blockchain.saveAsChannel(channelNode.NodeHash)
+	err = blockchain.saveAsChannel(blockchain.db, channelNode.NodeHash)
 	assert.NoError(t, err)
 
 	// GetNodeItem
@@ -546,11 +887,11 @@ func TestChannelFunctionality(t *testing.T) {
 		NodeHash:   []byte{33},
 		ParentHash: channelNode.NodeHash,
 	}
-	err = blockchain.saveNode(&childNode)
+	err = blockchain.saveNode(blockchain.db, &childNode)
 	assert.NoError(t, err)
 
 	// saveNodeAsChildNode
-	err = blockchain.saveNodeAsChildNode(channelNode.NodeHash, childNode.NodeHash)
+	err = blockchain.saveNodeAsChildNode(blockchain.db, channelNode.NodeHash, childNode.NodeHash)
 	assert.NoError(t, err)
 	newChildNodes, err := blockchain.GetChildNodeItems(channelNode.NodeHash)
 	assert.NoError(t, err)
@@ -569,11 +910,11 @@ func TestChannelFunctionality(t *testing.T) {
 		ParentHash: childNode.NodeHash,
 		NodeType:   NodeItemType_ENTRY,
 	}
-	err = blockchain.saveNode(&childChildNode)
+	err = blockchain.saveNode(blockchain.db, &childChildNode)
 	assert.NoError(t, err)
 
 	// saveNodeAsChildNode
-	err = blockchain.saveNodeAsChildNode(childNode.NodeHash, childChildNode.NodeHash)
+	err = blockchain.saveNodeAsChildNode(blockchain.db, childNode.NodeHash, childChildNode.NodeHash)
 	assert.NoError(t, err)
 	newChildChildNodes, err := blockchain.GetChildNodeItems(childNode.NodeHash)
 	assert.NoError(t, err)
@@ -655,18 +996,18 @@ func TestPerformStateUpdateFromDataPayload(t *testing.T) {
 		TransactionFees: "0x0",
 		Chain:           mainChain,
 	}
-	err = blockchain.performStateUpdateFromDataPayload(txWithChannelPayload)
+	err = blockchain.performStateUpdateFromDataPayload(blockchain.db, txWithChannelPayload)
 	assert.EqualError(t, err, "total cost of channel actions (20000000000000000000000) are higher than the supplied transaction fee (0)")
 	fees := currency.FFG().Mul(currency.FFG(), big.NewInt(ChannelCreationFeesFFG))
 	txWithChannelPayload.TransactionFees = "0x" + fees.Text(16)
-	err = blockchain.performStateUpdateFromDataPayload(txWithChannelPayload)
+	err = blockchain.performStateUpdateFromDataPayload(blockchain.db, txWithChannelPayload)
 	assert.NoError(t, err)
 	channels, err := blockchain.GetChannels(10, 0)
 	assert.NoError(t, err)
 	assert.Len(t, channels, 1)
 	assert.Equal(t, "channel one", channels[0].Name)
 	// creating the same channel again should be an error
-	err = blockchain.performStateUpdateFromDataPayload(txWithChannelPayload)
+	err = blockchain.performStateUpdateFromDataPayload(blockchain.db, txWithChannelPayload)
 	assert.EqualError(t, err, "failed to create channel node: node with this hash already exists in db 0x20148fb96726ef3ff2e0c6ee73458dda99f6c9b7914ee1aec918f8ac35e949d0")
 
 	// create another node with subchannel and assert the error with the required fees
@@ -690,10 +1031,10 @@ func TestPerformStateUpdateFromDataPayload(t *testing.T) {
 		TransactionFees: "0x0",
 		Chain:           mainChain,
 	}
-	err = blockchain.performStateUpdateFromDataPayload(txWithChannelPayload2)
+	err = blockchain.performStateUpdateFromDataPayload(blockchain.db, txWithChannelPayload2)
 	assert.EqualError(t, err, "total cost of channel actions (50000000000000000) are higher than the supplied transaction fee (0)")
 	txWithChannelPayload2.TransactionFees = "0x" + fees.Text(16)
-	err = blockchain.performStateUpdateFromDataPayload(txWithChannelPayload2)
+	err = blockchain.performStateUpdateFromDataPayload(blockchain.db, txWithChannelPayload2)
 	assert.NoError(t, err)
 	subchan, err := blockchain.GetChildNodeItems(channels[0].NodeHash)
 	assert.NoError(t, err)
@@ -775,7 +1116,7 @@ func TestPerformStateUpdateFromDataPayload(t *testing.T) {
 		TransactionFees: "0x" + fees.Mul(fees, big.NewInt(4)).Text(16),
 		Chain:           mainChain,
 	}
-	err = blockchain.performStateUpdateFromDataPayload(txWithChannelPayload3)
+	err = blockchain.performStateUpdateFromDataPayload(blockchain.db, txWithChannelPayload3)
 	assert.NoError(t, err)
 
 	allChannels, err := blockchain.GetChannels(10, 0)
@@ -839,7 +1180,7 @@ func TestPerformStateUpdateFromDataPayload(t *testing.T) {
 		TransactionFees: "0x" + fees.Mul(fees, big.NewInt(4)).Text(16),
 		Chain:           mainChain,
 	}
-	err = blockchain.performStateUpdateFromDataPayload(txWithContractPayload)
+	err = blockchain.performStateUpdateFromDataPayload(blockchain.db, txWithContractPayload)
 	assert.NoError(t, err)
 
 	contractMetadata, err := blockchain.GetDownloadContractInTransactionDataTransactionHash([]byte{23})
@@ -854,6 +1195,187 @@ func TestPerformStateUpdateFromDataPayload(t *testing.T) {
 	assert.Equal(t, "0x5", contractMetadata[0].DownloadContractInTransactionDataProto.FileHosterFees)
 }
 
+func TestPerformStateUpdateFromUpdateNodePayload(t *testing.T) {
+	genesisblockValid, err := block.GetGenesisBlock()
+	assert.NoError(t, err)
+	db, err := leveldb.OpenFile("updatenode.db", nil)
+	assert.NoError(t, err)
+	driver, err := database.New(db)
+	assert.NoError(t, err)
+	blv, err := search.NewBleveSearch("updatenodeSearch.db")
+	assert.NoError(t, err)
+	searchEngine, err := search.New(blv)
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		db.Close()
+		searchEngine.Close()
+		os.RemoveAll("updatenode.db")
+		os.RemoveAll("updatenodeSearch.db")
+	})
+
+	blockchain, err := New(driver, searchEngine, genesisblockValid.Hash)
+	assert.NoError(t, err)
+
+	ownerAddrString := "0xdd9a374e8dce9d656073ec153580301b7d2c3850"
+	ownerAddr, err := hexutil.Decode(ownerAddrString)
+	assert.NoError(t, err)
+	adminAddrString := "0x01"
+	adminAddr, err := hexutil.Decode(adminAddrString)
+	assert.NoError(t, err)
+	posterAddrString := "0x02"
+	posterAddr, err := hexutil.Decode(posterAddrString)
+	assert.NoError(t, err)
+	guestAddrString := "0x03"
+	mainChain, err := hexutil.Decode("0x01")
+	assert.NoError(t, err)
+	fees := currency.FFG().Mul(currency.FFG(), big.NewInt(ChannelCreationFeesFFG))
+	feesHex := "0x" + fees.Text(16)
+
+	newTx := func(from string, data []byte) *transaction.Transaction {
+		return &transaction.Transaction{
+			Nounce:          []byte{1},
+			From:            from,
+			To:              from,
+			Data:            data,
+			Value:           "0x0",
+			TransactionFees: feesHex,
+			Chain:           mainChain,
+		}
+	}
+
+	err = blockchain.performStateUpdateFromDataPayload(blockchain.db, newTx(ownerAddrString, transactionWithChannelPayload(t, []*NodeItem{
+		{
+			Name:        "channel one",
+			NodeType:    NodeItemType_CHANNEL,
+			Timestamp:   time.Now().Unix(),
+			Description: proto.String("descriptoin with typo"),
+		},
+	})))
+	assert.NoError(t, err)
+	channels, err := blockchain.GetChannels(10, 0)
+	assert.NoError(t, err)
+	assert.Len(t, channels, 1)
+	channel := channels[0]
+
+	// not enough fees
+	updateTx := newTx(ownerAddrString, transactionWithUpdateNodePayload(t, []*NodeItem{{NodeHash: channel.NodeHash}}))
+	updateTx.TransactionFees = "0x0"
+	err = blockchain.performStateUpdateFromDataPayload(blockchain.db, updateTx)
+	assert.EqualError(t, err, "total cost of channel actions (50000000000000000) are higher than the supplied transaction fee (0)")
+
+	// node doesn't exist
+	err = blockchain.performStateUpdateFromDataPayload(blockchain.db, newTx(ownerAddrString, transactionWithUpdateNodePayload(t, []*NodeItem{{NodeHash: []byte{1}}})))
+	assert.ErrorContains(t, err, "failed to update node: failed to get node 0x01")
+
+	// owner fixes the description and adds an admin
+	err = blockchain.performStateUpdateFromDataPayload(blockchain.db, newTx(ownerAddrString, transactionWithUpdateNodePayload(t, []*NodeItem{
+		{
+			NodeHash:    channel.NodeHash,
+			Enabled:     true,
+			Description: proto.String("description without typo"),
+			Admins:      [][]byte{adminAddr},
+		},
+	})))
+	assert.NoError(t, err)
+	channel, err = blockchain.GetNodeItem(channel.NodeHash)
+	assert.NoError(t, err)
+	assert.Equal(t, "channel one", channel.Name)
+	assert.Equal(t, ownerAddr, channel.Owner)
+	assert.Equal(t, "description without typo", channel.GetDescription())
+	assert.Equal(t, [][]byte{adminAddr}, channel.Admins)
+
+	searchResults, err := searchEngine.Search(context.TODO(), "without", 100, 0, search.AnyTermRequired)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{hexutil.Encode(channel.NodeHash)}, searchResults)
+
+	// admin can't change the admins but can add posters
+	err = blockchain.performStateUpdateFromDataPayload(blockchain.db, newTx(adminAddrString, transactionWithUpdateNodePayload(t, []*NodeItem{
+		{
+			NodeHash:    channel.NodeHash,
+			Enabled:     true,
+			Description: channel.Description,
+		},
+	})))
+	assert.EqualError(t, err, "failed to update node: only the owner can change the admins of a channel")
+
+	err = blockchain.performStateUpdateFromDataPayload(blockchain.db, newTx(adminAddrString, transactionWithUpdateNodePayload(t, []*NodeItem{
+		{
+			NodeHash:    channel.NodeHash,
+			Enabled:     true,
+			Description: channel.Description,
+			Admins:      channel.Admins,
+			Posters:     [][]byte{posterAddr},
+		},
+	})))
+	assert.NoError(t, err)
+
+	// guest can't update the channel
+	err = blockchain.performStateUpdateFromDataPayload(blockchain.db, newTx(guestAddrString, transactionWithUpdateNodePayload(t, []*NodeItem{{NodeHash: channel.NodeHash}})))
+	assert.EqualError(t, err, "failed to update node: only the owner and admins can update a channel")
+
+	// poster creates an entry and updates it
+	err = blockchain.performStateUpdateFromDataPayload(blockchain.db, newTx(posterAddrString, transactionWithChannelPayload(t, []*NodeItem{
+		{
+			Name:        "poster entry",
+			NodeType:    NodeItemType_ENTRY,
+			ParentHash:  channel.NodeHash,
+			Timestamp:   time.Now().Unix(),
+			Description: proto.String("spam"),
+		},
+	})))
+	assert.NoError(t, err)
+	entries, err := blockchain.GetChildNodeItems(channel.NodeHash)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	entry := entries[0]
+
+	err = blockchain.performStateUpdateFromDataPayload(blockchain.db, newTx(posterAddrString, transactionWithUpdateNodePayload(t, []*NodeItem{
+		{
+			NodeHash:    entry.NodeHash,
+			Enabled:     true,
+			Description: proto.String("useful entry"),
+			Attributes:  [][]byte{[]byte("a:b")},
+		},
+	})))
+	assert.NoError(t, err)
+	entry, err = blockchain.GetNodeItem(entry.NodeHash)
+	assert.NoError(t, err)
+	assert.Equal(t, "useful entry", entry.GetDescription())
+	assert.Equal(t, [][]byte{[]byte("a:b")}, entry.Attributes)
+
+	// poster can't add other posters
+	err = blockchain.performStateUpdateFromDataPayload(blockchain.db, newTx(posterAddrString, transactionWithUpdateNodePayload(t, []*NodeItem{
+		{
+			NodeHash: entry.NodeHash,
+			Posters:  [][]byte{{4}},
+		},
+	})))
+	assert.EqualError(t, err, "failed to update node: only the owner and admins can change the admins and posters")
+
+	// guest can't update the entry of the poster
+	err = blockchain.performStateUpdateFromDataPayload(blockchain.db, newTx(guestAddrString, transactionWithUpdateNodePayload(t, []*NodeItem{{NodeHash: entry.NodeHash}})))
+	assert.EqualError(t, err, "failed to update node: posters and guests can only update their own nodes")
+
+	// admin disables the entry and it's removed from the search index
+	searchResults, err = searchEngine.Search(context.TODO(), "useful", 100, 0, search.AnyTermRequired)
+	assert.NoError(t, err)
+	assert.Len(t, searchResults, 1)
+	err = blockchain.performStateUpdateFromDataPayload(blockchain.db, newTx(adminAddrString, transactionWithUpdateNodePayload(t, []*NodeItem{
+		{
+			NodeHash:    entry.NodeHash,
+			Enabled:     false,
+			Description: entry.Description,
+		},
+	})))
+	assert.NoError(t, err)
+	entry, err = blockchain.GetNodeItem(entry.NodeHash)
+	assert.NoError(t, err)
+	assert.False(t, entry.Enabled)
+	searchResults, err = searchEngine.Search(context.TODO(), "useful", 100, 0, search.AnyTermRequired)
+	assert.NoError(t, err)
+	assert.Len(t, searchResults, 0)
+}
+
 func TestPerformAddressStateUpdate(t *testing.T) {
 	genesisblockValid, err := block.GetGenesisBlock()
 	assert.NoError(t, err)
@@ -918,6 +1440,113 @@ func TestPerformAddressStateUpdate(t *testing.T) {
 	assert.NoError(t, blockchain.CloseDB())
 }
 
+func TestPerformAddressStateUpdateBatchTransfer(t *testing.T) {
+	genesisblockValid, err := block.GetGenesisBlock()
+	assert.NoError(t, err)
+	db, err := leveldb.OpenFile("batchtransfer.db", nil)
+	assert.NoError(t, err)
+	driver, err := database.New(db)
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		db.Close()
+		os.RemoveAll("batchtransfer.db")
+	})
+	blockchain, err := New(driver, &search.Search{}, genesisblockValid.Hash)
+	assert.NoError(t, err)
+
+	tx, kp := validTransaction(t)
+	senderAddr, err := hexutil.Decode(kp.Address)
+	assert.NoError(t, err)
+	state := AddressState{}
+	state.SetBalance(big.NewInt(10))
+	state.SetNounce(0)
+	assert.NoError(t, blockchain.UpdateAddressState(senderAddr, state))
+
+	recipients := []string{
+		"0x0101010101010101010101010101010101010101",
+		"0x0202020202020202020202020202020202020202",
+	}
+	verifierAddr, err := hexutil.Decode("0x0303030303030303030303030303030303030303")
+	assert.NoError(t, err)
+
+	// the total of the transfers is more than the balance so no recipient is credited
+	data, total, err := transaction.NewBatchTransferData([]transaction.BatchTransfer{
+		{To: recipients[0], Value: "0x5"},
+		{To: recipients[1], Value: "0x6"},
+	})
+	assert.NoError(t, err)
+	tx.Nounce = []byte{1}
+	tx.Data = data
+	tx.Value = hexutil.EncodeBig(total)
+	tx.TransactionFees = "0x1"
+	assert.NoError(t, tx.Sign(kp.PrivateKey))
+	err = blockchain.PerformAddressStateUpdate(*tx, verifierAddr, false)
+	assert.ErrorContains(t, err, "failed to subtract total value from address")
+	for _, recipient := range recipients {
+		recipientBytes, err := hexutil.Decode(recipient)
+		assert.NoError(t, err)
+		_, err = blockchain.GetAddressState(recipientBytes)
+		assert.Error(t, err)
+	}
+
+	// a batch transfer with a value different to the total is rejected
+	tx.Data, _, err = transaction.NewBatchTransferData([]transaction.BatchTransfer{
+		{To: recipients[0], Value: "0x2"},
+		{To: recipients[1], Value: "0x3"},
+	})
+	assert.NoError(t, err)
+	tx.Value = "0x4"
+	assert.NoError(t, tx.Sign(kp.PrivateKey))
+	err = blockchain.PerformAddressStateUpdate(*tx, verifierAddr, false)
+	assert.EqualError(t, err, "failed to validate batch transfer: transaction value 4 doesn't match the total value of the transfers 5")
+
+	tx.Value = "0x5"
+	assert.NoError(t, tx.Sign(kp.PrivateKey))
+	assert.NoError(t, blockchain.PerformAddressStateUpdate(*tx, verifierAddr, false))
+
+	expBalances := map[string]string{
+		kp.Address:    "4",
+		recipients[0]: "2",
+		recipients[1]: "3",
+		"0x0303030303030303030303030303030303030303": "1",
+	}
+	for addr, expBalance := range expBalances {
+		addrBytes, err := hexutil.Decode(addr)
+		assert.NoError(t, err)
+		state, err := blockchain.GetAddressState(addrBytes)
+		assert.NoError(t, err)
+		balance, err := state.GetBalance()
+		assert.NoError(t, err)
+		assert.Equal(t, expBalance, balance.String())
+	}
+
+	// every recipient of the batch receives the transaction
+	blck := block.Block{Hash: []byte{1}, Number: 1, Transactions: []transaction.Transaction{*tx}}
+	assert.NoError(t, blockchain.SaveBlockInDB(blck))
+	assert.NoError(t, blockchain.indexBlockHashByBlockNumber(blockchain.db, blck.Hash, blck.Number))
+	assert.NoError(t, blockchain.indexTransactionsByAddresses(blockchain.db, blck))
+	for _, recipient := range recipients {
+		recipientBytes, err := hexutil.Decode(recipient)
+		assert.NoError(t, err)
+		txs, blockNumbers, err := blockchain.GetAddressTransactions(recipientBytes)
+		assert.NoError(t, err)
+		assert.Len(t, txs, 1)
+		assert.Equal(t, []uint64{1}, blockNumbers)
+
+		page, err := blockchain.QueryAddressTransactions(recipientBytes, AddressTransactionsQuery{Flow: ReceivedTransactions})
+		assert.NoError(t, err)
+		assert.Len(t, page.Transactions, 1)
+		assert.Equal(t, tx.Hash, page.Transactions[0].Hash)
+	}
+
+	// the sender doesn't receive its own batch transfer
+	page, err := blockchain.QueryAddressTransactions(senderAddr, AddressTransactionsQuery{Flow: ReceivedTransactions})
+	assert.NoError(t, err)
+	assert.Empty(t, page.Transactions)
+
+	assert.NoError(t, blockchain.CloseDB())
+}
+
 // generate a block and propagate the keypair used for the tx
 func validBlock(t *testing.T, blockNumber uint64) (*block.Block, crypto.KeyPair, crypto.KeyPair) {
 	coinbasetx, kp := validTransaction(t)
@@ -966,6 +1595,22 @@ func transactionWithChannelPayload(t *testing.T, nodes []*NodeItem) []byte {
 	return txPayloadBytes
 }
 
+func transactionWithUpdateNodePayload(t *testing.T, nodes []*NodeItem) []byte {
+	items := NodeItems{
+		Nodes: nodes,
+	}
+	itemsBytes, err := proto.Marshal(&items)
+	assert.NoError(t, err)
+	txPayload := transaction.DataPayload{
+		Type:    transaction.DataType_UPDATE_NODE,
+		Payload: itemsBytes,
+	}
+
+	txPayloadBytes, err := proto.Marshal(&txPayload)
+	assert.NoError(t, err)
+	return txPayloadBytes
+}
+
 func transactionWithContractPayload(t *testing.T) []byte {
 	dc := messages.DownloadContractInTransactionDataProto{
 		ContractHash:               []byte{23},
@@ -1019,3 +1664,15 @@ func validTransaction(t *testing.T) (*transaction.Transaction, crypto.KeyPair) {
 	assert.NoError(t, err)
 	return &tx, keypair
 }
+
+type failingWriteDB struct {
+	database.Database
+	fail bool
+}
+
+func (f *failingWriteDB) Write(batch *leveldb.Batch, wo *opt.WriteOptions) error {
+	if f.fail {
+		return errors.New("write failed")
+	}
+	return f.Database.Write(batch, wo)
+}
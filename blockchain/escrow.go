@@ -0,0 +1,310 @@
+package blockchain
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/filefilego/filefilego/common/hexutil"
+	"github.com/filefilego/filefilego/crypto"
+	"github.com/filefilego/filefilego/database"
+	"github.com/filefilego/filefilego/node/protocols/messages"
+	"github.com/filefilego/filefilego/transaction"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+	"google.golang.org/protobuf/proto"
+)
+
+// ContractEscrowTimeout is the number of blocks in which the fees of a download contract can be released, about a day.
+const ContractEscrowTimeout uint64 = 8640
+
+// ContractEscrowActivationHeight is the block height from which the fees of every download contract are locked in an escrow.
+// the escrows were introduced by the same network upgrade as the state root. before it the contracts paid the verifier directly.
+const ContractEscrowActivationHeight = StateRootActivationHeight
+
+// SetContractEscrowActivationHeight sets the block height from which the contract fees are locked in escrows.
+// it's used by networks which start with the escrows, such as test networks.
+func (b *Blockchain) SetContractEscrowActivationHeight(height uint64) {
+	b.stateMu.Lock()
+	defer b.stateMu.Unlock()
+
+	b.contractEscrowActivationHeight = height
+}
+
+// GetContractEscrow returns the escrow of a download contract which is not released or refunded yet.
+func (b *Blockchain) GetContractEscrow(contractHash []byte) (*ContractEscrowProto, error) {
+	return b.getContractEscrow(b.db, contractHash)
+}
+
+// getContractEscrow gets the escrow of a contract from the given db.
+func (b *Blockchain) getContractEscrow(db database.Database, contractHash []byte) (*ContractEscrowProto, error) {
+	data, err := db.Get(append([]byte(escrowPrefix), contractHash...))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contract escrow: %w", err)
+	}
+
+	escrow := ContractEscrowProto{}
+	if err := proto.Unmarshal(data, &escrow); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal contract escrow: %w", err)
+	}
+	return &escrow, nil
+}
+
+// contractEscrowOf returns the escrow which locks the value of a DATA_CONTRACT transaction.
+// it returns nil if the contract fees are paid directly to the verifier before the escrow activation height.
+func (b *Blockchain) contractEscrowOf(db database.Database, tx transaction.Transaction, isCoinbase bool) (*ContractEscrowProto, error) {
+	if isCoinbase {
+		return nil, nil
+	}
+
+	contracts := downloadContractsOf(tx, transaction.DataType_DATA_CONTRACT)
+	if len(contracts) == 0 {
+		return nil, nil
+	}
+
+	blockNumber, ok := b.applyingBlockNumber()
+	if !ok {
+		blockNumber = b.GetHeight() + 1
+	}
+
+	if blockNumber < b.contractEscrowActivationHeight {
+		return nil, nil
+	}
+
+	// the value of the transaction can't be split between contracts, so an escrowed contract is paid by its own transaction
+	if len(contracts) != 1 {
+		return nil, errors.New("escrowed contract should be the only contract of the transaction")
+	}
+	contract := contracts[0]
+
+	// the file hoster and the fees are taken from the contract signed by the verifier
+	if err := messages.VerifyDownloadContractInTransactionData(contract); err != nil {
+		return nil, fmt.Errorf("failed to verify contract: %w", err)
+	}
+
+	// the contract is public once it's gossiped, so only its requester can lock the escrow of the contract hash
+	requesterAddr, err := crypto.RawPublicToAddress(contract.FileRequesterNodePublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get address of file requester: %w", err)
+	}
+
+	if tx.From != requesterAddr {
+		return nil, errors.New("escrowed contract should be paid by its file requester")
+	}
+
+	if _, err := b.getContractEscrow(db, contract.ContractHash); err == nil {
+		return nil, fmt.Errorf("escrow of contract %s already exists", hexutil.Encode(contract.ContractHash))
+	}
+
+	verifierAddr, err := crypto.RawPublicToAddress(contract.VerifierPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get address of verifier: %w", err)
+	}
+
+	if tx.To != verifierAddr {
+		return nil, errors.New("escrowed contract should be sent to its verifier")
+	}
+
+	fileHosterAddr, err := crypto.RawPublicToAddressBytes(contract.FileHosterNodePublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get address of file hoster: %w", err)
+	}
+
+	value, err := hexutil.DecodeBig(tx.Value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode transaction value: %w", err)
+	}
+
+	verifierFees, err := hexutil.DecodeBig(contract.VerifierFees)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode verifier fees: %w", err)
+	}
+
+	if verifierFees.Sign() < 0 || verifierFees.Cmp(value) > 0 {
+		return nil, fmt.Errorf("verifier fees %s should be between zero and the transaction value %s", verifierFees.Text(10), value.Text(10))
+	}
+
+	deadline := blockNumber + ContractEscrowTimeout
+	requester, err := hexutil.Decode(tx.From)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode from address: %w", err)
+	}
+
+	verifier, err := hexutil.Decode(verifierAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode verifier address: %w", err)
+	}
+
+	return &ContractEscrowProto{
+		ContractHash: contract.ContractHash,
+		Requester:    requester,
+		Verifier:     verifier,
+		FileHoster:   fileHosterAddr,
+		Value:        tx.Value,
+		VerifierFees: contract.VerifierFees,
+		Deadline:     deadline,
+		TxHash:       tx.Hash,
+	}, nil
+}
+
+// contractEscrowsReleasedBy returns the escrows released by a DATA_CONTRACT_RELEASE_HOSTER_FEES transaction.
+// contracts without an escrow were paid directly to the verifier, who pays the file hoster with the transaction value.
+func (b *Blockchain) contractEscrowsReleasedBy(db database.Database, tx transaction.Transaction, isCoinbase bool) ([]*ContractEscrowProto, error) {
+	if isCoinbase {
+		return nil, nil
+	}
+
+	contracts := downloadContractsOf(tx, transaction.DataType_DATA_CONTRACT_RELEASE_HOSTER_FEES)
+	if len(contracts) == 0 {
+		return nil, nil
+	}
+
+	from, err := hexutil.Decode(tx.From)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode from address: %w", err)
+	}
+
+	escrows := make([]*ContractEscrowProto, 0)
+	released := make(map[string]struct{})
+	for _, c := range contracts {
+		escrow, err := b.getContractEscrow(db, c.ContractHash)
+		if err != nil {
+			continue
+		}
+
+		contractHash := hexutil.Encode(c.ContractHash)
+		if _, ok := released[contractHash]; ok {
+			return nil, fmt.Errorf("escrow of contract %s is released more than once", contractHash)
+		}
+		released[contractHash] = struct{}{}
+
+		if !bytes.Equal(escrow.Verifier, from) {
+			return nil, fmt.Errorf("escrow of contract %s can only be released by its verifier", contractHash)
+		}
+		escrows = append(escrows, escrow)
+	}
+	return escrows, nil
+}
+
+// lockContractEscrow saves the escrow and indexes it by its deadline.
+func (b *Blockchain) lockContractEscrow(db database.Database, escrow *ContractEscrowProto) error {
+	data, err := proto.Marshal(escrow)
+	if err != nil {
+		return fmt.Errorf("failed to marshal contract escrow: %w", err)
+	}
+
+	err = db.Put(append([]byte(escrowPrefix), escrow.ContractHash...), data)
+	if err != nil {
+		return fmt.Errorf("failed to insert contract escrow into db: %w", err)
+	}
+
+	err = db.Put(escrowDeadlineKey(escrow.Deadline, escrow.ContractHash), []byte{})
+	if err != nil {
+		return fmt.Errorf("failed to index contract escrow by deadline: %w", err)
+	}
+	return nil
+}
+
+// releaseContractEscrow pays the locked fees to the file hoster and the verifier.
+func (b *Blockchain) releaseContractEscrow(db database.Database, escrow *ContractEscrowProto) error {
+	value, err := hexutil.DecodeBig(escrow.Value)
+	if err != nil {
+		return fmt.Errorf("failed to decode escrow value: %w", err)
+	}
+
+	verifierFees, err := hexutil.DecodeBig(escrow.VerifierFees)
+	if err != nil {
+		return fmt.Errorf("failed to decode escrow verifier fees: %w", err)
+	}
+
+	err = b.addBalanceTo(db, escrow.FileHoster, big.NewInt(0).Sub(value, verifierFees))
+	if err != nil {
+		return fmt.Errorf("failed to add escrow fees to file hoster's balance: %w", err)
+	}
+
+	err = b.addBalanceTo(db, escrow.Verifier, verifierFees)
+	if err != nil {
+		return fmt.Errorf("failed to add escrow fees to verifier's balance: %w", err)
+	}
+
+	return b.deleteContractEscrow(db, escrow)
+}
+
+// refundExpiredContractEscrows refunds the escrows which were not released up to their deadline.
+func (b *Blockchain) refundExpiredContractEscrows(db database.Database, blockNumber uint64) error {
+	expired := make([][]byte, 0)
+	iter := db.NewIterator(util.BytesPrefix([]byte(escrowDeadlinePrefix)), nil)
+	for iter.Next() {
+		key := iter.Key()[len(escrowDeadlinePrefix):]
+		if len(key) < 8 {
+			continue
+		}
+
+		// the keys are sorted by deadline, so the remaining escrows are not expired
+		if binary.BigEndian.Uint64(key[:8]) >= blockNumber {
+			break
+		}
+		expired = append(expired, append([]byte{}, key[8:]...))
+	}
+	iter.Release()
+	if err := iter.Error(); err != nil {
+		return fmt.Errorf("failed to release contract escrow iterator: %w", err)
+	}
+
+	for _, contractHash := range expired {
+		escrow, err := b.getContractEscrow(db, contractHash)
+		if err != nil {
+			return err
+		}
+
+		value, err := hexutil.DecodeBig(escrow.Value)
+		if err != nil {
+			return fmt.Errorf("failed to decode escrow value: %w", err)
+		}
+
+		err = b.addBalanceTo(db, escrow.Requester, value)
+		if err != nil {
+			return fmt.Errorf("failed to refund escrow to requester: %w", err)
+		}
+
+		err = b.deleteContractEscrow(db, escrow)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *Blockchain) deleteContractEscrow(db database.Database, escrow *ContractEscrowProto) error {
+	batch := new(leveldb.Batch)
+	batch.Delete(append([]byte(escrowPrefix), escrow.ContractHash...))
+	batch.Delete(escrowDeadlineKey(escrow.Deadline, escrow.ContractHash))
+	err := db.Write(batch, nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete contract escrow: %w", err)
+	}
+	return nil
+}
+
+func escrowDeadlineKey(deadline uint64, contractHash []byte) []byte {
+	key := make([]byte, 0, len(escrowDeadlinePrefix)+8+len(contractHash))
+	key = append(key, escrowDeadlinePrefix...)
+	key = binary.BigEndian.AppendUint64(key, deadline)
+	return append(key, contractHash...)
+}
+
+// downloadContractsOf returns the download contracts of a transaction with the given data payload type.
+func downloadContractsOf(tx transaction.Transaction, dataType transaction.DataType) []*messages.DownloadContractInTransactionDataProto {
+	dataPayload := transaction.DataPayload{}
+	if err := proto.Unmarshal(tx.Data, &dataPayload); err != nil || dataPayload.Type != dataType {
+		return nil
+	}
+
+	downloadContracts := messages.DownloadContractsHashesProto{}
+	if err := proto.Unmarshal(dataPayload.Payload, &downloadContracts); err != nil {
+		return nil
+	}
+	return downloadContracts.Contracts
+}
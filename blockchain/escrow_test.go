@@ -0,0 +1,229 @@
+package blockchain
+
+import (
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/filefilego/filefilego/block"
+	"github.com/filefilego/filefilego/common/hexutil"
+	"github.com/filefilego/filefilego/crypto"
+	"github.com/filefilego/filefilego/database"
+	"github.com/filefilego/filefilego/node/protocols/messages"
+	"github.com/filefilego/filefilego/search"
+	"github.com/filefilego/filefilego/transaction"
+	"github.com/stretchr/testify/assert"
+	"github.com/syndtr/goleveldb/leveldb"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestContractEscrow(t *testing.T) {
+	genesisblockValid, err := block.GetGenesisBlock()
+	assert.NoError(t, err)
+	db, err := leveldb.OpenFile("contractescrow.db", nil)
+	assert.NoError(t, err)
+	driver, err := database.New(db)
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		db.Close()
+		os.RemoveAll("contractescrow.db")
+	})
+	bchain, err := New(driver, &search.Search{}, genesisblockValid.Hash)
+	assert.NoError(t, err)
+	bchain.SetContractEscrowActivationHeight(0)
+
+	requester, verifier, hoster := escrowKeyPair(t), escrowKeyPair(t), escrowKeyPair(t)
+	requesterAddr, err := hexutil.Decode(requester.Address)
+	assert.NoError(t, err)
+	state := AddressState{}
+	state.SetBalance(big.NewInt(100))
+	state.SetNounce(0)
+	assert.NoError(t, bchain.UpdateAddressState(requesterAddr, state))
+	blockVerifierAddr, err := hexutil.Decode("0x0303030303030303030303030303030303030303")
+	assert.NoError(t, err)
+
+	contract := escrowContract(t, []byte{1}, requester, verifier, hoster)
+	contractTx := escrowTransaction(t, requester, 1, verifier.Address, "0xa", transaction.DataType_DATA_CONTRACT, contract)
+
+	// the requester can't name another file hoster or change the fees of the contract signed by the verifier
+	otherHoster := proto.Clone(contract).(*messages.DownloadContractInTransactionDataProto)
+	otherHoster.FileHosterNodePublicKey = contract.FileRequesterNodePublicKey
+	otherFees := proto.Clone(contract).(*messages.DownloadContractInTransactionDataProto)
+	otherFees.VerifierFees = "0x0"
+	unsigned := proto.Clone(contract).(*messages.DownloadContractInTransactionDataProto)
+	unsigned.VerifierSignature = nil
+
+	cases := map[string]struct {
+		tx     transaction.Transaction
+		expErr string
+	}{
+		"other file hoster": {
+			tx:     escrowTransaction(t, requester, 1, verifier.Address, "0xa", transaction.DataType_DATA_CONTRACT, otherHoster),
+			expErr: "failed to validate contract escrow: failed to verify contract: contract hash doesn't match the contract data",
+		},
+		"other verifier fees": {
+			tx:     escrowTransaction(t, requester, 1, verifier.Address, "0xa", transaction.DataType_DATA_CONTRACT, otherFees),
+			expErr: "failed to validate contract escrow: failed to verify contract: contract hash doesn't match the contract data",
+		},
+		"not signed by the verifier": {
+			tx:     escrowTransaction(t, requester, 1, verifier.Address, "0xa", transaction.DataType_DATA_CONTRACT, unsigned),
+			expErr: "failed to validate contract escrow: failed to verify contract: contract is not signed by its verifier",
+		},
+		"more than one contract": {
+			tx:     escrowTransaction(t, requester, 1, verifier.Address, "0xa", transaction.DataType_DATA_CONTRACT, contract, escrowContract(t, []byte{2}, requester, verifier, hoster)),
+			expErr: "failed to validate contract escrow: escrowed contract should be the only contract of the transaction",
+		},
+		"not paid by the file requester": {
+			tx:     escrowTransaction(t, hoster, 1, verifier.Address, "0xa", transaction.DataType_DATA_CONTRACT, contract),
+			expErr: "failed to validate contract escrow: escrowed contract should be paid by its file requester",
+		},
+		"not sent to the verifier": {
+			tx:     escrowTransaction(t, requester, 1, hoster.Address, "0xa", transaction.DataType_DATA_CONTRACT, contract),
+			expErr: "failed to validate contract escrow: escrowed contract should be sent to its verifier",
+		},
+		"verifier fees greater than value": {
+			tx:     escrowTransaction(t, requester, 1, verifier.Address, "0x1", transaction.DataType_DATA_CONTRACT, contract),
+			expErr: "failed to validate contract escrow: verifier fees 2 should be between zero and the transaction value 1",
+		},
+	}
+
+	for name, tt := range cases {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			err := bchain.PerformAddressStateUpdate(tt.tx, blockVerifierAddr, false)
+			assert.EqualError(t, err, tt.expErr)
+		})
+	}
+
+	// the value is locked instead of being paid to the verifier
+	assert.NoError(t, bchain.PerformAddressStateUpdate(contractTx, blockVerifierAddr, false))
+	escrow, err := bchain.GetContractEscrow(contract.ContractHash)
+	assert.NoError(t, err)
+	assert.Equal(t, "0xa", escrow.Value)
+	assert.Equal(t, 1+ContractEscrowTimeout, escrow.Deadline)
+	assertEscrowBalance(t, bchain, requester.Address, "90")
+	verifierAddr, err := hexutil.Decode(verifier.Address)
+	assert.NoError(t, err)
+	_, err = bchain.GetAddressState(verifierAddr)
+	assert.Error(t, err)
+
+	// the contract can't be escrowed twice
+	err = bchain.PerformAddressStateUpdate(escrowTransaction(t, requester, 2, verifier.Address, "0xa", transaction.DataType_DATA_CONTRACT, contract), blockVerifierAddr, false)
+	assert.EqualError(t, err, "failed to validate contract escrow: escrow of contract "+hexutil.Encode(contract.ContractHash)+" already exists")
+
+	// only the verifier releases the escrow
+	err = bchain.PerformAddressStateUpdate(escrowTransaction(t, requester, 2, hoster.Address, "0x0", transaction.DataType_DATA_CONTRACT_RELEASE_HOSTER_FEES, contract), blockVerifierAddr, false)
+	assert.EqualError(t, err, "failed to validate contract escrow release: escrow of contract "+hexutil.Encode(contract.ContractHash)+" can only be released by its verifier")
+
+	err = bchain.PerformAddressStateUpdate(escrowTransaction(t, verifier, 1, hoster.Address, "0x0", transaction.DataType_DATA_CONTRACT_RELEASE_HOSTER_FEES, contract, contract), blockVerifierAddr, false)
+	assert.EqualError(t, err, "failed to validate contract escrow release: escrow of contract "+hexutil.Encode(contract.ContractHash)+" is released more than once")
+
+	assert.NoError(t, bchain.PerformAddressStateUpdate(escrowTransaction(t, verifier, 1, hoster.Address, "0x0", transaction.DataType_DATA_CONTRACT_RELEASE_HOSTER_FEES, contract), blockVerifierAddr, false))
+	assertEscrowBalance(t, bchain, hoster.Address, "8")
+	assertEscrowBalance(t, bchain, verifier.Address, "2")
+	_, err = bchain.GetContractEscrow(contract.ContractHash)
+	assert.Error(t, err)
+
+	// an escrow which is not released up to its deadline is refunded
+	expiringContract := escrowContract(t, []byte{2}, requester, verifier, hoster)
+	assert.NoError(t, bchain.PerformAddressStateUpdate(escrowTransaction(t, requester, 2, verifier.Address, "0xa", transaction.DataType_DATA_CONTRACT, expiringContract), blockVerifierAddr, false))
+	assertEscrowBalance(t, bchain, requester.Address, "80")
+
+	assert.NoError(t, bchain.refundExpiredContractEscrows(bchain.db, 1+ContractEscrowTimeout))
+	_, err = bchain.GetContractEscrow(expiringContract.ContractHash)
+	assert.NoError(t, err)
+
+	assert.NoError(t, bchain.refundExpiredContractEscrows(bchain.db, 2+ContractEscrowTimeout))
+	_, err = bchain.GetContractEscrow(expiringContract.ContractHash)
+	assert.Error(t, err)
+	assertEscrowBalance(t, bchain, requester.Address, "90")
+
+	// before the activation height the contracts pay the verifier directly
+	bchain.SetContractEscrowActivationHeight(2)
+	directContract := escrowContract(t, []byte{3}, requester, verifier, hoster)
+	assert.NoError(t, bchain.PerformAddressStateUpdate(escrowTransaction(t, requester, 3, verifier.Address, "0xa", transaction.DataType_DATA_CONTRACT, directContract), blockVerifierAddr, false))
+	_, err = bchain.GetContractEscrow(directContract.ContractHash)
+	assert.Error(t, err)
+	assertEscrowBalance(t, bchain, requester.Address, "80")
+	assertEscrowBalance(t, bchain, verifier.Address, "12")
+
+	assert.NoError(t, bchain.CloseDB())
+}
+
+func escrowKeyPair(t *testing.T) crypto.KeyPair {
+	kp, err := crypto.GenerateKeyPair()
+	assert.NoError(t, err)
+	return kp
+}
+
+// escrowContract returns a contract signed by the verifier for the given file hash.
+func escrowContract(t *testing.T, fileHash []byte, requester, verifier, hoster crypto.KeyPair) *messages.DownloadContractInTransactionDataProto {
+	requesterPublicKey, err := requester.PublicKey.Raw()
+	assert.NoError(t, err)
+	verifierPublicKey, err := verifier.PublicKey.Raw()
+	assert.NoError(t, err)
+	hosterPublicKey, err := hoster.PublicKey.Raw()
+	assert.NoError(t, err)
+
+	contract := &messages.DownloadContractProto{
+		FileHosterResponse: &messages.DataQueryResponseProto{
+			PublicKey: hosterPublicKey,
+			Signature: []byte{1},
+		},
+		FileRequesterNodePublicKey: requesterPublicKey,
+		FileHashesNeeded:           [][]byte{fileHash},
+		FileHashesNeededSizes:      []uint64{1},
+		VerifierPublicKey:          verifierPublicKey,
+		VerifierFees:               "0x2",
+	}
+	contract.ContractHash = messages.GetDownloadContractHash(contract)
+	contract.VerifierSignature, err = messages.SignDownloadContractProto(verifier.PrivateKey, contract)
+	assert.NoError(t, err)
+
+	return &messages.DownloadContractInTransactionDataProto{
+		ContractHash:               contract.ContractHash,
+		FileRequesterNodePublicKey: requesterPublicKey,
+		FileHosterNodePublicKey:    hosterPublicKey,
+		VerifierPublicKey:          verifierPublicKey,
+		VerifierFees:               contract.VerifierFees,
+		FileHosterFees:             "0x1",
+		FileHosterSignature:        contract.FileHosterResponse.Signature,
+		FileHashesNeeded:           contract.FileHashesNeeded,
+		FileHashesNeededSizes:      contract.FileHashesNeededSizes,
+		VerifierSignature:          contract.VerifierSignature,
+	}
+}
+
+func escrowTransaction(t *testing.T, from crypto.KeyPair, nounce byte, to, value string, dataType transaction.DataType, contracts ...*messages.DownloadContractInTransactionDataProto) transaction.Transaction {
+	payload, err := proto.Marshal(&messages.DownloadContractsHashesProto{Contracts: contracts})
+	assert.NoError(t, err)
+	data, err := proto.Marshal(&transaction.DataPayload{Type: dataType, Payload: payload})
+	assert.NoError(t, err)
+	publicKey, err := from.PublicKey.Raw()
+	assert.NoError(t, err)
+	mainChain, err := hexutil.Decode(transaction.ChainID)
+	assert.NoError(t, err)
+
+	tx := transaction.Transaction{
+		PublicKey:       publicKey,
+		Nounce:          []byte{nounce},
+		Data:            data,
+		From:            from.Address,
+		To:              to,
+		Value:           value,
+		TransactionFees: "0x0",
+		Chain:           mainChain,
+	}
+	assert.NoError(t, tx.Sign(from.PrivateKey))
+	return tx
+}
+
+func assertEscrowBalance(t *testing.T, bchain *Blockchain, address, expBalance string) {
+	addr, err := hexutil.Decode(address)
+	assert.NoError(t, err)
+	state, err := bchain.GetAddressState(addr)
+	assert.NoError(t, err)
+	balance, err := state.GetBalance()
+	assert.NoError(t, err)
+	assert.Equal(t, expBalance, balance.String())
+}
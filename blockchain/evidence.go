@@ -0,0 +1,219 @@
+package blockchain
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/filefilego/filefilego/block"
+	"github.com/filefilego/filefilego/common/hexutil"
+	"github.com/filefilego/filefilego/crypto"
+	"github.com/filefilego/filefilego/database"
+	log "github.com/sirupsen/logrus"
+	"github.com/syndtr/goleveldb/leveldb/util"
+	"google.golang.org/protobuf/proto"
+)
+
+// NewDoubleSignEvidence creates the evidence of two different blocks with the same number signed by the same verifier.
+func NewDoubleSignEvidence(first, second block.Block) (*DoubleSignEvidenceProto, error) {
+	if first.Number != second.Number {
+		return nil, fmt.Errorf("block numbers %d and %d are different", first.Number, second.Number)
+	}
+
+	if bytes.Equal(first.Hash, second.Hash) {
+		return nil, errors.New("blocks are the same")
+	}
+
+	signers := make([]string, 2)
+	for i, blck := range []block.Block{first, second} {
+		ok, err := blck.Validate()
+		if err != nil || !ok {
+			return nil, fmt.Errorf("failed to validate block %s: %w", hexutil.Encode(blck.Hash), err)
+		}
+
+		signers[i], err = blockSigner(blck)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if signers[0] != signers[1] {
+		return nil, errors.New("blocks are signed by different verifiers")
+	}
+
+	if bytes.Compare(first.Hash, second.Hash) > 0 {
+		first, second = second, first
+	}
+
+	firstData, err := block.MarshalProtoBlock(block.ToProtoBlock(first))
+	if err != nil {
+		return nil, err
+	}
+
+	secondData, err := block.MarshalProtoBlock(block.ToProtoBlock(second))
+	if err != nil {
+		return nil, err
+	}
+
+	return &DoubleSignEvidenceProto{
+		Verifier:    signers[0],
+		BlockNumber: first.Number,
+		FirstBlock:  firstData,
+		SecondBlock: secondData,
+	}, nil
+}
+
+// VerifyDoubleSignEvidence checks that the evidence contains two valid conflicting blocks of the verifier.
+func VerifyDoubleSignEvidence(evidence *DoubleSignEvidenceProto) error {
+	first, err := block.UnmarshalProtoBlock(evidence.FirstBlock)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal first block: %w", err)
+	}
+
+	second, err := block.UnmarshalProtoBlock(evidence.SecondBlock)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal second block: %w", err)
+	}
+
+	verified, err := NewDoubleSignEvidence(block.ProtoBlockToBlock(first), block.ProtoBlockToBlock(second))
+	if err != nil {
+		return err
+	}
+
+	if verified.Verifier != evidence.Verifier || verified.BlockNumber != evidence.BlockNumber {
+		return errors.New("evidence doesn't match the blocks")
+	}
+	return nil
+}
+
+// GetDoubleSignEvidence returns the double sign evidence of a verifier, or of all the verifiers if the address is empty.
+func (b *Blockchain) GetDoubleSignEvidence(address string) ([]*DoubleSignEvidenceProto, error) {
+	prefix := append([]byte(evidencePrefix), []byte(address)...)
+	iter := b.db.NewIterator(util.BytesPrefix(prefix), nil)
+	items := make([]*DoubleSignEvidenceProto, 0)
+	for iter.Next() {
+		evidence := DoubleSignEvidenceProto{}
+		if err := proto.Unmarshal(iter.Value(), &evidence); err != nil {
+			iter.Release()
+			return nil, fmt.Errorf("failed to unmarshal evidence: %w", err)
+		}
+		items = append(items, &evidence)
+	}
+	iter.Release()
+	if err := iter.Error(); err != nil {
+		return nil, fmt.Errorf("failed to release evidence iterator: %w", err)
+	}
+	return items, nil
+}
+
+// saveDoubleSignEvidence saves the evidence of a verifier.
+// evidence which was already committed in a block is not overwritten.
+func (b *Blockchain) saveDoubleSignEvidence(db database.Database, evidence *DoubleSignEvidenceProto) error {
+	key := doubleSignEvidenceKey(evidence.Verifier, evidence.BlockNumber)
+	data, err := db.Get(key)
+	if err == nil {
+		existing := DoubleSignEvidenceProto{}
+		if err := proto.Unmarshal(data, &existing); err == nil && existing.CommittedInBlock > 0 {
+			return nil
+		}
+	}
+
+	data, err = proto.Marshal(evidence)
+	if err != nil {
+		return fmt.Errorf("failed to marshal evidence: %w", err)
+	}
+
+	err = db.Put(key, data)
+	if err != nil {
+		return fmt.Errorf("failed to insert evidence into db: %w", err)
+	}
+	return nil
+}
+
+// commitDoubleSignEvidence saves the evidence of a transaction data payload.
+func (b *Blockchain) commitDoubleSignEvidence(db database.Database, payload []byte) error {
+	blockNumber, ok := b.applyingBlockNumber()
+	if !ok {
+		return errors.New("evidence can only be committed by a block")
+	}
+
+	evidence := DoubleSignEvidenceProto{}
+	if err := proto.Unmarshal(payload, &evidence); err != nil {
+		return fmt.Errorf("failed to unmarshal evidence: %w", err)
+	}
+
+	if err := VerifyDoubleSignEvidence(&evidence); err != nil {
+		return fmt.Errorf("failed to verify evidence: %w", err)
+	}
+
+	evidence.CommittedInBlock = blockNumber
+	return b.saveDoubleSignEvidence(db, &evidence)
+}
+
+// detectDoubleSign compares a block with the known blocks of the same number and saves the evidence
+// if the same verifier signed a different block.
+func (b *Blockchain) detectDoubleSign(blck block.Block) {
+	signer, err := blockSigner(blck)
+	if err != nil {
+		return
+	}
+
+	candidates := make([]block.Block, 0)
+	for _, pooled := range b.GetBlocksFromPool() {
+		if pooled.Number == blck.Number {
+			candidates = append(candidates, pooled)
+		}
+	}
+
+	if canonical, err := b.GetBlockByNumber(blck.Number); err == nil {
+		candidates = append(candidates, *canonical)
+	}
+
+	for _, other := range candidates {
+		if bytes.Equal(other.Hash, blck.Hash) {
+			continue
+		}
+
+		otherSigner, err := blockSigner(other)
+		if err != nil || otherSigner != signer {
+			continue
+		}
+
+		evidence, err := NewDoubleSignEvidence(blck, other)
+		if err != nil {
+			continue
+		}
+
+		log.Warnf("verifier %s signed two different blocks with number %d", signer, blck.Number)
+		// the evidence is saved in the committed state and is serialized with the blocks which are being applied
+		b.stateMu.Lock()
+		err = b.saveDoubleSignEvidence(b.db, evidence)
+		b.stateMu.Unlock()
+		if err != nil {
+			log.Errorf("failed to save double sign evidence: %v", err)
+		}
+		return
+	}
+}
+
+// blockSigner returns the address of the verifier which signed the block.
+func blockSigner(blck block.Block) (string, error) {
+	coinbase, err := blck.GetAndValidateCoinbaseTransaction()
+	if err != nil {
+		return "", fmt.Errorf("failed to get coinbase transaction: %w", err)
+	}
+
+	addr, err := crypto.RawPublicToAddress(coinbase.PublicKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to get verifier's address: %w", err)
+	}
+	return addr, nil
+}
+
+func doubleSignEvidenceKey(address string, blockNumber uint64) []byte {
+	key := append([]byte(evidencePrefix), []byte(address)...)
+	blockNumberBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(blockNumberBytes, blockNumber)
+	return append(key, blockNumberBytes...)
+}
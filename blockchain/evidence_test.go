@@ -0,0 +1,145 @@
+package blockchain
+
+import (
+	"os"
+	"testing"
+
+	"github.com/filefilego/filefilego/block"
+	"github.com/filefilego/filefilego/common/hexutil"
+	"github.com/filefilego/filefilego/database"
+	"github.com/filefilego/filefilego/search"
+	"github.com/filefilego/filefilego/transaction"
+	"github.com/stretchr/testify/assert"
+	"github.com/syndtr/goleveldb/leveldb"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestDoubleSignEvidence(t *testing.T) {
+	genesisblockValid, err := block.GetGenesisBlock()
+	assert.NoError(t, err)
+	db, err := leveldb.OpenFile("evidence.db", nil)
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		db.Close()
+		os.RemoveAll("evidence.db")
+	})
+
+	driver, err := database.New(db)
+	assert.NoError(t, err)
+	bchain, err := New(driver, &search.Search{}, genesisblockValid.Hash)
+	assert.NoError(t, err)
+	assert.NoError(t, bchain.InitOrLoad(true))
+
+	first, kp, _ := validBlock(t, 1)
+	first.PreviousBlockHash = genesisblockValid.Hash
+	pubKeyBytes, err := kp.PublicKey.Raw()
+	assert.NoError(t, err)
+	block.SetBlockVerifiers(block.Verifier{
+		Address:   kp.Address,
+		PublicKey: hexutil.Encode(pubKeyBytes),
+	})
+	assert.NoError(t, first.Sign(kp.PrivateKey))
+
+	// the same verifier signs another block with the same number on an unknown branch
+	second := *first
+	second.Data = []byte{2}
+	second.PreviousBlockHash = []byte{2}
+	assert.NoError(t, second.Sign(kp.PrivateKey))
+
+	other, otherKp, _ := validBlock(t, 1)
+	otherPubKeyBytes, err := otherKp.PublicKey.Raw()
+	assert.NoError(t, err)
+	block.SetBlockVerifiers(block.Verifier{
+		Address:   otherKp.Address,
+		PublicKey: hexutil.Encode(otherPubKeyBytes),
+	})
+	assert.NoError(t, other.Sign(otherKp.PrivateKey))
+
+	next, _, _ := validBlock(t, 2)
+	next.Transactions = first.Transactions
+	assert.NoError(t, next.Sign(kp.PrivateKey))
+
+	cases := map[string]struct {
+		first  block.Block
+		second block.Block
+		expErr string
+	}{
+		"different numbers": {
+			first:  *first,
+			second: *next,
+			expErr: "block numbers 1 and 2 are different",
+		},
+		"same block": {
+			first:  *first,
+			second: *first,
+			expErr: "blocks are the same",
+		},
+		"different verifiers": {
+			first:  *first,
+			second: *other,
+			expErr: "blocks are signed by different verifiers",
+		},
+		"success": {
+			first:  *first,
+			second: second,
+		},
+	}
+
+	for name, tt := range cases {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			evidence, err := NewDoubleSignEvidence(tt.first, tt.second)
+			if tt.expErr != "" {
+				assert.EqualError(t, err, tt.expErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, kp.Address, evidence.Verifier)
+			assert.Equal(t, uint64(1), evidence.BlockNumber)
+			assert.NoError(t, VerifyDoubleSignEvidence(evidence))
+
+			evidence.Verifier = otherKp.Address
+			assert.EqualError(t, VerifyDoubleSignEvidence(evidence), "evidence doesn't match the blocks")
+		})
+	}
+
+	// the conflicting block is detected against the chain
+	assert.NoError(t, bchain.PutBlockPool(*first))
+	assert.Equal(t, uint64(1), bchain.GetHeight())
+	assert.NoError(t, bchain.PutBlockPool(*other))
+	items, err := bchain.GetDoubleSignEvidence("")
+	assert.NoError(t, err)
+	assert.Empty(t, items)
+
+	assert.NoError(t, bchain.PutBlockPool(second))
+	items, err = bchain.GetDoubleSignEvidence(kp.Address)
+	assert.NoError(t, err)
+	assert.Len(t, items, 1)
+	assert.Equal(t, uint64(0), items[0].CommittedInBlock)
+	detected := items[0]
+	items, err = bchain.GetDoubleSignEvidence(otherKp.Address)
+	assert.NoError(t, err)
+	assert.Empty(t, items)
+
+	// the evidence is committed in a transaction
+	payload, err := proto.Marshal(detected)
+	assert.NoError(t, err)
+	data, err := proto.Marshal(&transaction.DataPayload{Type: transaction.DataType_DOUBLE_SIGN_EVIDENCE, Payload: payload})
+	assert.NoError(t, err)
+	evidenceTx := signedTransaction(t, kp, kp.Address, 2, "0x0", "0x0", data)
+
+	next.Transactions = []transaction.Transaction{first.Transactions[0], evidenceTx}
+	next.PreviousBlockHash = first.Hash
+	assert.NoError(t, next.Sign(kp.PrivateKey))
+	assert.NoError(t, bchain.PerformStateUpdateFromBlock(*next))
+	items, err = bchain.GetDoubleSignEvidence(kp.Address)
+	assert.NoError(t, err)
+	assert.Len(t, items, 1)
+	assert.Equal(t, uint64(2), items[0].CommittedInBlock)
+
+	// the committed evidence isn't replaced by a detected one
+	assert.NoError(t, bchain.saveDoubleSignEvidence(bchain.db, &DoubleSignEvidenceProto{Verifier: kp.Address, BlockNumber: 1}))
+	items, err = bchain.GetDoubleSignEvidence(kp.Address)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), items[0].CommittedInBlock)
+}
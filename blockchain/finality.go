@@ -0,0 +1,225 @@
+package blockchain
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/filefilego/filefilego/block"
+	"github.com/filefilego/filefilego/common/hexutil"
+	ffgcrypto "github.com/filefilego/filefilego/crypto"
+	"github.com/filefilego/filefilego/node/protocols/messages"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	log "github.com/sirupsen/logrus"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// CheckpointInterval is the number of blocks between two checkpoints attested by the verifiers.
+const CheckpointInterval = 10
+
+// ErrNotAttestationVerifier is returned when an attestation is signed by a key which is not a verifier at the checkpoint height.
+var ErrNotAttestationVerifier = errors.New("attestation signer is not a verifier")
+
+// checkpointSignatureDomain is the domain tag of the checkpoint attestations.
+var checkpointSignatureDomain = []byte("filefilego/checkpoint/v1")
+
+// checkpointAttestations are the signatures of a checkpoint by verifier address.
+type checkpointAttestations map[string][]byte
+
+// CheckpointHash returns the hash which is signed by the verifiers to attest a checkpoint.
+func CheckpointHash(blockNumber uint64, blockHash []byte) []byte {
+	numberBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(numberBytes, blockNumber)
+
+	data := make([]byte, 0, len(checkpointSignatureDomain)+8+len(blockHash))
+	data = append(data, checkpointSignatureDomain...)
+	data = append(data, numberBytes...)
+	data = append(data, blockHash...)
+	return ffgcrypto.Sha256(data)
+}
+
+// NewCheckpointAttestation creates a signed attestation of a checkpoint.
+func NewCheckpointAttestation(privateKey crypto.PrivKey, blockNumber uint64, blockHash []byte) (*messages.CheckpointAttestationProto, error) {
+	publicKey, err := privateKey.GetPublic().Raw()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get public key: %w", err)
+	}
+
+	sig, err := privateKey.Sign(CheckpointHash(blockNumber, blockHash))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign checkpoint: %w", err)
+	}
+
+	return &messages.CheckpointAttestationProto{
+		BlockNumber: blockNumber,
+		BlockHash:   blockHash,
+		PublicKey:   publicKey,
+		Signature:   sig,
+	}, nil
+}
+
+// AddCheckpointAttestation verifies and records a verifier's attestation of a checkpoint.
+// once a quorum of the verifiers at the checkpoint height attested the same block of the chain, the block
+// and all its ancestors are final and can't be reverted. attestations of a block which isn't part of the chain yet
+// are kept until the block is applied. it returns true if the attestation finalized a checkpoint.
+func (b *Blockchain) AddCheckpointAttestation(attestation *messages.CheckpointAttestationProto) (bool, error) {
+	finalizedNumber, _ := b.GetFinalizedCheckpoint()
+	if attestation.BlockNumber <= finalizedNumber {
+		return false, nil
+	}
+
+	if attestation.BlockNumber > b.GetHeight()+maxReorgDepth {
+		return false, fmt.Errorf("checkpoint %d is too far ahead of the chain", attestation.BlockNumber)
+	}
+
+	verifiers, err := b.GetVerifiersAtHeight(attestation.BlockNumber)
+	if err != nil {
+		return false, fmt.Errorf("failed to get verifiers: %w", err)
+	}
+
+	addr, err := VerifyCheckpointAttestation(attestation, verifiers)
+	if err != nil {
+		return false, err
+	}
+
+	b.finalityMu.Lock()
+	byHash, found := b.attestations[attestation.BlockNumber]
+	if !found {
+		byHash = make(map[string]checkpointAttestations)
+		b.attestations[attestation.BlockNumber] = byHash
+	}
+	blockHash := hexutil.Encode(attestation.BlockHash)
+	if _, found := byHash[blockHash]; !found {
+		byHash[blockHash] = make(checkpointAttestations)
+	}
+	byHash[blockHash][addr] = attestation.Signature
+	b.finalityMu.Unlock()
+
+	b.stateMu.Lock()
+	defer b.stateMu.Unlock()
+
+	return b.finalizePendingCheckpoints(), nil
+}
+
+// VerifyCheckpointAttestation checks the signature of an attestation and that it's signed by one of the verifiers.
+// it returns the address of the signer.
+func VerifyCheckpointAttestation(attestation *messages.CheckpointAttestationProto, verifiers []block.Verifier) (string, error) {
+	pubKey, err := ffgcrypto.PublicKeyFromBytes(attestation.PublicKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to get public key of attestation signer: %w", err)
+	}
+
+	ok, err := pubKey.Verify(CheckpointHash(attestation.BlockNumber, attestation.BlockHash), attestation.Signature)
+	if err != nil || !ok {
+		return "", errors.New("invalid attestation signature")
+	}
+
+	addr, err := ffgcrypto.RawPublicToAddress(attestation.PublicKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to get address of attestation signer: %w", err)
+	}
+
+	for _, v := range verifiers {
+		if v.Address == addr {
+			return addr, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s at block %d", ErrNotAttestationVerifier, addr, attestation.BlockNumber)
+}
+
+// GetFinalizedCheckpoint returns the number and hash of the last finalized block.
+// the genesis block is final if no checkpoint was finalized.
+func (b *Blockchain) GetFinalizedCheckpoint() (uint64, []byte) {
+	b.finalityMu.RLock()
+	defer b.finalityMu.RUnlock()
+
+	return b.finalizedNumber, b.finalizedHash
+}
+
+// IsFinalized checks if the block with the given number of the chain is final.
+func (b *Blockchain) IsFinalized(blockNumber uint64) bool {
+	finalizedNumber, _ := b.GetFinalizedCheckpoint()
+	return blockNumber <= finalizedNumber
+}
+
+// finalizePendingCheckpoints finalizes the checkpoints of the chain which reached the quorum.
+// it must be called while holding the state lock.
+func (b *Blockchain) finalizePendingCheckpoints() bool {
+	b.finalityMu.Lock()
+	defer b.finalityMu.Unlock()
+
+	finalized := false
+	for number, byHash := range b.attestations {
+		if number <= b.finalizedNumber {
+			continue
+		}
+
+		canonical, err := b.GetBlockByNumber(number)
+		if err != nil {
+			continue
+		}
+
+		signers, ok := byHash[hexutil.Encode(canonical.Hash)]
+		if !ok {
+			continue
+		}
+
+		verifiers, err := b.GetVerifiersAtHeight(number)
+		if err != nil || len(signers) < VerifierQuorum(len(verifiers)) {
+			continue
+		}
+
+		if err := b.db.Put([]byte(finalizedCheckpointPrefix), encodeCheckpoint(number, canonical.Hash)); err != nil {
+			log.Errorf("failed to save finalized checkpoint %d: %v", number, err)
+			continue
+		}
+
+		b.finalizedNumber = number
+		b.finalizedHash = canonical.Hash
+		finalized = true
+	}
+
+	if finalized {
+		for number := range b.attestations {
+			if number <= b.finalizedNumber {
+				delete(b.attestations, number)
+			}
+		}
+		log.Infof("finalized checkpoint %d: %s", b.finalizedNumber, hexutil.Encode(b.finalizedHash))
+	}
+	return finalized
+}
+
+// loadFinalizedCheckpoint loads the finalized checkpoint from the database.
+func (b *Blockchain) loadFinalizedCheckpoint() error {
+	data, err := b.db.Get([]byte(finalizedCheckpointPrefix))
+	if errors.Is(err, leveldb.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get finalized checkpoint: %w", err)
+	}
+
+	if len(data) < 8 {
+		return errors.New("invalid finalized checkpoint")
+	}
+
+	b.finalityMu.Lock()
+	defer b.finalityMu.Unlock()
+
+	b.finalizedNumber = binary.BigEndian.Uint64(data[:8])
+	b.finalizedHash = data[8:]
+	return nil
+}
+
+// isBeforeFinalizedCheckpoint checks if reverting the chain to the given block would revert a finalized block.
+func (b *Blockchain) isBeforeFinalizedCheckpoint(ancestorNumber uint64) bool {
+	finalizedNumber, _ := b.GetFinalizedCheckpoint()
+	return ancestorNumber < finalizedNumber
+}
+
+func encodeCheckpoint(blockNumber uint64, blockHash []byte) []byte {
+	key := make([]byte, 8, 8+len(blockHash))
+	binary.BigEndian.PutUint64(key, blockNumber)
+	return append(key, blockHash...)
+}
@@ -0,0 +1,158 @@
+package blockchain
+
+import (
+	"os"
+	"testing"
+
+	"github.com/filefilego/filefilego/block"
+	"github.com/filefilego/filefilego/common/hexutil"
+	"github.com/filefilego/filefilego/crypto"
+	"github.com/filefilego/filefilego/database"
+	"github.com/filefilego/filefilego/node/protocols/messages"
+	"github.com/filefilego/filefilego/search"
+	"github.com/stretchr/testify/assert"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+func TestCheckpointFinality(t *testing.T) {
+	genesisblockValid, err := block.GetGenesisBlock()
+	assert.NoError(t, err)
+	db, err := leveldb.OpenFile("finality.db", nil)
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		db.Close()
+		os.RemoveAll("finality.db")
+	})
+
+	driver, err := database.New(db)
+	assert.NoError(t, err)
+	bchain, err := New(driver, &search.Search{}, genesisblockValid.Hash)
+	assert.NoError(t, err)
+	assert.NoError(t, bchain.InitOrLoad(true))
+
+	// the genesis block is final
+	assert.True(t, bchain.IsFinalized(0))
+	assert.False(t, bchain.IsFinalized(1))
+
+	keyPairs := make([]crypto.KeyPair, 3)
+	set := &VerifierSetProto{Sequence: 1}
+	for i := range keyPairs {
+		keyPairs[i] = newVerifierKeyPair(t)
+		set.Verifiers = append(set.Verifiers, verifierProtoFromKeyPair(t, keyPairs[i]))
+	}
+	assert.NoError(t, bchain.saveVerifierSet(bchain.db, 1, set))
+
+	signBlock := func(blck *block.Block, previous []byte, kp crypto.KeyPair) {
+		blck.PreviousBlockHash = previous
+		assert.NoError(t, blck.Sign(kp.PrivateKey))
+		pubKeyBytes, err := kp.PublicKey.Raw()
+		assert.NoError(t, err)
+		block.SetBlockVerifiers(block.Verifier{
+			Address:   kp.Address,
+			PublicKey: hexutil.Encode(pubKeyBytes),
+		})
+	}
+
+	a1, kpA, _ := validBlock(t, 1)
+	signBlock(a1, genesisblockValid.Hash, kpA)
+	assert.NoError(t, bchain.PutBlockPool(*a1))
+	assert.Equal(t, uint64(1), bchain.GetHeight())
+
+	attest := func(kp crypto.KeyPair, blockNumber uint64, blockHash []byte) *messages.CheckpointAttestationProto {
+		attestation, err := NewCheckpointAttestation(kp.PrivateKey, blockNumber, blockHash)
+		assert.NoError(t, err)
+		return attestation
+	}
+
+	invalidSignature := attest(keyPairs[0], 1, a1.Hash)
+	invalidSignature.BlockHash = []byte{1}
+	outsider := newVerifierKeyPair(t)
+
+	cases := map[string]struct {
+		attestation *messages.CheckpointAttestationProto
+		expErr      string
+	}{
+		"not a verifier": {
+			attestation: attest(outsider, 1, a1.Hash),
+			expErr:      "attestation signer is not a verifier: " + outsider.Address + " at block 1",
+		},
+		"invalid signature": {
+			attestation: invalidSignature,
+			expErr:      "invalid attestation signature",
+		},
+		"too far ahead": {
+			attestation: attest(keyPairs[0], 1000, a1.Hash),
+			expErr:      "checkpoint 1000 is too far ahead of the chain",
+		},
+	}
+
+	for name, tt := range cases {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			finalized, err := bchain.AddCheckpointAttestation(tt.attestation)
+			assert.EqualError(t, err, tt.expErr)
+			assert.False(t, finalized)
+		})
+	}
+
+	// the block is final once the quorum attested it
+	for _, kp := range keyPairs[:2] {
+		finalized, err := bchain.AddCheckpointAttestation(attest(kp, 1, a1.Hash))
+		assert.NoError(t, err)
+		assert.False(t, finalized)
+	}
+	assert.False(t, bchain.IsFinalized(1))
+	finalized, err := bchain.AddCheckpointAttestation(attest(keyPairs[2], 1, a1.Hash))
+	assert.NoError(t, err)
+	assert.True(t, finalized)
+	assert.True(t, bchain.IsFinalized(1))
+	number, hash := bchain.GetFinalizedCheckpoint()
+	assert.Equal(t, uint64(1), number)
+	assert.Equal(t, a1.Hash, hash)
+
+	// attestations of final blocks are ignored
+	finalized, err = bchain.AddCheckpointAttestation(attest(keyPairs[0], 1, a1.Hash))
+	assert.NoError(t, err)
+	assert.False(t, finalized)
+
+	// a longer branch which conflicts with the finalized block doesn't reorganize the chain
+	b1, kpB, _ := validBlock(t, 1)
+	signBlock(b1, genesisblockValid.Hash, kpB)
+	b2, _, _ := validBlock(t, 2)
+	b2.Transactions[0].PublicKey, err = kpB.PublicKey.Raw()
+	assert.NoError(t, err)
+	b2.Transactions[0].From = kpB.Address
+	b2.Transactions[0].To = kpB.Address
+	assert.NoError(t, b2.Transactions[0].Sign(kpB.PrivateKey))
+	signBlock(b2, b1.Hash, kpB)
+	assert.NoError(t, bchain.PutBlockPool(*b2))
+	assert.NoError(t, bchain.PutBlockPool(*b1))
+	assert.Equal(t, uint64(1), bchain.GetHeight())
+	assert.Equal(t, a1.Hash, bchain.GetLastBlockHash())
+
+	reverted, err := bchain.revertToBlock(*genesisblockValid)
+	assert.EqualError(t, err, "block 1 is finalized and can't be reverted")
+	assert.Empty(t, reverted)
+
+	// a block attested before it's applied is final once it becomes part of the chain
+	a2, _, _ := validBlock(t, 2)
+	a2.Transactions[0] = a1.Transactions[0]
+	signBlock(a2, a1.Hash, kpA)
+	for _, kp := range keyPairs {
+		finalized, err := bchain.AddCheckpointAttestation(attest(kp, 2, a2.Hash))
+		assert.NoError(t, err)
+		assert.False(t, finalized)
+	}
+	assert.False(t, bchain.IsFinalized(2))
+	assert.NoError(t, bchain.PutBlockPool(*a2))
+	assert.Equal(t, a2.Hash, bchain.GetLastBlockHash())
+	assert.True(t, bchain.IsFinalized(2))
+
+	// the finalized checkpoint is loaded again
+	reloaded, err := New(driver, &search.Search{}, genesisblockValid.Hash)
+	assert.NoError(t, err)
+	assert.NoError(t, reloaded.InitOrLoad(false))
+	number, hash = reloaded.GetFinalizedCheckpoint()
+	assert.Equal(t, uint64(2), number)
+	assert.Equal(t, a2.Hash, hash)
+}
@@ -0,0 +1,102 @@
+package blockchain
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// minKeepBlocks is the minimum number of blocks a pruned node keeps so chain reorganizations are still possible.
+const minKeepBlocks = maxReorgDepth + 1
+
+// SetPruning enables pruning so only the last keepBlocks blocks are kept.
+// the current state is always kept. zero disables pruning.
+func (b *Blockchain) SetPruning(keepBlocks uint64) error {
+	if keepBlocks > 0 && keepBlocks < minKeepBlocks {
+		return fmt.Errorf("pruned nodes must keep at least %d blocks", minKeepBlocks)
+	}
+
+	b.pruneMu.Lock()
+	defer b.pruneMu.Unlock()
+
+	b.keepBlocks = keepBlocks
+	return nil
+}
+
+// IsPruned returns true if old blocks are removed from the database.
+func (b *Blockchain) IsPruned() bool {
+	return b.getKeepBlocks() > 0
+}
+
+func (b *Blockchain) getKeepBlocks() uint64 {
+	b.pruneMu.RLock()
+	defer b.pruneMu.RUnlock()
+
+	return b.keepBlocks
+}
+
+// GetLowestBlockNumber returns the number of the oldest block which is stored in the database.
+func (b *Blockchain) GetLowestBlockNumber() uint64 {
+	iter := b.db.NewIterator(util.BytesPrefix([]byte(blockNumberPrefix)), nil)
+	defer iter.Release()
+
+	if !iter.Next() {
+		return 0
+	}
+
+	// block numbers are big endian encoded, so the first key is the lowest block number
+	return binary.BigEndian.Uint64(iter.Key()[len(blockNumberPrefix):])
+}
+
+// pruneBlocks removes the blocks which are older than the blocks which should be kept.
+func (b *Blockchain) pruneBlocks() error {
+	keepBlocks := b.getKeepBlocks()
+	height := b.GetHeight()
+	if keepBlocks == 0 || height < keepBlocks {
+		return nil
+	}
+
+	pruneTo := height - keepBlocks
+	for blockNumber := b.GetLowestBlockNumber(); blockNumber <= pruneTo; blockNumber++ {
+		if err := b.pruneBlock(blockNumber); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pruneBlock removes a block, its undo log and the indexes of its transactions.
+func (b *Blockchain) pruneBlock(blockNumber uint64) error {
+	blck, err := b.GetBlockByNumber(blockNumber)
+	if err != nil {
+		return fmt.Errorf("failed to get block %d: %w", blockNumber, err)
+	}
+
+	blockNumberBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(blockNumberBytes, blockNumber)
+
+	batch := new(leveldb.Batch)
+	batch.Delete(append([]byte(blockPrefix), blck.Hash...))
+	batch.Delete(append([]byte(blockNumberPrefix), blockNumberBytes...))
+	batch.Delete(append([]byte(undoPrefix), blck.Hash...))
+	for i, tx := range blck.Transactions {
+		indexBytes := make([]byte, 8)
+		binary.BigEndian.PutUint64(indexBytes, uint64(i))
+
+		txKey := append([]byte(transactionPrefix), tx.Hash...)
+		batch.Delete(append(txKey, blockNumberBytes...))
+
+		for _, addrBytes := range transactionAddresses(tx) {
+			key := append([]byte(addressTransactionPrefix), addrBytes...)
+			key = append(key, blockNumberBytes...)
+			batch.Delete(append(key, indexBytes...))
+		}
+	}
+
+	if err := b.db.Write(batch, nil); err != nil {
+		return fmt.Errorf("failed to prune block %d: %w", blockNumber, err)
+	}
+	return nil
+}
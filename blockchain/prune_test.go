@@ -0,0 +1,101 @@
+package blockchain
+
+import (
+	"os"
+	"testing"
+
+	"github.com/filefilego/filefilego/block"
+	"github.com/filefilego/filefilego/common/hexutil"
+	"github.com/filefilego/filefilego/database"
+	"github.com/filefilego/filefilego/search"
+	"github.com/stretchr/testify/assert"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+func TestSetPruning(t *testing.T) {
+	bchain := &Blockchain{}
+	assert.False(t, bchain.IsPruned())
+
+	err := bchain.SetPruning(minKeepBlocks - 1)
+	assert.EqualError(t, err, "pruned nodes must keep at least 101 blocks")
+	assert.False(t, bchain.IsPruned())
+
+	assert.NoError(t, bchain.SetPruning(minKeepBlocks))
+	assert.True(t, bchain.IsPruned())
+
+	assert.NoError(t, bchain.SetPruning(0))
+	assert.False(t, bchain.IsPruned())
+}
+
+func TestPruneBlocks(t *testing.T) {
+	genesisblockValid, err := block.GetGenesisBlock()
+	assert.NoError(t, err)
+	db, err := leveldb.OpenFile("prune.db", nil)
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		db.Close()
+		os.RemoveAll("prune.db")
+	})
+
+	driver, err := database.New(db)
+	assert.NoError(t, err)
+	bchain, err := New(driver, &search.Search{}, genesisblockValid.Hash)
+	assert.NoError(t, err)
+	assert.NoError(t, bchain.InitOrLoad(true))
+
+	// the minimum is bypassed to keep the test chain short
+	bchain.keepBlocks = 2
+
+	blocks := make([]*block.Block, 0)
+	previousHash := genesisblockValid.Hash
+	for i := uint64(1); i <= 3; i++ {
+		blck, kp, _ := validBlock(t, i)
+		blck.PreviousBlockHash = previousHash
+		err = blck.Sign(kp.PrivateKey)
+		assert.NoError(t, err)
+		pubKeyBytes, err := kp.PublicKey.Raw()
+		assert.NoError(t, err)
+		block.SetBlockVerifiers(block.Verifier{
+			Address:   kp.Address,
+			PublicKey: hexutil.Encode(pubKeyBytes),
+		})
+		assert.NoError(t, bchain.PerformStateUpdateFromBlock(*blck))
+		blocks = append(blocks, blck)
+		previousHash = blck.Hash
+	}
+
+	// the genesis block and the first block are pruned
+	assert.Equal(t, uint64(3), bchain.GetHeight())
+	assert.Equal(t, uint64(2), bchain.GetLowestBlockNumber())
+	_, err = bchain.GetBlockByNumber(0)
+	assert.Error(t, err)
+	_, err = bchain.GetBlockByHash(blocks[0].Hash)
+	assert.Error(t, err)
+	_, err = driver.Get(append([]byte(undoPrefix), blocks[0].Hash...))
+	assert.Error(t, err)
+	txs, _, err := bchain.GetTransactionByHash(blocks[0].Transactions[1].Hash)
+	assert.NoError(t, err)
+	assert.Len(t, txs, 0)
+	fromAddr, err := hexutil.Decode(blocks[0].Transactions[1].From)
+	assert.NoError(t, err)
+	txs, _, err = bchain.GetAddressTransactions(fromAddr)
+	assert.NoError(t, err)
+	assert.Len(t, txs, 0)
+
+	// the state of the pruned blocks is kept
+	_, err = bchain.GetAddressState(fromAddr)
+	assert.NoError(t, err)
+
+	// the kept blocks are still available
+	for _, blck := range blocks[1:] {
+		found, err := bchain.GetBlockByNumber(blck.Number)
+		assert.NoError(t, err)
+		assert.Equal(t, blck.Hash, found.Hash)
+	}
+
+	// a pruned blockchain can be loaded and verified
+	reloaded, err := New(driver, &search.Search{}, genesisblockValid.Hash)
+	assert.NoError(t, err)
+	assert.NoError(t, reloaded.InitOrLoad(true))
+	assert.Equal(t, uint64(3), reloaded.GetHeight())
+}
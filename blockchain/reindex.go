@@ -0,0 +1,318 @@
+package blockchain
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/filefilego/filefilego/common/hexutil"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+	"google.golang.org/protobuf/proto"
+)
+
+// Index is a derived index which can be rebuilt from the stored blocks and node items.
+type Index string
+
+const (
+	// BlockNumberIndex maps the block numbers to the block hashes.
+	BlockNumberIndex Index = "block_numbers"
+	// TransactionIndex maps the transaction hashes to the block numbers.
+	TransactionIndex Index = "transactions"
+	// AddressTransactionIndex maps the addresses to their transactions.
+	AddressTransactionIndex Index = "address_transactions"
+	// ChannelIndex lists the channels and their count.
+	ChannelIndex Index = "channels"
+	// ChildNodeIndex maps the node items to their child nodes.
+	ChildNodeIndex Index = "child_nodes"
+	// SearchIndex is the full-text search index of the node items.
+	SearchIndex Index = "search"
+)
+
+// AllIndexes are the indexes which can be rebuilt.
+var AllIndexes = []Index{
+	BlockNumberIndex,
+	TransactionIndex,
+	AddressTransactionIndex,
+	ChannelIndex,
+	ChildNodeIndex,
+	SearchIndex,
+}
+
+// indexPrefixes are the database key prefixes of the indexes which are stored in the blockchain database.
+var indexPrefixes = map[Index]string{
+	BlockNumberIndex:        blockNumberPrefix,
+	TransactionIndex:        transactionPrefix,
+	AddressTransactionIndex: addressTransactionPrefix,
+	// the channels count key shares the channel prefix, so it's removed and rebuilt with the channels.
+	ChannelIndex:   channelPrefix,
+	ChildNodeIndex: nodeNodesPrefix,
+}
+
+// ParseIndexes parses the index names. no names select all the indexes.
+func ParseIndexes(names []string) ([]Index, error) {
+	if len(names) == 0 {
+		return AllIndexes, nil
+	}
+
+	indexes := make([]Index, 0, len(names))
+	for _, name := range names {
+		found := false
+		for _, index := range AllIndexes {
+			if strings.TrimSpace(name) == string(index) {
+				indexes = append(indexes, index)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown index %s", name)
+		}
+	}
+	return indexes, nil
+}
+
+// ReindexProgress is called after a block is reindexed.
+type ReindexProgress func(processed, total uint64)
+
+// ReindexResult reports what was rebuilt by a reindex.
+type ReindexResult struct {
+	Blocks       uint64
+	Transactions uint64
+	Nodes        uint64
+	Channels     uint64
+}
+
+// Reindex removes the given indexes and rebuilds them from the stored blocks and node items.
+// the blockchain should be loaded and not running, since the indexes are incomplete while they are rebuilt.
+// the rebuilt indexes are verified against the blocks and the current state.
+func (b *Blockchain) Reindex(indexes []Index, progress ReindexProgress) (ReindexResult, error) {
+	b.stateMu.Lock()
+	defer b.stateMu.Unlock()
+
+	return b.reindex(indexes, progress)
+}
+
+// reindex rebuilds the given indexes, the caller must hold the state lock.
+func (b *Blockchain) reindex(indexes []Index, progress ReindexProgress) (ReindexResult, error) {
+	result := ReindexResult{}
+	selected := make(map[Index]bool)
+	for _, index := range indexes {
+		selected[index] = true
+	}
+
+	blockHashes, err := b.storedBlockHashes()
+	if err != nil {
+		return result, err
+	}
+
+	for _, index := range indexes {
+		prefix, ok := indexPrefixes[index]
+		if !ok {
+			continue
+		}
+		if err := b.deleteKeysWithPrefix(prefix); err != nil {
+			return result, fmt.Errorf("failed to remove %s index: %w", index, err)
+		}
+	}
+
+	total := uint64(len(blockHashes))
+	for i, blockHash := range blockHashes {
+		blck, err := b.GetBlockByHash(blockHash)
+		if err != nil {
+			return result, fmt.Errorf("failed to get block %s: %w", hexutil.Encode(blockHash), err)
+		}
+
+		if selected[BlockNumberIndex] {
+			if err := b.indexBlockHashByBlockNumber(b.db, blck.Hash, blck.Number); err != nil {
+				return result, err
+			}
+		}
+		if selected[TransactionIndex] {
+			if err := b.indexBlockTransactions(b.db, blck); err != nil {
+				return result, err
+			}
+		}
+		if selected[AddressTransactionIndex] {
+			if err := b.indexTransactionsByAddresses(b.db, blck); err != nil {
+				return result, err
+			}
+		}
+
+		result.Blocks++
+		result.Transactions += uint64(len(blck.Transactions))
+		if progress != nil {
+			progress(uint64(i+1), total)
+		}
+	}
+
+	if selected[SearchIndex] {
+		if err := b.search.Clear(); err != nil {
+			return result, fmt.Errorf("failed to clear search index: %w", err)
+		}
+	}
+
+	err = b.iterateNodeItems(func(node *NodeItem) error {
+		result.Nodes++
+		if node.NodeType == NodeItemType_CHANNEL {
+			result.Channels++
+			if selected[ChannelIndex] {
+				if err := b.saveAsChannel(b.db, node.NodeHash); err != nil {
+					return err
+				}
+			}
+		} else if selected[ChildNodeIndex] && len(node.ParentHash) > 0 {
+			if err := b.saveNodeAsChildNode(b.db, node.ParentHash, node.NodeHash); err != nil {
+				return err
+			}
+		}
+
+		if selected[SearchIndex] {
+			if err := b.updateSearchIndex(node); err != nil {
+				return fmt.Errorf("failed to index node %s: %w", hexutil.Encode(node.NodeHash), err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+
+	if err := b.verifyIndexes(selected, blockHashes, result); err != nil {
+		return result, fmt.Errorf("failed to verify indexes: %w", err)
+	}
+	return result, nil
+}
+
+// storedBlockHashes walks the chain back from the last block and returns the block hashes in ascending order.
+// the walk stops at the genesis block, or at the lowest block of a pruned node or of a node started from a snapshot.
+func (b *Blockchain) storedBlockHashes() ([][]byte, error) {
+	hashes := make([][]byte, 0)
+	blockHash := b.GetLastBlockHash()
+	for {
+		blck, err := b.GetBlockByHash(blockHash)
+		if err != nil {
+			if len(hashes) > 0 && (b.IsPruned() || b.snapshotImportInProgress() || b.GetLowestBlockNumber() > 0) {
+				break
+			}
+			return nil, fmt.Errorf("failed to get block %s: %w", hexutil.Encode(blockHash), err)
+		}
+		hashes = append(hashes, blck.Hash)
+
+		if blck.Number == 0 {
+			break
+		}
+		blockHash = blck.PreviousBlockHash
+	}
+
+	for i, j := 0, len(hashes)-1; i < j; i, j = i+1, j-1 {
+		hashes[i], hashes[j] = hashes[j], hashes[i]
+	}
+	return hashes, nil
+}
+
+// verifyIndexes checks that the rebuilt indexes resolve to the stored blocks and node items.
+func (b *Blockchain) verifyIndexes(selected map[Index]bool, blockHashes [][]byte, result ReindexResult) error {
+	if len(blockHashes) == 0 {
+		return errors.New("no blocks were reindexed")
+	}
+
+	lastBlock, err := b.GetBlockByHash(blockHashes[len(blockHashes)-1])
+	if err != nil {
+		return fmt.Errorf("failed to get last block: %w", err)
+	}
+	if lastBlock.Number != b.GetHeight() {
+		return fmt.Errorf("last reindexed block %d doesn't match the blockchain height %d", lastBlock.Number, b.GetHeight())
+	}
+
+	for _, blockHash := range blockHashes {
+		blck, err := b.GetBlockByHash(blockHash)
+		if err != nil {
+			return fmt.Errorf("failed to get block %s: %w", hexutil.Encode(blockHash), err)
+		}
+
+		if selected[BlockNumberIndex] {
+			found, err := b.GetBlockByNumber(blck.Number)
+			if err != nil {
+				return fmt.Errorf("block %d is not indexed: %w", blck.Number, err)
+			}
+			if !bytes.Equal(found.Hash, blck.Hash) {
+				return fmt.Errorf("block %d is indexed with a different hash", blck.Number)
+			}
+		}
+
+		blockNumberBytes := make([]byte, 8)
+		binary.BigEndian.PutUint64(blockNumberBytes, blck.Number)
+		for i, tx := range blck.Transactions {
+			if selected[TransactionIndex] {
+				key := append([]byte(transactionPrefix), tx.Hash...)
+				if !b.hasKey(append(key, blockNumberBytes...)) {
+					return fmt.Errorf("transaction %s of block %d is not indexed", hexutil.Encode(tx.Hash), blck.Number)
+				}
+			}
+
+			if selected[AddressTransactionIndex] {
+				indexBytes := make([]byte, 8)
+				binary.BigEndian.PutUint64(indexBytes, uint64(i))
+				for _, addrBytes := range transactionAddresses(tx) {
+					key := append([]byte(addressTransactionPrefix), addrBytes...)
+					key = append(key, blockNumberBytes...)
+					if !b.hasKey(append(key, indexBytes...)) {
+						return fmt.Errorf("transaction %s of address %s is not indexed", hexutil.Encode(tx.Hash), hexutil.Encode(addrBytes))
+					}
+				}
+			}
+		}
+	}
+
+	if selected[ChannelIndex] && b.GetChannelsCount() != result.Channels {
+		return fmt.Errorf("channels count %d doesn't match the %d channel nodes", b.GetChannelsCount(), result.Channels)
+	}
+	return nil
+}
+
+// iterateNodeItems calls fn for every stored node item.
+func (b *Blockchain) iterateNodeItems(fn func(node *NodeItem) error) error {
+	iter := b.db.NewIterator(util.BytesPrefix([]byte(nodePrefix)), nil)
+	defer iter.Release()
+	for iter.Next() {
+		node := NodeItem{}
+		if err := proto.Unmarshal(iter.Value(), &node); err != nil {
+			return fmt.Errorf("failed to unmarshal node item: %w", err)
+		}
+
+		if err := fn(&node); err != nil {
+			return err
+		}
+	}
+
+	if err := iter.Error(); err != nil {
+		return fmt.Errorf("failed to iterate node items: %w", err)
+	}
+	return nil
+}
+
+// deleteKeysWithPrefix removes all the keys which start with the prefix.
+func (b *Blockchain) deleteKeysWithPrefix(prefix string) error {
+	iter := b.db.NewIterator(util.BytesPrefix([]byte(prefix)), nil)
+	batch := new(leveldb.Batch)
+	for iter.Next() {
+		batch.Delete(append([]byte{}, iter.Key()...))
+	}
+	iter.Release()
+	if err := iter.Error(); err != nil {
+		return fmt.Errorf("failed to iterate keys: %w", err)
+	}
+
+	if err := b.db.Write(batch, nil); err != nil {
+		return fmt.Errorf("failed to delete keys: %w", err)
+	}
+	return nil
+}
+
+func (b *Blockchain) hasKey(key []byte) bool {
+	_, err := b.db.Get(key)
+	return err == nil
+}
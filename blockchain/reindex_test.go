@@ -0,0 +1,131 @@
+package blockchain
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/filefilego/filefilego/block"
+	"github.com/filefilego/filefilego/common/hexutil"
+	"github.com/filefilego/filefilego/database"
+	"github.com/filefilego/filefilego/search"
+	"github.com/stretchr/testify/assert"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+func TestParseIndexes(t *testing.T) {
+	indexes, err := ParseIndexes(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, AllIndexes, indexes)
+
+	indexes, err = ParseIndexes([]string{"channels", "block_numbers"})
+	assert.NoError(t, err)
+	assert.Equal(t, []Index{ChannelIndex, BlockNumberIndex}, indexes)
+
+	_, err = ParseIndexes([]string{"unknown"})
+	assert.EqualError(t, err, "unknown index unknown")
+}
+
+func TestReindex(t *testing.T) {
+	genesisblockValid, err := block.GetGenesisBlock()
+	assert.NoError(t, err)
+	db, err := leveldb.OpenFile("reindex.db", nil)
+	assert.NoError(t, err)
+	blv, err := search.NewBleveSearch(filepath.Join(t.TempDir(), "search.db"))
+	assert.NoError(t, err)
+	searchEngine, err := search.New(blv)
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		searchEngine.Close()
+		db.Close()
+		os.RemoveAll("reindex.db")
+	})
+
+	driver, err := database.New(db)
+	assert.NoError(t, err)
+	bchain, err := New(driver, searchEngine, genesisblockValid.Hash)
+	assert.NoError(t, err)
+	assert.NoError(t, bchain.InitOrLoad(true))
+
+	blocks := make([]*block.Block, 0)
+	previousHash := genesisblockValid.Hash
+	for i := uint64(1); i <= 3; i++ {
+		blck, kp, _ := validBlock(t, i)
+		blck.PreviousBlockHash = previousHash
+		assert.NoError(t, blck.Sign(kp.PrivateKey))
+		pubKeyBytes, err := kp.PublicKey.Raw()
+		assert.NoError(t, err)
+		block.SetBlockVerifiers(block.Verifier{
+			Address:   kp.Address,
+			PublicKey: hexutil.Encode(pubKeyBytes),
+		})
+		assert.NoError(t, bchain.PerformStateUpdateFromBlock(*blck))
+		blocks = append(blocks, blck)
+		previousHash = blck.Hash
+	}
+
+	channelNode := NodeItem{Name: "channel", NodeHash: []byte{1}, NodeType: NodeItemType_CHANNEL, Enabled: true}
+	childNode := NodeItem{Name: "entry", NodeHash: []byte{2}, ParentHash: channelNode.NodeHash, NodeType: NodeItemType_ENTRY, Enabled: true}
+	assert.NoError(t, bchain.saveNode(bchain.db, &channelNode))
+	assert.NoError(t, bchain.saveAsChannel(bchain.db, channelNode.NodeHash))
+	assert.NoError(t, bchain.saveNode(bchain.db, &childNode))
+	assert.NoError(t, bchain.saveNodeAsChildNode(bchain.db, channelNode.NodeHash, childNode.NodeHash))
+
+	// the indexes are lost, the channels count is wrong and the search index has a stale item
+	assert.NoError(t, searchEngine.Index(search.IndexItem{Hash: hexutil.Encode([]byte{5}), Name: "stale entry"}))
+	for _, prefix := range indexPrefixes {
+		assert.NoError(t, bchain.deleteKeysWithPrefix(prefix))
+	}
+	assert.NoError(t, bchain.saveAsChannel(bchain.db, []byte{3}))
+	assert.NoError(t, bchain.saveAsChannel(bchain.db, []byte{4}))
+	_, err = bchain.GetBlockByNumber(2)
+	assert.Error(t, err)
+
+	processed := make([]uint64, 0)
+	result, err := bchain.Reindex(AllIndexes, func(current, total uint64) {
+		assert.Equal(t, uint64(4), total)
+		processed = append(processed, current)
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{1, 2, 3, 4}, processed)
+	assert.Equal(t, ReindexResult{Blocks: 4, Transactions: 7, Nodes: 2, Channels: 1}, result)
+
+	for _, blck := range blocks {
+		found, err := bchain.GetBlockByNumber(blck.Number)
+		assert.NoError(t, err)
+		assert.Equal(t, blck.Hash, found.Hash)
+
+		txs, blockNumbers, err := bchain.GetTransactionByHash(blck.Transactions[1].Hash)
+		assert.NoError(t, err)
+		assert.Len(t, txs, 1)
+		assert.Equal(t, []uint64{blck.Number}, blockNumbers)
+
+		toAddr, err := hexutil.Decode(blck.Transactions[1].To)
+		assert.NoError(t, err)
+		txs, _, err = bchain.GetAddressTransactions(toAddr)
+		assert.NoError(t, err)
+		assert.Len(t, txs, 1)
+	}
+
+	assert.Equal(t, uint64(1), bchain.GetChannelsCount())
+	channels, err := bchain.GetChannels(10, 0)
+	assert.NoError(t, err)
+	assert.Len(t, channels, 1)
+	children, err := bchain.GetChildNodeItems(channelNode.NodeHash)
+	assert.NoError(t, err)
+	assert.Len(t, children, 1)
+
+	found, err := searchEngine.Search(context.TODO(), "entry", 10, 0, search.AnyTermRequired)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{hexutil.Encode(childNode.NodeHash)}, found)
+
+	// a subset of indexes is rebuilt and the rest are left untouched
+	assert.NoError(t, bchain.deleteKeysWithPrefix(blockNumberPrefix))
+	result, err = bchain.Reindex([]Index{BlockNumberIndex}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(4), result.Blocks)
+	assert.Equal(t, uint64(1), bchain.GetChannelsCount())
+	_, err = bchain.GetBlockByNumber(3)
+	assert.NoError(t, err)
+}
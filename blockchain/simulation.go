@@ -0,0 +1,104 @@
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/filefilego/filefilego/block"
+	"github.com/filefilego/filefilego/crypto"
+	"github.com/filefilego/filefilego/transaction"
+)
+
+// RejectedTransaction is a transaction which failed during a block simulation.
+type RejectedTransaction struct {
+	Transaction transaction.Transaction
+	Reason      error
+}
+
+// BlockSimulation is the result of executing the transactions of a block without committing them.
+type BlockSimulation struct {
+	// Transactions are the coinbase transaction followed by the transactions which succeeded.
+	Transactions []transaction.Transaction
+	// Rejected are the transactions which failed.
+	Rejected []RejectedTransaction
+	// StateRoot is the state root after applying the succeeded transactions.
+	StateRoot []byte
+}
+
+// SimulateBlock executes the transactions of an unsigned block against the current state using the same
+// state updates as a block import, but rolls back every change at the end.
+// the first transaction is the coinbase transaction. a failing or expired transaction is rolled back and rejected, and the
+// next transactions of its sender are left out because of the nounce gap without being rejected.
+// transactions which aren't valid yet are left out without being rejected.
+func (b *Blockchain) SimulateBlock(blck block.Block) (*BlockSimulation, error) {
+	b.stateMu.Lock()
+	defer b.stateMu.Unlock()
+
+	if len(blck.Transactions) == 0 {
+		return nil, errors.New("no transactions in block")
+	}
+	coinbaseTx := blck.Transactions[0]
+
+	verifierAddr, err := crypto.RawPublicToAddressBytes(coinbaseTx.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get address of verifier: %w", err)
+	}
+
+	// the overlay is dropped at the end, so the changes are never committed
+	overlay, err := b.beginStateOverlay()
+	if err != nil {
+		return nil, err
+	}
+	b.startJournal(blck.Number)
+	defer b.stopJournal()
+
+	if err := b.refundExpiredContractEscrows(overlay, blck.Number); err != nil {
+		return nil, fmt.Errorf("failed to refund expired contract escrows: %w", err)
+	}
+
+	if err := b.performAddressStateUpdate(overlay, coinbaseTx, verifierAddr, true, true); err != nil {
+		return nil, fmt.Errorf("failed to apply coinbase transaction: %w", err)
+	}
+
+	simulation := &BlockSimulation{
+		Transactions: []transaction.Transaction{coinbaseTx},
+		Rejected:     make([]RejectedTransaction, 0),
+	}
+	failedSenders := make(map[string]struct{})
+	for _, tx := range blck.Transactions[1:] {
+		if _, ok := failedSenders[tx.From]; ok {
+			continue
+		}
+
+		// a transaction which isn't valid yet waits in the mempool, an expired one is rejected
+		if tx.IsExpired(blck.Number) {
+			failedSenders[tx.From] = struct{}{}
+			simulation.Rejected = append(simulation.Rejected, RejectedTransaction{Transaction: tx, Reason: tx.ValidateValidityWindow(blck.Number)})
+			continue
+		}
+
+		if err := tx.ValidateValidityWindow(blck.Number); err != nil {
+			failedSenders[tx.From] = struct{}{}
+			continue
+		}
+
+		checkpoint := overlay.Checkpoint()
+		err := b.performAddressStateUpdate(overlay, tx, verifierAddr, false, true)
+		if err == nil {
+			simulation.Transactions = append(simulation.Transactions, tx)
+			continue
+		}
+
+		if rerr := overlay.RevertToCheckpoint(checkpoint); rerr != nil {
+			return nil, fmt.Errorf("failed to revert transaction state: %w", rerr)
+		}
+		failedSenders[tx.From] = struct{}{}
+		simulation.Rejected = append(simulation.Rejected, RejectedTransaction{Transaction: tx, Reason: err})
+	}
+
+	simulation.StateRoot, err = b.updateStateTree(overlay)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate state root: %w", err)
+	}
+	return simulation, nil
+}
@@ -0,0 +1,103 @@
+package blockchain
+
+import (
+	"os"
+	"testing"
+
+	"github.com/filefilego/filefilego/block"
+	"github.com/filefilego/filefilego/crypto"
+	"github.com/filefilego/filefilego/database"
+	"github.com/filefilego/filefilego/search"
+	"github.com/filefilego/filefilego/transaction"
+	"github.com/stretchr/testify/assert"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+func TestSimulateBlock(t *testing.T) {
+	genesisblockValid, err := block.GetGenesisBlock()
+	assert.NoError(t, err)
+	db, err := leveldb.OpenFile("simulation.db", nil)
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		db.Close()
+		os.RemoveAll("simulation.db")
+	})
+
+	driver, err := database.New(db)
+	assert.NoError(t, err)
+	bchain, err := New(driver, &search.Search{}, genesisblockValid.Hash)
+	assert.NoError(t, err)
+	assert.NoError(t, bchain.InitOrLoad(true))
+
+	_, err = bchain.SimulateBlock(block.Block{})
+	assert.EqualError(t, err, "no transactions in block")
+
+	validBlock, kp, kp2 := validBlock(t, 1)
+	validBlock.PreviousBlockHash = genesisblockValid.Hash
+
+	// the balance of the sender isn't enough
+	overspend := signedTransaction(t, kp, kp2.Address, 2, "0x22b1c8c1227a00000", "0x1", nil)
+	// the next transaction of the sender has a nounce gap
+	afterGap := signedTransaction(t, kp, kp2.Address, 3, "0x1", "0x1", nil)
+	// the channel creation costs more than the fees
+	channel := signedTransaction(t, kp2, kp2.Address, 1, "0x0", "0x0", transactionWithChannelPayload(t, []*NodeItem{
+		{Name: "channel", NodeType: NodeItemType_CHANNEL, Timestamp: 1},
+	}))
+	validBlock.Transactions = append(validBlock.Transactions, overspend, afterGap, channel)
+
+	genesisRoot, err := bchain.GetStateRoot()
+	assert.NoError(t, err)
+	simulation, err := bchain.SimulateBlock(*validBlock)
+	assert.NoError(t, err)
+	assert.Equal(t, validBlock.Transactions[:2], simulation.Transactions)
+	assert.Len(t, simulation.Rejected, 2)
+	assert.Equal(t, overspend, simulation.Rejected[0].Transaction)
+	assert.ErrorContains(t, simulation.Rejected[0].Reason, "failed to subtract total value from address")
+	assert.Equal(t, channel, simulation.Rejected[1].Transaction)
+	assert.ErrorContains(t, simulation.Rejected[1].Reason, "total cost of channel actions")
+
+	// the state isn't changed
+	currentRoot, err := bchain.GetStateRoot()
+	assert.NoError(t, err)
+	assert.Equal(t, genesisRoot, currentRoot)
+
+	// the simulated state root matches the import of the succeeded transactions
+	validBlock.Transactions = simulation.Transactions
+	stateRoot, err := bchain.CalculateStateRoot(*validBlock)
+	assert.NoError(t, err)
+	assert.Equal(t, stateRoot, simulation.StateRoot)
+
+	// an expired transaction is rejected and a transaction which isn't valid yet is left out
+	windowBlock := *validBlock
+	windowBlock.Number = 5
+	expired := signedTransaction(t, kp, kp2.Address, 2, "0x1", "0x1", nil)
+	expired.ValidUntil = 4
+	assert.NoError(t, expired.Sign(kp.PrivateKey))
+	notValidYet := signedTransaction(t, kp2, kp.Address, 1, "0x1", "0x1", nil)
+	notValidYet.ValidAfter = 5
+	assert.NoError(t, notValidYet.Sign(kp2.PrivateKey))
+	windowBlock.Transactions = []transaction.Transaction{validBlock.Transactions[0], expired, notValidYet}
+	simulation, err = bchain.SimulateBlock(windowBlock)
+	assert.NoError(t, err)
+	assert.Equal(t, windowBlock.Transactions[:1], simulation.Transactions)
+	assert.Len(t, simulation.Rejected, 1)
+	assert.Equal(t, expired, simulation.Rejected[0].Transaction)
+	assert.EqualError(t, simulation.Rejected[0].Reason, "transaction expired at block 4")
+}
+
+func signedTransaction(t *testing.T, kp crypto.KeyPair, to string, nounce byte, value, fees string, data []byte) transaction.Transaction {
+	tx, _ := validTransaction(t)
+	pubKey, err := kp.PublicKey.Raw()
+	assert.NoError(t, err)
+	tx.PublicKey = pubKey
+	tx.From = kp.Address
+	tx.To = to
+	tx.Nounce = []byte{nounce}
+	tx.Value = value
+	tx.TransactionFees = fees
+	if data != nil {
+		tx.Data = data
+	}
+	assert.NoError(t, tx.Sign(kp.PrivateKey))
+	return *tx
+}
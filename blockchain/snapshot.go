@@ -0,0 +1,303 @@
+package blockchain
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/filefilego/filefilego/common/hexutil"
+	"github.com/filefilego/filefilego/node/protocols/messages"
+	log "github.com/sirupsen/logrus"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+	"google.golang.org/protobuf/proto"
+)
+
+// snapshotStatePrefixes are the key prefixes of the records which are included in a snapshot besides the latest blocks.
+// the records committed by the state root are verified against the snapshot block, and each evidence is verified on its own.
+var snapshotStatePrefixes = append([]string{evidencePrefix}, stateRootPrefixes...)
+
+// snapshotPrefixes are the key prefixes of the records which can be imported from a snapshot.
+// only the latest blocks are included, the transaction and the channel indexes are rebuilt once the import is finalized.
+var snapshotPrefixes = append([]string{blockPrefix, blockNumberPrefix}, snapshotStatePrefixes...)
+
+// snapshotIndexPrefixes are the key prefixes of the indexes which are rebuilt from the imported records.
+var snapshotIndexPrefixes = []string{
+	transactionPrefix,
+	addressTransactionPrefix,
+	channelPrefix,
+	nodeNodesPrefix,
+}
+
+// contractStoreKey is the key used by the contract store which shares the database with the blockchain.
+const contractStoreKey = "contract_data"
+
+// isSnapshotKey returns true if the key belongs to the blockchain state.
+func isSnapshotKey(key []byte) bool {
+	if bytes.HasPrefix(key, []byte(contractStoreKey)) {
+		return false
+	}
+
+	for _, prefix := range snapshotPrefixes {
+		if bytes.HasPrefix(key, []byte(prefix)) {
+			return true
+		}
+	}
+	return false
+}
+
+// ExportSnapshot calls fn with every record of the blockchain state and returns the height and the hash of the last block of the snapshot.
+// blocks are not applied while the snapshot is exported.
+func (b *Blockchain) ExportSnapshot(fn func(key, value []byte) error) (uint64, []byte, error) {
+	b.stateMu.Lock()
+	defer b.stateMu.Unlock()
+
+	lastBlockHash := b.GetLastBlockHash()
+	if len(lastBlockHash) == 0 {
+		return 0, nil, errors.New("blockchain is not initialized")
+	}
+
+	if err := b.iterateSnapshotRecords(fn); err != nil {
+		return 0, nil, err
+	}
+
+	return b.GetHeight(), lastBlockHash, nil
+}
+
+// ImportSnapshotRecords writes the records of a snapshot into the database.
+// records can only be imported into a blockchain which contains only the genesis block.
+// the imported state is not used until the import is finalized.
+func (b *Blockchain) ImportSnapshotRecords(records []*messages.SnapshotRecordProto) error {
+	b.stateMu.Lock()
+	defer b.stateMu.Unlock()
+
+	if b.GetHeight() != 0 {
+		return errors.New("snapshots can only be imported into an empty blockchain")
+	}
+
+	batch := new(leveldb.Batch)
+	batch.Put([]byte(snapshotImportPrefix), []byte{1})
+	for _, r := range records {
+		if !isSnapshotKey(r.Key) {
+			return fmt.Errorf("key %s is not part of the blockchain state", hexutil.Encode(r.Key))
+		}
+
+		// evidence isn't committed by the state root, so it's verified on its own
+		if bytes.HasPrefix(r.Key, []byte(evidencePrefix)) {
+			evidence := DoubleSignEvidenceProto{}
+			if err := proto.Unmarshal(r.Value, &evidence); err != nil {
+				return fmt.Errorf("failed to unmarshal evidence: %w", err)
+			}
+
+			if err := VerifyDoubleSignEvidence(&evidence); err != nil {
+				return fmt.Errorf("failed to verify evidence: %w", err)
+			}
+		}
+		batch.Put(r.Key, r.Value)
+	}
+
+	if err := b.db.Write(batch, nil); err != nil {
+		return fmt.Errorf("failed to write snapshot records: %w", err)
+	}
+	return nil
+}
+
+// FinalizeSnapshotImport makes the imported snapshot the current state of the blockchain.
+// the block with the given hash must be part of the imported records and be the block at the given height.
+func (b *Blockchain) FinalizeSnapshotImport(height uint64, blockHash []byte) error {
+	b.stateMu.Lock()
+	defer b.stateMu.Unlock()
+
+	if !b.snapshotImportInProgress() {
+		return errors.New("snapshot import is not in progress")
+	}
+
+	lastBlock, err := b.GetBlockByNumber(height)
+	if err != nil {
+		return fmt.Errorf("failed to get snapshot block: %w", err)
+	}
+
+	if !bytes.Equal(lastBlock.Hash, blockHash) {
+		return fmt.Errorf("block at height %d doesn't match the snapshot block hash", height)
+	}
+
+	ok, err := lastBlock.Validate()
+	if err != nil || !ok {
+		return fmt.Errorf("failed to validate snapshot block: %w", err)
+	}
+
+	// the imported records can only be trusted if they lead to the state root of the snapshot block
+	if len(lastBlock.StateRoot) == 0 {
+		return fmt.Errorf("snapshot block %d doesn't commit to a state root", height)
+	}
+
+	if err := b.rebuildStateTree(); err != nil {
+		return fmt.Errorf("failed to build state tree: %w", err)
+	}
+
+	stateRoot, err := b.stateTreeNode(b.db, 0, nil)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(stateRoot, lastBlock.StateRoot) {
+		return fmt.Errorf("snapshot state root %s doesn't match the block state root %s", hexutil.Encode(stateRoot), hexutil.Encode(lastBlock.StateRoot))
+	}
+
+	if err := b.db.Put([]byte(lastBlockPrefix), blockHash); err != nil {
+		return fmt.Errorf("failed to save last block of snapshot: %w", err)
+	}
+	b.SetHeight(height)
+
+	if err := b.rebuildSnapshotIndexes(); err != nil {
+		return err
+	}
+
+	batch := new(leveldb.Batch)
+	batch.Delete([]byte(snapshotImportPrefix))
+	if err := b.db.Write(batch, nil); err != nil {
+		return fmt.Errorf("failed to finalize snapshot import: %w", err)
+	}
+
+	return b.reindexAllNodes()
+}
+
+// rebuildSnapshotIndexes removes the blocks of the blockchain which are older than the imported blocks
+// and rebuilds the indexes of the imported blocks and node items.
+func (b *Blockchain) rebuildSnapshotIndexes() error {
+	blockHashes, err := b.storedBlockHashes()
+	if err != nil {
+		return err
+	}
+
+	lowest, err := b.GetBlockByHash(blockHashes[0])
+	if err != nil {
+		return fmt.Errorf("failed to get lowest snapshot block: %w", err)
+	}
+
+	// the genesis block is left from the blockchain which was replaced by the snapshot
+	if lowest.Number > 0 {
+		if err := b.pruneBlock(0); err != nil {
+			return err
+		}
+	}
+
+	_, err = b.reindex([]Index{BlockNumberIndex, TransactionIndex, AddressTransactionIndex, ChannelIndex, ChildNodeIndex}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to rebuild indexes of snapshot: %w", err)
+	}
+	return nil
+}
+
+// DiscardSnapshotImport removes the records of an unfinished snapshot import and initializes the blockchain from the genesis block.
+func (b *Blockchain) DiscardSnapshotImport() error {
+	b.stateMu.Lock()
+	err := b.discardSnapshotImport()
+	b.stateMu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	return b.InitOrLoad(false)
+}
+
+// snapshotImportInProgress returns true if snapshot records were imported but the import is not finalized.
+func (b *Blockchain) snapshotImportInProgress() bool {
+	_, err := b.db.Get([]byte(snapshotImportPrefix))
+	return err == nil
+}
+
+// discardSnapshotImport removes the records of an unfinished snapshot import.
+func (b *Blockchain) discardSnapshotImport() error {
+	if !b.snapshotImportInProgress() {
+		return nil
+	}
+
+	log.Warn("removing the records of an unfinished snapshot import")
+	prefixes := append([]string{lastBlockPrefix, undoPrefix, stateTreePrefix, stateTreeBuiltPrefix}, snapshotIndexPrefixes...)
+	prefixes = append(prefixes, snapshotPrefixes...)
+	batch := new(leveldb.Batch)
+	for _, prefix := range prefixes {
+		iter := b.db.NewIterator(util.BytesPrefix([]byte(prefix)), nil)
+		for iter.Next() {
+			if bytes.HasPrefix(iter.Key(), []byte(contractStoreKey)) {
+				continue
+			}
+			batch.Delete(append([]byte{}, iter.Key()...))
+		}
+		iter.Release()
+		if err := iter.Error(); err != nil {
+			return fmt.Errorf("failed to release snapshot iterator: %w", err)
+		}
+	}
+
+	batch.Delete([]byte(snapshotImportPrefix))
+	if err := b.db.Write(batch, nil); err != nil {
+		return fmt.Errorf("failed to remove snapshot records: %w", err)
+	}
+	return nil
+}
+
+// iterateSnapshotRecords calls fn with every record of the blockchain state and with the latest blocks.
+func (b *Blockchain) iterateSnapshotRecords(fn func(key, value []byte) error) error {
+	for _, prefix := range snapshotStatePrefixes {
+		iter := b.db.NewIterator(util.BytesPrefix([]byte(prefix)), nil)
+		for iter.Next() {
+			if !isSnapshotKey(iter.Key()) {
+				continue
+			}
+
+			if err := fn(iter.Key(), iter.Value()); err != nil {
+				iter.Release()
+				return err
+			}
+		}
+		iter.Release()
+		if err := iter.Error(); err != nil {
+			return fmt.Errorf("failed to release snapshot iterator: %w", err)
+		}
+	}
+
+	// the older blocks are not needed to continue the chain, so only the blocks kept by a pruned node are included
+	height := b.GetHeight()
+	from := uint64(0)
+	if height >= minKeepBlocks {
+		from = height - minKeepBlocks + 1
+	}
+
+	for blockNumber := from; blockNumber <= height; blockNumber++ {
+		blockNumberKey := make([]byte, len(blockNumberPrefix)+8)
+		copy(blockNumberKey, blockNumberPrefix)
+		binary.BigEndian.PutUint64(blockNumberKey[len(blockNumberPrefix):], blockNumber)
+		blockHash, err := b.db.Get(blockNumberKey)
+		if err != nil {
+			return fmt.Errorf("failed to get hash of block %d: %w", blockNumber, err)
+		}
+
+		blockKey := append([]byte(blockPrefix), blockHash...)
+		data, err := b.db.Get(blockKey)
+		if err != nil {
+			return fmt.Errorf("failed to get block %d: %w", blockNumber, err)
+		}
+
+		if err := fn(blockNumberKey, blockHash); err != nil {
+			return err
+		}
+
+		if err := fn(blockKey, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reindexAllNodes indexes all the node items in the search engine.
+func (b *Blockchain) reindexAllNodes() error {
+	return b.iterateNodeItems(func(node *NodeItem) error {
+		if err := b.updateSearchIndex(node); err != nil {
+			log.Warnf("failed to index node %s: %v", hexutil.Encode(node.NodeHash), err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,212 @@
+package blockchain
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/filefilego/filefilego/block"
+	"github.com/filefilego/filefilego/common/hexutil"
+	"github.com/filefilego/filefilego/database"
+	"github.com/filefilego/filefilego/node/protocols/messages"
+	"github.com/filefilego/filefilego/search"
+	"github.com/filefilego/filefilego/transaction"
+	"github.com/stretchr/testify/assert"
+	"github.com/syndtr/goleveldb/leveldb"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestSnapshotExportImport(t *testing.T) {
+	genesisblockValid, err := block.GetGenesisBlock()
+	assert.NoError(t, err)
+	db1, err := leveldb.OpenFile("snapshot1.db", nil)
+	assert.NoError(t, err)
+	db2, err := leveldb.OpenFile("snapshot2.db", nil)
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		db1.Close()
+		db2.Close()
+		os.RemoveAll("snapshot1.db")
+		os.RemoveAll("snapshot2.db")
+	})
+
+	driver1, err := database.New(db1)
+	assert.NoError(t, err)
+	driver2, err := database.New(db2)
+	assert.NoError(t, err)
+
+	bchain1, err := New(driver1, &search.Search{}, genesisblockValid.Hash)
+	assert.NoError(t, err)
+	bchain1.SetContractEscrowActivationHeight(0)
+	assert.NoError(t, bchain1.InitOrLoad(true))
+	bchain2, err := New(driver2, &search.Search{}, genesisblockValid.Hash)
+	assert.NoError(t, err)
+	assert.NoError(t, bchain2.InitOrLoad(true))
+
+	validBlock, kp, _ := validBlock(t, 1)
+	validBlock.PreviousBlockHash = make([]byte, len(genesisblockValid.Hash))
+	copy(validBlock.PreviousBlockHash, genesisblockValid.Hash)
+	pubKeyBytes, err := kp.PublicKey.Raw()
+	assert.NoError(t, err)
+	block.SetBlockVerifiers(block.Verifier{
+		Address:   kp.Address,
+		PublicKey: hexutil.Encode(pubKeyBytes),
+	})
+
+	// an open contract escrow is part of the state
+	requester, verifier, hoster := escrowKeyPair(t), escrowKeyPair(t), escrowKeyPair(t)
+	requesterAddr, err := hexutil.Decode(requester.Address)
+	assert.NoError(t, err)
+	requesterState := AddressState{}
+	requesterState.SetBalance(big.NewInt(100))
+	requesterState.SetNounce(0)
+	assert.NoError(t, bchain1.UpdateAddressState(requesterAddr, requesterState))
+	blockVerifierAddr, err := hexutil.Decode(kp.Address)
+	assert.NoError(t, err)
+	contract := escrowContract(t, []byte{1}, requester, verifier, hoster)
+	err = bchain1.PerformAddressStateUpdate(escrowTransaction(t, requester, 1, verifier.Address, "0xa", transaction.DataType_DATA_CONTRACT, contract), blockVerifierAddr, false)
+	assert.NoError(t, err)
+	escrow1, err := bchain1.GetContractEscrow(contract.ContractHash)
+	assert.NoError(t, err)
+
+	// the snapshot block commits to the state
+	stateRoot, err := bchain1.CalculateStateRoot(*validBlock)
+	assert.NoError(t, err)
+	validBlock.StateRoot = stateRoot
+	assert.NoError(t, validBlock.Sign(kp.PrivateKey))
+	assert.NoError(t, bchain1.PerformStateUpdateFromBlock(*validBlock))
+
+	// keys of the other stores sharing the database are not exported
+	assert.NoError(t, driver1.Put([]byte(contractStoreKey), []byte{1}))
+	assert.NoError(t, driver1.Put([]byte("token1"), []byte{1}))
+
+	records := make([]*messages.SnapshotRecordProto, 0)
+	height, blockHash, err := bchain1.ExportSnapshot(func(key, value []byte) error {
+		assert.True(t, isSnapshotKey(key))
+		records = append(records, &messages.SnapshotRecordProto{
+			Key:   append([]byte{}, key...),
+			Value: append([]byte{}, value...),
+		})
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), height)
+	assert.Equal(t, validBlock.Hash, blockHash)
+	assert.NotEmpty(t, records)
+
+	// the transaction and channel indexes are rebuilt by the importer
+	for _, r := range records {
+		for _, prefix := range snapshotIndexPrefixes {
+			assert.False(t, bytes.HasPrefix(r.Key, []byte(prefix)))
+		}
+	}
+
+	// records outside of the blockchain state are rejected
+	err = bchain2.ImportSnapshotRecords([]*messages.SnapshotRecordProto{{Key: []byte(lastBlockPrefix), Value: []byte{1}}})
+	assert.EqualError(t, err, "key "+hexutil.Encode([]byte(lastBlockPrefix))+" is not part of the blockchain state")
+
+	err = bchain2.FinalizeSnapshotImport(height, blockHash)
+	assert.EqualError(t, err, "snapshot import is not in progress")
+
+	// a snapshot of a block without a state root can't be verified
+	genesisRecords := make([]*messages.SnapshotRecordProto, 0)
+	_, _, err = bchain2.ExportSnapshot(func(key, value []byte) error {
+		genesisRecords = append(genesisRecords, &messages.SnapshotRecordProto{
+			Key:   append([]byte{}, key...),
+			Value: append([]byte{}, value...),
+		})
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, bchain2.ImportSnapshotRecords(genesisRecords))
+	err = bchain2.FinalizeSnapshotImport(0, genesisblockValid.Hash)
+	assert.EqualError(t, err, "snapshot block 0 doesn't commit to a state root")
+	assert.NoError(t, bchain2.DiscardSnapshotImport())
+
+	// records which don't match the state root of the snapshot block are rejected
+	tampered := make([]*messages.SnapshotRecordProto, 0, len(records)+1)
+	tampered = append(tampered, records...)
+	tampered = append(tampered, &messages.SnapshotRecordProto{Key: append([]byte(verifierSetPrefix), 1), Value: []byte{1}})
+	assert.NoError(t, bchain2.ImportSnapshotRecords(tampered))
+	err = bchain2.FinalizeSnapshotImport(height, blockHash)
+	assert.ErrorContains(t, err, "doesn't match the block state root")
+	assert.NoError(t, bchain2.DiscardSnapshotImport())
+
+	assert.NoError(t, bchain2.ImportSnapshotRecords(records))
+
+	// blocks are not applied during the import
+	err = bchain2.PerformStateUpdateFromBlock(*validBlock)
+	assert.EqualError(t, err, "blocks can't be applied while a snapshot is imported")
+
+	err = bchain2.FinalizeSnapshotImport(height, genesisblockValid.Hash)
+	assert.EqualError(t, err, "block at height 1 doesn't match the snapshot block hash")
+
+	assert.NoError(t, bchain2.FinalizeSnapshotImport(height, blockHash))
+	assert.Equal(t, uint64(1), bchain2.GetHeight())
+	assert.Equal(t, validBlock.Hash, bchain2.GetLastBlockHash())
+	blockNumberBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(blockNumberBytes, 1)
+	txKey := append([]byte(transactionPrefix), validBlock.Transactions[0].Hash...)
+	assert.True(t, bchain2.hasKey(append(txKey, blockNumberBytes...)))
+
+	addr, err := hexutil.Decode(kp.Address)
+	assert.NoError(t, err)
+	state1, err := bchain1.GetAddressState(addr)
+	assert.NoError(t, err)
+	state2, err := bchain2.GetAddressState(addr)
+	assert.NoError(t, err)
+	assert.Equal(t, state1, state2)
+
+	// the imported state has the same root
+	root1, err := bchain1.GetStateRoot()
+	assert.NoError(t, err)
+	root2, err := bchain2.GetStateRoot()
+	assert.NoError(t, err)
+	assert.Equal(t, root1, root2)
+
+	// the imported escrow is refunded at its deadline
+	escrow2, err := bchain2.GetContractEscrow(contract.ContractHash)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(escrow1, escrow2))
+	assert.NoError(t, bchain2.refundExpiredContractEscrows(bchain2.db, escrow2.Deadline+1))
+	_, err = bchain2.GetContractEscrow(contract.ContractHash)
+	assert.Error(t, err)
+	assertEscrowBalance(t, bchain2, requester.Address, "100")
+
+	// a finalized import can't be continued
+	err = bchain2.ImportSnapshotRecords(records)
+	assert.EqualError(t, err, "snapshots can only be imported into an empty blockchain")
+}
+
+func TestDiscardSnapshotImport(t *testing.T) {
+	genesisblockValid, err := block.GetGenesisBlock()
+	assert.NoError(t, err)
+	db, err := leveldb.OpenFile("snapshotdiscard.db", nil)
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		db.Close()
+		os.RemoveAll("snapshotdiscard.db")
+	})
+
+	driver, err := database.New(db)
+	assert.NoError(t, err)
+	bchain, err := New(driver, &search.Search{}, genesisblockValid.Hash)
+	assert.NoError(t, err)
+	assert.NoError(t, bchain.InitOrLoad(true))
+
+	importedKey := append([]byte(addressPrefix), 1, 2, 3)
+	err = bchain.ImportSnapshotRecords([]*messages.SnapshotRecordProto{{Key: importedKey, Value: []byte{1}}})
+	assert.NoError(t, err)
+
+	// an unfinished import is removed when the blockchain is loaded
+	bchain, err = New(driver, &search.Search{}, genesisblockValid.Hash)
+	assert.NoError(t, err)
+	assert.NoError(t, bchain.InitOrLoad(true))
+	assert.False(t, bchain.snapshotImportInProgress())
+	_, err = driver.Get(importedKey)
+	assert.Error(t, err)
+	assert.Equal(t, uint64(0), bchain.GetHeight())
+	assert.Equal(t, genesisblockValid.Hash, bchain.GetLastBlockHash())
+}
@@ -0,0 +1,365 @@
+package blockchain
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/filefilego/filefilego/block"
+	"github.com/filefilego/filefilego/common/hexutil"
+	"github.com/filefilego/filefilego/crypto"
+	"github.com/filefilego/filefilego/database"
+	log "github.com/sirupsen/logrus"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// stateRootPrefixes are the key prefixes of the records which are committed by the state root.
+// the records are written only while applying blocks, so they are the same on every node.
+// the other records are excluded:
+//   - blocks, block numbers, transactions and address transactions are the chain history, which is committed by the block hashes.
+//   - channels, the channels count and the child nodes are derived from the node items and are rebuilt by a reindex.
+//   - evidence is saved as soon as a node detects a double sign, before a block commits it, so it differs between nodes.
+//     each evidence carries the two signed blocks and can be verified on its own.
+//   - undo logs, the finalized checkpoint, the state tree and the snapshot import mark are local records of a node.
+var stateRootPrefixes = []string{
+	addressPrefix,
+	nodePrefix,
+	fileNodePrefix,
+	contractPrefix,
+	contractFeesReleasePrefix,
+	verifierSetPrefix,
+	escrowPrefix,
+	escrowDeadlinePrefix,
+}
+
+const (
+	stateLeafPrefix = byte(0)
+	stateNodePrefix = byte(1)
+
+	// stateTreeDepth is the depth of the sparse merkle tree, the path of a record is the hash of its key.
+	stateTreeDepth = 256
+)
+
+// stateTreeDefaults are the hashes of the empty subtrees at each depth of the state tree.
+var stateTreeDefaults = func() [][]byte {
+	defaults := make([][]byte, stateTreeDepth+1)
+	defaults[stateTreeDepth] = make([]byte, 32)
+	for depth := stateTreeDepth - 1; depth >= 0; depth-- {
+		defaults[depth] = stateNodeHash(defaults[depth+1], defaults[depth+1])
+	}
+	return defaults
+}()
+
+// StateProof proves that a record is part of the state committed by a state root.
+type StateProof struct {
+	Key   []byte
+	Value []byte
+	// Siblings are the hashes needed to compute the root starting from the leaf of the record.
+	Siblings [][]byte
+}
+
+// Verify checks that the proof leads to the given state root.
+func (p StateProof) Verify(stateRoot []byte) bool {
+	if len(p.Siblings) != stateTreeDepth {
+		return false
+	}
+
+	path := crypto.Sha256(p.Key)
+	hash := stateLeafHash(p.Key, p.Value)
+	for i, sibling := range p.Siblings {
+		hash = stateParentHash(path, stateTreeDepth-1-i, hash, sibling)
+	}
+	return bytes.Equal(hash, stateRoot)
+}
+
+// CalculateStateRoot applies a block without committing it and returns the resulting state root.
+// it's used by the validators to include the state root in a block before signing it.
+func (b *Blockchain) CalculateStateRoot(blck block.Block) ([]byte, error) {
+	b.stateMu.Lock()
+	defer b.stateMu.Unlock()
+
+	// the block is not signed yet, so the coinbase transaction can't be validated against the block signature
+	if len(blck.Transactions) == 0 {
+		return nil, errors.New("no transactions in block")
+	}
+	coinbaseTx := blck.Transactions[0]
+
+	verifierAddr, err := crypto.RawPublicToAddressBytes(coinbaseTx.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get address of verifier: %w", err)
+	}
+
+	overlay, err := b.beginStateOverlay()
+	if err != nil {
+		return nil, err
+	}
+	b.startJournal(blck.Number)
+	defer b.stopJournal()
+
+	if err := b.applyBlockTransactions(overlay, blck, coinbaseTx, verifierAddr, false); err != nil {
+		return nil, err
+	}
+	return b.updateStateTree(overlay)
+}
+
+// GetAddressStateProof returns the proof of the address state against the current state root.
+func (b *Blockchain) GetAddressStateProof(address []byte) (*StateProof, error) {
+	return b.getStateProof(append([]byte(addressPrefix), address...))
+}
+
+// GetNodeItemProof returns the proof of a node item against the current state root.
+func (b *Blockchain) GetNodeItemProof(nodeHash []byte) (*StateProof, error) {
+	return b.getStateProof(append([]byte(nodePrefix), nodeHash...))
+}
+
+// GetStateRoot returns the root of the current state.
+func (b *Blockchain) GetStateRoot() ([]byte, error) {
+	b.stateMu.Lock()
+	defer b.stateMu.Unlock()
+
+	return b.stateTreeNode(b.db, 0, nil)
+}
+
+// getStateProof returns the proof of the record with the given key.
+func (b *Blockchain) getStateProof(key []byte) (*StateProof, error) {
+	b.stateMu.Lock()
+	defer b.stateMu.Unlock()
+
+	value, err := b.db.Get(key)
+	if err != nil {
+		return nil, errors.New("record is not part of the state")
+	}
+
+	path := crypto.Sha256(key)
+	proof := &StateProof{
+		Key:      append([]byte{}, key...),
+		Value:    value,
+		Siblings: make([][]byte, 0, stateTreeDepth),
+	}
+	for depth := stateTreeDepth; depth > 0; depth-- {
+		sibling, err := b.stateTreeNode(b.db, depth, siblingPath(path, depth))
+		if err != nil {
+			return nil, err
+		}
+		proof.Siblings = append(proof.Siblings, sibling)
+	}
+	return proof, nil
+}
+
+// updateStateTree updates the state tree with the state records which were staged in the overlay
+// and returns the new state root. only the paths of the changed records are hashed again.
+func (b *Blockchain) updateStateTree(overlay *database.Overlay) ([]byte, error) {
+	for _, key := range overlay.StagedKeys() {
+		if !isStateRootKey(key) {
+			continue
+		}
+
+		value, err := overlay.Get(key)
+		if err != nil {
+			value = nil
+		}
+
+		if err := b.updateStateTreeLeaf(overlay, key, value); err != nil {
+			return nil, err
+		}
+	}
+	return b.stateTreeNode(overlay, 0, nil)
+}
+
+// updateStateTreeLeaf sets the leaf of a record and hashes its path up to the root.
+// a nil value removes the record from the tree.
+func (b *Blockchain) updateStateTreeLeaf(db database.Database, key, value []byte) error {
+	path := crypto.Sha256(key)
+	hash := stateTreeDefaults[stateTreeDepth]
+	if value != nil {
+		hash = stateLeafHash(key, value)
+	}
+
+	batch := new(leveldb.Batch)
+	putStateTreeNode(batch, stateTreeDepth, path, hash)
+	for depth := stateTreeDepth; depth > 0; depth-- {
+		sibling, err := b.stateTreeNode(db, depth, siblingPath(path, depth))
+		if err != nil {
+			return err
+		}
+		hash = stateParentHash(path, depth-1, hash, sibling)
+		putStateTreeNode(batch, depth-1, path, hash)
+	}
+
+	if err := db.Write(batch, nil); err != nil {
+		return fmt.Errorf("failed to write state tree nodes: %w", err)
+	}
+	return nil
+}
+
+// rebuildStateTree removes the state tree and builds it again from all the state records.
+// it's used when the state records were written without updating the tree.
+func (b *Blockchain) rebuildStateTree() error {
+	if err := b.deleteStateTree(); err != nil {
+		return err
+	}
+
+	for _, prefix := range stateRootPrefixes {
+		iter := b.db.NewIterator(util.BytesPrefix([]byte(prefix)), nil)
+		for iter.Next() {
+			if !isStateRootKey(iter.Key()) {
+				continue
+			}
+
+			key := append([]byte{}, iter.Key()...)
+			value := append([]byte{}, iter.Value()...)
+			if err := b.updateStateTreeLeaf(b.db, key, value); err != nil {
+				iter.Release()
+				return err
+			}
+		}
+		iter.Release()
+		if err := iter.Error(); err != nil {
+			return fmt.Errorf("failed to release state iterator: %w", err)
+		}
+	}
+	return b.setStateTreeBuilt()
+}
+
+// buildStateTreeIfMissing builds the state tree of a blockchain which was created before the tree was stored
+// or which stopped while building it.
+func (b *Blockchain) buildStateTreeIfMissing() error {
+	if b.hasKey([]byte(stateTreeBuiltPrefix)) {
+		return nil
+	}
+
+	log.Info("building the state tree")
+	return b.rebuildStateTree()
+}
+
+// setStateTreeBuilt marks the state tree as complete.
+func (b *Blockchain) setStateTreeBuilt() error {
+	if err := b.db.Put([]byte(stateTreeBuiltPrefix), []byte{1}); err != nil {
+		return fmt.Errorf("failed to mark the state tree as built: %w", err)
+	}
+	return nil
+}
+
+// deleteStateTree removes the state tree and the mark which tells that it's complete.
+func (b *Blockchain) deleteStateTree() error {
+	batch := new(leveldb.Batch)
+	batch.Delete([]byte(stateTreeBuiltPrefix))
+	if err := b.db.Write(batch, nil); err != nil {
+		return fmt.Errorf("failed to remove the state tree mark: %w", err)
+	}
+
+	if err := b.deleteKeysWithPrefix(stateTreePrefix); err != nil {
+		return fmt.Errorf("failed to remove state tree: %w", err)
+	}
+	return nil
+}
+
+// validateStateRoot checks the state root of a block against the state root after applying it.
+func (b *Blockchain) validateStateRoot(blck block.Block, stateRoot []byte) error {
+	if len(blck.StateRoot) == 0 {
+		if blck.Number >= b.stateRootActivationHeight {
+			return fmt.Errorf("block %d doesn't commit to a state root", blck.Number)
+		}
+		return nil
+	}
+
+	if !bytes.Equal(stateRoot, blck.StateRoot) {
+		return fmt.Errorf("state root %s doesn't match the block state root %s", hexutil.Encode(stateRoot), hexutil.Encode(blck.StateRoot))
+	}
+	return nil
+}
+
+// stateTreeNode returns the hash of the tree node at the given depth on the path.
+func (b *Blockchain) stateTreeNode(db database.Database, depth int, path []byte) ([]byte, error) {
+	hash, err := db.Get(stateTreeKey(depth, path))
+	if err == nil {
+		return hash, nil
+	}
+
+	if !errors.Is(err, leveldb.ErrNotFound) {
+		return nil, fmt.Errorf("failed to get state tree node: %w", err)
+	}
+	return stateTreeDefaults[depth], nil
+}
+
+// putStateTreeNode writes a tree node into the batch. the nodes of empty subtrees are not stored.
+func putStateTreeNode(batch *leveldb.Batch, depth int, path, hash []byte) {
+	key := stateTreeKey(depth, path)
+	if bytes.Equal(hash, stateTreeDefaults[depth]) {
+		batch.Delete(key)
+		return
+	}
+	batch.Put(key, hash)
+}
+
+// stateTreeKey returns the key of the tree node at the given depth on the path.
+// only the first depth bits of the path identify the node.
+func stateTreeKey(depth int, path []byte) []byte {
+	size := (depth + 7) / 8
+	key := make([]byte, 0, len(stateTreePrefix)+2+size)
+	key = append(key, stateTreePrefix...)
+	key = binary.BigEndian.AppendUint16(key, uint16(depth))
+	if size == 0 {
+		return key
+	}
+
+	nodePath := make([]byte, size)
+	copy(nodePath, path)
+	if rem := depth % 8; rem != 0 {
+		nodePath[size-1] &= byte(0xff << (8 - rem))
+	}
+	return append(key, nodePath...)
+}
+
+// siblingPath returns the path of the sibling of the node at the given depth.
+func siblingPath(path []byte, depth int) []byte {
+	sibling := append([]byte{}, path...)
+	bit := depth - 1
+	sibling[bit/8] ^= 1 << (7 - bit%8)
+	return sibling
+}
+
+// stateParentHash hashes a node with its sibling, the bit of the path at the parent depth tells which one is on the left.
+func stateParentHash(path []byte, parentDepth int, hash, sibling []byte) []byte {
+	if path[parentDepth/8]&(1<<(7-parentDepth%8)) == 0 {
+		return stateNodeHash(hash, sibling)
+	}
+	return stateNodeHash(sibling, hash)
+}
+
+// isStateRootKey returns true if the record is committed by the state root.
+func isStateRootKey(key []byte) bool {
+	// the contract store shares the contract prefix but it's local data
+	if bytes.HasPrefix(key, []byte(contractStoreKey)) {
+		return false
+	}
+
+	for _, prefix := range stateRootPrefixes {
+		if bytes.HasPrefix(key, []byte(prefix)) {
+			return true
+		}
+	}
+	return false
+}
+
+func stateLeafHash(key, value []byte) []byte {
+	keyLength := make([]byte, 8)
+	binary.BigEndian.PutUint64(keyLength, uint64(len(key)))
+
+	data := make([]byte, 0, 1+len(keyLength)+len(key)+len(value))
+	data = append(data, stateLeafPrefix)
+	data = append(data, keyLength...)
+	data = append(data, key...)
+	data = append(data, value...)
+	return crypto.Sha256(data)
+}
+
+func stateNodeHash(left, right []byte) []byte {
+	data := make([]byte, 0, 1+len(left)+len(right))
+	data = append(data, stateNodePrefix)
+	data = append(data, left...)
+	data = append(data, right...)
+	return crypto.Sha256(data)
+}
@@ -0,0 +1,183 @@
+package blockchain
+
+import (
+	"os"
+	"testing"
+
+	"github.com/filefilego/filefilego/block"
+	"github.com/filefilego/filefilego/common/hexutil"
+	"github.com/filefilego/filefilego/database"
+	"github.com/filefilego/filefilego/search"
+	"github.com/stretchr/testify/assert"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+func TestStateRoot(t *testing.T) {
+	genesisblockValid, err := block.GetGenesisBlock()
+	assert.NoError(t, err)
+	db, err := leveldb.OpenFile("stateroot.db", nil)
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		db.Close()
+		os.RemoveAll("stateroot.db")
+	})
+
+	driver, err := database.New(db)
+	assert.NoError(t, err)
+	bchain, err := New(driver, &search.Search{}, genesisblockValid.Hash)
+	assert.NoError(t, err)
+	assert.NoError(t, bchain.InitOrLoad(true))
+
+	genesisRoot, err := bchain.GetStateRoot()
+	assert.NoError(t, err)
+
+	validBlock, kp, kp2 := validBlock(t, 1)
+	validBlock.PreviousBlockHash = genesisblockValid.Hash
+	pubKeyBytes, err := kp.PublicKey.Raw()
+	assert.NoError(t, err)
+	block.SetBlockVerifiers(block.Verifier{
+		Address:   kp.Address,
+		PublicKey: hexutil.Encode(pubKeyBytes),
+	})
+
+	// the state root is calculated without changing the state
+	stateRoot, err := bchain.CalculateStateRoot(*validBlock)
+	assert.NoError(t, err)
+	assert.NotEqual(t, genesisRoot, stateRoot)
+	currentRoot, err := bchain.GetStateRoot()
+	assert.NoError(t, err)
+	assert.Equal(t, genesisRoot, currentRoot)
+
+	// a block with a wrong state root is rejected
+	invalidBlock := *validBlock
+	invalidBlock.StateRoot = genesisRoot
+	assert.NoError(t, invalidBlock.Sign(kp.PrivateKey))
+	err = bchain.PerformStateUpdateFromBlock(invalidBlock)
+	assert.ErrorContains(t, err, "doesn't match the block state root")
+	assert.Equal(t, uint64(0), bchain.GetHeight())
+
+	validBlock.StateRoot = stateRoot
+	assert.NoError(t, validBlock.Sign(kp.PrivateKey))
+	assert.NoError(t, bchain.PerformStateUpdateFromBlock(*validBlock))
+	currentRoot, err = bchain.GetStateRoot()
+	assert.NoError(t, err)
+	assert.Equal(t, stateRoot, currentRoot)
+
+	// the state root is part of the block hash
+	foundBlock, err := bchain.GetBlockByNumber(1)
+	assert.NoError(t, err)
+	assert.Equal(t, stateRoot, foundBlock.StateRoot)
+	ok, err := foundBlock.Validate()
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	for _, address := range []string{kp.Address, kp2.Address} {
+		addr, err := hexutil.Decode(address)
+		assert.NoError(t, err)
+		proof, err := bchain.GetAddressStateProof(addr)
+		assert.NoError(t, err)
+		assert.True(t, proof.Verify(stateRoot))
+		assert.False(t, proof.Verify(genesisRoot))
+
+		proof.Value = append(proof.Value, 1)
+		assert.False(t, proof.Verify(stateRoot))
+	}
+
+	_, err = bchain.GetNodeItemProof([]byte{1})
+	assert.EqualError(t, err, "record is not part of the state")
+
+	// the incremental tree matches a tree built from all the state records
+	assert.NoError(t, bchain.rebuildStateTree())
+	currentRoot, err = bchain.GetStateRoot()
+	assert.NoError(t, err)
+	assert.Equal(t, stateRoot, currentRoot)
+
+	// reverting the block restores the previous state root
+	assert.NoError(t, bchain.revertBlock(*validBlock))
+	currentRoot, err = bchain.GetStateRoot()
+	assert.NoError(t, err)
+	assert.Equal(t, genesisRoot, currentRoot)
+}
+
+func TestStateRootActivationHeight(t *testing.T) {
+	genesisblockValid, err := block.GetGenesisBlock()
+	assert.NoError(t, err)
+	db, err := leveldb.OpenFile("staterootactivation.db", nil)
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		db.Close()
+		os.RemoveAll("staterootactivation.db")
+	})
+
+	driver, err := database.New(db)
+	assert.NoError(t, err)
+	bchain, err := New(driver, &search.Search{}, genesisblockValid.Hash)
+	assert.NoError(t, err)
+	bchain.stateRootActivationHeight = 1
+	assert.NoError(t, bchain.InitOrLoad(true))
+
+	validBlock, kp, _ := validBlock(t, 1)
+	validBlock.PreviousBlockHash = genesisblockValid.Hash
+	pubKeyBytes, err := kp.PublicKey.Raw()
+	assert.NoError(t, err)
+	block.SetBlockVerifiers(block.Verifier{
+		Address:   kp.Address,
+		PublicKey: hexutil.Encode(pubKeyBytes),
+	})
+
+	// blocks from the activation height must commit to the state root
+	assert.NoError(t, validBlock.Sign(kp.PrivateKey))
+	err = bchain.PerformStateUpdateFromBlock(*validBlock)
+	assert.EqualError(t, err, "block 1 doesn't commit to a state root")
+	assert.Equal(t, uint64(0), bchain.GetHeight())
+
+	stateRoot, err := bchain.CalculateStateRoot(*validBlock)
+	assert.NoError(t, err)
+	validBlock.StateRoot = stateRoot
+	assert.NoError(t, validBlock.Sign(kp.PrivateKey))
+	assert.NoError(t, bchain.PerformStateUpdateFromBlock(*validBlock))
+	assert.Equal(t, uint64(1), bchain.GetHeight())
+}
+
+func TestIsStateRootKey(t *testing.T) {
+	cases := map[string]struct {
+		key      []byte
+		expected bool
+	}{
+		"address state": {
+			key:      []byte(addressPrefix + "0x01"),
+			expected: true,
+		},
+		"verifier set": {
+			key:      []byte(verifierSetPrefix + "1"),
+			expected: true,
+		},
+		"contract": {
+			key:      []byte(contractPrefix + "1"),
+			expected: true,
+		},
+		"contract store": {
+			key:      []byte(contractStoreKey),
+			expected: false,
+		},
+		"channels count": {
+			key:      []byte(channelsCountPrefix),
+			expected: false,
+		},
+		"evidence": {
+			key:      []byte(evidencePrefix + "0x01"),
+			expected: false,
+		},
+		"block": {
+			key:      []byte(blockPrefix + "1"),
+			expected: false,
+		},
+	}
+
+	for name, tt := range cases {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isStateRootKey(tt.key))
+		})
+	}
+}
@@ -2,7 +2,7 @@
 // versions:
 // 	protoc-gen-go v1.28.1
 // 	protoc        v3.17.3
-// source: internal/blockchain/types.proto
+// source: blockchain/types.proto
 
 package blockchain
 
@@ -66,11 +66,11 @@ func (x NodeItemType) String() string {
 }
 
 func (NodeItemType) Descriptor() protoreflect.EnumDescriptor {
-	return file_internal_blockchain_types_proto_enumTypes[0].Descriptor()
+	return file_blockchain_types_proto_enumTypes[0].Descriptor()
 }
 
 func (NodeItemType) Type() protoreflect.EnumType {
-	return &file_internal_blockchain_types_proto_enumTypes[0]
+	return &file_blockchain_types_proto_enumTypes[0]
 }
 
 func (x NodeItemType) Number() protoreflect.EnumNumber {
@@ -79,7 +79,7 @@ func (x NodeItemType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use NodeItemType.Descriptor instead.
 func (NodeItemType) EnumDescriptor() ([]byte, []int) {
-	return file_internal_blockchain_types_proto_rawDescGZIP(), []int{0}
+	return file_blockchain_types_proto_rawDescGZIP(), []int{0}
 }
 
 // AddressStateProto represents the state of an address in a proto message.
@@ -97,7 +97,7 @@ type AddressStateProto struct {
 func (x *AddressStateProto) Reset() {
 	*x = AddressStateProto{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_blockchain_types_proto_msgTypes[0]
+		mi := &file_blockchain_types_proto_msgTypes[0]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -110,7 +110,7 @@ func (x *AddressStateProto) String() string {
 func (*AddressStateProto) ProtoMessage() {}
 
 func (x *AddressStateProto) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_blockchain_types_proto_msgTypes[0]
+	mi := &file_blockchain_types_proto_msgTypes[0]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -123,7 +123,7 @@ func (x *AddressStateProto) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddressStateProto.ProtoReflect.Descriptor instead.
 func (*AddressStateProto) Descriptor() ([]byte, []int) {
-	return file_internal_blockchain_types_proto_rawDescGZIP(), []int{0}
+	return file_blockchain_types_proto_rawDescGZIP(), []int{0}
 }
 
 func (x *AddressStateProto) GetBalance() []byte {
@@ -166,7 +166,7 @@ type NodeItem struct {
 func (x *NodeItem) Reset() {
 	*x = NodeItem{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_blockchain_types_proto_msgTypes[1]
+		mi := &file_blockchain_types_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -179,7 +179,7 @@ func (x *NodeItem) String() string {
 func (*NodeItem) ProtoMessage() {}
 
 func (x *NodeItem) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_blockchain_types_proto_msgTypes[1]
+	mi := &file_blockchain_types_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -192,7 +192,7 @@ func (x *NodeItem) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NodeItem.ProtoReflect.Descriptor instead.
 func (*NodeItem) Descriptor() ([]byte, []int) {
-	return file_internal_blockchain_types_proto_rawDescGZIP(), []int{1}
+	return file_blockchain_types_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *NodeItem) GetName() string {
@@ -312,7 +312,7 @@ type NodeItems struct {
 func (x *NodeItems) Reset() {
 	*x = NodeItems{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_blockchain_types_proto_msgTypes[2]
+		mi := &file_blockchain_types_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -325,7 +325,7 @@ func (x *NodeItems) String() string {
 func (*NodeItems) ProtoMessage() {}
 
 func (x *NodeItems) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_blockchain_types_proto_msgTypes[2]
+	mi := &file_blockchain_types_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -338,7 +338,7 @@ func (x *NodeItems) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NodeItems.ProtoReflect.Descriptor instead.
 func (*NodeItems) Descriptor() ([]byte, []int) {
-	return file_internal_blockchain_types_proto_rawDescGZIP(), []int{2}
+	return file_blockchain_types_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *NodeItems) GetNodes() []*NodeItem {
@@ -348,106 +348,809 @@ func (x *NodeItems) GetNodes() []*NodeItem {
 	return nil
 }
 
-var File_internal_blockchain_types_proto protoreflect.FileDescriptor
-
-var file_internal_blockchain_types_proto_rawDesc = []byte{
-	0x0a, 0x1f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x62, 0x6c, 0x6f, 0x63, 0x6b,
-	0x63, 0x68, 0x61, 0x69, 0x6e, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x12, 0x0a, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x22, 0x45, 0x0a,
-	0x11, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x53, 0x74, 0x61, 0x74, 0x65, 0x50, 0x72, 0x6f,
-	0x74, 0x6f, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x07, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06,
-	0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x6e, 0x6f,
-	0x75, 0x6e, 0x63, 0x65, 0x22, 0xc0, 0x04, 0x0a, 0x08, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x74, 0x65,
-	0x6d, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x68, 0x61,
-	0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x6e, 0x6f, 0x64, 0x65, 0x48, 0x61,
-	0x73, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62,
-	0x6c, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c,
-	0x65, 0x64, 0x12, 0x35, 0x0a, 0x09, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x63, 0x68, 0x61,
-	0x69, 0x6e, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x74, 0x65, 0x6d, 0x54, 0x79, 0x70, 0x65, 0x52,
-	0x08, 0x6e, 0x6f, 0x64, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x74, 0x74,
-	0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0a, 0x61,
-	0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x64, 0x6d,
-	0x69, 0x6e, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x06, 0x61, 0x64, 0x6d, 0x69, 0x6e,
-	0x73, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x6f, 0x73, 0x74, 0x65, 0x72, 0x73, 0x18, 0x08, 0x20, 0x03,
-	0x28, 0x0c, 0x52, 0x07, 0x70, 0x6f, 0x73, 0x74, 0x65, 0x72, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x74,
-	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09,
-	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x25, 0x0a, 0x0b, 0x64, 0x65, 0x73,
-	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00,
-	0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x88, 0x01, 0x01,
-	0x12, 0x24, 0x0a, 0x0b, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x18,
-	0x0b, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x01, 0x52, 0x0a, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x52,
-	0x6f, 0x6f, 0x74, 0x88, 0x01, 0x01, 0x12, 0x20, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x68,
-	0x61, 0x73, 0x68, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x02, 0x52, 0x08, 0x66, 0x69, 0x6c,
-	0x65, 0x48, 0x61, 0x73, 0x68, 0x88, 0x01, 0x01, 0x12, 0x17, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65,
-	0x18, 0x0d, 0x20, 0x01, 0x28, 0x04, 0x48, 0x03, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x88, 0x01,
-	0x01, 0x12, 0x24, 0x0a, 0x0b, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68,
-	0x18, 0x0e, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x04, 0x52, 0x0a, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74,
-	0x48, 0x61, 0x73, 0x68, 0x88, 0x01, 0x01, 0x12, 0x26, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x65,
-	0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x48, 0x05, 0x52,
-	0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x88, 0x01, 0x01, 0x42,
-	0x0e, 0x0a, 0x0c, 0x5f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x42,
-	0x0e, 0x0a, 0x0c, 0x5f, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x42,
-	0x0c, 0x0a, 0x0a, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x42, 0x07, 0x0a,
-	0x05, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x70, 0x61, 0x72, 0x65, 0x6e,
-	0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x65,
-	0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x22, 0x37, 0x0a, 0x09, 0x4e, 0x6f, 0x64, 0x65, 0x49,
-	0x74, 0x65, 0x6d, 0x73, 0x12, 0x2a, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x63, 0x68, 0x61, 0x69, 0x6e,
-	0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73,
-	0x2a, 0x61, 0x0a, 0x0c, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x74, 0x65, 0x6d, 0x54, 0x79, 0x70, 0x65,
-	0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x08, 0x0a,
-	0x04, 0x46, 0x49, 0x4c, 0x45, 0x10, 0x01, 0x12, 0x07, 0x0a, 0x03, 0x44, 0x49, 0x52, 0x10, 0x02,
-	0x12, 0x0b, 0x0a, 0x07, 0x43, 0x48, 0x41, 0x4e, 0x4e, 0x45, 0x4c, 0x10, 0x03, 0x12, 0x0e, 0x0a,
-	0x0a, 0x53, 0x55, 0x42, 0x43, 0x48, 0x41, 0x4e, 0x4e, 0x45, 0x4c, 0x10, 0x04, 0x12, 0x09, 0x0a,
-	0x05, 0x45, 0x4e, 0x54, 0x52, 0x59, 0x10, 0x05, 0x12, 0x09, 0x0a, 0x05, 0x4f, 0x54, 0x48, 0x45,
-	0x52, 0x10, 0x06, 0x42, 0x36, 0x5a, 0x34, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
-	0x6d, 0x2f, 0x66, 0x69, 0x6c, 0x65, 0x66, 0x69, 0x6c, 0x65, 0x67, 0x6f, 0x2f, 0x66, 0x69, 0x6c,
-	0x65, 0x66, 0x69, 0x6c, 0x65, 0x67, 0x6f, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c,
-	0x2f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x33,
+// UndoEntryProto represents the value of a database key before a block was applied.
+type UndoEntryProto struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key   []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// existed is false if the key was created by the block.
+	Existed bool `protobuf:"varint,3,opt,name=existed,proto3" json:"existed,omitempty"`
+}
+
+func (x *UndoEntryProto) Reset() {
+	*x = UndoEntryProto{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_blockchain_types_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UndoEntryProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UndoEntryProto) ProtoMessage() {}
+
+func (x *UndoEntryProto) ProtoReflect() protoreflect.Message {
+	mi := &file_blockchain_types_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UndoEntryProto.ProtoReflect.Descriptor instead.
+func (*UndoEntryProto) Descriptor() ([]byte, []int) {
+	return file_blockchain_types_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UndoEntryProto) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *UndoEntryProto) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *UndoEntryProto) GetExisted() bool {
+	if x != nil {
+		return x.Existed
+	}
+	return false
+}
+
+// UndoLogProto contains the data required to revert the state changes of a block.
+type UndoLogProto struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Entries []*UndoEntryProto `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	// indexed_nodes contains the node hashes which were indexed in the search engine.
+	IndexedNodes [][]byte `protobuf:"bytes,2,rep,name=indexed_nodes,json=indexedNodes,proto3" json:"indexed_nodes,omitempty"`
+}
+
+func (x *UndoLogProto) Reset() {
+	*x = UndoLogProto{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_blockchain_types_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UndoLogProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UndoLogProto) ProtoMessage() {}
+
+func (x *UndoLogProto) ProtoReflect() protoreflect.Message {
+	mi := &file_blockchain_types_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UndoLogProto.ProtoReflect.Descriptor instead.
+func (*UndoLogProto) Descriptor() ([]byte, []int) {
+	return file_blockchain_types_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UndoLogProto) GetEntries() []*UndoEntryProto {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *UndoLogProto) GetIndexedNodes() [][]byte {
+	if x != nil {
+		return x.IndexedNodes
+	}
+	return nil
+}
+
+// VerifierProto represents a block verifier.
+type VerifierProto struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address      string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	PublicKey    string `protobuf:"bytes,2,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	DataVerifier bool   `protobuf:"varint,3,opt,name=data_verifier,json=dataVerifier,proto3" json:"data_verifier,omitempty"`
+}
+
+func (x *VerifierProto) Reset() {
+	*x = VerifierProto{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_blockchain_types_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifierProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifierProto) ProtoMessage() {}
+
+func (x *VerifierProto) ProtoReflect() protoreflect.Message {
+	mi := &file_blockchain_types_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifierProto.ProtoReflect.Descriptor instead.
+func (*VerifierProto) Descriptor() ([]byte, []int) {
+	return file_blockchain_types_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *VerifierProto) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *VerifierProto) GetPublicKey() string {
+	if x != nil {
+		return x.PublicKey
+	}
+	return ""
+}
+
+func (x *VerifierProto) GetDataVerifier() bool {
+	if x != nil {
+		return x.DataVerifier
+	}
+	return false
+}
+
+// VerifierSetProto is the set of verifiers which is active starting from a block height.
+type VerifierSetProto struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// sequence is incremented by each change of the verifier set.
+	Sequence  uint64           `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Verifiers []*VerifierProto `protobuf:"bytes,2,rep,name=verifiers,proto3" json:"verifiers,omitempty"`
+}
+
+func (x *VerifierSetProto) Reset() {
+	*x = VerifierSetProto{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_blockchain_types_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifierSetProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifierSetProto) ProtoMessage() {}
+
+func (x *VerifierSetProto) ProtoReflect() protoreflect.Message {
+	mi := &file_blockchain_types_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifierSetProto.ProtoReflect.Descriptor instead.
+func (*VerifierSetProto) Descriptor() ([]byte, []int) {
+	return file_blockchain_types_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *VerifierSetProto) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *VerifierSetProto) GetVerifiers() []*VerifierProto {
+	if x != nil {
+		return x.Verifiers
+	}
+	return nil
+}
+
+// VerifierSetUpdateProto represents a change of the verifier set.
+type VerifierSetUpdateProto struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// sequence must be the next sequence of the verifier set, so an update can't be replayed.
+	Sequence uint64 `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// verifiers are added or updated if a verifier with the same address exists.
+	Verifiers        []*VerifierProto `protobuf:"bytes,2,rep,name=verifiers,proto3" json:"verifiers,omitempty"`
+	RemovedAddresses []string         `protobuf:"bytes,3,rep,name=removed_addresses,json=removedAddresses,proto3" json:"removed_addresses,omitempty"`
+}
+
+func (x *VerifierSetUpdateProto) Reset() {
+	*x = VerifierSetUpdateProto{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_blockchain_types_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifierSetUpdateProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifierSetUpdateProto) ProtoMessage() {}
+
+func (x *VerifierSetUpdateProto) ProtoReflect() protoreflect.Message {
+	mi := &file_blockchain_types_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifierSetUpdateProto.ProtoReflect.Descriptor instead.
+func (*VerifierSetUpdateProto) Descriptor() ([]byte, []int) {
+	return file_blockchain_types_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *VerifierSetUpdateProto) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *VerifierSetUpdateProto) GetVerifiers() []*VerifierProto {
+	if x != nil {
+		return x.Verifiers
+	}
+	return nil
+}
+
+func (x *VerifierSetUpdateProto) GetRemovedAddresses() []string {
+	if x != nil {
+		return x.RemovedAddresses
+	}
+	return nil
+}
+
+// SettingsSignatureProto is the signature of a verifier over blockchain settings.
+type SettingsSignatureProto struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PublicKey []byte `protobuf:"bytes,1,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	Signature []byte `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (x *SettingsSignatureProto) Reset() {
+	*x = SettingsSignatureProto{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_blockchain_types_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SettingsSignatureProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SettingsSignatureProto) ProtoMessage() {}
+
+func (x *SettingsSignatureProto) ProtoReflect() protoreflect.Message {
+	mi := &file_blockchain_types_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SettingsSignatureProto.ProtoReflect.Descriptor instead.
+func (*SettingsSignatureProto) Descriptor() ([]byte, []int) {
+	return file_blockchain_types_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SettingsSignatureProto) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+func (x *SettingsSignatureProto) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+// BlockchainSettingsProto is the payload of an UPDATE_BLOCKCHAIN_SETTINGS transaction.
+type BlockchainSettingsProto struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// verifier_set_update is a serialized VerifierSetUpdateProto.
+	VerifierSetUpdate []byte `protobuf:"bytes,1,opt,name=verifier_set_update,json=verifierSetUpdate,proto3" json:"verifier_set_update,omitempty"`
+	// signatures contains the signatures of the verifiers over the sha256 hash of verifier_set_update.
+	Signatures []*SettingsSignatureProto `protobuf:"bytes,2,rep,name=signatures,proto3" json:"signatures,omitempty"`
+}
+
+func (x *BlockchainSettingsProto) Reset() {
+	*x = BlockchainSettingsProto{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_blockchain_types_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlockchainSettingsProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockchainSettingsProto) ProtoMessage() {}
+
+func (x *BlockchainSettingsProto) ProtoReflect() protoreflect.Message {
+	mi := &file_blockchain_types_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockchainSettingsProto.ProtoReflect.Descriptor instead.
+func (*BlockchainSettingsProto) Descriptor() ([]byte, []int) {
+	return file_blockchain_types_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *BlockchainSettingsProto) GetVerifierSetUpdate() []byte {
+	if x != nil {
+		return x.VerifierSetUpdate
+	}
+	return nil
+}
+
+func (x *BlockchainSettingsProto) GetSignatures() []*SettingsSignatureProto {
+	if x != nil {
+		return x.Signatures
+	}
+	return nil
+}
+
+// DoubleSignEvidenceProto proves that a verifier signed two different blocks with the same number.
+type DoubleSignEvidenceProto struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Verifier    string `protobuf:"bytes,1,opt,name=verifier,proto3" json:"verifier,omitempty"`
+	BlockNumber uint64 `protobuf:"varint,2,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	// first_block and second_block are serialized blocks ordered by their hash.
+	FirstBlock  []byte `protobuf:"bytes,3,opt,name=first_block,json=firstBlock,proto3" json:"first_block,omitempty"`
+	SecondBlock []byte `protobuf:"bytes,4,opt,name=second_block,json=secondBlock,proto3" json:"second_block,omitempty"`
+	// committed_in_block is the block which included the evidence in a transaction, zero if it was detected locally.
+	CommittedInBlock uint64 `protobuf:"varint,5,opt,name=committed_in_block,json=committedInBlock,proto3" json:"committed_in_block,omitempty"`
+}
+
+func (x *DoubleSignEvidenceProto) Reset() {
+	*x = DoubleSignEvidenceProto{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_blockchain_types_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DoubleSignEvidenceProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DoubleSignEvidenceProto) ProtoMessage() {}
+
+func (x *DoubleSignEvidenceProto) ProtoReflect() protoreflect.Message {
+	mi := &file_blockchain_types_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DoubleSignEvidenceProto.ProtoReflect.Descriptor instead.
+func (*DoubleSignEvidenceProto) Descriptor() ([]byte, []int) {
+	return file_blockchain_types_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DoubleSignEvidenceProto) GetVerifier() string {
+	if x != nil {
+		return x.Verifier
+	}
+	return ""
+}
+
+func (x *DoubleSignEvidenceProto) GetBlockNumber() uint64 {
+	if x != nil {
+		return x.BlockNumber
+	}
+	return 0
+}
+
+func (x *DoubleSignEvidenceProto) GetFirstBlock() []byte {
+	if x != nil {
+		return x.FirstBlock
+	}
+	return nil
+}
+
+func (x *DoubleSignEvidenceProto) GetSecondBlock() []byte {
+	if x != nil {
+		return x.SecondBlock
+	}
+	return nil
+}
+
+func (x *DoubleSignEvidenceProto) GetCommittedInBlock() uint64 {
+	if x != nil {
+		return x.CommittedInBlock
+	}
+	return 0
+}
+
+// ContractEscrowProto holds the fees of a download contract until they are released to the file hoster or refunded.
+type ContractEscrowProto struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ContractHash []byte `protobuf:"bytes,1,opt,name=contract_hash,json=contractHash,proto3" json:"contract_hash,omitempty"`
+	// requester paid the fees and gets them back if they are not released before the deadline.
+	Requester  []byte `protobuf:"bytes,2,opt,name=requester,proto3" json:"requester,omitempty"`
+	Verifier   []byte `protobuf:"bytes,3,opt,name=verifier,proto3" json:"verifier,omitempty"`
+	FileHoster []byte `protobuf:"bytes,4,opt,name=file_hoster,json=fileHoster,proto3" json:"file_hoster,omitempty"`
+	// value is the locked amount, verifier_fees of it are paid to the verifier and the rest to the file hoster.
+	Value        string `protobuf:"bytes,5,opt,name=value,proto3" json:"value,omitempty"`
+	VerifierFees string `protobuf:"bytes,6,opt,name=verifier_fees,json=verifierFees,proto3" json:"verifier_fees,omitempty"`
+	// deadline is the last block in which the fees can be released.
+	Deadline uint64 `protobuf:"varint,7,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	TxHash   []byte `protobuf:"bytes,8,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`
+}
+
+func (x *ContractEscrowProto) Reset() {
+	*x = ContractEscrowProto{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_blockchain_types_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ContractEscrowProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContractEscrowProto) ProtoMessage() {}
+
+func (x *ContractEscrowProto) ProtoReflect() protoreflect.Message {
+	mi := &file_blockchain_types_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContractEscrowProto.ProtoReflect.Descriptor instead.
+func (*ContractEscrowProto) Descriptor() ([]byte, []int) {
+	return file_blockchain_types_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ContractEscrowProto) GetContractHash() []byte {
+	if x != nil {
+		return x.ContractHash
+	}
+	return nil
+}
+
+func (x *ContractEscrowProto) GetRequester() []byte {
+	if x != nil {
+		return x.Requester
+	}
+	return nil
+}
+
+func (x *ContractEscrowProto) GetVerifier() []byte {
+	if x != nil {
+		return x.Verifier
+	}
+	return nil
+}
+
+func (x *ContractEscrowProto) GetFileHoster() []byte {
+	if x != nil {
+		return x.FileHoster
+	}
+	return nil
+}
+
+func (x *ContractEscrowProto) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *ContractEscrowProto) GetVerifierFees() string {
+	if x != nil {
+		return x.VerifierFees
+	}
+	return ""
+}
+
+func (x *ContractEscrowProto) GetDeadline() uint64 {
+	if x != nil {
+		return x.Deadline
+	}
+	return 0
+}
+
+func (x *ContractEscrowProto) GetTxHash() []byte {
+	if x != nil {
+		return x.TxHash
+	}
+	return nil
+}
+
+var File_blockchain_types_proto protoreflect.FileDescriptor
+
+var file_blockchain_types_proto_rawDesc = []byte{
+	0x0a, 0x16, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x2f, 0x74, 0x79, 0x70,
+	0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x63,
+	0x68, 0x61, 0x69, 0x6e, 0x22, 0x45, 0x0a, 0x11, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x61, 0x6c,
+	0x61, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x62, 0x61, 0x6c, 0x61,
+	0x6e, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x06, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x22, 0xc0, 0x04, 0x0a, 0x08,
+	0x4e, 0x6f, 0x64, 0x65, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09,
+	0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x08, 0x6e, 0x6f, 0x64, 0x65, 0x48, 0x61, 0x73, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e,
+	0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x12,
+	0x18, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x12, 0x35, 0x0a, 0x09, 0x6e, 0x6f, 0x64,
+	0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x62,
+	0x6c, 0x6f, 0x63, 0x6b, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x74,
+	0x65, 0x6d, 0x54, 0x79, 0x70, 0x65, 0x52, 0x08, 0x6e, 0x6f, 0x64, 0x65, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x18, 0x06,
+	0x20, 0x03, 0x28, 0x0c, 0x52, 0x0a, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73,
+	0x12, 0x16, 0x0a, 0x06, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0c,
+	0x52, 0x06, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x6f, 0x73, 0x74,
+	0x65, 0x72, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x07, 0x70, 0x6f, 0x73, 0x74, 0x65,
+	0x72, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x12, 0x25, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x24, 0x0a, 0x0b, 0x6d, 0x65, 0x72, 0x6b, 0x6c,
+	0x65, 0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x01, 0x52, 0x0a,
+	0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x88, 0x01, 0x01, 0x12, 0x20, 0x0a,
+	0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0c,
+	0x48, 0x02, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x48, 0x61, 0x73, 0x68, 0x88, 0x01, 0x01, 0x12,
+	0x17, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x04, 0x48, 0x03, 0x52,
+	0x04, 0x73, 0x69, 0x7a, 0x65, 0x88, 0x01, 0x01, 0x12, 0x24, 0x0a, 0x0b, 0x70, 0x61, 0x72, 0x65,
+	0x6e, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x04, 0x52,
+	0x0a, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x48, 0x61, 0x73, 0x68, 0x88, 0x01, 0x01, 0x12, 0x26,
+	0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x0f,
+	0x20, 0x01, 0x28, 0x09, 0x48, 0x05, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54,
+	0x79, 0x70, 0x65, 0x88, 0x01, 0x01, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x64, 0x65, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x6d, 0x65, 0x72, 0x6b, 0x6c,
+	0x65, 0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x5f,
+	0x68, 0x61, 0x73, 0x68, 0x42, 0x07, 0x0a, 0x05, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x42, 0x0e, 0x0a,
+	0x0c, 0x5f, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x42, 0x0f, 0x0a,
+	0x0d, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x22, 0x37,
+	0x0a, 0x09, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x2a, 0x0a, 0x05, 0x6e,
+	0x6f, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x62, 0x6c, 0x6f,
+	0x63, 0x6b, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x74, 0x65, 0x6d,
+	0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x22, 0x52, 0x0a, 0x0e, 0x55, 0x6e, 0x64, 0x6f, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x78, 0x69, 0x73, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x07, 0x65, 0x78, 0x69, 0x73, 0x74, 0x65, 0x64, 0x22, 0x69, 0x0a, 0x0c, 0x55,
+	0x6e, 0x64, 0x6f, 0x4c, 0x6f, 0x67, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x34, 0x0a, 0x07, 0x65,
+	0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x62,
+	0x6c, 0x6f, 0x63, 0x6b, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x2e, 0x55, 0x6e, 0x64, 0x6f, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65,
+	0x73, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x64, 0x5f, 0x6e, 0x6f, 0x64,
+	0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0c, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x65,
+	0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x22, 0x6d, 0x0a, 0x0d, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69,
+	0x65, 0x72, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79,
+	0x12, 0x23, 0x0a, 0x0d, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65,
+	0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x64, 0x61, 0x74, 0x61, 0x56, 0x65, 0x72,
+	0x69, 0x66, 0x69, 0x65, 0x72, 0x22, 0x67, 0x0a, 0x10, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65,
+	0x72, 0x53, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71,
+	0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x73, 0x65, 0x71,
+	0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x37, 0x0a, 0x09, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65,
+	0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x62, 0x6c, 0x6f, 0x63, 0x6b,
+	0x63, 0x68, 0x61, 0x69, 0x6e, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x72, 0x50, 0x72,
+	0x6f, 0x74, 0x6f, 0x52, 0x09, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x72, 0x73, 0x22, 0x9a,
+	0x01, 0x0a, 0x16, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x72, 0x53, 0x65, 0x74, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71,
+	0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x73, 0x65, 0x71,
+	0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x37, 0x0a, 0x09, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65,
+	0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x62, 0x6c, 0x6f, 0x63, 0x6b,
+	0x63, 0x68, 0x61, 0x69, 0x6e, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x72, 0x50, 0x72,
+	0x6f, 0x74, 0x6f, 0x52, 0x09, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x72, 0x73, 0x12, 0x2b,
+	0x0a, 0x11, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x10, 0x72, 0x65, 0x6d, 0x6f, 0x76,
+	0x65, 0x64, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x22, 0x55, 0x0a, 0x16, 0x53,
+	0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69,
+	0x63, 0x4b, 0x65, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x22, 0x8d, 0x01, 0x0a, 0x17, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x63, 0x68, 0x61, 0x69,
+	0x6e, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x2e,
+	0x0a, 0x13, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x72, 0x5f, 0x73, 0x65, 0x74, 0x5f, 0x75,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x11, 0x76, 0x65, 0x72,
+	0x69, 0x66, 0x69, 0x65, 0x72, 0x53, 0x65, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x42,
+	0x0a, 0x0a, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x22, 0x2e, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x2e,
+	0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x52, 0x0a, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x73, 0x22, 0xca, 0x01, 0x0a, 0x17, 0x44, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x53, 0x69, 0x67,
+	0x6e, 0x45, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x1a,
+	0x0a, 0x08, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x6c,
+	0x6f, 0x63, 0x6b, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0b, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1f, 0x0a,
+	0x0b, 0x66, 0x69, 0x72, 0x73, 0x74, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x0a, 0x66, 0x69, 0x72, 0x73, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x21,
+	0x0a, 0x0c, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x12, 0x2c, 0x0a, 0x12, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x5f, 0x69,
+	0x6e, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x63,
+	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x49, 0x6e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x22,
+	0x85, 0x02, 0x0a, 0x13, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x45, 0x73, 0x63, 0x72,
+	0x6f, 0x77, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x72,
+	0x61, 0x63, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c,
+	0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x48, 0x61, 0x73, 0x68, 0x12, 0x1c, 0x0a, 0x09,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x76, 0x65,
+	0x72, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x76, 0x65,
+	0x72, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x68,
+	0x6f, 0x73, 0x74, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x66, 0x69, 0x6c,
+	0x65, 0x48, 0x6f, 0x73, 0x74, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x23, 0x0a,
+	0x0d, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x72, 0x5f, 0x66, 0x65, 0x65, 0x73, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x72, 0x46, 0x65,
+	0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x17,
+	0x0a, 0x07, 0x74, 0x78, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x06, 0x74, 0x78, 0x48, 0x61, 0x73, 0x68, 0x2a, 0x61, 0x0a, 0x0c, 0x4e, 0x6f, 0x64, 0x65, 0x49,
+	0x74, 0x65, 0x6d, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f,
+	0x57, 0x4e, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x46, 0x49, 0x4c, 0x45, 0x10, 0x01, 0x12, 0x07,
+	0x0a, 0x03, 0x44, 0x49, 0x52, 0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07, 0x43, 0x48, 0x41, 0x4e, 0x4e,
+	0x45, 0x4c, 0x10, 0x03, 0x12, 0x0e, 0x0a, 0x0a, 0x53, 0x55, 0x42, 0x43, 0x48, 0x41, 0x4e, 0x4e,
+	0x45, 0x4c, 0x10, 0x04, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x4e, 0x54, 0x52, 0x59, 0x10, 0x05, 0x12,
+	0x09, 0x0a, 0x05, 0x4f, 0x54, 0x48, 0x45, 0x52, 0x10, 0x06, 0x42, 0x2d, 0x5a, 0x2b, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x66, 0x69, 0x6c, 0x65, 0x66, 0x69, 0x6c,
+	0x65, 0x67, 0x6f, 0x2f, 0x66, 0x69, 0x6c, 0x65, 0x66, 0x69, 0x6c, 0x65, 0x67, 0x6f, 0x2f, 0x62,
+	0x6c, 0x6f, 0x63, 0x6b, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
 }
 
 var (
-	file_internal_blockchain_types_proto_rawDescOnce sync.Once
-	file_internal_blockchain_types_proto_rawDescData = file_internal_blockchain_types_proto_rawDesc
+	file_blockchain_types_proto_rawDescOnce sync.Once
+	file_blockchain_types_proto_rawDescData = file_blockchain_types_proto_rawDesc
 )
 
-func file_internal_blockchain_types_proto_rawDescGZIP() []byte {
-	file_internal_blockchain_types_proto_rawDescOnce.Do(func() {
-		file_internal_blockchain_types_proto_rawDescData = protoimpl.X.CompressGZIP(file_internal_blockchain_types_proto_rawDescData)
+func file_blockchain_types_proto_rawDescGZIP() []byte {
+	file_blockchain_types_proto_rawDescOnce.Do(func() {
+		file_blockchain_types_proto_rawDescData = protoimpl.X.CompressGZIP(file_blockchain_types_proto_rawDescData)
 	})
-	return file_internal_blockchain_types_proto_rawDescData
+	return file_blockchain_types_proto_rawDescData
 }
 
-var file_internal_blockchain_types_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_internal_blockchain_types_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
-var file_internal_blockchain_types_proto_goTypes = []interface{}{
-	(NodeItemType)(0),         // 0: blockchain.NodeItemType
-	(*AddressStateProto)(nil), // 1: blockchain.AddressStateProto
-	(*NodeItem)(nil),          // 2: blockchain.NodeItem
-	(*NodeItems)(nil),         // 3: blockchain.NodeItems
+var file_blockchain_types_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_blockchain_types_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_blockchain_types_proto_goTypes = []interface{}{
+	(NodeItemType)(0),               // 0: blockchain.NodeItemType
+	(*AddressStateProto)(nil),       // 1: blockchain.AddressStateProto
+	(*NodeItem)(nil),                // 2: blockchain.NodeItem
+	(*NodeItems)(nil),               // 3: blockchain.NodeItems
+	(*UndoEntryProto)(nil),          // 4: blockchain.UndoEntryProto
+	(*UndoLogProto)(nil),            // 5: blockchain.UndoLogProto
+	(*VerifierProto)(nil),           // 6: blockchain.VerifierProto
+	(*VerifierSetProto)(nil),        // 7: blockchain.VerifierSetProto
+	(*VerifierSetUpdateProto)(nil),  // 8: blockchain.VerifierSetUpdateProto
+	(*SettingsSignatureProto)(nil),  // 9: blockchain.SettingsSignatureProto
+	(*BlockchainSettingsProto)(nil), // 10: blockchain.BlockchainSettingsProto
+	(*DoubleSignEvidenceProto)(nil), // 11: blockchain.DoubleSignEvidenceProto
+	(*ContractEscrowProto)(nil),     // 12: blockchain.ContractEscrowProto
 }
-var file_internal_blockchain_types_proto_depIdxs = []int32{
+var file_blockchain_types_proto_depIdxs = []int32{
 	0, // 0: blockchain.NodeItem.node_type:type_name -> blockchain.NodeItemType
 	2, // 1: blockchain.NodeItems.nodes:type_name -> blockchain.NodeItem
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	4, // 2: blockchain.UndoLogProto.entries:type_name -> blockchain.UndoEntryProto
+	6, // 3: blockchain.VerifierSetProto.verifiers:type_name -> blockchain.VerifierProto
+	6, // 4: blockchain.VerifierSetUpdateProto.verifiers:type_name -> blockchain.VerifierProto
+	9, // 5: blockchain.BlockchainSettingsProto.signatures:type_name -> blockchain.SettingsSignatureProto
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
 }
 
-func init() { file_internal_blockchain_types_proto_init() }
-func file_internal_blockchain_types_proto_init() {
-	if File_internal_blockchain_types_proto != nil {
+func init() { file_blockchain_types_proto_init() }
+func file_blockchain_types_proto_init() {
+	if File_blockchain_types_proto != nil {
 		return
 	}
 	if !protoimpl.UnsafeEnabled {
-		file_internal_blockchain_types_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+		file_blockchain_types_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*AddressStateProto); i {
 			case 0:
 				return &v.state
@@ -459,7 +1162,7 @@ func file_internal_blockchain_types_proto_init() {
 				return nil
 			}
 		}
-		file_internal_blockchain_types_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+		file_blockchain_types_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*NodeItem); i {
 			case 0:
 				return &v.state
@@ -471,7 +1174,7 @@ func file_internal_blockchain_types_proto_init() {
 				return nil
 			}
 		}
-		file_internal_blockchain_types_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+		file_blockchain_types_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*NodeItems); i {
 			case 0:
 				return &v.state
@@ -483,25 +1186,133 @@ func file_internal_blockchain_types_proto_init() {
 				return nil
 			}
 		}
+		file_blockchain_types_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UndoEntryProto); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_blockchain_types_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UndoLogProto); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_blockchain_types_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifierProto); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_blockchain_types_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifierSetProto); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_blockchain_types_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifierSetUpdateProto); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_blockchain_types_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SettingsSignatureProto); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_blockchain_types_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlockchainSettingsProto); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_blockchain_types_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DoubleSignEvidenceProto); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_blockchain_types_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ContractEscrowProto); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
-	file_internal_blockchain_types_proto_msgTypes[1].OneofWrappers = []interface{}{}
+	file_blockchain_types_proto_msgTypes[1].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: file_internal_blockchain_types_proto_rawDesc,
+			RawDescriptor: file_blockchain_types_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   3,
+			NumMessages:   12,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
-		GoTypes:           file_internal_blockchain_types_proto_goTypes,
-		DependencyIndexes: file_internal_blockchain_types_proto_depIdxs,
-		EnumInfos:         file_internal_blockchain_types_proto_enumTypes,
-		MessageInfos:      file_internal_blockchain_types_proto_msgTypes,
+		GoTypes:           file_blockchain_types_proto_goTypes,
+		DependencyIndexes: file_blockchain_types_proto_depIdxs,
+		EnumInfos:         file_blockchain_types_proto_enumTypes,
+		MessageInfos:      file_blockchain_types_proto_msgTypes,
 	}.Build()
-	File_internal_blockchain_types_proto = out.File
-	file_internal_blockchain_types_proto_rawDesc = nil
-	file_internal_blockchain_types_proto_goTypes = nil
-	file_internal_blockchain_types_proto_depIdxs = nil
+	File_blockchain_types_proto = out.File
+	file_blockchain_types_proto_rawDesc = nil
+	file_blockchain_types_proto_goTypes = nil
+	file_blockchain_types_proto_depIdxs = nil
 }
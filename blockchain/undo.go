@@ -0,0 +1,441 @@
+package blockchain
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/filefilego/filefilego/block"
+	"github.com/filefilego/filefilego/common/hexutil"
+	"github.com/filefilego/filefilego/crypto"
+	"github.com/filefilego/filefilego/database"
+	"github.com/filefilego/filefilego/search"
+	log "github.com/sirupsen/logrus"
+	"github.com/syndtr/goleveldb/leveldb"
+	"google.golang.org/protobuf/proto"
+)
+
+// maxReorgDepth is the maximum number of blocks which can be reverted during a chain reorganization.
+const maxReorgDepth = 100
+
+// undoJournal records the search index updates made while applying a block.
+type undoJournal struct {
+	blockNumber  uint64
+	indexedNodes []*NodeItem
+}
+
+// startJournal starts recording the search index updates of the block being applied.
+func (b *Blockchain) startJournal(blockNumber uint64) {
+	b.journalMu.Lock()
+	defer b.journalMu.Unlock()
+
+	b.journal = &undoJournal{
+		blockNumber:  blockNumber,
+		indexedNodes: make([]*NodeItem, 0),
+	}
+}
+
+// applyingBlockNumber returns the number of the block being applied.
+func (b *Blockchain) applyingBlockNumber() (uint64, bool) {
+	b.journalMu.Lock()
+	defer b.journalMu.Unlock()
+
+	if b.journal == nil {
+		return 0, false
+	}
+	return b.journal.blockNumber, true
+}
+
+// stopJournal stops recording and returns the recorded journal.
+func (b *Blockchain) stopJournal() *undoJournal {
+	b.journalMu.Lock()
+	defer b.journalMu.Unlock()
+
+	j := b.journal
+	b.journal = nil
+	return j
+}
+
+// journalCheckpoint returns the number of the node updates recorded so far.
+func (b *Blockchain) journalCheckpoint() int {
+	b.journalMu.Lock()
+	defer b.journalMu.Unlock()
+
+	if b.journal == nil {
+		return 0
+	}
+	return len(b.journal.indexedNodes)
+}
+
+// revertJournal drops the node updates which were recorded after the checkpoint.
+func (b *Blockchain) revertJournal(checkpoint int) {
+	b.journalMu.Lock()
+	defer b.journalMu.Unlock()
+
+	if b.journal != nil && checkpoint <= len(b.journal.indexedNodes) {
+		b.journal.indexedNodes = b.journal.indexedNodes[:checkpoint]
+	}
+}
+
+// indexNodeItem indexes a node item in the search engine.
+// while a block is being applied, the node is indexed once the block is committed.
+func (b *Blockchain) indexNodeItem(node *NodeItem) error {
+	b.journalMu.Lock()
+	if b.journal != nil {
+		b.journal.indexedNodes = append(b.journal.indexedNodes, node)
+		b.journalMu.Unlock()
+		return nil
+	}
+	b.journalMu.Unlock()
+
+	return b.updateSearchIndex(node)
+}
+
+// indexNodeItems indexes the nodes in the search engine.
+// indexing a node more than once overwrites the previous entry, so it's safe to repeat it.
+func (b *Blockchain) indexNodeItems(nodes []*NodeItem) {
+	for _, node := range nodes {
+		if err := b.updateSearchIndex(node); err != nil {
+			log.Warnf("failed to index node %s: %v", hexutil.Encode(node.NodeHash), err)
+		}
+	}
+}
+
+// updateSearchIndex indexes an enabled node and removes a disabled node from the search engine.
+func (b *Blockchain) updateSearchIndex(node *NodeItem) error {
+	if !node.Enabled {
+		return b.search.Delete(hexutil.Encode(node.NodeHash))
+	}
+	return b.search.Index(toSearchIndexItem(node))
+}
+
+func toSearchIndexItem(node *NodeItem) search.IndexItem {
+	nodeDescription := ""
+	if node.Description != nil {
+		nodeDescription = *node.Description
+	}
+
+	return search.IndexItem{
+		Hash:        hexutil.Encode(node.NodeHash),
+		Type:        int32(node.NodeType),
+		Name:        node.Name,
+		Description: nodeDescription,
+	}
+}
+
+// undoLogBatch creates a batch containing the undo log of the staged block changes.
+func (b *Blockchain) undoLogBatch(overlay *database.Overlay, blockHash []byte, j *undoJournal) (*leveldb.Batch, error) {
+	undoLog := UndoLogProto{
+		Entries:      make([]*UndoEntryProto, 0),
+		IndexedNodes: make([][]byte, 0, len(j.indexedNodes)),
+	}
+
+	for _, key := range overlay.StagedKeys() {
+		// the state tree is updated again from the reverted records
+		if bytes.HasPrefix(key, []byte(stateTreePrefix)) {
+			continue
+		}
+
+		entry := &UndoEntryProto{Key: key}
+		value, err := overlay.GetCommitted(key)
+		if err == nil {
+			entry.Existed = true
+			entry.Value = value
+		} else if !errors.Is(err, leveldb.ErrNotFound) {
+			return nil, fmt.Errorf("failed to get committed value: %w", err)
+		}
+		undoLog.Entries = append(undoLog.Entries, entry)
+	}
+
+	for _, node := range j.indexedNodes {
+		undoLog.IndexedNodes = append(undoLog.IndexedNodes, node.NodeHash)
+	}
+
+	data, err := proto.Marshal(&undoLog)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal undo log: %w", err)
+	}
+
+	batch := new(leveldb.Batch)
+	batch.Put(append([]byte(undoPrefix), blockHash...), data)
+	return batch, nil
+}
+
+// getUndoLog gets the undo log of a block.
+func (b *Blockchain) getUndoLog(blockHash []byte) (*UndoLogProto, error) {
+	data, err := b.db.Get(append([]byte(undoPrefix), blockHash...))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get undo log of block %s: %w", hexutil.Encode(blockHash), err)
+	}
+
+	undoLog := UndoLogProto{}
+	if err := proto.Unmarshal(data, &undoLog); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal undo log: %w", err)
+	}
+	return &undoLog, nil
+}
+
+// reindexBlockNodes indexes again the nodes which were created or updated by a block.
+// it recovers the search index if the node stopped before indexing the nodes of the last block.
+func (b *Blockchain) reindexBlockNodes(blockHash []byte) error {
+	undoLog, err := b.getUndoLog(blockHash)
+	if errors.Is(err, leveldb.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	nodes := make([]*NodeItem, 0, len(undoLog.IndexedNodes))
+	for _, nodeHash := range undoLog.IndexedNodes {
+		item, err := b.GetNodeItem(nodeHash)
+		if err != nil {
+			return fmt.Errorf("failed to get node %s: %w", hexutil.Encode(nodeHash), err)
+		}
+		nodes = append(nodes, item)
+	}
+	b.indexNodeItems(nodes)
+	return nil
+}
+
+// revertBlock reverts the state changes of the last block of the chain using its undo log.
+// The non-coinbase transactions of the block are put back into the mempool.
+func (b *Blockchain) revertBlock(blck block.Block) error {
+	if blck.Number == 0 {
+		return errors.New("genesis block can't be reverted")
+	}
+
+	if !bytes.Equal(b.GetLastBlockHash(), blck.Hash) {
+		return errors.New("only the last block of the chain can be reverted")
+	}
+
+	undoLog, err := b.getUndoLog(blck.Hash)
+	if err != nil {
+		return err
+	}
+
+	batch := new(leveldb.Batch)
+	for _, entry := range undoLog.Entries {
+		if entry.Existed {
+			batch.Put(entry.Key, entry.Value)
+		} else {
+			batch.Delete(entry.Key)
+		}
+	}
+	batch.Delete(append([]byte(undoPrefix), blck.Hash...))
+
+	err = b.updateCommittedState(func(db database.Database) error {
+		if err := db.Write(batch, nil); err != nil {
+			return fmt.Errorf("failed to write undo batch: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// nodes which still exist are indexed again with their previous values, the rest are removed.
+	for _, nodeHash := range undoLog.IndexedNodes {
+		item, err := b.GetNodeItem(nodeHash)
+		if err != nil {
+			if err := b.search.Delete(hexutil.Encode(nodeHash)); err != nil {
+				log.Warnf("failed to remove node %s from search index: %v", hexutil.Encode(nodeHash), err)
+			}
+			continue
+		}
+
+		if err := b.updateSearchIndex(item); err != nil {
+			log.Warnf("failed to index node %s: %v", hexutil.Encode(nodeHash), err)
+		}
+	}
+
+	b.SetHeight(blck.Number - 1)
+
+	for _, tx := range blck.Transactions[1:] {
+		if err := b.PutMemPool(tx); err != nil {
+			log.Warnf("failed to put transaction of reverted block back to mempool: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// revertToBlock reverts the chain until the given block is the last block.
+// it returns the reverted blocks starting from the last block.
+func (b *Blockchain) revertToBlock(ancestor block.Block) ([]block.Block, error) {
+	reverted := make([]block.Block, 0)
+	for {
+		lastBlockHash := b.GetLastBlockHash()
+		if bytes.Equal(lastBlockHash, ancestor.Hash) {
+			return reverted, nil
+		}
+
+		lastBlock, err := b.GetBlockByHash(lastBlockHash)
+		if err != nil {
+			return reverted, fmt.Errorf("failed to get last block: %w", err)
+		}
+
+		if lastBlock.Number <= ancestor.Number {
+			return reverted, fmt.Errorf("block %s is not an ancestor of the chain", hexutil.Encode(ancestor.Hash))
+		}
+
+		if b.IsFinalized(lastBlock.Number) {
+			return reverted, fmt.Errorf("block %d is finalized and can't be reverted", lastBlock.Number)
+		}
+
+		if err := b.revertBlock(lastBlock); err != nil {
+			return reverted, fmt.Errorf("failed to revert block %d: %w", lastBlock.Number, err)
+		}
+		reverted = append(reverted, lastBlock)
+	}
+}
+
+// reorganize switches the chain to the given branch which starts after ancestor.
+// if a block of the branch fails to be applied, the previous chain is restored.
+func (b *Blockchain) reorganize(ancestor block.Block, branch []block.Block) error {
+	b.stateMu.Lock()
+	defer b.stateMu.Unlock()
+
+	reverted, err := b.revertToBlock(ancestor)
+	if err != nil {
+		b.reapplyBlocks(reverted)
+		return fmt.Errorf("failed to revert chain: %w", err)
+	}
+
+	for i, blck := range branch {
+		err := b.performStateUpdateFromBlock(blck)
+		if err == nil {
+			continue
+		}
+
+		// the block and its descendants can't be part of the chain.
+		for _, invalid := range branch[i:] {
+			_ = b.DeleteFromBlockPool(invalid)
+		}
+
+		restored, rerr := b.revertToBlock(ancestor)
+		if rerr != nil {
+			b.reapplyBlocks(restored)
+			return fmt.Errorf("failed to apply block %d: %v and failed to revert the branch: %w", blck.Number, err, rerr)
+		}
+		b.reapplyBlocks(reverted)
+		return fmt.Errorf("failed to apply block %d of branch: %w", blck.Number, err)
+	}
+
+	for _, blck := range branch {
+		_ = b.DeleteFromBlockPool(blck)
+	}
+
+	// keep the reverted blocks in the pool, in case their branch becomes the best chain again.
+	b.bmu.Lock()
+	for _, blck := range reverted {
+		b.blockPool[hexutil.Encode(blck.Hash)] = blck
+	}
+	b.bmu.Unlock()
+
+	log.Infof("chain reorganized at block %d: reverted %d blocks and applied %d blocks", ancestor.Number, len(reverted), len(branch))
+	return nil
+}
+
+// reapplyBlocks applies blocks which were returned by revertToBlock.
+func (b *Blockchain) reapplyBlocks(reverted []block.Block) {
+	for i := len(reverted) - 1; i >= 0; i-- {
+		if err := b.performStateUpdateFromBlock(reverted[i]); err != nil {
+			log.Errorf("failed to reapply block %d: %v", reverted[i].Number, err)
+			return
+		}
+	}
+}
+
+// findBestBranch finds a branch in the block pool which is preferred over the current chain.
+// it returns the common ancestor and the branch blocks in ascending order.
+func (b *Blockchain) findBestBranch() (block.Block, []block.Block, bool) {
+	lastBlock, err := b.GetBlockByHash(b.GetLastBlockHash())
+	if err != nil {
+		return block.Block{}, nil, false
+	}
+
+	blocks := b.GetBlocksFromPool()
+	pool := make(map[string]block.Block, len(blocks))
+	for _, blck := range blocks {
+		pool[hexutil.Encode(blck.Hash)] = blck
+	}
+
+	found := false
+	bestTip := lastBlock
+	bestAncestor := block.Block{}
+	var bestBranch []block.Block
+	for _, tip := range blocks {
+		if !isPreferredTip(tip, bestTip) {
+			continue
+		}
+
+		branch := []block.Block{tip}
+		first := tip
+		for len(branch) <= len(blocks) {
+			parent, ok := pool[hexutil.Encode(first.PreviousBlockHash)]
+			if !ok {
+				break
+			}
+			branch = append([]block.Block{parent}, branch...)
+			first = parent
+		}
+
+		ancestor, err := b.GetBlockByHash(first.PreviousBlockHash)
+		if err != nil || !b.isCanonicalBlock(ancestor) {
+			continue
+		}
+
+		if lastBlock.Number-ancestor.Number > maxReorgDepth || ancestor.Number+uint64(len(branch)) != tip.Number {
+			continue
+		}
+
+		// the chain is never reorganized past a finalized block
+		if b.isBeforeFinalizedCheckpoint(ancestor.Number) {
+			continue
+		}
+
+		found = true
+		bestTip = tip
+		bestAncestor = ancestor
+		bestBranch = branch
+	}
+
+	return bestAncestor, bestBranch, found
+}
+
+// isCanonicalBlock checks if the block is part of the current chain.
+func (b *Blockchain) isCanonicalBlock(blck block.Block) bool {
+	canonical, err := b.GetBlockByNumber(blck.Number)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(canonical.Hash, blck.Hash)
+}
+
+// isPreferredTip reports whether candidate is preferred over current as the last block of the chain.
+// The longest chain wins, ties are broken by the lowest verifier address and then by the lowest block hash.
+func isPreferredTip(candidate, current block.Block) bool {
+	if candidate.Number != current.Number {
+		return candidate.Number > current.Number
+	}
+
+	if cmp := bytes.Compare(blockVerifierAddress(candidate), blockVerifierAddress(current)); cmp != 0 {
+		return cmp < 0
+	}
+
+	return bytes.Compare(candidate.Hash, current.Hash) < 0
+}
+
+// blockVerifierAddress returns the address of the block sealer from the coinbase transaction.
+func blockVerifierAddress(blck block.Block) []byte {
+	if len(blck.Transactions) == 0 {
+		return nil
+	}
+
+	addr, err := crypto.RawPublicToAddressBytes(blck.Transactions[0].PublicKey)
+	if err != nil {
+		return nil
+	}
+	return addr
+}
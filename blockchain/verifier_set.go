@@ -0,0 +1,236 @@
+package blockchain
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/filefilego/filefilego/block"
+	"github.com/filefilego/filefilego/common/hexutil"
+	"github.com/filefilego/filefilego/crypto"
+	"github.com/filefilego/filefilego/database"
+	"github.com/syndtr/goleveldb/leveldb/util"
+	"google.golang.org/protobuf/proto"
+)
+
+// VerifierQuorum returns the number of verifier signatures required to change a verifier set of the given size.
+func VerifierQuorum(totalVerifiers int) int {
+	return totalVerifiers*2/3 + 1
+}
+
+// GetVerifiersAtHeight returns the verifiers which are active at the given block height.
+func (b *Blockchain) GetVerifiersAtHeight(height uint64) ([]block.Verifier, error) {
+	set, err := b.getVerifierSetAtHeight(b.db, height)
+	if err != nil {
+		return nil, err
+	}
+
+	verifiers := make([]block.Verifier, len(set.Verifiers))
+	for i, v := range set.Verifiers {
+		verifiers[i] = block.Verifier{
+			Address:      v.Address,
+			PublicKey:    v.PublicKey,
+			DataVerifier: v.DataVerifier,
+		}
+	}
+	return verifiers, nil
+}
+
+// getVerifierSetAtHeight returns the verifier set which is active at the given block height.
+// if the verifier set was never changed, the genesis verifiers are returned.
+func (b *Blockchain) getVerifierSetAtHeight(db database.Database, height uint64) (*VerifierSetProto, error) {
+	iter := db.NewIterator(util.BytesPrefix([]byte(verifierSetPrefix)), nil)
+	var data []byte
+	for iter.Next() {
+		key := iter.Key()
+		activeFrom := binary.BigEndian.Uint64(key[len(verifierSetPrefix):])
+		if activeFrom > height {
+			break
+		}
+		data = make([]byte, len(iter.Value()))
+		copy(data, iter.Value())
+	}
+	iter.Release()
+	err := iter.Error()
+	if err != nil {
+		return nil, fmt.Errorf("failed to release verifier set iterator: %w", err)
+	}
+
+	if data == nil {
+		genesisVerifiers := block.GetGenesisBlockVerifiers()
+		set := &VerifierSetProto{
+			Verifiers: make([]*VerifierProto, len(genesisVerifiers)),
+		}
+		for i, v := range genesisVerifiers {
+			set.Verifiers[i] = &VerifierProto{
+				Address:      v.Address,
+				PublicKey:    v.PublicKey,
+				DataVerifier: v.DataVerifier,
+			}
+		}
+		return set, nil
+	}
+
+	set := VerifierSetProto{}
+	if err := proto.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal verifier set: %w", err)
+	}
+	return &set, nil
+}
+
+// saveVerifierSet saves a verifier set which is active starting from the given block height.
+func (b *Blockchain) saveVerifierSet(db database.Database, activeFrom uint64, set *VerifierSetProto) error {
+	data, err := proto.Marshal(set)
+	if err != nil {
+		return fmt.Errorf("failed to marshal verifier set: %w", err)
+	}
+
+	key := make([]byte, len(verifierSetPrefix)+8)
+	copy(key, verifierSetPrefix)
+	binary.BigEndian.PutUint64(key[len(verifierSetPrefix):], activeFrom)
+
+	err = db.Put(key, data)
+	if err != nil {
+		return fmt.Errorf("failed to insert verifier set into db: %w", err)
+	}
+	return nil
+}
+
+// updateBlockchainSettings applies a verifier set update which is signed by a quorum of the verifiers.
+// the new verifier set is active starting from the block after the block which contains the update.
+func (b *Blockchain) updateBlockchainSettings(db database.Database, payload []byte) error {
+	blockNumber, ok := b.applyingBlockNumber()
+	if !ok {
+		return errors.New("blockchain settings can only be updated by a block")
+	}
+
+	settings := BlockchainSettingsProto{}
+	if err := proto.Unmarshal(payload, &settings); err != nil {
+		return fmt.Errorf("failed to unmarshal blockchain settings: %w", err)
+	}
+
+	update := VerifierSetUpdateProto{}
+	if err := proto.Unmarshal(settings.VerifierSetUpdate, &update); err != nil {
+		return fmt.Errorf("failed to unmarshal verifier set update: %w", err)
+	}
+
+	activeFrom := blockNumber + 1
+	currentSet, err := b.getVerifierSetAtHeight(db, activeFrom)
+	if err != nil {
+		return fmt.Errorf("failed to get verifier set: %w", err)
+	}
+
+	if update.Sequence != currentSet.Sequence+1 {
+		return fmt.Errorf("verifier set update sequence %d is not the next sequence of %d", update.Sequence, currentSet.Sequence)
+	}
+
+	err = verifyQuorumSignatures(currentSet, crypto.Sha256(settings.VerifierSetUpdate), settings.Signatures)
+	if err != nil {
+		return err
+	}
+
+	newSet, err := applyVerifierSetUpdate(currentSet, &update)
+	if err != nil {
+		return err
+	}
+
+	return b.saveVerifierSet(db, activeFrom, newSet)
+}
+
+// verifyQuorumSignatures checks that a quorum of distinct verifiers of the set signed the hash.
+func verifyQuorumSignatures(set *VerifierSetProto, hash []byte, signatures []*SettingsSignatureProto) error {
+	verifiers := make(map[string]struct{}, len(set.Verifiers))
+	for _, v := range set.Verifiers {
+		verifiers[v.Address] = struct{}{}
+	}
+
+	signers := make(map[string]struct{})
+	for _, sig := range signatures {
+		addr, err := crypto.RawPublicToAddress(sig.PublicKey)
+		if err != nil {
+			continue
+		}
+
+		if _, ok := verifiers[addr]; !ok {
+			continue
+		}
+
+		pubKey, err := crypto.PublicKeyFromBytes(sig.PublicKey)
+		if err != nil {
+			continue
+		}
+
+		ok, err := pubKey.Verify(hash, sig.Signature)
+		if err != nil || !ok {
+			continue
+		}
+		signers[addr] = struct{}{}
+	}
+
+	quorum := VerifierQuorum(len(set.Verifiers))
+	if len(signers) < quorum {
+		return fmt.Errorf("verifier set update is signed by %d verifiers, quorum is %d", len(signers), quorum)
+	}
+	return nil
+}
+
+// applyVerifierSetUpdate returns a new verifier set with the update applied.
+func applyVerifierSetUpdate(set *VerifierSetProto, update *VerifierSetUpdateProto) (*VerifierSetProto, error) {
+	newSet := &VerifierSetProto{
+		Sequence:  update.Sequence,
+		Verifiers: make([]*VerifierProto, 0, len(set.Verifiers)+len(update.Verifiers)),
+	}
+
+	removed := make(map[string]struct{}, len(update.RemovedAddresses))
+	for _, addr := range update.RemovedAddresses {
+		removed[addr] = struct{}{}
+	}
+
+	updated := make(map[string]*VerifierProto, len(update.Verifiers))
+	for _, v := range update.Verifiers {
+		if _, ok := removed[v.Address]; ok {
+			return nil, fmt.Errorf("verifier %s can't be updated and removed at the same time", v.Address)
+		}
+
+		pubKey, err := hexutil.Decode(v.PublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode public key of verifier %s: %w", v.Address, err)
+		}
+
+		addr, err := crypto.RawPublicToAddress(pubKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get address of verifier %s: %w", v.Address, err)
+		}
+
+		if addr != v.Address {
+			return nil, fmt.Errorf("address %s doesn't match the public key of the verifier", v.Address)
+		}
+		updated[v.Address] = v
+	}
+
+	for _, v := range set.Verifiers {
+		if _, ok := removed[v.Address]; ok {
+			continue
+		}
+
+		if u, ok := updated[v.Address]; ok {
+			newSet.Verifiers = append(newSet.Verifiers, u)
+			delete(updated, v.Address)
+			continue
+		}
+		newSet.Verifiers = append(newSet.Verifiers, v)
+	}
+
+	// new verifiers are appended in the order of the update
+	for _, v := range update.Verifiers {
+		if _, ok := updated[v.Address]; ok {
+			newSet.Verifiers = append(newSet.Verifiers, v)
+		}
+	}
+
+	if len(newSet.Verifiers) == 0 {
+		return nil, errors.New("verifier set can't be empty")
+	}
+
+	return newSet, nil
+}
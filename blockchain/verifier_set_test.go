@@ -0,0 +1,208 @@
+package blockchain
+
+import (
+	"os"
+	"testing"
+
+	"github.com/filefilego/filefilego/block"
+	"github.com/filefilego/filefilego/common/hexutil"
+	"github.com/filefilego/filefilego/crypto"
+	"github.com/filefilego/filefilego/database"
+	"github.com/filefilego/filefilego/search"
+	"github.com/stretchr/testify/assert"
+	"github.com/syndtr/goleveldb/leveldb"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestUpdateBlockchainSettings(t *testing.T) {
+	genesisblockValid, err := block.GetGenesisBlock()
+	assert.NoError(t, err)
+	db, err := leveldb.OpenFile("verifierset.db", nil)
+	assert.NoError(t, err)
+	driver, err := database.New(db)
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		block.SetVerifierSetProvider(nil)
+		db.Close()
+		os.RemoveAll("verifierset.db")
+	})
+
+	blockchain, err := New(driver, &search.Search{}, genesisblockValid.Hash)
+	assert.NoError(t, err)
+	err = blockchain.InitOrLoad(true)
+	assert.NoError(t, err)
+
+	// the genesis verifiers are active until the set is changed
+	verifiers, err := blockchain.GetVerifiersAtHeight(0)
+	assert.NoError(t, err)
+	assert.Len(t, verifiers, len(block.GetGenesisBlockVerifiers()))
+
+	keyPairs := make([]crypto.KeyPair, 3)
+	set := &VerifierSetProto{Sequence: 1}
+	for i := range keyPairs {
+		keyPairs[i] = newVerifierKeyPair(t)
+		set.Verifiers = append(set.Verifiers, verifierProtoFromKeyPair(t, keyPairs[i]))
+	}
+	err = blockchain.saveVerifierSet(blockchain.db, 1, set)
+	assert.NoError(t, err)
+	verifiers, err = blockchain.GetVerifiersAtHeight(1)
+	assert.NoError(t, err)
+	assert.Len(t, verifiers, 3)
+
+	newKeyPair := newVerifierKeyPair(t)
+	update := &VerifierSetUpdateProto{
+		Sequence:         2,
+		Verifiers:        []*VerifierProto{verifierProtoFromKeyPair(t, newKeyPair)},
+		RemovedAddresses: []string{keyPairs[0].Address},
+	}
+
+	// only blocks can update the settings
+	err = blockchain.updateBlockchainSettings(blockchain.db, blockchainSettingsPayload(t, update, keyPairs))
+	assert.EqualError(t, err, "blockchain settings can only be updated by a block")
+
+	blockchain.startJournal(5)
+	defer blockchain.stopJournal()
+
+	// no quorum
+	err = blockchain.updateBlockchainSettings(blockchain.db, blockchainSettingsPayload(t, update, keyPairs[:2]))
+	assert.EqualError(t, err, "verifier set update is signed by 2 verifiers, quorum is 3")
+
+	// signatures of non verifiers are not counted
+	err = blockchain.updateBlockchainSettings(blockchain.db, blockchainSettingsPayload(t, update, []crypto.KeyPair{keyPairs[0], keyPairs[1], newKeyPair}))
+	assert.EqualError(t, err, "verifier set update is signed by 2 verifiers, quorum is 3")
+
+	// wrong sequence
+	update.Sequence = 3
+	err = blockchain.updateBlockchainSettings(blockchain.db, blockchainSettingsPayload(t, update, keyPairs))
+	assert.EqualError(t, err, "verifier set update sequence 3 is not the next sequence of 1")
+
+	update.Sequence = 2
+	err = blockchain.updateBlockchainSettings(blockchain.db, blockchainSettingsPayload(t, update, keyPairs))
+	assert.NoError(t, err)
+
+	// the update can't be replayed
+	err = blockchain.updateBlockchainSettings(blockchain.db, blockchainSettingsPayload(t, update, keyPairs))
+	assert.EqualError(t, err, "verifier set update sequence 2 is not the next sequence of 2")
+
+	verifiers, err = blockchain.GetVerifiersAtHeight(5)
+	assert.NoError(t, err)
+	assert.Len(t, verifiers, 3)
+	assert.Equal(t, keyPairs[0].Address, verifiers[0].Address)
+
+	verifiers, err = blockchain.GetVerifiersAtHeight(6)
+	assert.NoError(t, err)
+	assert.Len(t, verifiers, 3)
+	assert.Equal(t, keyPairs[1].Address, verifiers[0].Address)
+	assert.Equal(t, keyPairs[2].Address, verifiers[1].Address)
+	assert.Equal(t, newKeyPair.Address, verifiers[2].Address)
+	assert.True(t, verifiers[2].DataVerifier)
+
+	block.SetVerifierSetProvider(blockchain)
+	for _, c := range []struct {
+		address  string
+		height   uint64
+		expValid bool
+	}{
+		{address: keyPairs[0].Address, height: 5, expValid: true},
+		{address: keyPairs[0].Address, height: 6, expValid: false},
+		{address: newKeyPair.Address, height: 5, expValid: false},
+		{address: newKeyPair.Address, height: 6, expValid: true},
+	} {
+		isVerifier, err := block.IsValidVerifier(c.address, c.height)
+		assert.NoError(t, err)
+		assert.Equal(t, c.expValid, isVerifier)
+	}
+}
+
+func TestApplyVerifierSetUpdate(t *testing.T) {
+	kp := newVerifierKeyPair(t)
+	kp2 := newVerifierKeyPair(t)
+	set := &VerifierSetProto{
+		Sequence:  1,
+		Verifiers: []*VerifierProto{verifierProtoFromKeyPair(t, kp)},
+	}
+
+	mismatch := verifierProtoFromKeyPair(t, kp2)
+	mismatch.Address = kp.Address
+
+	cases := map[string]struct {
+		update  *VerifierSetUpdateProto
+		expErr  string
+		expAddr []string
+	}{
+		"address doesn't match public key": {
+			update: &VerifierSetUpdateProto{Sequence: 2, Verifiers: []*VerifierProto{mismatch}},
+			expErr: "address " + kp.Address + " doesn't match the public key of the verifier",
+		},
+		"updated and removed": {
+			update: &VerifierSetUpdateProto{Sequence: 2, Verifiers: []*VerifierProto{verifierProtoFromKeyPair(t, kp)}, RemovedAddresses: []string{kp.Address}},
+			expErr: "verifier " + kp.Address + " can't be updated and removed at the same time",
+		},
+		"empty set": {
+			update: &VerifierSetUpdateProto{Sequence: 2, RemovedAddresses: []string{kp.Address}},
+			expErr: "verifier set can't be empty",
+		},
+		"success": {
+			update:  &VerifierSetUpdateProto{Sequence: 2, Verifiers: []*VerifierProto{verifierProtoFromKeyPair(t, kp2)}},
+			expAddr: []string{kp.Address, kp2.Address},
+		},
+	}
+
+	for name, tt := range cases {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			newSet, err := applyVerifierSetUpdate(set, tt.update)
+			if tt.expErr != "" {
+				assert.EqualError(t, err, tt.expErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.update.Sequence, newSet.Sequence)
+			addrs := make([]string, 0, len(newSet.Verifiers))
+			for _, v := range newSet.Verifiers {
+				addrs = append(addrs, v.Address)
+			}
+			assert.Equal(t, tt.expAddr, addrs)
+		})
+	}
+}
+
+func newVerifierKeyPair(t *testing.T) crypto.KeyPair {
+	kp, err := crypto.GenerateKeyPair()
+	assert.NoError(t, err)
+	return kp
+}
+
+func verifierProtoFromKeyPair(t *testing.T, kp crypto.KeyPair) *VerifierProto {
+	pubKey, err := kp.PublicKey.Raw()
+	assert.NoError(t, err)
+	return &VerifierProto{
+		Address:      kp.Address,
+		PublicKey:    hexutil.Encode(pubKey),
+		DataVerifier: true,
+	}
+}
+
+func blockchainSettingsPayload(t *testing.T, update *VerifierSetUpdateProto, signers []crypto.KeyPair) []byte {
+	updateBytes, err := proto.Marshal(update)
+	assert.NoError(t, err)
+
+	settings := BlockchainSettingsProto{
+		VerifierSetUpdate: updateBytes,
+	}
+	hash := crypto.Sha256(updateBytes)
+	for _, kp := range signers {
+		sig, err := kp.PrivateKey.Sign(hash)
+		assert.NoError(t, err)
+		pubKey, err := kp.PublicKey.Raw()
+		assert.NoError(t, err)
+		settings.Signatures = append(settings.Signatures, &SettingsSignatureProto{
+			PublicKey: pubKey,
+			Signature: sig,
+		})
+	}
+
+	data, err := proto.Marshal(&settings)
+	assert.NoError(t, err)
+	return data
+}
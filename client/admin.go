@@ -0,0 +1,192 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/filefilego/filefilego/rpc"
+)
+
+// BannedPeers returns the peers banned by the node.
+// this method requires the address to be unlocked and a token supplied
+func (cli *Client) BannedPeers(ctx context.Context, token string) (rpc.BannedPeersResponse, error) {
+	payload := JSONRPCRequest{
+		JSONRPC: "2.0",
+		Method:  "admin.BannedPeers",
+		Params: []interface{}{rpc.BannedPeersArgs{
+			AccessToken: token,
+		}},
+		ID: 1,
+	}
+
+	bodyBuf, err := encodeDataToJSON(payload)
+	if err != nil {
+		return rpc.BannedPeersResponse{}, fmt.Errorf("failed to encode body to json: %w", err)
+	}
+
+	req, err := cli.buildRequest(ctx, http.MethodPost, cli.url, bodyBuf, nil)
+	if err != nil {
+		return rpc.BannedPeersResponse{}, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	response, err := cli.httpClient.Do(req)
+	if err != nil {
+		return rpc.BannedPeersResponse{}, fmt.Errorf("failed to do request: %w", err)
+	}
+
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return rpc.BannedPeersResponse{}, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	jsonResponse := JSONRPCResponse{}
+	if err := json.Unmarshal(body, &jsonResponse); err != nil {
+		return rpc.BannedPeersResponse{}, fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	if jsonResponse.Error != "" {
+		return rpc.BannedPeersResponse{}, errors.New(jsonResponse.Error)
+	}
+
+	if jsonResponse.Result == nil {
+		return rpc.BannedPeersResponse{}, errors.New("empty result in json response")
+	}
+
+	responsePayload := rpc.BannedPeersResponse{}
+	dbByte, err := json.Marshal(jsonResponse.Result)
+	if err != nil {
+		return rpc.BannedPeersResponse{}, errors.New("failed to marshal the result of response")
+	}
+
+	if err := json.Unmarshal(dbByte, &responsePayload); err != nil {
+		return rpc.BannedPeersResponse{}, fmt.Errorf("failed to unmarshal the result of response back to a struct: %w", err)
+	}
+
+	return responsePayload, nil
+}
+
+// BanPeer bans a peer and disconnects from it.
+// this method requires the address to be unlocked and a token supplied
+func (cli *Client) BanPeer(ctx context.Context, token, peerID, reason string) (rpc.BanPeerResponse, error) {
+	payload := JSONRPCRequest{
+		JSONRPC: "2.0",
+		Method:  "admin.BanPeer",
+		Params: []interface{}{rpc.BanPeerArgs{
+			AccessToken: token,
+			PeerID:      peerID,
+			Reason:      reason,
+		}},
+		ID: 1,
+	}
+
+	bodyBuf, err := encodeDataToJSON(payload)
+	if err != nil {
+		return rpc.BanPeerResponse{}, fmt.Errorf("failed to encode body to json: %w", err)
+	}
+
+	req, err := cli.buildRequest(ctx, http.MethodPost, cli.url, bodyBuf, nil)
+	if err != nil {
+		return rpc.BanPeerResponse{}, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	response, err := cli.httpClient.Do(req)
+	if err != nil {
+		return rpc.BanPeerResponse{}, fmt.Errorf("failed to do request: %w", err)
+	}
+
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return rpc.BanPeerResponse{}, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	jsonResponse := JSONRPCResponse{}
+	if err := json.Unmarshal(body, &jsonResponse); err != nil {
+		return rpc.BanPeerResponse{}, fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	if jsonResponse.Error != "" {
+		return rpc.BanPeerResponse{}, errors.New(jsonResponse.Error)
+	}
+
+	if jsonResponse.Result == nil {
+		return rpc.BanPeerResponse{}, errors.New("empty result in json response")
+	}
+
+	responsePayload := rpc.BanPeerResponse{}
+	dbByte, err := json.Marshal(jsonResponse.Result)
+	if err != nil {
+		return rpc.BanPeerResponse{}, errors.New("failed to marshal the result of response")
+	}
+
+	if err := json.Unmarshal(dbByte, &responsePayload); err != nil {
+		return rpc.BanPeerResponse{}, fmt.Errorf("failed to unmarshal the result of response back to a struct: %w", err)
+	}
+
+	return responsePayload, nil
+}
+
+// UnbanPeer removes a peer from the ban list of the node.
+// this method requires the address to be unlocked and a token supplied
+func (cli *Client) UnbanPeer(ctx context.Context, token, peerID string) (rpc.UnbanPeerResponse, error) {
+	payload := JSONRPCRequest{
+		JSONRPC: "2.0",
+		Method:  "admin.UnbanPeer",
+		Params: []interface{}{rpc.UnbanPeerArgs{
+			AccessToken: token,
+			PeerID:      peerID,
+		}},
+		ID: 1,
+	}
+
+	bodyBuf, err := encodeDataToJSON(payload)
+	if err != nil {
+		return rpc.UnbanPeerResponse{}, fmt.Errorf("failed to encode body to json: %w", err)
+	}
+
+	req, err := cli.buildRequest(ctx, http.MethodPost, cli.url, bodyBuf, nil)
+	if err != nil {
+		return rpc.UnbanPeerResponse{}, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	response, err := cli.httpClient.Do(req)
+	if err != nil {
+		return rpc.UnbanPeerResponse{}, fmt.Errorf("failed to do request: %w", err)
+	}
+
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return rpc.UnbanPeerResponse{}, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	jsonResponse := JSONRPCResponse{}
+	if err := json.Unmarshal(body, &jsonResponse); err != nil {
+		return rpc.UnbanPeerResponse{}, fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	if jsonResponse.Error != "" {
+		return rpc.UnbanPeerResponse{}, errors.New(jsonResponse.Error)
+	}
+
+	if jsonResponse.Result == nil {
+		return rpc.UnbanPeerResponse{}, errors.New("empty result in json response")
+	}
+
+	responsePayload := rpc.UnbanPeerResponse{}
+	dbByte, err := json.Marshal(jsonResponse.Result)
+	if err != nil {
+		return rpc.UnbanPeerResponse{}, errors.New("failed to marshal the result of response")
+	}
+
+	if err := json.Unmarshal(dbByte, &responsePayload); err != nil {
+		return rpc.UnbanPeerResponse{}, fmt.Errorf("failed to unmarshal the result of response back to a struct: %w", err)
+	}
+
+	return responsePayload, nil
+}
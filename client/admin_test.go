@@ -0,0 +1,51 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBannedPeers(t *testing.T) {
+	bodyReader := strings.NewReader(`{"result":{"peers":[{"peer_id":"16Uiu2HAm2edbaX9YyMauXDjdhcdF34P59zg29xtP9nmeS7MJNbxo","reason":"spam","until":1700000000}]},"error":null,"id":1}`)
+	c, err := New("http://localhost:8090/rpc", &httpClientStub{
+		response: &http.Response{
+			Body: io.NopCloser(bodyReader),
+		},
+	})
+	assert.NoError(t, err)
+	response, err := c.BannedPeers(context.TODO(), "token")
+	assert.NoError(t, err)
+	assert.Len(t, response.Peers, 1)
+	assert.Equal(t, "spam", response.Peers[0].Reason)
+	assert.Equal(t, int64(1700000000), response.Peers[0].Until)
+}
+
+func TestBanPeer(t *testing.T) {
+	bodyReader := strings.NewReader(`{"result":{"success":true},"error":null,"id":1}`)
+	c, err := New("http://localhost:8090/rpc", &httpClientStub{
+		response: &http.Response{
+			Body: io.NopCloser(bodyReader),
+		},
+	})
+	assert.NoError(t, err)
+	response, err := c.BanPeer(context.TODO(), "token", "16Uiu2HAm2edbaX9YyMauXDjdhcdF34P59zg29xtP9nmeS7MJNbxo", "spam")
+	assert.NoError(t, err)
+	assert.True(t, response.Success)
+}
+
+func TestUnbanPeer(t *testing.T) {
+	bodyReader := strings.NewReader(`{"result":null,"error":"failed to authorize access token <nil>","id":1}`)
+	c, err := New("http://localhost:8090/rpc", &httpClientStub{
+		response: &http.Response{
+			Body: io.NopCloser(bodyReader),
+		},
+	})
+	assert.NoError(t, err)
+	_, err = c.UnbanPeer(context.TODO(), "token", "16Uiu2HAm2edbaX9YyMauXDjdhcdF34P59zg29xtP9nmeS7MJNbxo")
+	assert.EqualError(t, err, "failed to authorize access token <nil>")
+}
@@ -188,3 +188,61 @@ func (cli *Client) GetBlockPool(ctx context.Context) (rpc.PoolResponse, error) {
 
 	return jsonBlockPool, nil
 }
+
+// GetDoubleSignEvidence gets the double sign evidence of a verifier, or of all the verifiers if the address is empty.
+func (cli *Client) GetDoubleSignEvidence(ctx context.Context, address string) (rpc.DoubleSignEvidenceResponse, error) {
+	payload := JSONRPCRequest{
+		JSONRPC: "2.0",
+		Method:  "block.DoubleSignEvidence",
+		Params:  []interface{}{rpc.DoubleSignEvidenceArgs{Address: address}},
+		ID:      1,
+	}
+
+	bodyBuf, err := encodeDataToJSON(payload)
+	if err != nil {
+		return rpc.DoubleSignEvidenceResponse{}, fmt.Errorf("failed to encode body to json: %w", err)
+	}
+
+	req, err := cli.buildRequest(ctx, http.MethodPost, cli.url, bodyBuf, nil)
+	if err != nil {
+		return rpc.DoubleSignEvidenceResponse{}, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	response, err := cli.httpClient.Do(req)
+	if err != nil {
+		return rpc.DoubleSignEvidenceResponse{}, fmt.Errorf("failed to do request: %w", err)
+	}
+
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return rpc.DoubleSignEvidenceResponse{}, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	jsonResponse := JSONRPCResponse{}
+	if err := json.Unmarshal(body, &jsonResponse); err != nil {
+		return rpc.DoubleSignEvidenceResponse{}, fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	if jsonResponse.Error != "" {
+		return rpc.DoubleSignEvidenceResponse{}, errors.New(jsonResponse.Error)
+	}
+
+	if jsonResponse.Result == nil {
+		return rpc.DoubleSignEvidenceResponse{}, errors.New("empty result in json response")
+	}
+
+	// the result contains a map
+	// the best way to convert it to a struct is through the json marshal and unmarshal
+	evidence := rpc.DoubleSignEvidenceResponse{}
+	dbByte, err := json.Marshal(jsonResponse.Result)
+	if err != nil {
+		return rpc.DoubleSignEvidenceResponse{}, errors.New("failed to marshal the result of response")
+	}
+
+	if err := json.Unmarshal(dbByte, &evidence); err != nil {
+		return rpc.DoubleSignEvidenceResponse{}, fmt.Errorf("failed to unmarshal the result of response back to a struct: %w", err)
+	}
+
+	return evidence, nil
+}
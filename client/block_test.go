@@ -53,3 +53,19 @@ func TestGetBlocPool(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Empty(t, block.BlockHashes)
 }
+
+func TestGetDoubleSignEvidence(t *testing.T) {
+	bodyReader := strings.NewReader(`{"result":{"evidence":[{"verifier":"0x01","block_number":5,"first_block_hash":"0x02","second_block_hash":"0x03","committed_in_block":0,"evidence":"0x04"}]},"error":null,"id":1}`)
+	stringReadCloser := io.NopCloser(bodyReader)
+	c, err := New("http://localhost:8090/rpc", &httpClientStub{
+		response: &http.Response{
+			Body: stringReadCloser,
+		},
+	})
+	assert.NoError(t, err)
+	evidence, err := c.GetDoubleSignEvidence(context.TODO(), "0x01")
+	assert.NoError(t, err)
+	assert.Len(t, evidence.Evidence, 1)
+	assert.Equal(t, "0x01", evidence.Evidence[0].Verifier)
+	assert.Equal(t, uint64(5), evidence.Evidence[0].BlockNumber)
+}
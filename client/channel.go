@@ -74,6 +74,68 @@ func (cli *Client) CreateChannelNodeItemsTxDataPayload(ctx context.Context, node
 	return responsePayload.TransactionDataPayloadHex, responsePayload.TotalFeesRequired, nil
 }
 
+// UpdateChannelNodeItemsTxDataPayload creates the transaction data payload for updating the given channel node items.
+func (cli *Client) UpdateChannelNodeItemsTxDataPayload(ctx context.Context, nodes []rpc.NodeItemJSON) (string, string, error) {
+	payload := JSONRPCRequest{
+		JSONRPC: "2.0",
+		Method:  "channel.UpdateNodeItemsTxDataPayload",
+		Params: []interface{}{
+			rpc.UpdateNodeItemsTxDataPayloadArgs{
+				Nodes: nodes,
+			},
+		},
+		ID: 1,
+	}
+
+	bodyBuf, err := encodeDataToJSON(payload)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to encode body to json: %w", err)
+	}
+
+	req, err := cli.buildRequest(ctx, http.MethodPost, cli.url, bodyBuf, nil)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to build request: %w", err)
+	}
+
+	response, err := cli.httpClient.Do(req)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to do request: %w", err)
+	}
+
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	jsonResponse := JSONRPCResponse{}
+	if err := json.Unmarshal(body, &jsonResponse); err != nil {
+		return "", "", fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	if jsonResponse.Error != "" {
+		return "", "", errors.New(jsonResponse.Error)
+	}
+
+	if jsonResponse.Result == nil {
+		return "", "", errors.New("empty result in json response")
+	}
+
+	// the result contains a map
+	// the best way to convert it to a struct is through the json marshal and unmarshal
+	responsePayload := rpc.UpdateNodeItemsTxDataPayloadResponse{}
+	dbByte, err := json.Marshal(jsonResponse.Result)
+	if err != nil {
+		return "", "", errors.New("failed to marshal the result of response")
+	}
+
+	if err := json.Unmarshal(dbByte, &responsePayload); err != nil {
+		return "", "", fmt.Errorf("failed to unmarshal the result of response back to a struct: %w", err)
+	}
+
+	return responsePayload.TransactionDataPayloadHex, responsePayload.TotalFeesRequired, nil
+}
+
 // ListChannels gets the list of channels.
 func (cli *Client) ListChannels(ctx context.Context, limit, offset int) (rpc.ListResponse, error) {
 	payload := JSONRPCRequest{
@@ -27,6 +27,21 @@ func TestCreateChannelNodeItemsTxDataPayload(t *testing.T) {
 	assert.Equal(t, "0x9", fees)
 }
 
+func TestUpdateChannelNodeItemsTxDataPayload(t *testing.T) {
+	bodyReader := strings.NewReader(`{"result":{"transaction_data_payload_hex":"0x02", "total_fees_required":"0x8"},"error":null,"id":1}`)
+	stringReadCloser := io.NopCloser(bodyReader)
+	c, err := New("http://localhost:8090/rpc", &httpClientStub{
+		response: &http.Response{
+			Body: stringReadCloser,
+		},
+	})
+	assert.NoError(t, err)
+	hexData, fees, err := c.UpdateChannelNodeItemsTxDataPayload(context.TODO(), []rpc.NodeItemJSON{})
+	assert.NoError(t, err)
+	assert.Equal(t, "0x02", hexData)
+	assert.Equal(t, "0x8", fees)
+}
+
 func TestListChannels(t *testing.T) {
 	bodyReader := strings.NewReader(`{"result":{"total":1,"limit":100,"offset":100,"channels":[{"name":"ffg channel"}]},"error":null,"id":1}`)
 	stringReadCloser := io.NopCloser(bodyReader)
@@ -19,6 +19,9 @@ type SendTransaction struct {
 	To              string
 	Value           string
 	TransactionFees string
+	// ValidAfter and ValidUntil are the optional block numbers of the validity window.
+	ValidAfter string
+	ValidUntil string
 }
 
 // SendRawTransaction sends a raw transaction.
@@ -95,6 +98,8 @@ func (cli *Client) SendTransaction(ctx context.Context, token string, tx SendTra
 			To:              tx.To,
 			Value:           tx.Value,
 			TransactionFees: tx.TransactionFees,
+			ValidAfter:      tx.ValidAfter,
+			ValidUntil:      tx.ValidUntil,
 		}},
 		ID: 1,
 	}
@@ -148,6 +153,81 @@ func (cli *Client) SendTransaction(ctx context.Context, token string, tx SendTra
 	return responseTx, nil
 }
 
+// SendBatchTransaction represents a batch transfer to be sent to the network.
+type SendBatchTransaction struct {
+	Nounce          string
+	From            string
+	Transfers       []rpc.JSONBatchTransfer
+	TransactionFees string
+	ValidAfter      string
+	ValidUntil      string
+}
+
+// SendBatchTransaction sends the transfers to many recipients in a single transaction.
+// this method requires the address to be unlocked and a token supplied
+func (cli *Client) SendBatchTransaction(ctx context.Context, token string, tx SendBatchTransaction) (rpc.TransactionResponse, error) {
+	payload := JSONRPCRequest{
+		JSONRPC: "2.0",
+		Method:  "transaction.SendBatchTransaction",
+		Params: []interface{}{rpc.SendBatchTransactionArgs{
+			AccessToken:     token,
+			Nounce:          tx.Nounce,
+			From:            tx.From,
+			Transfers:       tx.Transfers,
+			TransactionFees: tx.TransactionFees,
+			ValidAfter:      tx.ValidAfter,
+			ValidUntil:      tx.ValidUntil,
+		}},
+		ID: 1,
+	}
+
+	bodyBuf, err := encodeDataToJSON(payload)
+	if err != nil {
+		return rpc.TransactionResponse{}, fmt.Errorf("failed to encode body to json: %w", err)
+	}
+
+	req, err := cli.buildRequest(ctx, http.MethodPost, cli.url, bodyBuf, nil)
+	if err != nil {
+		return rpc.TransactionResponse{}, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	response, err := cli.httpClient.Do(req)
+	if err != nil {
+		return rpc.TransactionResponse{}, fmt.Errorf("failed to do request: %w", err)
+	}
+
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return rpc.TransactionResponse{}, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	jsonResponse := JSONRPCResponse{}
+	if err := json.Unmarshal(body, &jsonResponse); err != nil {
+		return rpc.TransactionResponse{}, fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	if jsonResponse.Error != "" {
+		return rpc.TransactionResponse{}, errors.New(jsonResponse.Error)
+	}
+
+	if jsonResponse.Result == nil {
+		return rpc.TransactionResponse{}, errors.New("empty result in json response")
+	}
+
+	responseTx := rpc.TransactionResponse{}
+	dbByte, err := json.Marshal(jsonResponse.Result)
+	if err != nil {
+		return rpc.TransactionResponse{}, errors.New("failed to marshal the result of response")
+	}
+
+	if err := json.Unmarshal(dbByte, &responseTx); err != nil {
+		return rpc.TransactionResponse{}, fmt.Errorf("failed to unmarshal the result of response back to a struct: %w", err)
+	}
+
+	return responseTx, nil
+}
+
 // TransactionPool returns the transaction in the tx pool
 func (cli *Client) TransactionPool(ctx context.Context, token string, tx SendTransaction) (rpc.MemPoolResponse, error) {
 	payload := JSONRPCRequest{
@@ -206,15 +286,14 @@ func (cli *Client) TransactionPool(ctx context.Context, token string, tx SendTra
 	return responseTx, nil
 }
 
-// GetTransactionByAddress given transactions of an address.
-func (cli *Client) GetTransactionByAddress(ctx context.Context, address string) (rpc.TransactionsResponse, error) {
+// GetTransactionByAddress given a page of transactions of an address.
+// the next cursor of the response is used in the args to get the next page.
+func (cli *Client) GetTransactionByAddress(ctx context.Context, args rpc.ByAddressArgs) (rpc.TransactionsResponse, error) {
 	payload := JSONRPCRequest{
 		JSONRPC: "2.0",
 		Method:  "transaction.ByAddress",
-		Params: []interface{}{rpc.ByAddressArgs{
-			Address: address,
-		}},
-		ID: 1,
+		Params:  []interface{}{args},
+		ID:      1,
 	}
 
 	bodyBuf, err := encodeDataToJSON(payload)
@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"errors"
 	"fmt"
+	"math/big"
 	"net"
 	"net/http"
 	"os"
@@ -25,12 +26,16 @@ import (
 	"github.com/filefilego/filefilego/database"
 	ffgcli "github.com/filefilego/filefilego/internal/cli"
 	"github.com/filefilego/filefilego/keystore"
+	"github.com/filefilego/filefilego/mempool"
 	"github.com/filefilego/filefilego/node"
 	blockdownloader "github.com/filefilego/filefilego/node/protocols/block_downloader"
+	compactblock "github.com/filefilego/filefilego/node/protocols/compact_block"
 	dataquery "github.com/filefilego/filefilego/node/protocols/data_query"
 	dataverification "github.com/filefilego/filefilego/node/protocols/data_verification"
+	"github.com/filefilego/filefilego/node/protocols/snapshot"
 	internalrpc "github.com/filefilego/filefilego/rpc"
 	"github.com/filefilego/filefilego/search"
+	"github.com/filefilego/filefilego/signer"
 	"github.com/filefilego/filefilego/storage"
 	"github.com/filefilego/filefilego/validator"
 	"github.com/gorilla/rpc/v2/json"
@@ -45,11 +50,16 @@ import (
 )
 
 const (
-	blockValidatorIntervalSeconds        = 10
+	sealingCheckIntervalSeconds          = 1
+	checkpointAttestationIntervalSeconds = 10
 	syncIntervalSeconds                  = 18
 	purgeContractStoreIntervalSeconds    = 60 * 60
 	purgeConstractStoreTimeWindowSeconds = 60 * 60 * 24 * 5
 	triggerSyncSinceLastUpdateSeconds    = 15
+	saveAddressBookIntervalSeconds       = 60 * 5
+	maxKnownPeersOnStartup               = 50
+	gossipNetwork                        = "ffgnet"
+	legacyGossipTopic                    = "ffgnet_pubsub"
 )
 
 func main() {
@@ -64,6 +74,8 @@ func main() {
 		ffgcli.AddressCommand,
 		ffgcli.StorageCommand,
 		ffgcli.ClientCommand,
+		ffgcli.ReindexCommand,
+		ffgcli.SignerCommand,
 	}
 	app.Suggest = true
 
@@ -126,7 +138,23 @@ func run(ctx *cli.Context) error {
 	}
 	routingDiscovery := drouting.NewRoutingDiscovery(kademliaDHT)
 
-	optsPS := []pubsub.Option{pubsub.WithMessageSigning(true), pubsub.WithMaxMessageSize(conf.P2P.GossipMaxMessageSize)} // 10 MB
+	banList, err := node.NewBanList(node.DefaultMaxOffences, node.DefaultBanDuration)
+	if err != nil {
+		return fmt.Errorf("failed to setup ban list: %w", err)
+	}
+
+	// the legacy topic is kept until all the nodes have migrated to the per message type topics
+	gossipTopics := node.NewGossipTopics(gossipNetwork, "")
+	if conf.P2P.GossipLegacyTopic {
+		gossipTopics.Legacy = legacyGossipTopic
+	}
+
+	optsPS := []pubsub.Option{
+		pubsub.WithMessageSigning(true),
+		pubsub.WithMaxMessageSize(conf.P2P.GossipMaxMessageSize), // 10 MB
+		pubsub.WithPeerScore(node.PeerScoreParams(banList, gossipTopics.Names()...), node.PeerScoreThresholds()),
+		pubsub.WithBlacklist(banList),
+	}
 	gossip, err := pubsub.NewGossipSub(ctx.Context, host, optsPS...)
 	if err != nil {
 		return fmt.Errorf("failed to setup pub sub: %w", err)
@@ -142,6 +170,11 @@ func run(ctx *cli.Context) error {
 		return fmt.Errorf("failed to setup global database: %w", err)
 	}
 
+	addressBook, err := node.NewAddressBook(globalDB, node.DefaultAddressBookMaxAge, node.DefaultAddressBookMaxPeers)
+	if err != nil {
+		return fmt.Errorf("failed to setup address book: %w", err)
+	}
+
 	// setup JSONRPC services
 	s := rpc.NewServer()
 	s.RegisterCodec(json.NewCodec(), "application/json")
@@ -160,6 +193,15 @@ func run(ctx *cli.Context) error {
 		return fmt.Errorf("failed to get genesis block: %w", err)
 	}
 
+	minRelayFeePerByte := big.NewInt(0)
+	if conf.Global.MemPoolMinRelayFeePerByte != "" {
+		fee, ok := big.NewInt(0).SetString(conf.Global.MemPoolMinRelayFeePerByte, 10)
+		if !ok {
+			return errors.New("failed to parse mempool min relay fee from config")
+		}
+		minRelayFeePerByte = fee
+	}
+
 	// super light node dependencies setup
 	if conf.Global.SuperLightNode {
 		bchain, err = blockchain.New(globalDB, &search.Search{}, genesisblockValid.Hash)
@@ -167,7 +209,7 @@ func run(ctx *cli.Context) error {
 			return fmt.Errorf("failed to setup super light blockchain: %w", err)
 		}
 
-		ffgNode, err = node.New(conf, host, kademliaDHT, routingDiscovery, gossip, &search.Search{}, &storage.Storage{}, bchain, &dataquery.Protocol{}, &blockdownloader.Protocol{})
+		ffgNode, err = node.New(conf, host, kademliaDHT, routingDiscovery, gossip, &search.Search{}, &storage.Storage{}, bchain, &dataquery.Protocol{}, &blockdownloader.Protocol{}, &compactblock.Protocol{}, banList, addressBook)
 		if err != nil {
 			return fmt.Errorf("failed to setup super light node node: %w", err)
 		}
@@ -195,6 +237,27 @@ func run(ctx *cli.Context) error {
 			return fmt.Errorf("failed to setup blockchain: %w", err)
 		}
 
+		err = bchain.SetPruning(conf.Global.PruneBlocks)
+		if err != nil {
+			return fmt.Errorf("failed to setup pruning: %w", err)
+		}
+
+		err = bchain.SetMemPoolConfig(mempool.Config{
+			MaxTransactions:           conf.Global.MemPoolMaxTransactions,
+			MaxTransactionsPerAddress: conf.Global.MemPoolMaxTransactionsPerAddress,
+			TTL:                       conf.Global.MemPoolTransactionTTL,
+			PriceBumpPercent:          conf.Global.MemPoolPriceBumpPercent,
+			MinRelayFeePerByte:        minRelayFeePerByte,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to setup mempool: %w", err)
+		}
+
+		// the verifier set of each block height is tracked by the blockchain, stored blocks are verified against it
+		block.SetVerifierSetProvider(bchain)
+		// the verifiers seal blocks in turns
+		block.SetSchedule(block.DefaultSchedule)
+
 		log.Info("verifying local blockchain")
 		start := time.Now()
 		err = bchain.InitOrLoad(conf.Global.VerifyBlocks)
@@ -209,38 +272,76 @@ func run(ctx *cli.Context) error {
 			return fmt.Errorf("failed to setup block downloader protocol: %w", err)
 		}
 
-		ffgNode, err = node.New(conf, host, kademliaDHT, routingDiscovery, gossip, searchEngine, storageEngine, bchain, dataQueryProtocol, blockDownloaderProtocol)
+		compactBlockProtocol, err := compactblock.New(host)
+		if err != nil {
+			return fmt.Errorf("failed to setup compact block protocol: %w", err)
+		}
+
+		snapshotStore, err := snapshot.NewStore(filepath.Join(conf.Global.DataDir, "snapshots"))
+		if err != nil {
+			return fmt.Errorf("failed to setup snapshot store: %w", err)
+		}
+
+		snapshotProtocol, err := snapshot.New(bchain, host, snapshotStore)
+		if err != nil {
+			return fmt.Errorf("failed to setup snapshot protocol: %w", err)
+		}
+
+		ffgNode, err = node.New(conf, host, kademliaDHT, routingDiscovery, gossip, searchEngine, storageEngine, bchain, dataQueryProtocol, blockDownloaderProtocol, compactBlockProtocol, banList, addressBook)
 		if err != nil {
 			return fmt.Errorf("failed to setup full node: %w", err)
 		}
 
 		// validator node
 		if conf.Global.Validator && !conf.Global.SuperLightNode {
-			keyData, err := os.ReadFile(conf.Global.ValidatorKeypath)
+			validatorSigner, err := newValidatorSigner(conf)
 			if err != nil {
-				return fmt.Errorf("failed to read validator key file: %w", err)
+				return fmt.Errorf("failed to setup validator signer: %w", err)
 			}
 
-			key, err := keystore.UnmarshalKey(keyData, conf.Global.ValidatorPass)
+			blockValidator, err := validator.New(ffgNode, bchain, validatorSigner)
 			if err != nil {
-				return fmt.Errorf("failed to restore validator private key file: %w", err)
+				return fmt.Errorf("failed to setup validator: %w", err)
 			}
 
-			blockValidator, err := validator.New(ffgNode, bchain, key.PrivateKey)
+			err = blockValidator.SetBuilderConfig(validator.BuilderConfig{
+				MaxBlockSize:    conf.Global.ValidatorMaxBlockSize,
+				MaxTransactions: conf.Global.ValidatorMaxBlockTransactions,
+			})
 			if err != nil {
-				return fmt.Errorf("failed to setup validator: %w", err)
+				return fmt.Errorf("failed to setup validator block limits: %w", err)
 			}
 
 			go func(validator *validator.Validator) {
 				for {
-					tickDuration := blockValidatorIntervalSeconds * time.Second
-					<-time.After(tickDuration)
-					sealedBlock, err := validator.SealBlock(time.Now().Unix())
+					<-time.After(sealingCheckIntervalSeconds * time.Second)
+					// wait for the turn of this verifier
+					sealingTime, err := validator.NextSealingTime()
+					if err != nil {
+						log.Errorf("failed to get sealing time: %v", err)
+						continue
+					}
+					now := time.Now().Unix()
+					if now < sealingTime {
+						continue
+					}
+
+					sealedBlock, err := validator.SealBlock(now)
 					if err != nil {
 						log.Errorf("sealing block failed: %v", err)
 						continue
 					}
-					log.Infof("block %d sealed from verifier %s", sealedBlock.Number, key.Address)
+					log.Infof("block %d sealed from verifier %s", sealedBlock.Number, sealedBlock.Transactions[0].From)
+					if conf.Global.SnapshotInterval > 0 && sealedBlock.Number%conf.Global.SnapshotInterval == 0 {
+						go func() {
+							manifest, err := snapshotProtocol.CreateSnapshot(validatorSigner)
+							if err != nil {
+								log.Errorf("failed to create snapshot: %v", err)
+								return
+							}
+							log.Infof("created snapshot at height %d with %d chunks", manifest.Height, len(manifest.ChunkHashes))
+						}()
+					}
 					// broadcast
 					go func() {
 						log.Infof("broadcasting block %d to %d peers", sealedBlock.Number, ffgNode.Peers().Len()-1)
@@ -250,12 +351,42 @@ func run(ctx *cli.Context) error {
 					}()
 				}
 			}(blockValidator)
+
+			// attest the latest checkpoint until it's final, so peers which missed the attestation receive it again.
+			go func(validator *validator.Validator) {
+				for {
+					<-time.After(checkpointAttestationIntervalSeconds * time.Second)
+					attestation, err := validator.AttestCheckpoint()
+					if err != nil {
+						log.Errorf("failed to attest checkpoint: %v", err)
+						continue
+					}
+					if attestation == nil {
+						continue
+					}
+
+					if err := validator.BroadcastCheckpointAttestation(ctx.Context, attestation); err != nil {
+						log.Errorf("failed to publish checkpoint attestation to the network: %v", err)
+					}
+				}
+			}(blockValidator)
 		}
 
 		// periodically sync
 		go func() {
+			fastSync := conf.Global.FastSync && bchain.GetHeight() == 0
 			for {
 				<-time.After(syncIntervalSeconds * time.Second)
+				if fastSync {
+					height, err := snapshotProtocol.FastSync(ctx.Context, ffgNode.Peers())
+					if err != nil {
+						log.Warnf("fast sync failed, syncing all blocks: %v", err)
+					} else {
+						log.Infof("imported snapshot with blockchain height at %d", height)
+					}
+					fastSync = false
+				}
+
 				if time.Now().Unix()-bchain.GetLastBlockUpdatedAt() >= triggerSyncSinceLastUpdateSeconds {
 					err := ffgNode.Sync(ctx.Context)
 					if err != nil {
@@ -268,6 +399,28 @@ func run(ctx *cli.Context) error {
 		}()
 	}
 
+	// reconnect to the best peers known from the previous runs
+	knownPeers, err := ffgNode.ConnectToKnownPeers(ctx.Context, maxKnownPeersOnStartup)
+	if err != nil {
+		log.Warnf("failed to connect to known peers: %v", err)
+	} else {
+		log.Infof("connected to %d known peers", knownPeers)
+	}
+
+	// periodically save the connected peers and remove the dead ones
+	go func() {
+		for {
+			<-time.After(saveAddressBookIntervalSeconds * time.Second)
+			if err := ffgNode.SaveConnectedPeers(); err != nil {
+				log.Warnf("failed to save connected peers: %v", err)
+			}
+
+			if _, err := addressBook.Prune(); err != nil {
+				log.Warnf("failed to prune address book: %v", err)
+			}
+		}
+	}()
+
 	// advertise
 	ffgNode.Advertise(ctx.Context, "ffgnet")
 	err = ffgNode.DiscoverPeers(ctx.Context, "ffgnet")
@@ -275,17 +428,15 @@ func run(ctx *cli.Context) error {
 		log.Warnf("discovering peers failed: %v", err)
 	}
 	// listen for pubsub messages
-	err = ffgNode.JoinPubSubNetwork(ctx.Context, "ffgnet_pubsub")
+	err = ffgNode.JoinPubSubNetwork(ctx.Context, gossipTopics)
 	if err != nil {
 		return fmt.Errorf("failed to listen for handling incoming pub sub messages: %w", err)
 	}
 
-	// if full node, then hanlde incoming block, transactions, and data queries
-	if !conf.Global.SuperLightNode {
-		err = ffgNode.HandleIncomingMessages(ctx.Context, "ffgnet_pubsub")
-		if err != nil {
-			return fmt.Errorf("failed to start handling incoming pub sub messages: %w", err)
-		}
+	// subscribe to the topics required by the node's mode, super light nodes don't subscribe to any
+	err = ffgNode.HandleIncomingMessages(ctx.Context)
+	if err != nil {
+		return fmt.Errorf("failed to start handling incoming pub sub messages: %w", err)
 	}
 
 	// bootstrap
@@ -339,7 +490,7 @@ func run(ctx *cli.Context) error {
 	}
 
 	if contains(conf.RPC.EnabledServices, internalrpc.TransactionServiceNamespace) {
-		transactionAPI, err := internalrpc.NewTransactionAPI(keystore, ffgNode, bchain, conf.Global.SuperLightNode)
+		transactionAPI, err := internalrpc.NewTransactionAPI(keystore, ffgNode, bchain, conf.Global.SuperLightNode, minRelayFeePerByte)
 		if err != nil {
 			return fmt.Errorf("failed to setup transaction rpc api: %w", err)
 		}
@@ -349,6 +500,17 @@ func run(ctx *cli.Context) error {
 		}
 	}
 
+	if contains(conf.RPC.EnabledServices, internalrpc.AdminServiceNamespace) {
+		adminAPI, err := internalrpc.NewAdminAPI(keystore, ffgNode)
+		if err != nil {
+			return fmt.Errorf("failed to setup admin rpc api: %w", err)
+		}
+		err = s.RegisterService(adminAPI, internalrpc.AdminServiceNamespace)
+		if err != nil {
+			return fmt.Errorf("failed to register admin rpc api service: %w", err)
+		}
+	}
+
 	if contains(conf.RPC.EnabledServices, internalrpc.ChannelServiceNamespace) {
 		channelAPI, err := internalrpc.NewChannelAPI(bchain, searchEngine)
 		if err != nil {
@@ -452,6 +614,29 @@ func run(ctx *cli.Context) error {
 	return server.ListenAndServe()
 }
 
+// newValidatorSigner creates the signer of the validator, which is either a remote signer or the key of the validator keypath.
+func newValidatorSigner(conf *config.Config) (signer.Signer, error) {
+	if conf.Global.ValidatorSignerSocket != "" {
+		secret, err := signer.ReadSecret(conf.Global.ValidatorSignerSecretPath)
+		if err != nil {
+			return nil, err
+		}
+		return signer.NewRemoteSigner(conf.Global.ValidatorSignerSocket, secret)
+	}
+
+	keyData, err := os.ReadFile(conf.Global.ValidatorKeypath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read validator key file: %w", err)
+	}
+
+	key, err := keystore.UnmarshalKey(keyData, conf.Global.ValidatorPass)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore validator private key file: %w", err)
+	}
+
+	return signer.NewLocalSigner(key.PrivateKey, filepath.Join(conf.Global.DataDir, signer.DefaultStateFileName))
+}
+
 // if * it means all services are allowed, otherwise a list of services will be scanned
 func contains(allowedServices []string, service string) bool {
 	for _, s := range allowedServices {
@@ -2,6 +2,7 @@ package config
 
 import (
 	"strings"
+	"time"
 
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/urfave/cli/v2"
@@ -38,10 +39,23 @@ type global struct {
 	SuperLightNode                          bool
 	Debug                                   bool
 	VerifyBlocks                            bool
+	FastSync                                bool
+	SnapshotInterval                        uint64
+	PruneBlocks                             uint64
+	MemPoolMaxTransactions                  int
+	MemPoolMaxTransactionsPerAddress        int
+	MemPoolTransactionTTL                   time.Duration
+	MemPoolPriceBumpPercent                 uint64
+	MemPoolMinRelayFeePerByte               string
+	ValidatorMaxBlockSize                   int
+	ValidatorMaxBlockTransactions           int
+	ValidatorSignerSocket                   string
+	ValidatorSignerSecretPath               string
 }
 
 type p2p struct {
 	GossipMaxMessageSize int
+	GossipLegacyTopic    bool
 	MinPeers             int
 	MaxPeers             int
 	ListenPort           int
@@ -82,6 +96,13 @@ func New(ctx *cli.Context) *Config {
 			SearchEngineResultCount:                 100,
 			StorageFileMerkleTreeTotalSegments:      1024,
 			StorageFileSegmentsEncryptionPercentage: 5,
+			SnapshotInterval:                        1000,
+			MemPoolMaxTransactions:                  10000,
+			MemPoolMaxTransactionsPerAddress:        64,
+			MemPoolTransactionTTL:                   3 * time.Hour,
+			MemPoolPriceBumpPercent:                 10,
+			ValidatorMaxBlockSize:                   2 * 1024 * 1024,
+			ValidatorMaxBlockTransactions:           5000,
 		},
 		RPC: rpc{
 			Whitelist:       []string{},
@@ -105,6 +126,7 @@ func New(ctx *cli.Context) *Config {
 		},
 		P2P: p2p{
 			GossipMaxMessageSize: 10 * pubsub.DefaultMaxMessageSize,
+			GossipLegacyTopic:    true,
 			MinPeers:             100,
 			MaxPeers:             400,
 			ListenPort:           10209,
@@ -204,6 +226,54 @@ func (conf *Config) applyFlags(ctx *cli.Context) {
 		conf.Global.VerifyBlocks = ctx.Bool(VerifyBlocks.Name)
 	}
 
+	if ctx.IsSet(FastSync.Name) {
+		conf.Global.FastSync = ctx.Bool(FastSync.Name)
+	}
+
+	if ctx.IsSet(SnapshotInterval.Name) {
+		conf.Global.SnapshotInterval = ctx.Uint64(SnapshotInterval.Name)
+	}
+
+	if ctx.IsSet(PruneBlocks.Name) {
+		conf.Global.PruneBlocks = ctx.Uint64(PruneBlocks.Name)
+	}
+
+	if ctx.IsSet(MemPoolMaxTransactions.Name) {
+		conf.Global.MemPoolMaxTransactions = ctx.Int(MemPoolMaxTransactions.Name)
+	}
+
+	if ctx.IsSet(MemPoolMaxTransactionsPerAddress.Name) {
+		conf.Global.MemPoolMaxTransactionsPerAddress = ctx.Int(MemPoolMaxTransactionsPerAddress.Name)
+	}
+
+	if ctx.IsSet(MemPoolTransactionTTL.Name) {
+		conf.Global.MemPoolTransactionTTL = ctx.Duration(MemPoolTransactionTTL.Name)
+	}
+
+	if ctx.IsSet(MemPoolPriceBumpPercent.Name) {
+		conf.Global.MemPoolPriceBumpPercent = ctx.Uint64(MemPoolPriceBumpPercent.Name)
+	}
+
+	if ctx.IsSet(MemPoolMinRelayFeePerByte.Name) {
+		conf.Global.MemPoolMinRelayFeePerByte = ctx.String(MemPoolMinRelayFeePerByte.Name)
+	}
+
+	if ctx.IsSet(ValidatorMaxBlockSize.Name) {
+		conf.Global.ValidatorMaxBlockSize = ctx.Int(ValidatorMaxBlockSize.Name)
+	}
+
+	if ctx.IsSet(ValidatorMaxBlockTransactions.Name) {
+		conf.Global.ValidatorMaxBlockTransactions = ctx.Int(ValidatorMaxBlockTransactions.Name)
+	}
+
+	if ctx.IsSet(ValidatorSignerSocket.Name) {
+		conf.Global.ValidatorSignerSocket = ctx.String(ValidatorSignerSocket.Name)
+	}
+
+	if ctx.IsSet(ValidatorSignerSecretPath.Name) {
+		conf.Global.ValidatorSignerSecretPath = ctx.String(ValidatorSignerSecretPath.Name)
+	}
+
 	if ctx.IsSet(RPCServicesFlag.Name) {
 		conf.RPC.EnabledServices = strings.Split(ctx.String(RPCServicesFlag.Name), ",")
 	}
@@ -260,6 +330,10 @@ func (conf *Config) applyFlags(ctx *cli.Context) {
 		conf.P2P.GossipMaxMessageSize = ctx.Int(P2PMaxGossipSize.Name)
 	}
 
+	if ctx.IsSet(P2PGossipLegacyTopicFlag.Name) {
+		conf.P2P.GossipLegacyTopic = ctx.Bool(P2PGossipLegacyTopicFlag.Name)
+	}
+
 	if ctx.IsSet(MaxPeersFlag.Name) {
 		conf.P2P.MaxPeers = ctx.Int(MaxPeersFlag.Name)
 	}
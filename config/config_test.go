@@ -3,6 +3,7 @@ package config
 import (
 	"flag"
 	"testing"
+	"time"
 
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/stretchr/testify/assert"
@@ -19,6 +20,13 @@ func TestNew(t *testing.T) {
 			SearchEngineResultCount:                 100,
 			StorageFileMerkleTreeTotalSegments:      1024,
 			StorageFileSegmentsEncryptionPercentage: 5,
+			SnapshotInterval:                        1000,
+			MemPoolMaxTransactions:                  10000,
+			MemPoolMaxTransactionsPerAddress:        64,
+			MemPoolTransactionTTL:                   3 * time.Hour,
+			MemPoolPriceBumpPercent:                 10,
+			ValidatorMaxBlockSize:                   2 * 1024 * 1024,
+			ValidatorMaxBlockTransactions:           5000,
 		},
 		RPC: rpc{
 			Whitelist:       []string{},
@@ -42,6 +50,7 @@ func TestNew(t *testing.T) {
 		},
 		P2P: p2p{
 			GossipMaxMessageSize: 10 * pubsub.DefaultMaxMessageSize,
+			GossipLegacyTopic:    true,
 			MinPeers:             100,
 			MaxPeers:             400,
 			ListenPort:           10209,
@@ -2,6 +2,7 @@ package config
 
 import (
 	"path/filepath"
+	"time"
 
 	"github.com/filefilego/filefilego/common"
 	"github.com/urfave/cli/v2"
@@ -126,6 +127,73 @@ var (
 		Usage: "Verifies all downloaded blocks",
 	}
 
+	FastSync = cli.BoolFlag{
+		Name:  "fast_sync",
+		Usage: "Imports a verifier signed state snapshot from peers before syncing the remaining blocks",
+	}
+
+	SnapshotInterval = cli.Uint64Flag{
+		Name:  "snapshot_interval",
+		Usage: "Number of blocks between the state snapshots created by a validator",
+		Value: 1000,
+	}
+
+	PruneBlocks = cli.Uint64Flag{
+		Name:  "prune_blocks",
+		Usage: "Keeps only the given number of latest blocks and removes the older blocks, 0 keeps all the blocks",
+	}
+
+	MemPoolMaxTransactions = cli.IntFlag{
+		Name:  "mempool_max_transactions",
+		Usage: "Maximum number of transactions in the mempool",
+		Value: 10000,
+	}
+
+	MemPoolMaxTransactionsPerAddress = cli.IntFlag{
+		Name:  "mempool_max_transactions_per_address",
+		Usage: "Maximum number of transactions of an address in the mempool",
+		Value: 64,
+	}
+
+	MemPoolTransactionTTL = cli.DurationFlag{
+		Name:  "mempool_transaction_ttl",
+		Usage: "Duration a transaction is kept in the mempool before it expires",
+		Value: 3 * time.Hour,
+	}
+
+	MemPoolPriceBumpPercent = cli.Uint64Flag{
+		Name:  "mempool_price_bump",
+		Usage: "Minimum fee increase in percent to replace a transaction of the mempool with the same nounce",
+		Value: 10,
+	}
+
+	MemPoolMinRelayFeePerByte = cli.StringFlag{
+		Name:  "mempool_min_relay_fee",
+		Usage: "Minimum transaction fees per Byte accepted in the mempool and relayed to the network",
+	}
+
+	ValidatorMaxBlockSize = cli.IntFlag{
+		Name:  "validator_max_block_size",
+		Usage: "Maximum size in bytes of the transactions of a sealed block",
+		Value: 2 * 1024 * 1024,
+	}
+
+	ValidatorMaxBlockTransactions = cli.IntFlag{
+		Name:  "validator_max_block_transactions",
+		Usage: "Maximum number of transactions of a sealed block",
+		Value: 5000,
+	}
+
+	ValidatorSignerSocket = cli.StringFlag{
+		Name:  "validator_signer_socket",
+		Usage: "Unix socket of a remote signer which holds the key for sealing blocks instead of the validator keypath",
+	}
+
+	ValidatorSignerSecretPath = cli.StringFlag{
+		Name:  "validator_signer_secret_path",
+		Usage: "Path to the file with the secret shared with the remote signer",
+	}
+
 	RPCWhitelistFlag = cli.StringFlag{
 		Name:  "rpc_whitelist",
 		Usage: "Allow IP addresses to access the RPC servers",
@@ -195,6 +263,12 @@ var (
 		Usage: "Maximum gossip size",
 	}
 
+	P2PGossipLegacyTopicFlag = cli.BoolFlag{
+		Name:  "gossip_legacy_topic",
+		Usage: "Publish and receive gossip messages on the legacy single topic during the migration to per message type topics",
+		Value: true,
+	}
+
 	MaxPeersFlag = cli.IntFlag{
 		Name:  "max_peers",
 		Usage: "Maximum number of peers to connect",
@@ -255,6 +329,18 @@ var AppFlags = []cli.Flag{
 	&SuperLightNode,
 	&DebugMode,
 	&VerifyBlocks,
+	&FastSync,
+	&SnapshotInterval,
+	&PruneBlocks,
+	&MemPoolMaxTransactions,
+	&MemPoolMaxTransactionsPerAddress,
+	&MemPoolTransactionTTL,
+	&MemPoolPriceBumpPercent,
+	&MemPoolMinRelayFeePerByte,
+	&ValidatorMaxBlockSize,
+	&ValidatorMaxBlockTransactions,
+	&ValidatorSignerSocket,
+	&ValidatorSignerSecretPath,
 
 	&RPCServicesFlag,
 	&RPCWhitelistFlag,
@@ -270,6 +356,7 @@ var AppFlags = []cli.Flag{
 	&RPCWSCrossOriginFlag,
 
 	&P2PMaxGossipSize,
+	&P2PGossipLegacyTopicFlag,
 	&MaxPeersFlag,
 	&P2PListenPortFlag,
 	&P2PListenAddrFlag,
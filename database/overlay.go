@@ -0,0 +1,327 @@
+package database
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// stagedValue is a value which is not yet written to the underlying database.
+type stagedValue struct {
+	value   []byte
+	deleted bool
+}
+
+// stagedChange is the previous staged value of a key which is used to revert to a checkpoint.
+type stagedChange struct {
+	key     string
+	prev    stagedValue
+	existed bool
+}
+
+// Overlay stages writes in memory on top of a database so they can be committed atomically.
+// Reads see the staged writes. When no staging is in progress, writes go directly to the underlying database.
+type Overlay struct {
+	db      Database
+	mu      sync.RWMutex
+	staging bool
+	staged  map[string]stagedValue
+	changes []stagedChange
+}
+
+// NewOverlay creates a new overlay on top of a database.
+func NewOverlay(db Database) (*Overlay, error) {
+	if db == nil {
+		return nil, errors.New("db is nil")
+	}
+
+	return &Overlay{
+		db:     db,
+		staged: make(map[string]stagedValue),
+	}, nil
+}
+
+// Begin starts staging the writes in memory.
+func (o *Overlay) Begin() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.staging {
+		return errors.New("staging is already in progress")
+	}
+
+	o.staging = true
+	o.staged = make(map[string]stagedValue)
+	o.changes = nil
+	return nil
+}
+
+// Discard drops the staged writes and stops staging.
+func (o *Overlay) Discard() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.staging = false
+	o.staged = make(map[string]stagedValue)
+	o.changes = nil
+}
+
+// Commit writes the staged writes together with the given batch in a single batch and stops staging.
+// The staged writes are applied after the batch.
+func (o *Overlay) Commit(batch *leveldb.Batch) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if !o.staging {
+		return errors.New("staging is not in progress")
+	}
+
+	if batch == nil {
+		batch = new(leveldb.Batch)
+	}
+
+	for _, key := range o.sortedKeys() {
+		v := o.staged[key]
+		if v.deleted {
+			batch.Delete([]byte(key))
+		} else {
+			batch.Put([]byte(key), v.value)
+		}
+	}
+
+	if err := o.db.Write(batch, nil); err != nil {
+		return fmt.Errorf("failed to write staged batch: %w", err)
+	}
+
+	o.staging = false
+	o.staged = make(map[string]stagedValue)
+	o.changes = nil
+	return nil
+}
+
+// Checkpoint returns a checkpoint of the staged writes which can be restored with RevertToCheckpoint.
+func (o *Overlay) Checkpoint() int {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	return len(o.changes)
+}
+
+// RevertToCheckpoint drops the staged writes which were made after the checkpoint.
+func (o *Overlay) RevertToCheckpoint(checkpoint int) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if !o.staging {
+		return errors.New("staging is not in progress")
+	}
+
+	if checkpoint < 0 || checkpoint > len(o.changes) {
+		return fmt.Errorf("invalid checkpoint %d", checkpoint)
+	}
+
+	for i := len(o.changes) - 1; i >= checkpoint; i-- {
+		c := o.changes[i]
+		if c.existed {
+			o.staged[c.key] = c.prev
+		} else {
+			delete(o.staged, c.key)
+		}
+	}
+	o.changes = o.changes[:checkpoint]
+	return nil
+}
+
+// StagedKeys returns the keys which were written since staging started in ascending order.
+func (o *Overlay) StagedKeys() [][]byte {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	keys := o.sortedKeys()
+	result := make([][]byte, len(keys))
+	for i, k := range keys {
+		result[i] = []byte(k)
+	}
+	return result
+}
+
+// GetCommitted gets a record from the underlying database ignoring the staged writes.
+func (o *Overlay) GetCommitted(key []byte) ([]byte, error) {
+	return o.db.Get(key)
+}
+
+// Put a record into the db.
+func (o *Overlay) Put(key, value []byte) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if !o.staging {
+		return o.db.Put(key, value)
+	}
+
+	o.stage(key, value, false)
+	return nil
+}
+
+// Get a record based on key.
+func (o *Overlay) Get(key []byte) ([]byte, error) {
+	o.mu.RLock()
+	v, ok := o.staged[string(key)]
+	o.mu.RUnlock()
+
+	if !ok {
+		return o.db.Get(key)
+	}
+
+	if v.deleted {
+		return nil, fmt.Errorf("failed to get value: %w", leveldb.ErrNotFound)
+	}
+
+	data := make([]byte, len(v.value))
+	copy(data, v.value)
+	return data, nil
+}
+
+// Close the database engine.
+func (o *Overlay) Close() error {
+	return o.db.Close()
+}
+
+// Write batch write.
+func (o *Overlay) Write(batch *leveldb.Batch, wo *opt.WriteOptions) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if !o.staging {
+		return o.db.Write(batch, wo)
+	}
+
+	return batch.Replay(&overlayBatchReplayer{overlay: o})
+}
+
+// NewIterator creates a new database iterator which includes the staged writes.
+func (o *Overlay) NewIterator(slice *util.Range, ro *opt.ReadOptions) iterator.Iterator {
+	o.mu.RLock()
+	staged := make(map[string]stagedValue)
+	for k, v := range o.staged {
+		if inRange(slice, []byte(k)) {
+			staged[k] = v
+		}
+	}
+	o.mu.RUnlock()
+
+	if len(staged) == 0 {
+		return o.db.NewIterator(slice, ro)
+	}
+
+	merged := make(map[string][]byte)
+	iter := o.db.NewIterator(slice, ro)
+	for iter.Next() {
+		value := make([]byte, len(iter.Value()))
+		copy(value, iter.Value())
+		merged[string(iter.Key())] = value
+	}
+	iter.Release()
+	if err := iter.Error(); err != nil {
+		return iterator.NewEmptyIterator(fmt.Errorf("failed to iterate database: %w", err))
+	}
+
+	for k, v := range staged {
+		if v.deleted {
+			delete(merged, k)
+			continue
+		}
+		value := make([]byte, len(v.value))
+		copy(value, v.value)
+		merged[k] = value
+	}
+
+	items := make(keyValues, 0, len(merged))
+	for k, v := range merged {
+		items = append(items, keyValue{key: []byte(k), value: v})
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return bytes.Compare(items[i].key, items[j].key) < 0
+	})
+
+	return iterator.NewArrayIterator(items)
+}
+
+func (o *Overlay) stage(key, value []byte, deleted bool) {
+	prev, existed := o.staged[string(key)]
+	o.changes = append(o.changes, stagedChange{key: string(key), prev: prev, existed: existed})
+
+	v := stagedValue{deleted: deleted}
+	if !deleted {
+		v.value = make([]byte, len(value))
+		copy(v.value, value)
+	}
+	o.staged[string(key)] = v
+}
+
+func (o *Overlay) sortedKeys() []string {
+	keys := make([]string, 0, len(o.staged))
+	for k := range o.staged {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// overlayBatchReplayer stages the records of a batch.
+type overlayBatchReplayer struct {
+	overlay *Overlay
+}
+
+func (r *overlayBatchReplayer) Put(key, value []byte) {
+	r.overlay.stage(key, value, false)
+}
+
+func (r *overlayBatchReplayer) Delete(key []byte) {
+	r.overlay.stage(key, nil, true)
+}
+
+func inRange(slice *util.Range, key []byte) bool {
+	if slice == nil {
+		return true
+	}
+
+	if slice.Start != nil && bytes.Compare(key, slice.Start) < 0 {
+		return false
+	}
+
+	if slice.Limit != nil && bytes.Compare(key, slice.Limit) >= 0 {
+		return false
+	}
+
+	return true
+}
+
+type keyValue struct {
+	key   []byte
+	value []byte
+}
+
+// keyValues is a sorted list of records which implements iterator.Array.
+type keyValues []keyValue
+
+func (kv keyValues) Len() int {
+	return len(kv)
+}
+
+func (kv keyValues) Search(key []byte) int {
+	return sort.Search(len(kv), func(i int) bool {
+		return bytes.Compare(kv[i].key, key) >= 0
+	})
+}
+
+func (kv keyValues) Index(i int) ([]byte, []byte) {
+	return kv[i].key, kv[i].value
+}
@@ -0,0 +1,118 @@
+package database
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+func TestNewOverlay(t *testing.T) {
+	overlay, err := NewOverlay(nil)
+	assert.Nil(t, overlay)
+	assert.EqualError(t, err, "db is nil")
+
+	db, err := New(&dbEngineStub{})
+	assert.NoError(t, err)
+	overlay, err = NewOverlay(db)
+	assert.NoError(t, err)
+	assert.NotNil(t, overlay)
+}
+
+func TestOverlay(t *testing.T) {
+	bDB, err := leveldb.OpenFile("overlay.db", nil)
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		bDB.Close()
+		os.RemoveAll("overlay.db")
+	})
+	db, err := New(bDB)
+	assert.NoError(t, err)
+	overlay, err := NewOverlay(db)
+	assert.NoError(t, err)
+
+	// without staging writes go to the database
+	assert.NoError(t, overlay.Put([]byte("a1"), []byte{1}))
+	assert.NoError(t, overlay.Put([]byte("a2"), []byte{2}))
+	data, err := db.Get([]byte("a1"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{1}, data)
+
+	err = overlay.Commit(nil)
+	assert.EqualError(t, err, "staging is not in progress")
+
+	// staged writes are only visible through the overlay
+	assert.NoError(t, overlay.Begin())
+	assert.EqualError(t, overlay.Begin(), "staging is already in progress")
+	assert.NoError(t, overlay.Put([]byte("a3"), []byte{3}))
+	batch := new(leveldb.Batch)
+	batch.Delete([]byte("a1"))
+	batch.Put([]byte("a2"), []byte{22})
+	assert.NoError(t, overlay.Write(batch, nil))
+
+	_, err = overlay.Get([]byte("a1"))
+	assert.ErrorIs(t, err, leveldb.ErrNotFound)
+	data, err = overlay.Get([]byte("a2"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{22}, data)
+	data, err = overlay.GetCommitted([]byte("a2"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{2}, data)
+	_, err = db.Get([]byte("a3"))
+	assert.Error(t, err)
+	assert.Equal(t, [][]byte{[]byte("a1"), []byte("a2"), []byte("a3")}, overlay.StagedKeys())
+
+	iter := overlay.NewIterator(util.BytesPrefix([]byte("a")), nil)
+	keys := make([]string, 0)
+	values := make([][]byte, 0)
+	for iter.Next() {
+		keys = append(keys, string(iter.Key()))
+		values = append(values, iter.Value())
+	}
+	iter.Release()
+	assert.NoError(t, iter.Error())
+	assert.Equal(t, []string{"a2", "a3"}, keys)
+	assert.Equal(t, [][]byte{{22}, {3}}, values)
+
+	// discarded writes are dropped
+	overlay.Discard()
+	data, err = overlay.Get([]byte("a1"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{1}, data)
+	_, err = overlay.Get([]byte("a3"))
+	assert.Error(t, err)
+
+	// writes after a checkpoint are reverted
+	assert.EqualError(t, overlay.RevertToCheckpoint(0), "staging is not in progress")
+	assert.NoError(t, overlay.Begin())
+	assert.NoError(t, overlay.Put([]byte("a1"), []byte{11}))
+	checkpoint := overlay.Checkpoint()
+	assert.NoError(t, overlay.Put([]byte("a1"), []byte{12}))
+	assert.NoError(t, overlay.Put([]byte("a5"), []byte{5}))
+	assert.EqualError(t, overlay.RevertToCheckpoint(10), "invalid checkpoint 10")
+	assert.NoError(t, overlay.RevertToCheckpoint(checkpoint))
+	data, err = overlay.Get([]byte("a1"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{11}, data)
+	_, err = overlay.Get([]byte("a5"))
+	assert.Error(t, err)
+	assert.Equal(t, [][]byte{[]byte("a1")}, overlay.StagedKeys())
+	overlay.Discard()
+
+	// committed writes are written together with the given batch
+	assert.NoError(t, overlay.Begin())
+	assert.NoError(t, overlay.Put([]byte("a4"), []byte{4}))
+	extra := new(leveldb.Batch)
+	extra.Put([]byte("b1"), []byte{5})
+	assert.NoError(t, overlay.Commit(extra))
+	assert.Len(t, overlay.StagedKeys(), 0)
+	data, err = db.Get([]byte("a4"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{4}, data)
+	data, err = db.Get([]byte("b1"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{5}, data)
+	assert.NoError(t, overlay.Close())
+}
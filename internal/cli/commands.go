@@ -1,10 +1,13 @@
 package cli
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/filefilego/filefilego/common"
 	"github.com/filefilego/filefilego/common/hexutil"
@@ -12,7 +15,9 @@ import (
 	"github.com/filefilego/filefilego/crypto"
 	"github.com/filefilego/filefilego/database"
 	"github.com/filefilego/filefilego/keystore"
+	"github.com/filefilego/filefilego/rpc"
 	"github.com/filefilego/filefilego/storage"
+	"github.com/filefilego/filefilego/transaction"
 	log "github.com/sirupsen/logrus"
 	"github.com/syndtr/goleveldb/leveldb"
 	"github.com/urfave/cli/v2"
@@ -80,6 +85,22 @@ var (
 				Description: `
 				Lists all available addresses`,
 			},
+			{
+				Name:   "create_multisig",
+				Usage:  "create_multisig <threshold> <public_key1,public_key2>",
+				Action: CreateMultiSigAddress,
+				Flags:  []cli.Flag{},
+				Description: `
+				Creates a multi-signature address which requires threshold of the public keys to sign`,
+			},
+			{
+				Name:   "sign_multisig",
+				Usage:  "sign_multisig <keypath> <passphrase> <raw_transaction>",
+				Action: SignMultiSigTransaction,
+				Flags:  []cli.Flag{},
+				Description: `
+				Signs a multi-signature transaction offline and prints the partial signature`,
+			},
 		},
 	}
 
@@ -295,6 +316,71 @@ func GetAddressInfo(ctx *cli.Context) error {
 	return nil
 }
 
+// CreateMultiSigAddress prints the address and public key of a multi-signature account.
+func CreateMultiSigAddress(ctx *cli.Context) error {
+	threshold, err := strconv.Atoi(ctx.Args().Get(0))
+	if err != nil {
+		return fmt.Errorf("failed to parse threshold: %w", err)
+	}
+
+	publicKeys := make([][]byte, 0)
+	for _, v := range strings.Split(ctx.Args().Get(1), ",") {
+		publicKey, err := hexutil.Decode(strings.TrimSpace(v))
+		if err != nil {
+			return fmt.Errorf("failed to decode public key: %w", err)
+		}
+		publicKeys = append(publicKeys, publicKey)
+	}
+
+	multiSigPublicKey, err := transaction.NewMultiSigPublicKey(threshold, publicKeys)
+	if err != nil {
+		return fmt.Errorf("failed to create multi-signature public key: %w", err)
+	}
+
+	address, err := crypto.RawPublicToAddress(multiSigPublicKey)
+	if err != nil {
+		return fmt.Errorf("failed to get address: %w", err)
+	}
+
+	log.Infof("Address:\t%s", address)
+	log.Infof("PublicKey:\t%s", hexutil.Encode(multiSigPublicKey))
+
+	return nil
+}
+
+// SignMultiSigTransaction prints the partial signature of a key for a multi-signature transaction.
+func SignMultiSigTransaction(ctx *cli.Context) error {
+	keyPath := ctx.Args().Get(0)
+	passphrase := ctx.Args().Get(1)
+
+	data, err := os.ReadFile(keyPath)
+	if err != nil {
+		return fmt.Errorf("failed to read key path: %w", err)
+	}
+	key, err := keystore.UnmarshalKey(data, passphrase)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal key: %w", err)
+	}
+
+	tx, err := rpc.ParseRawTransaction(ctx.Args().Get(2))
+	if err != nil {
+		return err
+	}
+
+	sig, err := tx.PartialSign(key.PrivateKey)
+	if err != nil {
+		return fmt.Errorf("failed to sign multi-signature transaction: %w", err)
+	}
+
+	sigData, err := json.Marshal(rpc.ToJSONPartialSignature(sig))
+	if err != nil {
+		return fmt.Errorf("failed to marshal partial signature: %w", err)
+	}
+
+	fmt.Println(string(sigData))
+	return nil
+}
+
 // CreateNodeIDKey creates a node key identity file.
 func CreateNodeIDKey(ctx *cli.Context) error {
 	conf := config.New(ctx)
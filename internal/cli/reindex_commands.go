@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/filefilego/filefilego/block"
+	"github.com/filefilego/filefilego/blockchain"
+	"github.com/filefilego/filefilego/config"
+	"github.com/filefilego/filefilego/database"
+	"github.com/filefilego/filefilego/search"
+	log "github.com/sirupsen/logrus"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/urfave/cli/v2"
+)
+
+// reindexProgressInterval is the number of blocks between two progress reports.
+const reindexProgressInterval = 1000
+
+// ReindexCommand rebuilds the derived indexes from the stored blocks.
+var ReindexCommand = &cli.Command{
+	Name:     "reindex",
+	Usage:    "reindex [index...]",
+	Category: "Blockchain",
+	Action:   Reindex,
+	Description: `
+				Rebuilds the derived indexes from the stored blocks while the node is stopped.
+				Available indexes: ` + indexNames() + `. All the indexes are rebuilt if none is given.`,
+}
+
+// Reindex rebuilds the selected indexes and verifies them against the blockchain state.
+func Reindex(ctx *cli.Context) error {
+	conf := config.New(ctx)
+	indexes, err := blockchain.ParseIndexes(ctx.Args().Slice())
+	if err != nil {
+		return fmt.Errorf("failed to parse indexes: %w", err)
+	}
+
+	db, err := leveldb.OpenFile(filepath.Join(conf.Global.DataDir, "blockchain.db"), nil)
+	if err != nil {
+		return fmt.Errorf("failed to open leveldb database file: %w", err)
+	}
+	defer db.Close()
+	globalDB, err := database.New(db)
+	if err != nil {
+		return fmt.Errorf("failed to setup global database: %w", err)
+	}
+
+	// the search index can't be cleared by key prefix, so it's recreated from scratch
+	searchDBPath := filepath.Join(conf.Global.DataDir, "search.db")
+	for _, index := range indexes {
+		if index == blockchain.SearchIndex {
+			if err := os.RemoveAll(searchDBPath); err != nil {
+				return fmt.Errorf("failed to remove search index: %w", err)
+			}
+		}
+	}
+
+	blv, err := search.NewBleveSearch(searchDBPath)
+	if err != nil {
+		return fmt.Errorf("failed to setup bleve search: %w", err)
+	}
+	searchEngine, err := search.New(blv)
+	if err != nil {
+		return fmt.Errorf("failed to setup search engine: %w", err)
+	}
+	defer searchEngine.Close()
+
+	genesisblockValid, err := block.GetGenesisBlock()
+	if err != nil {
+		return fmt.Errorf("failed to get genesis block: %w", err)
+	}
+
+	bchain, err := blockchain.New(globalDB, searchEngine, genesisblockValid.Hash)
+	if err != nil {
+		return fmt.Errorf("failed to setup blockchain: %w", err)
+	}
+
+	err = bchain.SetPruning(conf.Global.PruneBlocks)
+	if err != nil {
+		return fmt.Errorf("failed to setup pruning: %w", err)
+	}
+
+	err = bchain.InitOrLoad(false)
+	if err != nil {
+		return fmt.Errorf("failed to load blockchain: %w", err)
+	}
+
+	log.Infof("reindexing %s up to block %d", joinIndexes(indexes), bchain.GetHeight())
+	result, err := bchain.Reindex(indexes, func(processed, total uint64) {
+		if processed%reindexProgressInterval == 0 || processed == total {
+			log.Infof("reindexed %d/%d blocks", processed, total)
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reindex: %w", err)
+	}
+
+	log.Infof("Blocks:\t%d", result.Blocks)
+	log.Infof("Transactions:\t%d", result.Transactions)
+	log.Infof("Nodes:\t%d", result.Nodes)
+	log.Infof("Channels:\t%d", result.Channels)
+	log.Info("indexes verified")
+	return nil
+}
+
+func indexNames() string {
+	return joinIndexes(blockchain.AllIndexes)
+}
+
+func joinIndexes(indexes []blockchain.Index) string {
+	names := make([]string, len(indexes))
+	for i, index := range indexes {
+		names[i] = string(index)
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/filefilego/filefilego/keystore"
+	"github.com/filefilego/filefilego/signer"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+// SignerCommand runs a signer daemon which keeps the validator key outside of the node process.
+var SignerCommand = &cli.Command{
+	Name:     "signer",
+	Usage:    "signer <keypath>",
+	Category: "Validator",
+	Action:   RunSigner,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:    "passphrase",
+			Usage:   "Passphrase of keyfile",
+			EnvVars: []string{"FFG_VERIFIER_PASSPHRASE"},
+		},
+		&cli.StringFlag{
+			Name:     "socket",
+			Usage:    "Unix socket to listen on for the validator node",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:     "secret_path",
+			Usage:    "Path to the file with the secret shared with the validator node",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:  "state_path",
+			Usage: "Path to the file which keeps the last signed heights",
+			Value: signer.DefaultStateFileName,
+		},
+	},
+	Description: `
+				Serves the validator key to a validator node over a unix socket.
+				The node is started with --validator_signer_socket and --validator_signer_secret_path.
+				The signer refuses to sign two different blocks or checkpoints at the same height.`,
+}
+
+// RunSigner serves the validator key until the process is stopped.
+func RunSigner(ctx *cli.Context) error {
+	keyPath := ctx.Args().Get(0)
+	if keyPath == "" {
+		return errors.New("keypath is required")
+	}
+
+	data, err := os.ReadFile(keyPath)
+	if err != nil {
+		return fmt.Errorf("failed to read key path: %w", err)
+	}
+
+	key, err := keystore.UnmarshalKey(data, ctx.String("passphrase"))
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal key: %w", err)
+	}
+
+	secret, err := signer.ReadSecret(ctx.String("secret_path"))
+	if err != nil {
+		return err
+	}
+
+	localSigner, err := signer.NewLocalSigner(key.PrivateKey, ctx.String("state_path"))
+	if err != nil {
+		return fmt.Errorf("failed to setup signer: %w", err)
+	}
+
+	server, err := signer.NewServer(localSigner, secret)
+	if err != nil {
+		return fmt.Errorf("failed to setup signer server: %w", err)
+	}
+
+	socketPath := ctx.String("socket")
+	if err := os.RemoveAll(socketPath); err != nil {
+		return fmt.Errorf("failed to remove existing socket: %w", err)
+	}
+
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return fmt.Errorf("failed to listen on socket: %w", err)
+	}
+
+	if err := os.Chmod(socketPath, 0o600); err != nil {
+		listener.Close()
+		return fmt.Errorf("failed to set socket permissions: %w", err)
+	}
+
+	go func() {
+		sigs := make(chan os.Signal, 1)
+		signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+		<-sigs
+		listener.Close()
+	}()
+
+	log.Infof("signer for %s listening on %s", key.Address, socketPath)
+	return server.Serve(listener)
+}
@@ -0,0 +1,400 @@
+// Package mempool keeps the transactions which are waiting to be included in a block.
+//
+// The transactions of each sender are queued by nounce. The transactions which continue
+// the nounce of the sender's state are pending and can be included in the next block,
+// while the transactions after a nounce gap are kept in the future queue until the gap is filled.
+package mempool
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/filefilego/filefilego/common/hexutil"
+	"github.com/filefilego/filefilego/transaction"
+)
+
+// Config represents the limits of the mempool.
+type Config struct {
+	// MaxTransactions is the maximum number of transactions in the mempool.
+	MaxTransactions int
+	// MaxTransactionsPerAddress is the maximum number of transactions of a sender.
+	MaxTransactionsPerAddress int
+	// TTL is the duration a transaction is kept in the mempool.
+	TTL time.Duration
+	// PriceBumpPercent is the minimum fee increase required to replace a transaction with the same nounce.
+	PriceBumpPercent uint64
+	// MinRelayFeePerByte is the minimum fees per byte of a transaction, nil or zero accepts any fees.
+	MinRelayFeePerByte *big.Int
+}
+
+// DefaultConfig returns the default mempool limits.
+func DefaultConfig() Config {
+	return Config{
+		MaxTransactions:           10000,
+		MaxTransactionsPerAddress: 64,
+		TTL:                       3 * time.Hour,
+		PriceBumpPercent:          10,
+	}
+}
+
+// Validate checks the limits.
+func (c Config) Validate() error {
+	if c.MaxTransactions <= 0 {
+		return errors.New("max transactions should be greater than zero")
+	}
+
+	if c.MaxTransactionsPerAddress <= 0 {
+		return errors.New("max transactions per address should be greater than zero")
+	}
+
+	if c.TTL <= 0 {
+		return errors.New("ttl should be greater than zero")
+	}
+
+	if c.MinRelayFeePerByte != nil && c.MinRelayFeePerByte.Sign() < 0 {
+		return errors.New("min relay fee per byte should not be negative")
+	}
+	return nil
+}
+
+// ValidateRelayFee checks that the fees of a transaction pay at least the minimum fee for each byte.
+func ValidateRelayFee(tx transaction.Transaction, minFeePerByte *big.Int) error {
+	if minFeePerByte == nil || minFeePerByte.Sign() == 0 {
+		return nil
+	}
+
+	fees, err := hexutil.DecodeBig(tx.TransactionFees)
+	if err != nil {
+		return fmt.Errorf("failed to decode transaction fees: %w", err)
+	}
+
+	size := tx.Size()
+	minFees := new(big.Int).Mul(minFeePerByte, big.NewInt(int64(size)))
+	if fees.Cmp(minFees) < 0 {
+		return fmt.Errorf("transaction fees %s are lower than the minimum relay fee %s for %d bytes", fees.String(), minFees.String(), size)
+	}
+	return nil
+}
+
+// NounceProvider returns the nounce of an address in the current state.
+type NounceProvider func(address string) uint64
+
+// Pool is a bounded mempool with per-sender nounce queues.
+type Pool struct {
+	config  Config
+	nounces NounceProvider
+	now     func() time.Time
+
+	// all holds the transactions by hash.
+	all map[string]*entry
+	// senders holds the transactions of each sender by nounce.
+	senders map[string]map[uint64]*entry
+	mu      sync.RWMutex
+}
+
+type entry struct {
+	tx      transaction.Transaction
+	hash    string
+	nounce  uint64
+	fees    *big.Int
+	size    int
+	addedAt time.Time
+}
+
+// New creates a new mempool.
+func New(config Config, nounces NounceProvider) (*Pool, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	if nounces == nil {
+		return nil, errors.New("nounce provider is nil")
+	}
+
+	return &Pool{
+		config:  config,
+		nounces: nounces,
+		now:     time.Now,
+		all:     make(map[string]*entry),
+		senders: make(map[string]map[uint64]*entry),
+	}, nil
+}
+
+// Add adds a transaction to the mempool.
+// transactions which don't pay the minimum relay fee are rejected.
+// a transaction with the same sender and nounce is replaced only if its fees are increased by the price bump.
+// if the mempool is full, the transaction with the lowest fees per byte at the end of a sender queue is evicted.
+func (p *Pool) Add(tx transaction.Transaction) error {
+	fees, err := hexutil.DecodeBig(tx.TransactionFees)
+	if err != nil {
+		return fmt.Errorf("failed to decode transaction fees: %w", err)
+	}
+
+	if err := ValidateRelayFee(tx, p.config.MinRelayFeePerByte); err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	e := &entry{
+		tx:      tx,
+		hash:    hexutil.Encode(tx.Hash),
+		nounce:  hexutil.DecodeBigFromBytesToUint64(tx.Nounce),
+		fees:    fees,
+		size:    tx.Size(),
+		addedAt: p.now(),
+	}
+
+	queue := p.senders[tx.From]
+	if existing, ok := queue[e.nounce]; ok {
+		if existing.hash == e.hash && existing.fees.Cmp(fees) == 0 {
+			return nil
+		}
+
+		minFees := bumpedFees(existing.fees, p.config.PriceBumpPercent)
+		if fees.Cmp(existing.fees) <= 0 || fees.Cmp(minFees) < 0 {
+			return fmt.Errorf("replacement transaction fees should be at least %d%% higher", p.config.PriceBumpPercent)
+		}
+		p.remove(existing)
+		p.insert(e)
+		return nil
+	}
+
+	if _, ok := p.all[e.hash]; ok {
+		return nil
+	}
+
+	if len(queue) >= p.config.MaxTransactionsPerAddress {
+		return fmt.Errorf("address %s has reached the limit of %d transactions in the mempool", tx.From, p.config.MaxTransactionsPerAddress)
+	}
+
+	if len(p.all) >= p.config.MaxTransactions {
+		evicted := p.evictionCandidate()
+		if evicted == nil || compareFeesPerByte(evicted, e) >= 0 {
+			return errors.New("mempool is full")
+		}
+		p.remove(evicted)
+	}
+
+	p.insert(e)
+	return nil
+}
+
+// Remove removes a transaction from the mempool.
+func (p *Pool) Remove(tx transaction.Transaction) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if e, ok := p.all[hexutil.Encode(tx.Hash)]; ok {
+		p.remove(e)
+	}
+}
+
+// Len returns the number of transactions in the mempool.
+func (p *Pool) Len() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return len(p.all)
+}
+
+// Transactions returns all the transactions of the mempool.
+func (p *Pool) Transactions() []transaction.Transaction {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	txs := make([]transaction.Transaction, 0, len(p.all))
+	for _, e := range p.all {
+		txs = append(txs, e.tx)
+	}
+	return txs
+}
+
+// HighestNounce returns the highest nounce of an address in the mempool.
+func (p *Pool) HighestNounce(address string) uint64 {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	highest := uint64(0)
+	for nounce := range p.senders[address] {
+		if nounce > highest {
+			highest = nounce
+		}
+	}
+	return highest
+}
+
+// Pending returns the executable transactions ordered by fees per byte.
+// the transactions of a sender start at the next nounce of the sender's state and are kept in nounce order.
+func (p *Pool) Pending() []transaction.Transaction {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	queues := make(senderHeap, 0, len(p.senders))
+	for _, entries := range p.pendingEntries() {
+		queues = append(queues, entries)
+	}
+	heap.Init(&queues)
+
+	txs := make([]transaction.Transaction, 0)
+	for queues.Len() > 0 {
+		entries := queues[0]
+		txs = append(txs, entries[0].tx)
+		if len(entries) == 1 {
+			heap.Pop(&queues)
+			continue
+		}
+		queues[0] = entries[1:]
+		heap.Fix(&queues, 0)
+	}
+	return txs
+}
+
+// Future returns the transactions which can't be executed until a nounce gap is filled.
+func (p *Pool) Future() []transaction.Transaction {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	pending := make(map[string]struct{})
+	for _, entries := range p.pendingEntries() {
+		for _, e := range entries {
+			pending[e.hash] = struct{}{}
+		}
+	}
+
+	txs := make([]transaction.Transaction, 0)
+	for _, e := range p.all {
+		if _, ok := pending[e.hash]; !ok {
+			txs = append(txs, e.tx)
+		}
+	}
+	sort.Slice(txs, func(i, j int) bool {
+		return hexutil.DecodeBigFromBytesToUint64(txs[i].Nounce) < hexutil.DecodeBigFromBytesToUint64(txs[j].Nounce)
+	})
+	return txs
+}
+
+// Prune removes the expired transactions and the transactions which nounce is already used by the state.
+// transactions with a validity window which ends before the next block number are expired too.
+func (p *Pool) Prune(nextBlockNumber uint64) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	removed := 0
+	for from, queue := range p.senders {
+		stateNounce := p.nounces(from)
+		for nounce, e := range queue {
+			if nounce <= stateNounce || now.Sub(e.addedAt) > p.config.TTL || e.tx.IsExpired(nextBlockNumber) {
+				p.remove(e)
+				removed++
+			}
+		}
+	}
+	return removed
+}
+
+// pendingEntries returns the executable transactions of each sender in nounce order.
+func (p *Pool) pendingEntries() map[string][]*entry {
+	now := p.now()
+	pending := make(map[string][]*entry)
+	for from, queue := range p.senders {
+		nounce := p.nounces(from) + 1
+		for {
+			e, ok := queue[nounce]
+			if !ok || now.Sub(e.addedAt) > p.config.TTL {
+				break
+			}
+			pending[from] = append(pending[from], e)
+			nounce++
+		}
+	}
+	return pending
+}
+
+// evictionCandidate returns the transaction with the lowest fees per byte among the last transactions of the senders.
+// only the last transaction of a sender is evicted so the queues don't get a nounce gap.
+func (p *Pool) evictionCandidate() *entry {
+	var candidate *entry
+	for _, queue := range p.senders {
+		var last *entry
+		for _, e := range queue {
+			if last == nil || e.nounce > last.nounce {
+				last = e
+			}
+		}
+		if last != nil && (candidate == nil || compareFeesPerByte(last, candidate) < 0) {
+			candidate = last
+		}
+	}
+	return candidate
+}
+
+func (p *Pool) insert(e *entry) {
+	queue, ok := p.senders[e.tx.From]
+	if !ok {
+		queue = make(map[uint64]*entry)
+		p.senders[e.tx.From] = queue
+	}
+	queue[e.nounce] = e
+	p.all[e.hash] = e
+}
+
+func (p *Pool) remove(e *entry) {
+	delete(p.all, e.hash)
+	queue := p.senders[e.tx.From]
+	if existing, ok := queue[e.nounce]; ok && existing.hash == e.hash {
+		delete(queue, e.nounce)
+	}
+	if len(queue) == 0 {
+		delete(p.senders, e.tx.From)
+	}
+}
+
+// bumpedFees returns the fees increased by the given percentage.
+func bumpedFees(fees *big.Int, percent uint64) *big.Int {
+	bumped := new(big.Int).Mul(fees, new(big.Int).SetUint64(100+percent))
+	return bumped.Div(bumped, big.NewInt(100))
+}
+
+// compareFeesPerByte compares the fees per byte of two transactions.
+func compareFeesPerByte(a, b *entry) int {
+	// compare a.fees/a.size with b.fees/b.size without losing precision
+	left := new(big.Int).Mul(a.fees, big.NewInt(int64(b.size)))
+	right := new(big.Int).Mul(b.fees, big.NewInt(int64(a.size)))
+	return left.Cmp(right)
+}
+
+// senderHeap orders the pending queues of the senders by the fees per byte of their next transaction.
+type senderHeap [][]*entry
+
+func (h senderHeap) Len() int { return len(h) }
+
+func (h senderHeap) Less(i, j int) bool {
+	a, b := h[i][0], h[j][0]
+	cmp := compareFeesPerByte(a, b)
+	if cmp == 0 {
+		return a.addedAt.Before(b.addedAt)
+	}
+	return cmp > 0
+}
+
+func (h senderHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *senderHeap) Push(x interface{}) {
+	*h = append(*h, x.([]*entry))
+}
+
+func (h *senderHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	*h = old[:n-1]
+	return item
+}
@@ -0,0 +1,245 @@
+package mempool
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/filefilego/filefilego/common/hexutil"
+	"github.com/filefilego/filefilego/transaction"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	cases := map[string]struct {
+		config  Config
+		nounces NounceProvider
+		expErr  string
+	}{
+		"no max transactions": {
+			config:  Config{MaxTransactionsPerAddress: 1, TTL: time.Second},
+			nounces: stateNounces(nil),
+			expErr:  "max transactions should be greater than zero",
+		},
+		"no max transactions per address": {
+			config:  Config{MaxTransactions: 1, TTL: time.Second},
+			nounces: stateNounces(nil),
+			expErr:  "max transactions per address should be greater than zero",
+		},
+		"no ttl": {
+			config:  Config{MaxTransactions: 1, MaxTransactionsPerAddress: 1},
+			nounces: stateNounces(nil),
+			expErr:  "ttl should be greater than zero",
+		},
+		"negative min relay fee": {
+			config:  Config{MaxTransactions: 1, MaxTransactionsPerAddress: 1, TTL: time.Second, MinRelayFeePerByte: big.NewInt(-1)},
+			nounces: stateNounces(nil),
+			expErr:  "min relay fee per byte should not be negative",
+		},
+		"no nounce provider": {
+			config: DefaultConfig(),
+			expErr: "nounce provider is nil",
+		},
+		"success": {
+			config:  DefaultConfig(),
+			nounces: stateNounces(nil),
+		},
+	}
+
+	for name, tt := range cases {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			pool, err := New(tt.config, tt.nounces)
+			if tt.expErr != "" {
+				assert.Nil(t, pool)
+				assert.EqualError(t, err, tt.expErr)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, pool)
+			}
+		})
+	}
+}
+
+func TestAddAndReplace(t *testing.T) {
+	pool, err := New(DefaultConfig(), stateNounces(nil))
+	assert.NoError(t, err)
+
+	tx := newTransaction("0x01", 1, "0x64", 1)
+	assert.NoError(t, pool.Add(tx))
+	// adding the same transaction again is a no-op
+	assert.NoError(t, pool.Add(tx))
+	assert.Equal(t, 1, pool.Len())
+
+	invalid := newTransaction("0x01", 1, "f", 2)
+	assert.EqualError(t, pool.Add(invalid), "failed to decode transaction fees: hex string without 0x prefix")
+
+	// the fees are increased by less than the price bump
+	underpriced := newTransaction("0x01", 1, "0x6d", 3)
+	assert.EqualError(t, pool.Add(underpriced), "replacement transaction fees should be at least 10% higher")
+
+	replacement := newTransaction("0x01", 1, "0x6e", 4)
+	assert.NoError(t, pool.Add(replacement))
+	txs := pool.Transactions()
+	assert.Len(t, txs, 1)
+	assert.Equal(t, replacement.Hash, txs[0].Hash)
+	assert.Equal(t, uint64(1), pool.HighestNounce("0x01"))
+	assert.Equal(t, uint64(0), pool.HighestNounce("0x02"))
+
+	pool.Remove(replacement)
+	assert.Equal(t, 0, pool.Len())
+}
+
+func TestMinRelayFee(t *testing.T) {
+	config := DefaultConfig()
+	config.MinRelayFeePerByte = big.NewInt(2)
+	pool, err := New(config, stateNounces(nil))
+	assert.NoError(t, err)
+
+	tx := newTransaction("0x01", 1, "0x1", 1)
+	minFees := big.NewInt(int64(2 * tx.Size()))
+	err = pool.Add(tx)
+	assert.EqualError(t, err, fmt.Sprintf("transaction fees 1 are lower than the minimum relay fee %s for %d bytes", minFees.String(), tx.Size()))
+	assert.Equal(t, 0, pool.Len())
+
+	// the fees are part of the size of the transaction
+	tx.TransactionFees = hexutil.EncodeBig(minFees.Mul(minFees, big.NewInt(2)))
+	assert.NoError(t, pool.Add(tx))
+	assert.Equal(t, 1, pool.Len())
+
+	// no minimum relay fee accepts any fees
+	assert.NoError(t, ValidateRelayFee(newTransaction("0x01", 1, "0x0", 1), nil))
+}
+
+func TestLimits(t *testing.T) {
+	pool, err := New(Config{MaxTransactions: 3, MaxTransactionsPerAddress: 2, TTL: time.Hour}, stateNounces(nil))
+	assert.NoError(t, err)
+
+	assert.NoError(t, pool.Add(newTransaction("0x01", 1, "0x5", 1)))
+	assert.NoError(t, pool.Add(newTransaction("0x01", 2, "0x1", 2)))
+	err = pool.Add(newTransaction("0x01", 3, "0x9", 3))
+	assert.EqualError(t, err, "address 0x01 has reached the limit of 2 transactions in the mempool")
+
+	assert.NoError(t, pool.Add(newTransaction("0x02", 1, "0x3", 4)))
+
+	// the pool is full and the new transaction doesn't pay more than the cheapest one
+	err = pool.Add(newTransaction("0x03", 1, "0x1", 5))
+	assert.EqualError(t, err, "mempool is full")
+
+	// the last transaction of 0x01 is the cheapest and gets evicted
+	assert.NoError(t, pool.Add(newTransaction("0x03", 1, "0x2", 6)))
+	assert.Equal(t, 3, pool.Len())
+	assert.Equal(t, uint64(1), pool.HighestNounce("0x01"))
+}
+
+func TestEvictionByFeesPerByte(t *testing.T) {
+	pool, err := New(Config{MaxTransactions: 2, MaxTransactionsPerAddress: 2, TTL: time.Hour}, stateNounces(nil))
+	assert.NoError(t, err)
+
+	// the large transaction pays the highest fees but the lowest fees per byte
+	large := newTransaction("0x01", 1, "0x64", 1)
+	large.Data = make([]byte, 1000)
+	assert.NoError(t, pool.Add(large))
+	small := newTransaction("0x02", 1, "0x32", 2)
+	assert.NoError(t, pool.Add(small))
+
+	// lower fees per byte than both transactions
+	cheap := newTransaction("0x03", 1, "0x1", 3)
+	cheap.Data = make([]byte, 1000)
+	assert.EqualError(t, pool.Add(cheap), "mempool is full")
+
+	// lower fees than the large transaction but higher fees per byte
+	assert.NoError(t, pool.Add(newTransaction("0x03", 1, "0x32", 4)))
+	assert.Equal(t, 2, pool.Len())
+	assert.Equal(t, uint64(0), pool.HighestNounce("0x01"))
+	assert.Equal(t, uint64(1), pool.HighestNounce("0x02"))
+}
+
+func TestPendingAndFuture(t *testing.T) {
+	nounces := map[string]uint64{"0x01": 4}
+	pool, err := New(DefaultConfig(), stateNounces(nounces))
+	assert.NoError(t, err)
+
+	assert.NoError(t, pool.Add(newTransaction("0x01", 6, "0x9", 1)))
+	assert.NoError(t, pool.Add(newTransaction("0x01", 5, "0x1", 2)))
+	// nounce 8 has a gap
+	assert.NoError(t, pool.Add(newTransaction("0x01", 8, "0x9", 3)))
+	assert.NoError(t, pool.Add(newTransaction("0x02", 1, "0x5", 4)))
+	assert.NoError(t, pool.Add(newTransaction("0x02", 2, "0x2", 5)))
+	assert.NoError(t, pool.Add(newTransaction("0x03", 2, "0x9", 6)))
+
+	// the highest fees go first while the nounce order of each address is kept
+	pending := pool.Pending()
+	assert.Equal(t, [][]byte{{4}, {5}, {2}, {1}}, hashes(pending))
+
+	future := pool.Future()
+	assert.Equal(t, [][]byte{{6}, {3}}, hashes(future))
+
+	// the fees are compared per byte
+	bigTx := newTransaction("0x04", 1, "0xa", 8)
+	bigTx.Data = make([]byte, 100)
+	assert.NoError(t, pool.Add(bigTx))
+	assert.NoError(t, pool.Add(newTransaction("0x05", 1, "0x3", 9)))
+	assert.Equal(t, [][]byte{{4}, {9}, {5}, {8}, {2}, {1}}, hashes(pool.Pending()))
+
+	// filling the gap moves the transaction to the pending queue
+	assert.NoError(t, pool.Add(newTransaction("0x03", 1, "0x1", 7)))
+	assert.Equal(t, [][]byte{{3}}, hashes(pool.Future()))
+}
+
+func TestPrune(t *testing.T) {
+	nounces := map[string]uint64{}
+	pool, err := New(Config{MaxTransactions: 10, MaxTransactionsPerAddress: 10, TTL: time.Minute}, stateNounces(nounces))
+	assert.NoError(t, err)
+
+	now := time.Now()
+	pool.now = func() time.Time { return now }
+	assert.NoError(t, pool.Add(newTransaction("0x01", 1, "0x1", 1)))
+	assert.NoError(t, pool.Add(newTransaction("0x01", 2, "0x1", 2)))
+
+	now = now.Add(30 * time.Second)
+	assert.NoError(t, pool.Add(newTransaction("0x02", 1, "0x1", 3)))
+
+	// the first transaction of 0x01 is included in a block
+	nounces["0x01"] = 1
+	assert.Equal(t, 1, pool.Prune(1))
+	assert.Equal(t, [][]byte{{2}, {3}}, hashes(pool.Pending()))
+
+	// expired transactions are not pending and get removed
+	now = now.Add(31 * time.Second)
+	assert.Equal(t, [][]byte{{3}}, hashes(pool.Pending()))
+	assert.Equal(t, 1, pool.Prune(1))
+	assert.Equal(t, 1, pool.Len())
+
+	// transactions which validity window ends before the next block are removed
+	expiring := newTransaction("0x03", 1, "0x1", 4)
+	expiring.ValidUntil = 5
+	assert.NoError(t, pool.Add(expiring))
+	assert.Equal(t, 0, pool.Prune(5))
+	assert.Equal(t, 1, pool.Prune(6))
+	assert.Equal(t, [][]byte{{3}}, hashes(pool.Transactions()))
+}
+
+func stateNounces(nounces map[string]uint64) NounceProvider {
+	return func(address string) uint64 {
+		return nounces[address]
+	}
+}
+
+func newTransaction(from string, nounce uint64, fees string, hash byte) transaction.Transaction {
+	return transaction.Transaction{
+		Hash:            []byte{hash},
+		From:            from,
+		Nounce:          hexutil.EncodeUint64ToBytes(nounce),
+		TransactionFees: fees,
+	}
+}
+
+func hashes(txs []transaction.Transaction) [][]byte {
+	items := make([][]byte, len(txs))
+	for i, tx := range txs {
+		items[i] = tx.Hash
+	}
+	return items
+}
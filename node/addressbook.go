@@ -0,0 +1,355 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/filefilego/filefilego/database"
+	"github.com/filefilego/filefilego/node/protocols/messages"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+	log "github.com/sirupsen/logrus"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// DefaultAddressBookMaxAge is the time after which the peers which were not seen are removed.
+	DefaultAddressBookMaxAge = 7 * 24 * time.Hour
+
+	// DefaultAddressBookMaxPeers is the max number of peers kept in the address book.
+	DefaultAddressBookMaxPeers = 1000
+
+	// maxPeerFailures is the number of failures more than the successes after which a peer is considered dead.
+	maxPeerFailures = 5
+
+	knownPeerDialTimeout = 10 * time.Second
+	ffgProtocolPrefix    = "/ffg/"
+	addressBookPrefix    = "pr"
+)
+
+// PeerRecord represents a known peer.
+type PeerRecord struct {
+	ID        peer.ID
+	Addrs     []multiaddr.Multiaddr
+	LastSeen  time.Time
+	Successes uint64
+	Failures  uint64
+	Protocols []string
+}
+
+// score ranks the peers by their connection history.
+func (r PeerRecord) score() int64 {
+	return int64(r.Successes) - int64(r.Failures)
+}
+
+// dead returns true if the peer was not seen for a long time or keeps failing.
+func (r PeerRecord) dead(now time.Time, maxAge time.Duration) bool {
+	return now.Sub(r.LastSeen) > maxAge || r.Failures >= r.Successes+maxPeerFailures
+}
+
+// AddressBook persists the known peers in the database, so they can be reconnected after a restart.
+type AddressBook struct {
+	db       database.Database
+	maxAge   time.Duration
+	maxPeers int
+	mu       sync.Mutex
+}
+
+// NewAddressBook creates a new address book.
+func NewAddressBook(db database.Database, maxAge time.Duration, maxPeers int) (*AddressBook, error) {
+	if db == nil {
+		return nil, errors.New("db is nil")
+	}
+
+	if maxAge <= 0 {
+		return nil, errors.New("max age should be greater than zero")
+	}
+
+	if maxPeers <= 0 {
+		return nil, errors.New("max peers should be greater than zero")
+	}
+
+	return &AddressBook{
+		db:       db,
+		maxAge:   maxAge,
+		maxPeers: maxPeers,
+	}, nil
+}
+
+// RecordSuccess records a successful connection to a peer with its addresses and advertised protocols.
+func (a *AddressBook) RecordSuccess(id peer.ID, addrs []multiaddr.Multiaddr, protocols []string) error {
+	return a.record(id, addrs, protocols, true)
+}
+
+// RecordSeen records that a peer is still connected with its addresses and advertised protocols.
+// unlike RecordSuccess it doesn't count as a successful connection.
+func (a *AddressBook) RecordSeen(id peer.ID, addrs []multiaddr.Multiaddr, protocols []string) error {
+	return a.record(id, addrs, protocols, false)
+}
+
+func (a *AddressBook) record(id peer.ID, addrs []multiaddr.Multiaddr, protocols []string, success bool) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	record, err := a.get(id)
+	if err != nil {
+		record = PeerRecord{ID: id}
+	}
+
+	if len(addrs) > 0 {
+		record.Addrs = addrs
+	}
+
+	if len(protocols) > 0 {
+		record.Protocols = protocols
+	}
+
+	if success {
+		record.Successes++
+	}
+	record.LastSeen = time.Now()
+	return a.put(record)
+}
+
+// RecordFailure records a failed connection to a known peer.
+func (a *AddressBook) RecordFailure(id peer.ID) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	record, err := a.get(id)
+	if err != nil {
+		return err
+	}
+
+	record.Failures++
+	return a.put(record)
+}
+
+// Get returns a peer record.
+func (a *AddressBook) Get(id peer.ID) (PeerRecord, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.get(id)
+}
+
+// Peers returns the known peers ordered from the best to the worst.
+func (a *AddressBook) Peers() ([]PeerRecord, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.peers()
+}
+
+// Prune removes the dead peers and the worst peers above the max number of peers.
+// it returns the number of removed peers.
+func (a *AddressBook) Prune() (int, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	records, err := a.peers()
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	batch := new(leveldb.Batch)
+	kept := 0
+	for _, r := range records {
+		if r.dead(now, a.maxAge) || kept >= a.maxPeers {
+			batch.Delete(addressBookKey(r.ID))
+			continue
+		}
+		kept++
+	}
+
+	if err := a.db.Write(batch, nil); err != nil {
+		return 0, fmt.Errorf("failed to delete peer records: %w", err)
+	}
+	return batch.Len(), nil
+}
+
+func (a *AddressBook) get(id peer.ID) (PeerRecord, error) {
+	data, err := a.db.Get(addressBookKey(id))
+	if err != nil {
+		return PeerRecord{}, fmt.Errorf("failed to get peer record: %w", err)
+	}
+
+	return unmarshalPeerRecord(data)
+}
+
+func (a *AddressBook) put(record PeerRecord) error {
+	data, err := marshalPeerRecord(record)
+	if err != nil {
+		return err
+	}
+
+	if err := a.db.Put(addressBookKey(record.ID), data); err != nil {
+		return fmt.Errorf("failed to insert peer record: %w", err)
+	}
+	return nil
+}
+
+func (a *AddressBook) peers() ([]PeerRecord, error) {
+	iter := a.db.NewIterator(util.BytesPrefix([]byte(addressBookPrefix)), nil)
+	records := make([]PeerRecord, 0)
+	for iter.Next() {
+		record, err := unmarshalPeerRecord(iter.Value())
+		if err != nil {
+			iter.Release()
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	iter.Release()
+	if err := iter.Error(); err != nil {
+		return nil, fmt.Errorf("failed to iterate peer records: %w", err)
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		if records[i].score() != records[j].score() {
+			return records[i].score() > records[j].score()
+		}
+		return records[i].LastSeen.After(records[j].LastSeen)
+	})
+	return records, nil
+}
+
+func addressBookKey(id peer.ID) []byte {
+	return append([]byte(addressBookPrefix), []byte(id)...)
+}
+
+func marshalPeerRecord(record PeerRecord) ([]byte, error) {
+	pr := &messages.PeerRecordProto{
+		PeerId:    []byte(record.ID),
+		Addrs:     make([][]byte, len(record.Addrs)),
+		LastSeen:  record.LastSeen.Unix(),
+		Successes: record.Successes,
+		Failures:  record.Failures,
+		Protocols: record.Protocols,
+	}
+
+	for i, addr := range record.Addrs {
+		pr.Addrs[i] = addr.Bytes()
+	}
+
+	data, err := proto.Marshal(pr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal peer record: %w", err)
+	}
+	return data, nil
+}
+
+func unmarshalPeerRecord(data []byte) (PeerRecord, error) {
+	pr := messages.PeerRecordProto{}
+	if err := proto.Unmarshal(data, &pr); err != nil {
+		return PeerRecord{}, fmt.Errorf("failed to unmarshal peer record: %w", err)
+	}
+
+	record := PeerRecord{
+		ID:        peer.ID(pr.PeerId),
+		Addrs:     make([]multiaddr.Multiaddr, 0, len(pr.Addrs)),
+		LastSeen:  time.Unix(pr.LastSeen, 0),
+		Successes: pr.Successes,
+		Failures:  pr.Failures,
+		Protocols: pr.Protocols,
+	}
+
+	for _, b := range pr.Addrs {
+		addr, err := multiaddr.NewMultiaddrBytes(b)
+		if err != nil {
+			continue
+		}
+		record.Addrs = append(record.Addrs, addr)
+	}
+	return record, nil
+}
+
+// SaveConnectedPeers records the connected peers in the address book with their addresses and ffg protocols.
+// only the dials of ConnectToKnownPeers count as successful connections.
+func (n *Node) SaveConnectedPeers() error {
+	for _, id := range n.host.Network().Peers() {
+		if id == n.host.ID() || n.banList.Contains(id) {
+			continue
+		}
+
+		protocols, err := n.host.Peerstore().GetProtocols(id)
+		if err != nil {
+			log.Warnf("failed to get protocols of peer %s: %v", id.String(), err)
+			continue
+		}
+
+		ffgProtocols := make([]string, 0)
+		for _, p := range protocols {
+			if strings.HasPrefix(string(p), ffgProtocolPrefix) {
+				ffgProtocols = append(ffgProtocols, string(p))
+			}
+		}
+
+		if err := n.addressBook.RecordSeen(id, n.host.Peerstore().Addrs(id), ffgProtocols); err != nil {
+			return fmt.Errorf("failed to save peer %s: %w", id.String(), err)
+		}
+	}
+	return nil
+}
+
+// ConnectToKnownPeers removes the dead peers of the address book and connects to the best of the others.
+// it returns the number of connected peers.
+func (n *Node) ConnectToKnownPeers(ctx context.Context, maxPeers int) (int, error) {
+	if _, err := n.addressBook.Prune(); err != nil {
+		return 0, fmt.Errorf("failed to prune address book: %w", err)
+	}
+
+	records, err := n.addressBook.Peers()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get known peers: %w", err)
+	}
+
+	candidates := make([]PeerRecord, 0, maxPeers)
+	for _, r := range records {
+		if len(candidates) >= maxPeers {
+			break
+		}
+
+		if r.ID == n.host.ID() || len(r.Addrs) == 0 || n.banList.Contains(r.ID) || n.host.Network().Connectedness(r.ID) == network.Connected {
+			continue
+		}
+		candidates = append(candidates, r)
+	}
+
+	var wg sync.WaitGroup
+	var connected int32
+	for _, r := range candidates {
+		wg.Add(1)
+		go func(r PeerRecord) {
+			defer wg.Done()
+			dialCtx, cancel := context.WithTimeout(ctx, knownPeerDialTimeout)
+			defer cancel()
+
+			if err := n.host.Connect(dialCtx, peer.AddrInfo{ID: r.ID, Addrs: r.Addrs}); err != nil {
+				log.Warnf("failed connecting to known peer %s: %v", r.ID.String(), err)
+				if err := n.addressBook.RecordFailure(r.ID); err != nil {
+					log.Warnf("failed to record failure of peer %s: %v", r.ID.String(), err)
+				}
+				return
+			}
+
+			atomic.AddInt32(&connected, 1)
+			if err := n.addressBook.RecordSuccess(r.ID, nil, nil); err != nil {
+				log.Warnf("failed to record success of peer %s: %v", r.ID.String(), err)
+			}
+		}(r)
+	}
+	wg.Wait()
+
+	return int(connected), nil
+}
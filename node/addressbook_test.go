@@ -0,0 +1,203 @@
+package node
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/filefilego/filefilego/database"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/assert"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+func TestNewAddressBook(t *testing.T) {
+	cases := map[string]struct {
+		db       database.Database
+		maxAge   time.Duration
+		maxPeers int
+		expErr   string
+	}{
+		"no db": {
+			expErr: "db is nil",
+		},
+		"zero max age": {
+			db:     &database.DB{},
+			expErr: "max age should be greater than zero",
+		},
+		"zero max peers": {
+			db:     &database.DB{},
+			maxAge: time.Hour,
+			expErr: "max peers should be greater than zero",
+		},
+		"success": {
+			db:       &database.DB{},
+			maxAge:   time.Hour,
+			maxPeers: 1,
+		},
+	}
+
+	for name, tt := range cases {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			addressBook, err := NewAddressBook(tt.db, tt.maxAge, tt.maxPeers)
+			if tt.expErr != "" {
+				assert.Nil(t, addressBook)
+				assert.EqualError(t, err, tt.expErr)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, addressBook)
+			}
+		})
+	}
+}
+
+func TestAddressBook(t *testing.T) {
+	db, err := leveldb.OpenFile("addressbook.db", nil)
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		db.Close()
+		os.RemoveAll("addressbook.db")
+	})
+	driver, err := database.New(db)
+	assert.NoError(t, err)
+
+	addressBook, err := NewAddressBook(driver, time.Hour, 2)
+	assert.NoError(t, err)
+	p1 := randomPeerID(t)
+	p2 := randomPeerID(t)
+	p3 := randomPeerID(t)
+	addr, err := multiaddr.NewMultiaddr("/ip4/127.0.0.1/tcp/10209")
+	assert.NoError(t, err)
+
+	// failures of unknown peers are not recorded
+	err = addressBook.RecordFailure(p1)
+	assert.ErrorContains(t, err, "failed to get peer record")
+
+	assert.NoError(t, addressBook.RecordSuccess(p1, []multiaddr.Multiaddr{addr}, []string{"/ffg/block_downloader/1.0.0"}))
+	assert.NoError(t, addressBook.RecordSuccess(p2, []multiaddr.Multiaddr{addr}, nil))
+	assert.NoError(t, addressBook.RecordSuccess(p2, nil, nil))
+	assert.NoError(t, addressBook.RecordSuccess(p3, []multiaddr.Multiaddr{addr}, nil))
+	assert.NoError(t, addressBook.RecordFailure(p3))
+
+	// addresses and protocols are kept when a success doesn't include them
+	record, err := addressBook.Get(p2)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), record.Successes)
+	assert.Equal(t, []multiaddr.Multiaddr{addr}, record.Addrs)
+	assert.WithinDuration(t, time.Now(), record.LastSeen, 2*time.Second)
+
+	// a seen peer updates its addresses without counting as a success
+	addr2, err := multiaddr.NewMultiaddr("/ip4/127.0.0.1/tcp/10210")
+	assert.NoError(t, err)
+	assert.NoError(t, addressBook.RecordSeen(p2, []multiaddr.Multiaddr{addr2}, nil))
+	record, err = addressBook.Get(p2)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), record.Successes)
+	assert.Equal(t, []multiaddr.Multiaddr{addr2}, record.Addrs)
+
+	record, err = addressBook.Get(p1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/ffg/block_downloader/1.0.0"}, record.Protocols)
+
+	// peers are ordered from the best to the worst
+	records, err := addressBook.Peers()
+	assert.NoError(t, err)
+	assert.Len(t, records, 3)
+	assert.Equal(t, p2, records[0].ID)
+	assert.Equal(t, p1, records[1].ID)
+	assert.Equal(t, p3, records[2].ID)
+
+	// the worst peers above the max peers are removed
+	removed, err := addressBook.Prune()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, removed)
+	_, err = addressBook.Get(p3)
+	assert.Error(t, err)
+
+	// peers which keep failing are removed
+	for i := 0; i < maxPeerFailures+1; i++ {
+		assert.NoError(t, addressBook.RecordFailure(p1))
+	}
+	removed, err = addressBook.Prune()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, removed)
+
+	// peers which were not seen are removed
+	addressBook.maxAge = time.Nanosecond
+	time.Sleep(time.Millisecond)
+	removed, err = addressBook.Prune()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, removed)
+	records, err = addressBook.Peers()
+	assert.NoError(t, err)
+	assert.Empty(t, records)
+}
+
+func TestConnectToKnownPeers(t *testing.T) {
+	n1 := createNode(t, "1045", "addressbooksearch1.bin", "addressbookdb1.bin")
+	n2 := createNode(t, "1046", "addressbooksearch2.bin", "addressbookdb2.bin")
+	t.Cleanup(func() {
+		n1.searchEngine.Close()
+		n2.searchEngine.Close()
+		// nolint:errcheck
+		n1.blockchain.CloseDB()
+		// nolint:errcheck
+		n2.blockchain.CloseDB()
+		os.RemoveAll("addressbooksearch1.bin")
+		os.RemoveAll("addressbooksearch2.bin")
+		os.RemoveAll("addressbookdb1.bin")
+		os.RemoveAll("addressbookdb2.bin")
+	})
+
+	_, err := n1.ConnectToPeerWithMultiaddr(context.TODO(), multiaddrOf(t, n2))
+	assert.NoError(t, err)
+	time.Sleep(100 * time.Millisecond)
+
+	// connected peers are saved with their ffg protocols and saving them again doesn't count as a success
+	err = n1.SaveConnectedPeers()
+	assert.NoError(t, err)
+	err = n1.SaveConnectedPeers()
+	assert.NoError(t, err)
+	record, err := n1.addressBook.Get(n2.host.ID())
+	assert.NoError(t, err)
+	assert.NotEmpty(t, record.Addrs)
+	assert.Contains(t, record.Protocols, "/ffg/block_downloader/1.0.0")
+	assert.Equal(t, uint64(0), record.Successes)
+
+	// an offline known peer is recorded as a failure
+	offline := randomPeerID(t)
+	addr, err := multiaddr.NewMultiaddr("/ip4/127.0.0.1/tcp/1047")
+	assert.NoError(t, err)
+	err = n1.addressBook.RecordSuccess(offline, []multiaddr.Multiaddr{addr}, nil)
+	assert.NoError(t, err)
+
+	// after a restart the known peers are reconnected
+	err = n1.host.Network().ClosePeer(n2.host.ID())
+	assert.NoError(t, err)
+	n1.host.Peerstore().ClearAddrs(n2.host.ID())
+	connected, err := n1.ConnectToKnownPeers(context.TODO(), 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, connected)
+
+	record, err = n1.addressBook.Get(n2.host.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), record.Successes)
+	record, err = n1.addressBook.Get(offline)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), record.Failures)
+
+	// banned peers are not reconnected
+	assert.NoError(t, n1.BanPeer(n2.host.ID(), "admin"))
+	connected, err = n1.ConnectToKnownPeers(context.TODO(), 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, connected)
+}
+
+func multiaddrOf(t *testing.T, n *Node) multiaddr.Multiaddr {
+	addrs, err := n.GetMultiaddr()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, addrs)
+	return addrs[0]
+}
@@ -0,0 +1,128 @@
+package node
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	// DefaultMaxOffences is the number of invalid gossip messages after which a peer is banned.
+	DefaultMaxOffences = 5
+	// DefaultBanDuration is how long a peer stays banned.
+	DefaultBanDuration = 24 * time.Hour
+)
+
+// BannedPeer represents a banned peer.
+type BannedPeer struct {
+	PeerID peer.ID
+	Reason string
+	Until  time.Time
+}
+
+// BanList keeps the peers which repeatedly send invalid gossip messages.
+// it implements the pubsub blacklist, so the messages and connections of banned peers are dropped.
+type BanList struct {
+	maxOffences int
+	banDuration time.Duration
+	offences    map[peer.ID]int
+	banned      map[peer.ID]BannedPeer
+	mu          sync.RWMutex
+}
+
+// NewBanList creates a ban list.
+func NewBanList(maxOffences int, banDuration time.Duration) (*BanList, error) {
+	if maxOffences <= 0 {
+		return nil, errors.New("max offences should be greater than zero")
+	}
+
+	if banDuration <= 0 {
+		return nil, errors.New("ban duration should be greater than zero")
+	}
+
+	return &BanList{
+		maxOffences: maxOffences,
+		banDuration: banDuration,
+		offences:    make(map[peer.ID]int),
+		banned:      make(map[peer.ID]BannedPeer),
+	}, nil
+}
+
+// Add bans a peer blacklisted by pubsub.
+func (b *BanList) Add(id peer.ID) bool {
+	b.Ban(id, "blacklisted by pubsub")
+	return true
+}
+
+// Contains returns true if the peer is banned.
+func (b *BanList) Contains(id peer.ID) bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	banned, ok := b.banned[id]
+	return ok && time.Now().Before(banned.Until)
+}
+
+// Ban bans a peer for the ban duration.
+func (b *BanList) Ban(id peer.ID, reason string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.offences, id)
+	b.banned[id] = BannedPeer{
+		PeerID: id,
+		Reason: reason,
+		Until:  time.Now().Add(b.banDuration),
+	}
+}
+
+// Unban removes a peer from the ban list and forgets its offences.
+func (b *BanList) Unban(id peer.ID) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.offences, id)
+	_, ok := b.banned[id]
+	delete(b.banned, id)
+	return ok
+}
+
+// RecordOffence counts an invalid message of a peer and bans it once it reaches the max offences.
+// it returns true if the peer got banned.
+func (b *BanList) RecordOffence(id peer.ID, reason string) bool {
+	b.mu.Lock()
+	b.offences[id]++
+	offences := b.offences[id]
+	b.mu.Unlock()
+
+	if offences < b.maxOffences {
+		return false
+	}
+
+	b.Ban(id, reason)
+	return true
+}
+
+// List returns the banned peers and removes the expired bans.
+func (b *BanList) List() []BannedPeer {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	list := make([]BannedPeer, 0, len(b.banned))
+	for id, banned := range b.banned {
+		if !now.Before(banned.Until) {
+			delete(b.banned, id)
+			continue
+		}
+		list = append(list, banned)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Until.Before(list[j].Until)
+	})
+	return list
+}
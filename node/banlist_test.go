@@ -0,0 +1,92 @@
+package node
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBanList(t *testing.T) {
+	cases := map[string]struct {
+		maxOffences int
+		banDuration time.Duration
+		expErr      string
+	}{
+		"zero max offences": {
+			banDuration: time.Hour,
+			expErr:      "max offences should be greater than zero",
+		},
+		"zero ban duration": {
+			maxOffences: 1,
+			expErr:      "ban duration should be greater than zero",
+		},
+		"success": {
+			maxOffences: 1,
+			banDuration: time.Hour,
+		},
+	}
+
+	for name, tt := range cases {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			banList, err := NewBanList(tt.maxOffences, tt.banDuration)
+			if tt.expErr != "" {
+				assert.Nil(t, banList)
+				assert.EqualError(t, err, tt.expErr)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, banList)
+			}
+		})
+	}
+}
+
+func TestBanList(t *testing.T) {
+	banList, err := NewBanList(3, time.Hour)
+	assert.NoError(t, err)
+	p1 := randomPeerID(t)
+	p2 := randomPeerID(t)
+
+	// peers are banned after the max offences
+	assert.False(t, banList.RecordOffence(p1, "invalid block"))
+	assert.False(t, banList.RecordOffence(p1, "invalid block"))
+	assert.False(t, banList.Contains(p1))
+	assert.True(t, banList.RecordOffence(p1, "invalid transaction"))
+	assert.True(t, banList.Contains(p1))
+
+	// pubsub blacklist
+	assert.True(t, banList.Add(p2))
+	assert.True(t, banList.Contains(p2))
+
+	list := banList.List()
+	assert.Len(t, list, 2)
+	assert.Equal(t, p1, list[0].PeerID)
+	assert.Equal(t, "invalid transaction", list[0].Reason)
+	assert.Equal(t, p2, list[1].PeerID)
+
+	// unban forgets the offences
+	assert.True(t, banList.Unban(p1))
+	assert.False(t, banList.Unban(p1))
+	assert.False(t, banList.Contains(p1))
+	assert.False(t, banList.RecordOffence(p1, "invalid block"))
+	assert.Len(t, banList.List(), 1)
+
+	// bans expire
+	banList, err = NewBanList(1, time.Millisecond)
+	assert.NoError(t, err)
+	banList.Ban(p1, "admin")
+	time.Sleep(5 * time.Millisecond)
+	assert.False(t, banList.Contains(p1))
+	assert.Empty(t, banList.List())
+}
+
+func randomPeerID(t *testing.T) peer.ID {
+	priv, _, err := crypto.GenerateKeyPair(crypto.Secp256k1, 256)
+	assert.NoError(t, err)
+	id, err := peer.IDFromPrivateKey(priv)
+	assert.NoError(t, err)
+	return id
+}
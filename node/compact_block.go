@@ -0,0 +1,118 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/filefilego/filefilego/block"
+	compactblock "github.com/filefilego/filefilego/node/protocols/compact_block"
+	"github.com/filefilego/filefilego/node/protocols/messages"
+	"github.com/filefilego/filefilego/transaction"
+	"github.com/libp2p/go-libp2p/core/peer"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
+)
+
+// publishBlocks publishes the blocks as compact blocks, since most peers already have their transactions.
+// nodes on the legacy topic can't rebuild compact blocks, so they receive the full blocks.
+func (n *Node) publishBlocks(ctx context.Context, protoBlocks []*block.ProtoBlock, data []byte) error {
+	for _, pb := range protoBlocks {
+		b := block.ProtoBlockToBlock(pb)
+		compact, err := compactblock.NewCompactBlock(b)
+		if err != nil {
+			return fmt.Errorf("failed to create compact block: %w", err)
+		}
+
+		compactData, err := proto.Marshal(&messages.GossipPayload{Message: &messages.GossipPayload_CompactBlock{CompactBlock: compact}})
+		if err != nil {
+			return fmt.Errorf("failed to marshal compact block: %w", err)
+		}
+
+		// peers request the missing transactions from the publisher
+		n.compactBlockProtocol.PutBlock(b)
+		if err := n.topics[n.gossipTopics.Blocks].Publish(ctx, compactData); err != nil {
+			return fmt.Errorf("failed to publish message to network: %w", err)
+		}
+	}
+
+	if n.gossipTopics.Legacy != "" {
+		if err := n.topics[n.gossipTopics.Legacy].Publish(ctx, data); err != nil {
+			return fmt.Errorf("failed to publish message to network: %w", err)
+		}
+	}
+	return nil
+}
+
+// rebuildCompactBlock rebuilds a block from the mempool and fetches the missing transactions from the peers.
+func (n *Node) rebuildCompactBlock(ctx context.Context, compact *messages.CompactBlockProto, peers ...peer.ID) (block.Block, error) {
+	partial, err := compactblock.NewPartialBlock(compact)
+	if err != nil {
+		return block.Block{}, fmt.Errorf("failed to create partial block: %w", err)
+	}
+
+	if b, ok := n.compactBlockProtocol.GetBlock(partial.Hash()); ok {
+		return b, nil
+	}
+
+	partial.FillFromPool(n.blockchain.GetTransactionsFromPool())
+	if missing := partial.Missing(); len(missing) > 0 {
+		if err := n.fetchBlockTransactions(ctx, partial, missing, peers); err != nil {
+			return block.Block{}, err
+		}
+	}
+
+	b, err := partial.Block()
+	if err != nil {
+		// short ids of the mempool transactions may collide, so all the transactions are fetched
+		log.Warnf("failed to rebuild compact block from mempool: %v", err)
+		if err := n.fetchBlockTransactions(ctx, partial, partial.Indexes(), peers); err != nil {
+			return block.Block{}, err
+		}
+
+		b, err = partial.Block()
+		if err != nil {
+			return block.Block{}, fmt.Errorf("failed to rebuild compact block: %w", err)
+		}
+	}
+
+	n.compactBlockProtocol.PutBlock(b)
+	return b, nil
+}
+
+// fetchBlockTransactions requests the transactions of a partial block from the first peer which serves them.
+func (n *Node) fetchBlockTransactions(ctx context.Context, partial *compactblock.PartialBlock, indexes []uint32, peers []peer.ID) error {
+	if len(indexes) == 0 {
+		return nil
+	}
+
+	request := &messages.BlockTransactionsRequestProto{
+		BlockHash: partial.Hash(),
+		Indexes:   indexes,
+	}
+
+	for _, p := range peers {
+		if p == "" || p == n.host.ID() {
+			continue
+		}
+
+		response, err := n.compactBlockProtocol.RequestTransactions(ctx, p, request)
+		if err != nil {
+			log.Warnf("failed to get block transactions from peer %s: %v", p.String(), err)
+			continue
+		}
+
+		txs := make([]transaction.Transaction, len(response.Transactions))
+		for i, tx := range response.Transactions {
+			txs[i] = transaction.ProtoTransactionToTransaction(tx)
+		}
+
+		if err := partial.Fill(indexes, txs); err != nil {
+			log.Warnf("invalid block transactions from peer %s: %v", p.String(), err)
+			continue
+		}
+		return nil
+	}
+
+	return errors.New("failed to fetch the missing transactions of block")
+}
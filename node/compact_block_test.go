@@ -0,0 +1,108 @@
+package node
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/filefilego/filefilego/block"
+	compactblock "github.com/filefilego/filefilego/node/protocols/compact_block"
+	"github.com/filefilego/filefilego/node/protocols/messages"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestRebuildCompactBlock(t *testing.T) {
+	n1 := createNode(t, "1043", "compactsearch1.bin", "compactdb1.bin")
+	n2 := createNode(t, "1044", "compactsearch2.bin", "compactdb2.bin")
+	t.Cleanup(func() {
+		n1.searchEngine.Close()
+		n2.searchEngine.Close()
+		// nolint:errcheck
+		n1.blockchain.CloseDB()
+		// nolint:errcheck
+		n2.blockchain.CloseDB()
+		os.RemoveAll("compactsearch1.bin")
+		os.RemoveAll("compactsearch2.bin")
+		os.RemoveAll("compactdb1.bin")
+		os.RemoveAll("compactdb2.bin")
+	})
+
+	err := n1.host.Connect(context.TODO(), peer.AddrInfo{ID: n2.host.ID(), Addrs: n2.host.Addrs()})
+	assert.NoError(t, err)
+
+	b, kp := validBlock(t)
+	tx, _ := validTransaction(t)
+	b.Transactions = append(b.Transactions, *tx)
+	block.SetBlockVerifiers(block.Verifier{Address: kp.Address})
+	err = b.Sign(kp.PrivateKey)
+	assert.NoError(t, err)
+	compact, err := compactblock.NewCompactBlock(*b)
+	assert.NoError(t, err)
+
+	// none of the peers has the block
+	_, err = n1.rebuildCompactBlock(context.TODO(), compact, n1.host.ID(), n2.host.ID())
+	assert.EqualError(t, err, "failed to fetch the missing transactions of block")
+
+	// the missing transactions are fetched from the first peer which serves them
+	n2.compactBlockProtocol.PutBlock(*b)
+	rebuilt, err := n1.rebuildCompactBlock(context.TODO(), compact, randomPeerID(t), n2.host.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, *b, rebuilt)
+
+	// the rebuilt block is relayed to other peers
+	cached, ok := n1.compactBlockProtocol.GetBlock(b.Hash)
+	assert.True(t, ok)
+	assert.Equal(t, *b, cached)
+	rebuilt, err = n1.rebuildCompactBlock(context.TODO(), compact)
+	assert.NoError(t, err)
+	assert.Equal(t, *b, rebuilt)
+}
+
+func TestValidateGossipCompactBlock(t *testing.T) {
+	n1 := createNode(t, "1047", "compactsearch3.bin", "compactdb3.bin")
+	n2 := createNode(t, "1048", "compactsearch4.bin", "compactdb4.bin")
+	t.Cleanup(func() {
+		n1.searchEngine.Close()
+		n2.searchEngine.Close()
+		// nolint:errcheck
+		n1.blockchain.CloseDB()
+		// nolint:errcheck
+		n2.blockchain.CloseDB()
+		os.RemoveAll("compactsearch3.bin")
+		os.RemoveAll("compactsearch4.bin")
+		os.RemoveAll("compactdb3.bin")
+		os.RemoveAll("compactdb4.bin")
+	})
+
+	err := n1.host.Connect(context.TODO(), peer.AddrInfo{ID: n2.host.ID(), Addrs: n2.host.Addrs()})
+	assert.NoError(t, err)
+
+	b, kp := validBlock(t)
+	tx, _ := validTransaction(t)
+	b.Transactions = append(b.Transactions, *tx)
+	block.SetBlockVerifiers(block.Verifier{Address: kp.Address})
+	err = b.Sign(kp.PrivateKey)
+	assert.NoError(t, err)
+	compact, err := compactblock.NewCompactBlock(*b)
+	assert.NoError(t, err)
+	data, err := proto.Marshal(&messages.GossipPayload{Message: &messages.GossipPayload_CompactBlock{CompactBlock: compact}})
+	assert.NoError(t, err)
+	message := &pubsub.Message{Message: &pb.Message{Data: data, From: []byte(n2.host.ID())}}
+
+	// a compact block which can't be rebuilt isn't relayed, without penalizing the peer
+	assert.Equal(t, pubsub.ValidationIgnore, n1.validateGossipMessage(context.TODO(), n2.host.ID(), message))
+	assert.False(t, n1.banList.Contains(n2.host.ID()))
+	_, ok := n1.compactBlockProtocol.GetBlock(b.Hash)
+	assert.False(t, ok)
+
+	// the block is rebuilt before it's relayed, so the node can serve its transactions
+	n2.compactBlockProtocol.PutBlock(*b)
+	assert.Equal(t, pubsub.ValidationAccept, n1.validateGossipMessage(context.TODO(), n2.host.ID(), message))
+	cached, ok := n1.compactBlockProtocol.GetBlock(b.Hash)
+	assert.True(t, ok)
+	assert.Equal(t, *b, cached)
+}
@@ -0,0 +1,385 @@
+package node
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/filefilego/filefilego/block"
+	"github.com/filefilego/filefilego/blockchain"
+	compactblock "github.com/filefilego/filefilego/node/protocols/compact_block"
+	"github.com/filefilego/filefilego/node/protocols/messages"
+	"github.com/filefilego/filefilego/transaction"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// bannedPeerScore is the application specific score of banned peers, which is below the graylist threshold.
+	bannedPeerScore = -1000
+
+	// maxTransactionMessageSize leaves room for the max transaction data and the rest of the transaction fields.
+	maxTransactionMessageSize = 512 * 1024
+	maxDataQueryMessageSize   = 64 * 1024
+
+	// seenGossipTTL is how long a processed message is remembered, which matches the pubsub seen messages TTL.
+	seenGossipTTL = 2 * time.Minute
+)
+
+// errAttestationAheadOfChain is returned when an attestation can't be verified since its checkpoint is ahead of the local chain.
+var errAttestationAheadOfChain = errors.New("checkpoint attestation is ahead of the chain")
+
+// errCompactBlockNotRebuilt is returned when the transactions of a compact block can't be found.
+var errCompactBlockNotRebuilt = errors.New("failed to rebuild compact block")
+
+// GossipTopics holds the pubsub topics of each gossip message type.
+type GossipTopics struct {
+	Blocks       string
+	Transactions string
+	DataQueries  string
+	// Legacy is the single topic which carried all the message types before they were split.
+	// during the migration window messages are published and received on it as well, it's disabled when empty.
+	Legacy string
+}
+
+// NewGossipTopics returns the gossip topics of a network.
+func NewGossipTopics(network, legacy string) GossipTopics {
+	return GossipTopics{
+		Blocks:       network + "_blocks",
+		Transactions: network + "_transactions",
+		DataQueries:  network + "_data_queries",
+		Legacy:       legacy,
+	}
+}
+
+// Names returns the names of all the topics.
+func (g GossipTopics) Names() []string {
+	names := []string{g.Blocks, g.Transactions, g.DataQueries}
+	if g.Legacy != "" {
+		names = append(names, g.Legacy)
+	}
+	return names
+}
+
+// topicOf returns the topic of a gossip payload.
+// checkpoint attestations are consensus messages so they share the blocks topic.
+func (g GossipTopics) topicOf(payload *messages.GossipPayload) (string, error) {
+	switch payload.GetMessage().(type) {
+	case *messages.GossipPayload_Blocks, *messages.GossipPayload_CompactBlock, *messages.GossipPayload_CheckpointAttestation:
+		return g.Blocks, nil
+	case *messages.GossipPayload_Transaction:
+		return g.Transactions, nil
+	case *messages.GossipPayload_Query:
+		return g.DataQueries, nil
+	default:
+		return "", errors.New("unknown gossip payload")
+	}
+}
+
+// PeerScoreParams returns the gossipsub peer scoring parameters of the given topics.
+// peers which deliver invalid messages are penalized and the banned peers are graylisted.
+func PeerScoreParams(banList *BanList, topics ...string) *pubsub.PeerScoreParams {
+	params := &pubsub.PeerScoreParams{
+		Topics:        make(map[string]*pubsub.TopicScoreParams),
+		TopicScoreCap: 50,
+		AppSpecificScore: func(p peer.ID) float64 {
+			if banList.Contains(p) {
+				return bannedPeerScore
+			}
+			return 0
+		},
+		AppSpecificWeight: 1,
+		// nodes behind the same NAT share an address, so they are not penalized.
+		IPColocationFactorWeight:  0,
+		BehaviourPenaltyWeight:    -1,
+		BehaviourPenaltyThreshold: 6,
+		BehaviourPenaltyDecay:     pubsub.ScoreParameterDecay(10 * time.Minute),
+		DecayInterval:             pubsub.DefaultDecayInterval,
+		DecayToZero:               pubsub.DefaultDecayToZero,
+		RetainScore:               time.Hour,
+	}
+
+	for _, topic := range topics {
+		params.Topics[topic] = &pubsub.TopicScoreParams{
+			TopicWeight:       1,
+			TimeInMeshWeight:  0.01,
+			TimeInMeshQuantum: time.Second,
+			TimeInMeshCap:     100,
+			// first deliveries are rewarded, mesh deliveries are not tracked since blocks are sealed every few seconds.
+			FirstMessageDeliveriesWeight:   1,
+			FirstMessageDeliveriesDecay:    pubsub.ScoreParameterDecay(time.Hour),
+			FirstMessageDeliveriesCap:      100,
+			InvalidMessageDeliveriesWeight: -10,
+			InvalidMessageDeliveriesDecay:  pubsub.ScoreParameterDecay(time.Hour),
+		}
+	}
+
+	return params
+}
+
+// PeerScoreThresholds returns the gossipsub peer score thresholds.
+func PeerScoreThresholds() *pubsub.PeerScoreThresholds {
+	return &pubsub.PeerScoreThresholds{
+		GossipThreshold:             -100,
+		PublishThreshold:            -200,
+		GraylistThreshold:           -400,
+		AcceptPXThreshold:           10,
+		OpportunisticGraftThreshold: 3,
+	}
+}
+
+// validateGossipMessage validates the incoming messages before they are delivered and propagated.
+// the peers which keep sending invalid messages are banned.
+func (n *Node) validateGossipMessage(ctx context.Context, pid peer.ID, message *pubsub.Message) pubsub.ValidationResult {
+	if pid == n.host.ID() {
+		return pubsub.ValidationAccept
+	}
+
+	if n.banList.Contains(pid) {
+		return pubsub.ValidationReject
+	}
+
+	// the forwarding peer may not have rebuilt a compact block yet, so the publisher is asked next
+	err := n.validateGossipTopicMessage(ctx, message.GetTopic(), message.Data, pid, message.GetFrom())
+	if err == nil {
+		return pubsub.ValidationAccept
+	}
+
+	// blocks of verifiers which are not known yet or which are ahead of the local clock are dropped without penalizing the peer,
+	// since they may be valid for nodes with a more recent state or a correct clock.
+	// the transactions of a compact block may not be available from the peers, which doesn't prove that the block is invalid.
+	if errors.Is(err, block.ErrUnknownVerifier) || errors.Is(err, block.ErrFutureTimestamp) || errors.Is(err, errAttestationAheadOfChain) || errors.Is(err, errCompactBlockNotRebuilt) {
+		return pubsub.ValidationIgnore
+	}
+
+	log.Warnf("rejected gossip message from peer %s: %v", pid.String(), err)
+	if n.banList.RecordOffence(pid, err.Error()) {
+		log.Warnf("banned peer %s for sending invalid gossip messages", pid.String())
+		if err := n.host.Network().ClosePeer(pid); err != nil {
+			log.Errorf("failed to disconnect banned peer %s: %v", pid.String(), err)
+		}
+	}
+	return pubsub.ValidationReject
+}
+
+// validateGossipTopicMessage checks the size limit of the topic and that the payload belongs to it.
+// the legacy topic carries every message type, so only the payload is validated.
+// the transactions of a compact block are requested from the given peers.
+func (n *Node) validateGossipTopicMessage(ctx context.Context, topic string, data []byte, peers ...peer.ID) error {
+	if topic == "" || topic == n.gossipTopics.Legacy {
+		payload, err := validateGossipPayload(data)
+		if err != nil {
+			return err
+		}
+		return n.validateGossipPayloadState(ctx, payload, peers)
+	}
+
+	maxSize := 0
+	switch topic {
+	case n.gossipTopics.Blocks:
+		maxSize = n.config.P2P.GossipMaxMessageSize
+	case n.gossipTopics.Transactions:
+		maxSize = maxTransactionMessageSize
+	case n.gossipTopics.DataQueries:
+		maxSize = maxDataQueryMessageSize
+	}
+
+	if maxSize > 0 && len(data) > maxSize {
+		return fmt.Errorf("message with size %d is greater than %d bytes allowed in topic %s", len(data), maxSize, topic)
+	}
+
+	payload, err := validateGossipPayload(data)
+	if err != nil {
+		return err
+	}
+
+	expectedTopic, err := n.gossipTopics.topicOf(payload)
+	if err != nil {
+		return err
+	}
+
+	if expectedTopic != topic {
+		return fmt.Errorf("message of topic %s was published in topic %s", expectedTopic, topic)
+	}
+	return n.validateGossipPayloadState(ctx, payload, peers)
+}
+
+// validateGossipPayloadState runs the checks of a gossip payload which depend on the local chain.
+// the blocks are kept to serve their transactions before they are relayed, so a node only relays the blocks it can serve.
+func (n *Node) validateGossipPayloadState(ctx context.Context, payload *messages.GossipPayload, peers []peer.ID) error {
+	switch msg := payload.GetMessage().(type) {
+	case *messages.GossipPayload_Blocks:
+		for _, b := range msg.Blocks.GetBlocks() {
+			n.compactBlockProtocol.PutBlock(block.ProtoBlockToBlock(b))
+		}
+		return nil
+
+	case *messages.GossipPayload_CompactBlock:
+		if _, err := n.rebuildCompactBlock(ctx, msg.CompactBlock, peers...); err != nil {
+			return fmt.Errorf("%w: %s", errCompactBlockNotRebuilt, err.Error())
+		}
+		return nil
+	}
+
+	attestation := payload.GetCheckpointAttestation()
+	if attestation == nil {
+		return nil
+	}
+
+	verifiers, err := block.GetBlockVerifiersAtHeight(attestation.BlockNumber)
+	if err != nil {
+		return fmt.Errorf("failed to get verifiers: %w", err)
+	}
+
+	_, err = blockchain.VerifyCheckpointAttestation(attestation, verifiers)
+	// the verifier set of a checkpoint ahead of the chain may not be known yet
+	if errors.Is(err, blockchain.ErrNotAttestationVerifier) && attestation.BlockNumber > n.blockchain.GetHeight() {
+		return fmt.Errorf("%w: %s", errAttestationAheadOfChain, err.Error())
+	}
+	if err != nil {
+		return fmt.Errorf("failed to verify checkpoint attestation: %w", err)
+	}
+	return nil
+}
+
+// validateGossipPayload runs the stateless checks of a gossip payload.
+func validateGossipPayload(data []byte) (*messages.GossipPayload, error) {
+	payload := messages.GossipPayload{}
+	if err := proto.Unmarshal(data, &payload); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal pubsub data: %w", err)
+	}
+
+	switch msg := payload.GetMessage().(type) {
+	case *messages.GossipPayload_Blocks:
+		protoBlocks := msg.Blocks.GetBlocks()
+		if len(protoBlocks) == 0 {
+			return nil, errors.New("gossip payload doesn't contain any block")
+		}
+
+		for _, b := range protoBlocks {
+			ok, err := block.ProtoBlockToBlock(b).Validate()
+			if err != nil {
+				return nil, fmt.Errorf("failed to validate block: %w", err)
+			}
+			if !ok {
+				return nil, errors.New("block is not valid")
+			}
+		}
+
+	case *messages.GossipPayload_CompactBlock:
+		if msg.CompactBlock == nil {
+			return nil, errors.New("gossip payload doesn't contain a compact block")
+		}
+
+		// the transactions are validated after the block is rebuilt
+		if err := compactblock.ValidateCompactBlock(msg.CompactBlock); err != nil {
+			return nil, fmt.Errorf("failed to validate compact block: %w", err)
+		}
+
+	case *messages.GossipPayload_Transaction:
+		if msg.Transaction == nil {
+			return nil, errors.New("gossip payload doesn't contain a transaction")
+		}
+
+		ok, err := transaction.ProtoTransactionToTransaction(msg.Transaction).Validate()
+		if err != nil {
+			return nil, fmt.Errorf("failed to validate transaction: %w", err)
+		}
+		if !ok {
+			return nil, errors.New("transaction is not valid")
+		}
+
+	case *messages.GossipPayload_Query:
+		if msg.Query == nil {
+			return nil, errors.New("gossip payload doesn't contain a data query")
+		}
+
+		if err := messages.ToDataQueryRequest(msg.Query).Validate(); err != nil {
+			return nil, fmt.Errorf("failed to validate data query request: %w", err)
+		}
+
+	case *messages.GossipPayload_CheckpointAttestation:
+		// the signature and the verifier are checked against the local chain
+		if msg.CheckpointAttestation == nil {
+			return nil, errors.New("gossip payload doesn't contain a checkpoint attestation")
+		}
+
+		if msg.CheckpointAttestation.BlockNumber%blockchain.CheckpointInterval != 0 {
+			return nil, fmt.Errorf("attestation of block %d is not a checkpoint", msg.CheckpointAttestation.BlockNumber)
+		}
+
+	default:
+		return nil, errors.New("unknown gossip payload")
+	}
+
+	return &payload, nil
+}
+
+// BanPeer bans a peer and disconnects from it.
+func (n *Node) BanPeer(id peer.ID, reason string) error {
+	if id == n.host.ID() {
+		return errors.New("node can't ban itself")
+	}
+
+	n.banList.Ban(id, reason)
+	if err := n.host.Network().ClosePeer(id); err != nil {
+		return fmt.Errorf("failed to disconnect banned peer: %w", err)
+	}
+	return nil
+}
+
+// UnbanPeer removes a peer from the ban list.
+func (n *Node) UnbanPeer(id peer.ID) bool {
+	return n.banList.Unban(id)
+}
+
+// BannedPeers returns the banned peers.
+func (n *Node) BannedPeers() []BannedPeer {
+	return n.banList.List()
+}
+
+// seenMessages remembers the recently processed gossip messages, since during the migration window
+// the same message is received on both the legacy and the new topics.
+type seenMessages struct {
+	ttl       time.Duration
+	entries   map[[sha256.Size]byte]time.Time
+	lastPrune time.Time
+	mu        sync.Mutex
+}
+
+func newSeenMessages(ttl time.Duration) *seenMessages {
+	return &seenMessages{
+		ttl:     ttl,
+		entries: make(map[[sha256.Size]byte]time.Time),
+	}
+}
+
+// add returns false if the message was already seen.
+func (s *seenMessages) add(data []byte) bool {
+	key := sha256.Sum256(data)
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if seenAt, ok := s.entries[key]; ok && now.Sub(seenAt) < s.ttl {
+		return false
+	}
+
+	if now.Sub(s.lastPrune) >= s.ttl {
+		for k, seenAt := range s.entries {
+			if now.Sub(seenAt) >= s.ttl {
+				delete(s.entries, k)
+			}
+		}
+		s.lastPrune = now
+	}
+
+	s.entries[key] = now
+	return true
+}
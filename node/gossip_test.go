@@ -0,0 +1,411 @@
+package node
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/filefilego/filefilego/block"
+	"github.com/filefilego/filefilego/blockchain"
+	ffgconfig "github.com/filefilego/filefilego/config"
+	ffgcrypto "github.com/filefilego/filefilego/crypto"
+	compactblock "github.com/filefilego/filefilego/node/protocols/compact_block"
+	"github.com/filefilego/filefilego/node/protocols/messages"
+	"github.com/filefilego/filefilego/transaction"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestValidateGossipPayload(t *testing.T) {
+	validTx, _ := validTransaction(t)
+	signedBlock, kp := validBlock(t)
+	block.SetBlockVerifiers(block.Verifier{Address: kp.Address})
+	err := signedBlock.Sign(kp.PrivateKey)
+	assert.NoError(t, err)
+
+	unknownVerifierBlock, unknownKp := validBlock(t)
+	err = unknownVerifierBlock.Sign(unknownKp.PrivateKey)
+	assert.NoError(t, err)
+
+	compact, err := compactblock.NewCompactBlock(*signedBlock)
+	assert.NoError(t, err)
+	unknownVerifierCompact, err := compactblock.NewCompactBlock(*unknownVerifierBlock)
+	assert.NoError(t, err)
+
+	query := messages.DataQueryRequest{
+		FileHashes:   [][]byte{{1}},
+		FromPeerAddr: "16Uiu2HAm2edbaX9YyMauXDjdhcdF34P59zg29xtP9nmeS7MJNbxo",
+		Timestamp:    time.Now().Unix(),
+	}
+	query.Hash = query.GetHash()
+
+	cases := map[string]struct {
+		payload *messages.GossipPayload
+		data    []byte
+		expErr  string
+	}{
+		"malformed data": {
+			data:   []byte{1, 2, 3},
+			expErr: "failed to unmarshal pubsub data",
+		},
+		"unknown payload": {
+			payload: &messages.GossipPayload{},
+			expErr:  "unknown gossip payload",
+		},
+		"no blocks": {
+			payload: &messages.GossipPayload{Message: &messages.GossipPayload_Blocks{Blocks: &messages.ProtoBlocks{}}},
+			expErr:  "gossip payload doesn't contain any block",
+		},
+		"invalid block": {
+			payload: &messages.GossipPayload{Message: &messages.GossipPayload_Blocks{Blocks: &messages.ProtoBlocks{Blocks: []*block.ProtoBlock{block.ToProtoBlock(block.Block{Hash: []byte{1}})}}}},
+			expErr:  "failed to validate block: merkle hash is empty",
+		},
+		"unknown verifier": {
+			payload: &messages.GossipPayload{Message: &messages.GossipPayload_Blocks{Blocks: &messages.ProtoBlocks{Blocks: []*block.ProtoBlock{block.ToProtoBlock(*unknownVerifierBlock)}}}},
+			expErr:  "failed to validate block: block was signed by a non-verifier",
+		},
+		"valid block": {
+			payload: &messages.GossipPayload{Message: &messages.GossipPayload_Blocks{Blocks: &messages.ProtoBlocks{Blocks: []*block.ProtoBlock{block.ToProtoBlock(*signedBlock)}}}},
+		},
+		"invalid compact block": {
+			payload: &messages.GossipPayload{Message: &messages.GossipPayload_CompactBlock{CompactBlock: &messages.CompactBlockProto{}}},
+			expErr:  "failed to validate compact block: compact block doesn't contain a header",
+		},
+		"compact block of unknown verifier": {
+			payload: &messages.GossipPayload{Message: &messages.GossipPayload_CompactBlock{CompactBlock: unknownVerifierCompact}},
+			expErr:  "block was signed by a non-verifier",
+		},
+		"valid compact block": {
+			payload: &messages.GossipPayload{Message: &messages.GossipPayload_CompactBlock{CompactBlock: compact}},
+		},
+		"invalid transaction": {
+			payload: &messages.GossipPayload{Message: &messages.GossipPayload_Transaction{Transaction: transaction.ToProtoTransaction(transaction.Transaction{Hash: []byte{1}, From: "0x2"})}},
+			expErr:  "failed to validate transaction: wrong chain",
+		},
+		"valid transaction": {
+			payload: &messages.GossipPayload{Message: &messages.GossipPayload_Transaction{Transaction: transaction.ToProtoTransaction(*validTx)}},
+		},
+		"invalid query": {
+			payload: &messages.GossipPayload{Message: &messages.GossipPayload_Query{Query: &messages.DataQueryRequestProto{}}},
+			expErr:  "failed to validate data query request: no file hashes in the request",
+		},
+		"valid query": {
+			payload: &messages.GossipPayload{Message: &messages.GossipPayload_Query{Query: messages.ToDataQueryRequestProto(query)}},
+		},
+		"attestation of a non checkpoint block": {
+			payload: &messages.GossipPayload{Message: &messages.GossipPayload_CheckpointAttestation{CheckpointAttestation: &messages.CheckpointAttestationProto{BlockNumber: 11}}},
+			expErr:  "attestation of block 11 is not a checkpoint",
+		},
+		"checkpoint attestation": {
+			payload: &messages.GossipPayload{Message: &messages.GossipPayload_CheckpointAttestation{CheckpointAttestation: &messages.CheckpointAttestationProto{BlockNumber: 10}}},
+		},
+	}
+
+	for name, tt := range cases {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			data := tt.data
+			if tt.payload != nil {
+				var err error
+				data, err = proto.Marshal(tt.payload)
+				assert.NoError(t, err)
+			}
+
+			payload, err := validateGossipPayload(data)
+			if tt.expErr != "" {
+				assert.Nil(t, payload)
+				assert.ErrorContains(t, err, tt.expErr)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, payload)
+			}
+		})
+	}
+}
+
+func TestGossipTopics(t *testing.T) {
+	topics := NewGossipTopics("ffgnet", "")
+	assert.Equal(t, []string{"ffgnet_blocks", "ffgnet_transactions", "ffgnet_data_queries"}, topics.Names())
+	topics.Legacy = "ffgnet_pubsub"
+	assert.Equal(t, []string{"ffgnet_blocks", "ffgnet_transactions", "ffgnet_data_queries", "ffgnet_pubsub"}, topics.Names())
+
+	cases := map[string]struct {
+		payload  *messages.GossipPayload
+		expTopic string
+		expErr   string
+	}{
+		"blocks": {
+			payload:  &messages.GossipPayload{Message: &messages.GossipPayload_Blocks{}},
+			expTopic: "ffgnet_blocks",
+		},
+		"compact block": {
+			payload:  &messages.GossipPayload{Message: &messages.GossipPayload_CompactBlock{}},
+			expTopic: "ffgnet_blocks",
+		},
+		"checkpoint attestation": {
+			payload:  &messages.GossipPayload{Message: &messages.GossipPayload_CheckpointAttestation{}},
+			expTopic: "ffgnet_blocks",
+		},
+		"transaction": {
+			payload:  &messages.GossipPayload{Message: &messages.GossipPayload_Transaction{}},
+			expTopic: "ffgnet_transactions",
+		},
+		"data query": {
+			payload:  &messages.GossipPayload{Message: &messages.GossipPayload_Query{}},
+			expTopic: "ffgnet_data_queries",
+		},
+		"unknown": {
+			payload: &messages.GossipPayload{},
+			expErr:  "unknown gossip payload",
+		},
+	}
+
+	for name, tt := range cases {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			topic, err := topics.topicOf(tt.payload)
+			if tt.expErr != "" {
+				assert.EqualError(t, err, tt.expErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expTopic, topic)
+			}
+		})
+	}
+}
+
+func TestValidateGossipTopicMessage(t *testing.T) {
+	validTx, _ := validTransaction(t)
+	txData, err := proto.Marshal(&messages.GossipPayload{Message: &messages.GossipPayload_Transaction{Transaction: transaction.ToProtoTransaction(*validTx)}})
+	assert.NoError(t, err)
+
+	cfg := &ffgconfig.Config{}
+	cfg.P2P.GossipMaxMessageSize = 10
+	n := &Node{
+		config:       cfg,
+		gossipTopics: NewGossipTopics("ffgnet", "ffgnet_pubsub"),
+	}
+
+	cases := map[string]struct {
+		topic  string
+		data   []byte
+		expErr string
+	}{
+		"transaction in its topic": {
+			topic: "ffgnet_transactions",
+			data:  txData,
+		},
+		"transaction in the legacy topic": {
+			topic: "ffgnet_pubsub",
+			data:  txData,
+		},
+		"transaction in the data queries topic": {
+			topic:  "ffgnet_data_queries",
+			data:   txData,
+			expErr: "message of topic ffgnet_transactions was published in topic ffgnet_data_queries",
+		},
+		"message bigger than the topic limit": {
+			topic:  "ffgnet_blocks",
+			data:   txData,
+			expErr: "allowed in topic ffgnet_blocks",
+		},
+		"message bigger than the data queries limit": {
+			topic:  "ffgnet_data_queries",
+			data:   make([]byte, maxDataQueryMessageSize+1),
+			expErr: "allowed in topic ffgnet_data_queries",
+		},
+		"invalid payload in the legacy topic": {
+			topic:  "ffgnet_pubsub",
+			data:   []byte{1, 2, 3},
+			expErr: "failed to unmarshal pubsub data",
+		},
+	}
+
+	for name, tt := range cases {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			err := n.validateGossipTopicMessage(context.TODO(), tt.topic, tt.data)
+			if tt.expErr != "" {
+				assert.ErrorContains(t, err, tt.expErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// heightBlockchain is a blockchain with a fixed height.
+type heightBlockchain struct {
+	blockchain.Interface
+	height uint64
+}
+
+func (b *heightBlockchain) GetHeight() uint64 {
+	return b.height
+}
+
+func TestValidateCheckpointAttestation(t *testing.T) {
+	_, kp := validTransaction(t)
+	_, outsider := validTransaction(t)
+	block.SetBlockVerifiers(block.Verifier{Address: kp.Address})
+
+	attest := func(kp ffgcrypto.KeyPair, blockNumber uint64) []byte {
+		attestation, err := blockchain.NewCheckpointAttestation(kp.PrivateKey, blockNumber, []byte{1})
+		assert.NoError(t, err)
+		data, err := proto.Marshal(&messages.GossipPayload{Message: &messages.GossipPayload_CheckpointAttestation{CheckpointAttestation: attestation}})
+		assert.NoError(t, err)
+		return data
+	}
+
+	invalidSignature, err := blockchain.NewCheckpointAttestation(kp.PrivateKey, 10, []byte{1})
+	assert.NoError(t, err)
+	invalidSignature.BlockHash = []byte{2}
+	invalidSignatureData, err := proto.Marshal(&messages.GossipPayload{Message: &messages.GossipPayload_CheckpointAttestation{CheckpointAttestation: invalidSignature}})
+	assert.NoError(t, err)
+
+	n := &Node{
+		config:       &ffgconfig.Config{},
+		gossipTopics: NewGossipTopics("ffgnet", "ffgnet_pubsub"),
+		blockchain:   &heightBlockchain{height: 15},
+	}
+
+	cases := map[string]struct {
+		topic  string
+		data   []byte
+		expErr string
+	}{
+		"invalid signature": {
+			topic:  "ffgnet_blocks",
+			data:   invalidSignatureData,
+			expErr: "failed to verify checkpoint attestation: invalid attestation signature",
+		},
+		"not a verifier": {
+			topic:  "ffgnet_blocks",
+			data:   attest(outsider, 10),
+			expErr: "failed to verify checkpoint attestation: attestation signer is not a verifier",
+		},
+		"not a verifier in the legacy topic": {
+			topic:  "ffgnet_pubsub",
+			data:   attest(outsider, 10),
+			expErr: "failed to verify checkpoint attestation: attestation signer is not a verifier",
+		},
+		"not a verifier ahead of the chain": {
+			topic:  "ffgnet_blocks",
+			data:   attest(outsider, 20),
+			expErr: "checkpoint attestation is ahead of the chain",
+		},
+		"valid attestation": {
+			topic: "ffgnet_blocks",
+			data:  attest(kp, 10),
+		},
+	}
+
+	for name, tt := range cases {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			err := n.validateGossipTopicMessage(context.TODO(), tt.topic, tt.data)
+			if tt.expErr != "" {
+				assert.ErrorContains(t, err, tt.expErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSeenMessages(t *testing.T) {
+	seen := newSeenMessages(10 * time.Millisecond)
+	assert.True(t, seen.add([]byte{1}))
+	assert.False(t, seen.add([]byte{1}))
+	assert.True(t, seen.add([]byte{2}))
+
+	time.Sleep(20 * time.Millisecond)
+	assert.True(t, seen.add([]byte{1}))
+	assert.Len(t, seen.entries, 1)
+}
+
+func TestValidateGossipMessage(t *testing.T) {
+	h := newHost(t, "1041")
+	t.Cleanup(func() {
+		h.Close()
+	})
+
+	banList, err := NewBanList(2, time.Hour)
+	assert.NoError(t, err)
+	n := &Node{host: h, banList: banList}
+	remote := randomPeerID(t)
+	invalid := &pubsub.Message{Message: &pb.Message{Data: []byte{1, 2, 3}}}
+
+	// messages of the node itself are accepted
+	assert.Equal(t, pubsub.ValidationAccept, n.validateGossipMessage(context.TODO(), h.ID(), invalid))
+
+	// blocks of unknown verifiers are ignored without penalizing the peer
+	unknownVerifierBlock, kp := validBlock(t)
+	err = unknownVerifierBlock.Sign(kp.PrivateKey)
+	assert.NoError(t, err)
+	data, err := proto.Marshal(&messages.GossipPayload{Message: &messages.GossipPayload_Blocks{Blocks: &messages.ProtoBlocks{Blocks: []*block.ProtoBlock{block.ToProtoBlock(*unknownVerifierBlock)}}}})
+	assert.NoError(t, err)
+	assert.Equal(t, pubsub.ValidationIgnore, n.validateGossipMessage(context.TODO(), remote, &pubsub.Message{Message: &pb.Message{Data: data}}))
+
+	// blocks ahead of the local clock are ignored without penalizing the peer
+	block.SetSchedule(block.Schedule{Period: 10 * time.Second, OutOfTurnDelay: 5 * time.Second})
+	t.Cleanup(func() {
+		block.SetSchedule(block.Schedule{})
+	})
+	futureBlock, kp := validBlock(t)
+	block.SetBlockVerifiers(block.Verifier{Address: kp.Address})
+	futureBlock.Timestamp = time.Now().Add(time.Minute).Unix()
+	err = futureBlock.Sign(kp.PrivateKey)
+	assert.NoError(t, err)
+	data, err = proto.Marshal(&messages.GossipPayload{Message: &messages.GossipPayload_Blocks{Blocks: &messages.ProtoBlocks{Blocks: []*block.ProtoBlock{block.ToProtoBlock(*futureBlock)}}}})
+	assert.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, pubsub.ValidationIgnore, n.validateGossipMessage(context.TODO(), remote, &pubsub.Message{Message: &pb.Message{Data: data}}))
+	}
+	assert.False(t, banList.Contains(remote))
+
+	// repeat offenders are banned
+	assert.Equal(t, pubsub.ValidationReject, n.validateGossipMessage(context.TODO(), remote, invalid))
+	assert.False(t, banList.Contains(remote))
+	assert.Equal(t, pubsub.ValidationReject, n.validateGossipMessage(context.TODO(), remote, invalid))
+	assert.True(t, banList.Contains(remote))
+	assert.Len(t, n.BannedPeers(), 1)
+
+	// banned peers are rejected even with valid messages
+	validTx, _ := validTransaction(t)
+	data, err = proto.Marshal(&messages.GossipPayload{Message: &messages.GossipPayload_Transaction{Transaction: transaction.ToProtoTransaction(*validTx)}})
+	assert.NoError(t, err)
+	valid := &pubsub.Message{Message: &pb.Message{Data: data}}
+	assert.Equal(t, pubsub.ValidationReject, n.validateGossipMessage(context.TODO(), remote, valid))
+
+	assert.True(t, n.UnbanPeer(remote))
+	assert.Equal(t, pubsub.ValidationAccept, n.validateGossipMessage(context.TODO(), remote, valid))
+
+	// admin bans
+	assert.EqualError(t, n.BanPeer(h.ID(), "admin"), "node can't ban itself")
+	assert.NoError(t, n.BanPeer(remote, "admin"))
+	assert.Equal(t, "admin", n.BannedPeers()[0].Reason)
+}
+
+func TestPeerScoreParams(t *testing.T) {
+	banList, err := NewBanList(DefaultMaxOffences, DefaultBanDuration)
+	assert.NoError(t, err)
+
+	h := newHost(t, "1042")
+	t.Cleanup(func() {
+		h.Close()
+	})
+
+	params := PeerScoreParams(banList, "topic1", "topic2")
+	assert.Len(t, params.Topics, 2)
+	_, err = pubsub.NewGossipSub(context.Background(), h, pubsub.WithPeerScore(params, PeerScoreThresholds()))
+	assert.NoError(t, err)
+
+	// banned peers are graylisted
+	p := randomPeerID(t)
+	assert.Equal(t, float64(0), params.AppSpecificScore(p))
+	banList.Ban(p, "admin")
+	assert.Less(t, params.AppSpecificScore(p), PeerScoreThresholds().GraylistThreshold)
+}
@@ -16,6 +16,7 @@ import (
 	ffgconfig "github.com/filefilego/filefilego/config"
 	ffgcrypto "github.com/filefilego/filefilego/crypto"
 	blockdownloader "github.com/filefilego/filefilego/node/protocols/block_downloader"
+	compactblock "github.com/filefilego/filefilego/node/protocols/compact_block"
 	dataquery "github.com/filefilego/filefilego/node/protocols/data_query"
 	"github.com/filefilego/filefilego/node/protocols/messages"
 	"github.com/filefilego/filefilego/search"
@@ -40,6 +41,8 @@ type PublishSubscriber interface {
 	Join(topic string, opts ...pubsub.TopicOpt) (*pubsub.Topic, error)
 	// Subscribe to a topic.
 	Subscribe(topic string, opts ...pubsub.SubOpt) (*pubsub.Subscription, error)
+	// RegisterTopicValidator registers a validator which runs before the messages of a topic are propagated.
+	RegisterTopicValidator(topic string, val interface{}, opts ...pubsub.ValidatorOpt) error
 }
 
 // PeerFinderBootstrapper is a dht interface.
@@ -56,14 +59,19 @@ type Interface interface {
 	Advertise(ctx context.Context, ns string)
 	DiscoverPeers(ctx context.Context, ns string) error
 	PublishMessageToNetwork(ctx context.Context, data []byte) error
-	HandleIncomingMessages(ctx context.Context, topicName string) error
+	HandleIncomingMessages(ctx context.Context) error
 	GetMultiaddr() ([]multiaddr.Multiaddr, error)
 	Peers() peer.IDSlice
 	GetID() string
 	GetPeerID() peer.ID
 	Bootstrap(ctx context.Context, bootstrapPeers []string) error
 	FindPeers(ctx context.Context, peerIDs []peer.ID) []peer.AddrInfo
-	JoinPubSubNetwork(ctx context.Context, topicName string) error
+	JoinPubSubNetwork(ctx context.Context, topics GossipTopics) error
+	BanPeer(id peer.ID, reason string) error
+	UnbanPeer(id peer.ID) bool
+	BannedPeers() []BannedPeer
+	SaveConnectedPeers() error
+	ConnectToKnownPeers(ctx context.Context, maxPeers int) (int, error)
 }
 
 // Node represents all the node functionalities
@@ -77,15 +85,20 @@ type Node struct {
 	blockchain              blockchain.Interface
 	dataQueryProtocol       dataquery.Interface
 	blockDownloaderProtocol blockdownloader.Interface
-
-	syncing     bool
-	syncingMu   sync.RWMutex
-	config      *ffgconfig.Config
-	gossipTopic *pubsub.Topic
+	compactBlockProtocol    compactblock.Interface
+	banList                 *BanList
+	addressBook             *AddressBook
+
+	syncing      bool
+	syncingMu    sync.RWMutex
+	config       *ffgconfig.Config
+	gossipTopics GossipTopics
+	topics       map[string]*pubsub.Topic
+	seenGossip   *seenMessages
 }
 
 // New creates a new node.
-func New(cfg *ffgconfig.Config, host host.Host, dht PeerFinderBootstrapper, discovery libp2pdiscovery.Discovery, pubSub PublishSubscriber, search search.IndexSearcher, storage storage.Interface, blockchain blockchain.Interface, dataQuery dataquery.Interface, blockDownloaderProtocol blockdownloader.Interface) (*Node, error) {
+func New(cfg *ffgconfig.Config, host host.Host, dht PeerFinderBootstrapper, discovery libp2pdiscovery.Discovery, pubSub PublishSubscriber, search search.IndexSearcher, storage storage.Interface, blockchain blockchain.Interface, dataQuery dataquery.Interface, blockDownloaderProtocol blockdownloader.Interface, compactBlockProtocol compactblock.Interface, banList *BanList, addressBook *AddressBook) (*Node, error) {
 	if cfg == nil {
 		return nil, errors.New("config is nil")
 	}
@@ -126,6 +139,18 @@ func New(cfg *ffgconfig.Config, host host.Host, dht PeerFinderBootstrapper, disc
 		return nil, errors.New("blockDownloader is nil")
 	}
 
+	if compactBlockProtocol == nil {
+		return nil, errors.New("compactBlock is nil")
+	}
+
+	if banList == nil {
+		return nil, errors.New("banList is nil")
+	}
+
+	if addressBook == nil {
+		return nil, errors.New("addressBook is nil")
+	}
+
 	return &Node{
 		host:                    host,
 		dht:                     dht,
@@ -136,7 +161,11 @@ func New(cfg *ffgconfig.Config, host host.Host, dht PeerFinderBootstrapper, disc
 		blockchain:              blockchain,
 		dataQueryProtocol:       dataQuery,
 		blockDownloaderProtocol: blockDownloaderProtocol,
+		compactBlockProtocol:    compactBlockProtocol,
+		banList:                 banList,
+		addressBook:             addressBook,
 		config:                  cfg,
+		seenGossip:              newSeenMessages(seenGossipTTL),
 	}, nil
 }
 
@@ -192,53 +221,16 @@ func (n *Node) Sync(ctx context.Context) error {
 	remotePeersList := n.blockDownloaderProtocol.GetRemotePeers()
 	log.Infof("syncing with nodes: %d", len(remotePeersList))
 	if len(remotePeersList) > 0 {
-		// while this node is behind the network
-		// try to download blocks
-		for n.blockchain.GetHeight() <= n.blockDownloaderProtocol.GetHeighestBlockNumberFromPeers() {
-			localHeight := n.blockchain.GetHeight()
-			request := messages.BlockDownloadRequestProto{
-				From: localHeight + 1,
-				To:   localHeight + 100,
-			}
-
-			remotePeer, err := n.blockDownloaderProtocol.GetNextPeer()
-			if err != nil {
-				log.Warn("no remote peers to download blocks from")
-				break
-			}
-
-			if n.blockchain.GetHeight() > remotePeer.CurrentHeight() {
-				n.blockDownloaderProtocol.RemoveRemotePeer(remotePeer)
-				continue
-			}
-
-			if request.To > remotePeer.CurrentHeight() {
-				request.To = remotePeer.CurrentHeight()
-			}
-
-			blockResponse, err := remotePeer.DownloadBlocksRange(ctx, &request)
-			if err != nil || blockResponse.Error {
-				n.blockDownloaderProtocol.RemoveRemotePeer(remotePeer)
-				continue
-			}
-
-			if blockResponse == nil {
-				continue
-			}
-
-			if len(blockResponse.Blocks) > 0 {
-				log.Infof("downloaded %d blocks from peer %s", len(blockResponse.Blocks), remotePeer.GetPeerID().String())
-			}
-
-			for _, blck := range blockResponse.Blocks {
-				if err := n.blockchain.PutBlockPool(block.ProtoBlockToBlock(blck)); err != nil {
-					return fmt.Errorf("failed to insert the downloaded block to blockPool: %w", err)
-				}
-			}
-
-			if blockResponse.NodeHeight <= n.blockchain.GetHeight() {
-				n.blockDownloaderProtocol.RemoveRemotePeer(remotePeer)
+		// headers are validated before the blocks are downloaded from all the peers concurrently.
+		// the blocks are applied before the next window is downloaded, even if the pool is being applied by a gossip message.
+		err := n.blockDownloaderProtocol.Sync(ctx, func(blck block.Block) error {
+			if err := n.blockchain.PutBlockPoolAndWait(blck); err != nil {
+				return fmt.Errorf("failed to insert the downloaded block to blockPool: %w", err)
 			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to sync blocks: %w", err)
 		}
 	}
 
@@ -282,59 +274,128 @@ func (n *Node) DiscoverPeers(ctx context.Context, ns string) error {
 	return nil
 }
 
-// PublishMessageToNetwork publish a message to the network.
+// PublishMessageToNetwork publish a message to the topic of its type.
+// during the migration window it's published to the legacy topic as well.
 func (n *Node) PublishMessageToNetwork(ctx context.Context, data []byte) error {
-	if n.gossipTopic == nil {
+	if len(n.topics) == 0 {
 		return errors.New("pubsub topic is not available")
 	}
 
-	if err := n.gossipTopic.Publish(ctx, data); err != nil {
-		return fmt.Errorf("failed to publish message to network: %w", err)
+	payload := messages.GossipPayload{}
+	if err := proto.Unmarshal(data, &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal gossip payload: %w", err)
+	}
+
+	if blocks, ok := payload.GetMessage().(*messages.GossipPayload_Blocks); ok {
+		return n.publishBlocks(ctx, blocks.Blocks.GetBlocks(), data)
+	}
+
+	topicName, err := n.gossipTopics.topicOf(&payload)
+	if err != nil {
+		return fmt.Errorf("failed to get topic of gossip payload: %w", err)
+	}
+
+	topicNames := []string{topicName}
+	// compact blocks can't be rebuilt by the nodes on the legacy topic
+	if _, ok := payload.GetMessage().(*messages.GossipPayload_CompactBlock); !ok && n.gossipTopics.Legacy != "" {
+		topicNames = append(topicNames, n.gossipTopics.Legacy)
+	}
+
+	for _, name := range topicNames {
+		if err := n.topics[name].Publish(ctx, data); err != nil {
+			return fmt.Errorf("failed to publish message to network: %w", err)
+		}
 	}
 	return nil
 }
 
-// JoinPubSubNetwork joins the gossip network.
-func (n *Node) JoinPubSubNetwork(ctx context.Context, topicName string) error {
-	if n.gossipTopic != nil {
+// JoinPubSubNetwork joins the gossip topics.
+// all the topics are joined so the node can publish any message type, but only the ones required by the node's mode are subscribed.
+func (n *Node) JoinPubSubNetwork(ctx context.Context, topics GossipTopics) error {
+	if len(n.topics) > 0 {
 		return errors.New("already subscribed to topic")
 	}
 
-	topic, err := n.pubSub.Join(topicName)
-	if err != nil {
-		return fmt.Errorf("failed to join pubsub topic: %w", err)
+	if topics.Blocks == "" || topics.Transactions == "" || topics.DataQueries == "" {
+		return errors.New("gossip topics are empty")
+	}
+
+	n.gossipTopics = topics
+	joinedTopics := make(map[string]*pubsub.Topic)
+	for _, name := range topics.Names() {
+		// invalid messages are dropped before they are propagated to other peers
+		err := n.pubSub.RegisterTopicValidator(name, n.validateGossipMessage)
+		if err != nil {
+			return fmt.Errorf("failed to register pubsub topic validator: %w", err)
+		}
+
+		topic, err := n.pubSub.Join(name)
+		if err != nil {
+			return fmt.Errorf("failed to join pubsub topic: %w", err)
+		}
+		joinedTopics[name] = topic
 	}
 
-	n.gossipTopic = topic
+	n.topics = joinedTopics
 	return nil
 }
 
-// HandleIncomingMessages gets the messages from gossip network.
-func (n *Node) HandleIncomingMessages(ctx context.Context, topicName string) error {
-	if n.gossipTopic == nil {
+// subscriptionTopics returns the topics required by the node's mode.
+// super light nodes only publish, and data queries are handled by storage nodes.
+func (n *Node) subscriptionTopics() []string {
+	if n.config.Global.SuperLightNode {
+		return nil
+	}
+
+	names := []string{n.gossipTopics.Blocks, n.gossipTopics.Transactions}
+	if n.config.Global.Storage {
+		names = append(names, n.gossipTopics.DataQueries)
+	}
+
+	if n.gossipTopics.Legacy != "" {
+		names = append(names, n.gossipTopics.Legacy)
+	}
+	return names
+}
+
+// HandleIncomingMessages subscribes to the topics required by the node's mode and handles their messages.
+func (n *Node) HandleIncomingMessages(ctx context.Context) error {
+	if len(n.topics) == 0 {
 		return errors.New("not subscribed to to topic")
 	}
 
-	sub, err := n.gossipTopic.Subscribe()
-	if err != nil {
-		return fmt.Errorf("failed to subscribe to topic %s: %w", topicName, err)
+	for _, name := range n.subscriptionTopics() {
+		sub, err := n.topics[name].Subscribe()
+		if err != nil {
+			return fmt.Errorf("failed to subscribe to topic %s: %w", name, err)
+		}
+
+		go n.handleSubscription(ctx, sub)
 	}
+	return nil
+}
 
-	go func() {
-		for {
-			msg, err := sub.Next(ctx)
-			if err != nil {
-				log.Errorf("failed to read next message from subscription: %v", err)
-				continue
+func (n *Node) handleSubscription(ctx context.Context, sub *pubsub.Subscription) {
+	for {
+		msg, err := sub.Next(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
 			}
+			log.Errorf("failed to read next message from subscription: %v", err)
+			continue
+		}
 
-			err = n.processIncomingMessage(ctx, msg)
-			if err != nil {
-				log.Errorf("failed to process incoming message: %v", err)
-			}
+		// the same message is received on the legacy and the new topic during the migration window
+		if !n.seenGossip.add(msg.Data) {
+			continue
 		}
-	}()
-	return nil
+
+		err = n.processIncomingMessage(ctx, msg)
+		if err != nil {
+			log.Errorf("failed to process incoming message: %v", err)
+		}
+	}
 }
 
 func (n *Node) processIncomingMessage(ctx context.Context, message *pubsub.Message) error {
@@ -365,6 +426,23 @@ func (n *Node) processIncomingMessage(ctx context.Context, message *pubsub.Messa
 			}
 		}
 
+	case *messages.GossipPayload_CompactBlock:
+		// handle incoming compact block
+		if n.host.ID().String() == message.ReceivedFrom.String() {
+			return nil
+		}
+
+		// the block was rebuilt when the message was validated
+		retrivedBlock, err := n.rebuildCompactBlock(ctx, payload.GetCompactBlock(), message.ReceivedFrom, message.GetFrom())
+		if err != nil {
+			return fmt.Errorf("failed to rebuild compact block: %w", err)
+		}
+		log.Infof("compact block %d received from peer %s | local blockchain height: %d", retrivedBlock.Number, message.ReceivedFrom.String(), n.blockchain.GetHeight())
+
+		if err := n.blockchain.PutBlockPool(retrivedBlock); err != nil {
+			return fmt.Errorf("failed to insert block to blockPool: %w", err)
+		}
+
 	case *messages.GossipPayload_Transaction:
 		// handle incoming transaction
 		if n.host.ID().String() == message.ReceivedFrom.String() {
@@ -382,6 +460,16 @@ func (n *Node) processIncomingMessage(ctx context.Context, message *pubsub.Messa
 			}
 		}
 
+	case *messages.GossipPayload_CheckpointAttestation:
+		// handle incoming checkpoint attestation
+		if n.host.ID().String() == message.ReceivedFrom.String() {
+			return nil
+		}
+
+		if _, err := n.blockchain.AddCheckpointAttestation(payload.GetCheckpointAttestation()); err != nil {
+			return fmt.Errorf("failed to add checkpoint attestation: %w", err)
+		}
+
 	case *messages.GossipPayload_Query:
 		// handle incoming data query
 		if !n.config.Global.Storage {
@@ -453,7 +541,10 @@ func (n *Node) processIncomingMessage(ctx context.Context, message *pubsub.Messa
 
 		// send to requester, if it fails
 		// then send to verifiers
-		verfiers := block.GetBlockVerifiers()
+		verfiers, err := block.GetBlockVerifiers()
+		if err != nil {
+			return fmt.Errorf("failed to get verifiers: %w", err)
+		}
 		peerIDs := make([]peer.ID, 0)
 		peerIDs = append(peerIDs, fileRequesterID)
 
@@ -14,6 +14,7 @@ import (
 	ffgcrypto "github.com/filefilego/filefilego/crypto"
 	"github.com/filefilego/filefilego/database"
 	blockdownloader "github.com/filefilego/filefilego/node/protocols/block_downloader"
+	compactblock "github.com/filefilego/filefilego/node/protocols/compact_block"
 	dataquery "github.com/filefilego/filefilego/node/protocols/data_query"
 	"github.com/filefilego/filefilego/node/protocols/messages"
 	"github.com/filefilego/filefilego/search"
@@ -56,6 +57,9 @@ func TestNew(t *testing.T) {
 		blockchain              blockchain.Interface
 		dataQueryProtocol       dataquery.Interface
 		blockDownloaderProtocol blockdownloader.Interface
+		compactBlockProtocol    compactblock.Interface
+		banList                 *BanList
+		addressBook             *AddressBook
 		config                  *ffgconfig.Config
 		expErr                  string
 	}{
@@ -134,6 +138,48 @@ func TestNew(t *testing.T) {
 			dataQueryProtocol: dataQueryProtocol,
 			expErr:            "blockDownloader is nil",
 		},
+		"no compactBlock": {
+			config:                  &ffgconfig.Config{},
+			host:                    h,
+			dht:                     kademliaDHT,
+			discovery:               &drouting.RoutingDiscovery{},
+			searchEngine:            &search.BleveSearch{},
+			storage:                 &storage.Storage{},
+			pubSub:                  &pubsub.PubSub{},
+			blockchain:              &blockchain.Blockchain{},
+			dataQueryProtocol:       dataQueryProtocol,
+			blockDownloaderProtocol: &blockdownloader.Protocol{},
+			expErr:                  "compactBlock is nil",
+		},
+		"no banList": {
+			config:                  &ffgconfig.Config{},
+			host:                    h,
+			dht:                     kademliaDHT,
+			discovery:               &drouting.RoutingDiscovery{},
+			searchEngine:            &search.BleveSearch{},
+			storage:                 &storage.Storage{},
+			pubSub:                  &pubsub.PubSub{},
+			blockchain:              &blockchain.Blockchain{},
+			dataQueryProtocol:       dataQueryProtocol,
+			blockDownloaderProtocol: &blockdownloader.Protocol{},
+			compactBlockProtocol:    &compactblock.Protocol{},
+			expErr:                  "banList is nil",
+		},
+		"no addressBook": {
+			config:                  &ffgconfig.Config{},
+			host:                    h,
+			dht:                     kademliaDHT,
+			discovery:               &drouting.RoutingDiscovery{},
+			searchEngine:            &search.BleveSearch{},
+			storage:                 &storage.Storage{},
+			pubSub:                  &pubsub.PubSub{},
+			blockchain:              &blockchain.Blockchain{},
+			dataQueryProtocol:       dataQueryProtocol,
+			blockDownloaderProtocol: &blockdownloader.Protocol{},
+			compactBlockProtocol:    &compactblock.Protocol{},
+			banList:                 &BanList{},
+			expErr:                  "addressBook is nil",
+		},
 		"success": {
 			config:                  &ffgconfig.Config{},
 			host:                    h,
@@ -145,6 +191,9 @@ func TestNew(t *testing.T) {
 			blockchain:              &blockchain.Blockchain{},
 			dataQueryProtocol:       dataQueryProtocol,
 			blockDownloaderProtocol: &blockdownloader.Protocol{},
+			compactBlockProtocol:    &compactblock.Protocol{},
+			banList:                 &BanList{},
+			addressBook:             &AddressBook{},
 		},
 	}
 
@@ -152,7 +201,7 @@ func TestNew(t *testing.T) {
 		tt := tt
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
-			node, err := New(tt.config, tt.host, tt.dht, tt.discovery, tt.pubSub, tt.searchEngine, tt.storage, tt.blockchain, tt.dataQueryProtocol, tt.blockDownloaderProtocol)
+			node, err := New(tt.config, tt.host, tt.dht, tt.discovery, tt.pubSub, tt.searchEngine, tt.storage, tt.blockchain, tt.dataQueryProtocol, tt.blockDownloaderProtocol, tt.compactBlockProtocol, tt.banList, tt.addressBook)
 			if tt.expErr != "" {
 				assert.Nil(t, node)
 				assert.EqualError(t, err, tt.expErr)
@@ -346,19 +395,25 @@ func TestNodeMethods(t *testing.T) {
 	assert.EqualError(t, err, "pubsub topic is not available")
 
 	// HandleIncomingMessages
-	err = n1.JoinPubSubNetwork(ctx, "randevouz")
+	err = n1.HandleIncomingMessages(ctx)
+	assert.EqualError(t, err, "not subscribed to to topic")
+	err = n1.JoinPubSubNetwork(ctx, GossipTopics{})
+	assert.EqualError(t, err, "gossip topics are empty")
+	err = n1.JoinPubSubNetwork(ctx, testGossipTopics)
 	assert.NoError(t, err)
-	err = n1.HandleIncomingMessages(ctx, "randevouz")
+	err = n1.HandleIncomingMessages(ctx)
 	assert.NoError(t, err)
+	err = n1.JoinPubSubNetwork(ctx, testGossipTopics)
+	assert.EqualError(t, err, "already subscribed to topic")
 
-	err = n2.JoinPubSubNetwork(ctx, "randevouz")
+	err = n2.JoinPubSubNetwork(ctx, testGossipTopics)
 	assert.NoError(t, err)
-	err = n2.HandleIncomingMessages(ctx, "randevouz")
+	err = n2.HandleIncomingMessages(ctx)
 	assert.NoError(t, err)
 
-	err = n3.JoinPubSubNetwork(ctx, "randevouz")
+	err = n3.JoinPubSubNetwork(ctx, testGossipTopics)
 	assert.NoError(t, err)
-	err = n3.HandleIncomingMessages(ctx, "randevouz")
+	err = n3.HandleIncomingMessages(ctx)
 	assert.NoError(t, err)
 
 	// add delay just to propagate the changes to the nodes.
@@ -375,9 +430,9 @@ func TestNodeMethods(t *testing.T) {
 
 	// node3 publishes a message to network
 	// PublishMessageToNetwork
+	// messages are published to the topic of their type, so unknown payloads can't be published
 	err = n3.PublishMessageToNetwork(ctx, []byte("hello world"))
-	time.Sleep(100 * time.Millisecond)
-	assert.NoError(t, err)
+	assert.ErrorContains(t, err, "gossip payload")
 
 	// send an invalid transaction to the network
 	tx := transaction.Transaction{
@@ -425,8 +480,9 @@ func TestNodeMethods(t *testing.T) {
 	}
 	blockData, err = proto.Marshal(&payload)
 	assert.NoError(t, err)
+	// blocks are published as compact blocks, which can't be created from an invalid block
 	err = n3.PublishMessageToNetwork(ctx, blockData)
-	assert.NoError(t, err)
+	assert.ErrorContains(t, err, "failed to create compact block")
 	time.Sleep(200 * time.Millisecond)
 
 	// blockpool should be empty
@@ -483,6 +539,8 @@ func newHost(t *testing.T, port string) host.Host {
 	return host
 }
 
+var testGossipTopics = NewGossipTopics("randevouz", "randevouz_pubsub")
+
 func createNode(t *testing.T, port string, searchDB string, blockchainDBPath string) *Node {
 	bgCtx := context.Background()
 	genesisblockValid, err := block.GetGenesisBlock()
@@ -501,9 +559,14 @@ func createNode(t *testing.T, port string, searchDB string, blockchainDBPath str
 	searchEngine, err := search.New(blv)
 	assert.NoError(t, err)
 
+	banList, err := NewBanList(DefaultMaxOffences, DefaultBanDuration)
+	assert.NoError(t, err)
+
 	optsPS := []pubsub.Option{
 		pubsub.WithMessageSigning(true),
 		pubsub.WithMaxMessageSize(10 * pubsub.DefaultMaxMessageSize),
+		pubsub.WithPeerScore(PeerScoreParams(banList, testGossipTopics.Names()...), PeerScoreThresholds()),
+		pubsub.WithBlacklist(banList),
 	}
 	gossip, err := pubsub.NewGossipSub(bgCtx, host, optsPS...)
 	assert.NoError(t, err)
@@ -529,7 +592,13 @@ func createNode(t *testing.T, port string, searchDB string, blockchainDBPath str
 	blockDownloader, err := blockdownloader.New(bchain, host)
 	assert.NoError(t, err)
 
-	node, err := New(&ffgconfig.Config{}, host, kademliaDHT, routingDiscovery, gossip, searchEngine, &storage.Storage{}, bchain, dataQueryProtocol, blockDownloader)
+	compactBlockProtocol, err := compactblock.New(host)
+	assert.NoError(t, err)
+
+	addressBook, err := NewAddressBook(blockchainDB, DefaultAddressBookMaxAge, DefaultAddressBookMaxPeers)
+	assert.NoError(t, err)
+
+	node, err := New(&ffgconfig.Config{}, host, kademliaDHT, routingDiscovery, gossip, searchEngine, &storage.Storage{}, bchain, dataQueryProtocol, blockDownloader, compactBlockProtocol, banList, addressBook)
 	assert.NoError(t, err)
 	return node
 }
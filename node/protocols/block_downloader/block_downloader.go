@@ -2,8 +2,10 @@ package blockdownloader
 
 import (
 	"bufio"
+	"context"
 	"encoding/binary"
 	"errors"
+	"fmt"
 	"io"
 	"sync"
 
@@ -13,6 +15,7 @@ import (
 	"github.com/filefilego/filefilego/node/protocols/messages"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/protobuf/proto"
 )
@@ -23,6 +26,9 @@ const BlockDownloaderProtocolID = "/ffg/block_downloader/1.0.0"
 // BlockchainHeightProtocolID is the protocol which returns the blockchain height of a node.
 const BlockchainHeightProtocolID = "/ffg/blockchain_height/1.0.0"
 
+// BlockHeadersProtocolID is the protocol which returns the headers of a block range.
+const BlockHeadersProtocolID = "/ffg/block_headers/1.0.0"
+
 // Interface defines the block download protocol functionality.
 type Interface interface {
 	AddRemotePeer(remote *RemotePeer)
@@ -31,6 +37,7 @@ type Interface interface {
 	Reset()
 	GetRemotePeers() []*RemotePeer
 	GetHeighestBlockNumberFromPeers() uint64
+	Sync(ctx context.Context, handler BlockHandler) error
 }
 
 // Protocol implements the block downloader functionality.
@@ -40,6 +47,10 @@ type Protocol struct {
 	remotePeers   []*RemotePeer
 	nextPeerIndex int
 	mu            sync.RWMutex
+
+	// stats keeps the download performance of the peers between syncs.
+	stats   map[peer.ID]*peerStats
+	statsMu sync.Mutex
 }
 
 // New creates a block downloader protocol.
@@ -56,12 +67,15 @@ func New(bchain blockchain.Interface, h host.Host) (*Protocol, error) {
 		blockchain:  bchain,
 		host:        h,
 		remotePeers: make([]*RemotePeer, 0),
+		stats:       make(map[peer.ID]*peerStats),
 	}
 
 	// listen for blockchain height request
 	p.host.SetStreamHandler(BlockchainHeightProtocolID, p.onBlockchainHeightRequest)
 	// listen for incoming block request
 	p.host.SetStreamHandler(BlockDownloaderProtocolID, p.onBlockDownloadRequest)
+	// listen for incoming block headers request
+	p.host.SetStreamHandler(BlockHeadersProtocolID, p.onBlockHeadersRequest)
 
 	return p, nil
 }
@@ -156,31 +170,11 @@ func (bd *Protocol) Reset() {
 
 // onBlockDownloadRequest handles block downloads request.
 func (bd *Protocol) onBlockDownloadRequest(s network.Stream) {
-	c := bufio.NewReader(s)
 	defer s.Close()
 
-	// read the first 8 bytes to determine the size of the message
-	msgLengthBuffer := make([]byte, 8)
-	_, err := c.Read(msgLengthBuffer)
-	if err != nil {
-		log.Errorf("failed to read from block downloader stream: %v", err)
-		return
-	}
-
-	// create a buffer with the size of the message and then read until its full
-	lengthPrefix := int64(binary.LittleEndian.Uint64(msgLengthBuffer))
-	buf := make([]byte, lengthPrefix)
-
-	// read the full message
-	_, err = io.ReadFull(c, buf)
+	downloadRequest, err := readRequest(s)
 	if err != nil {
-		log.Errorf("failed to read from stream to buffer: %v", err)
-		return
-	}
-
-	downloadRequest := messages.BlockDownloadRequestProto{}
-	if err := proto.Unmarshal(buf, &downloadRequest); err != nil {
-		log.Errorf("failed to unmarshall data from stream: %v", err)
+		log.Errorf("failed to read block download request: %v", err)
 		return
 	}
 
@@ -193,7 +187,8 @@ func (bd *Protocol) onBlockDownloadRequest(s network.Stream) {
 		Blocks:     make([]*block.ProtoBlock, 0),
 	}
 
-	if (downloadRequest.From > downloadRequest.To) || (downloadRequest.To > nodeHeight) {
+	// pruned nodes can't serve the blocks below their lowest block
+	if (downloadRequest.From > downloadRequest.To) || (downloadRequest.To > nodeHeight) || (downloadRequest.From < bd.blockchain.GetLowestBlockNumber()) {
 		downloadResponse.Error = true
 	} else {
 		for i := downloadRequest.From; i <= downloadRequest.To; i++ {
@@ -208,9 +203,88 @@ func (bd *Protocol) onBlockDownloadRequest(s network.Stream) {
 		}
 	}
 
-	payload, err := proto.Marshal(&downloadResponse)
+	writeResponse(s, &downloadResponse)
+}
+
+// onBlockHeadersRequest handles the block headers request.
+func (bd *Protocol) onBlockHeadersRequest(s network.Stream) {
+	defer s.Close()
+
+	headersRequest, err := readRequest(s)
+	if err != nil {
+		log.Errorf("failed to read block headers request: %v", err)
+		return
+	}
+
+	nodeHeight := bd.blockchain.GetHeight()
+	headersResponse := messages.BlockHeadersResponseProto{
+		From:       headersRequest.From,
+		To:         headersRequest.To,
+		Error:      false,
+		NodeHeight: nodeHeight,
+		Headers:    make([]*block.ProtoBlockHeader, 0),
+	}
+
+	if (headersRequest.From > headersRequest.To) || (headersRequest.To > nodeHeight) || (headersRequest.From < bd.blockchain.GetLowestBlockNumber()) || (headersRequest.To-headersRequest.From >= maxHeadersPerRequest) {
+		headersResponse.Error = true
+	} else {
+		for i := headersRequest.From; i <= headersRequest.To; i++ {
+			blck, err := bd.blockchain.GetBlockByNumber(i)
+			if err != nil {
+				headersResponse.Error = true
+				headersResponse.Headers = []*block.ProtoBlockHeader{}
+				break
+			}
+
+			header, err := blck.Header()
+			if err != nil {
+				headersResponse.Error = true
+				headersResponse.Headers = []*block.ProtoBlockHeader{}
+				break
+			}
+			headersResponse.Headers = append(headersResponse.Headers, block.ToProtoBlockHeader(header))
+		}
+	}
+
+	writeResponse(s, &headersResponse)
+}
+
+// readRequest reads a length prefixed block range request from the stream.
+func readRequest(s network.Stream) (*messages.BlockDownloadRequestProto, error) {
+	c := bufio.NewReader(s)
+
+	// read the first 8 bytes to determine the size of the message
+	msgLengthBuffer := make([]byte, 8)
+	_, err := io.ReadFull(c, msgLengthBuffer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read from stream: %w", err)
+	}
+
+	// create a buffer with the size of the message and then read until its full
+	lengthPrefix := binary.LittleEndian.Uint64(msgLengthBuffer)
+	if lengthPrefix > 2*common.KB {
+		return nil, fmt.Errorf("request size %d is too large", lengthPrefix)
+	}
+	buf := make([]byte, lengthPrefix)
+
+	// read the full message
+	_, err = io.ReadFull(c, buf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read from stream to buffer: %w", err)
+	}
+
+	request := messages.BlockDownloadRequestProto{}
+	if err := proto.Unmarshal(buf, &request); err != nil {
+		return nil, fmt.Errorf("failed to unmarshall data from stream: %w", err)
+	}
+	return &request, nil
+}
+
+// writeResponse writes a length prefixed response to the stream.
+func writeResponse(s network.Stream, response proto.Message) {
+	payload, err := proto.Marshal(response)
 	if err != nil {
-		log.Error("failed to marshal BlockQueryResponse")
+		log.Error("failed to marshal block download response")
 		return
 	}
 
@@ -235,7 +309,9 @@ func (bd *Protocol) onBlockDownloadRequest(s network.Stream) {
 // onBlockchainHeightRequest handles the blockchain height request.
 func (bd *Protocol) onBlockchainHeightRequest(s network.Stream) {
 	response := messages.BlockchainHeightResponseProto{
-		Height: bd.blockchain.GetHeight(),
+		Height:            bd.blockchain.GetHeight(),
+		LowestBlockNumber: bd.blockchain.GetLowestBlockNumber(),
+		Pruned:            bd.blockchain.IsPruned(),
 	}
 	data, _ := proto.Marshal(&response)
 	n, err := s.Write(data)
@@ -2,6 +2,7 @@ package blockdownloader
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"testing"
@@ -214,6 +215,9 @@ func TestProtocolMethods(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, responseFromH1)
 	assert.Equal(t, uint64(1), remoteFromH2ToH1.CurrentHeight())
+	assert.Equal(t, uint64(0), remoteFromH2ToH1.LowestBlockNumber())
+	assert.False(t, remoteFromH2ToH1.IsPruned())
+	assert.True(t, remoteFromH2ToH1.CanServe(1))
 
 	responseFromH3, err := remoteFromH2ToH3.GetHeight(context.TODO())
 	assert.NoError(t, err)
@@ -239,6 +243,232 @@ func TestProtocolMethods(t *testing.T) {
 	assert.Len(t, protocol2.GetRemotePeers(), 0)
 }
 
+func TestSync(t *testing.T) {
+	genesisblockValid, err := block.GetGenesisBlock()
+	assert.NoError(t, err)
+
+	chains := make([]*blockchain.Blockchain, 0, 3)
+	for _, name := range []string{"sync1.db", "sync2.db", "sync3.db"} {
+		name := name
+		db, err := leveldb.OpenFile(name, nil)
+		assert.NoError(t, err)
+		driver, err := database.New(db)
+		assert.NoError(t, err)
+		t.Cleanup(func() {
+			db.Close()
+			os.RemoveAll(name)
+		})
+
+		bchain, err := blockchain.New(driver, &search.Search{}, genesisblockValid.Hash)
+		assert.NoError(t, err)
+		err = bchain.InitOrLoad(true)
+		assert.NoError(t, err)
+		chains = append(chains, bchain)
+	}
+
+	// the first peer has the whole chain and the third one only the beginning of it
+	blocks := validChain(t, genesisblockValid.Hash, 1, 250)
+	for i, blck := range blocks {
+		err := chains[0].PerformStateUpdateFromBlock(blck)
+		assert.NoError(t, err)
+		if i < 120 {
+			err := chains[2].PerformStateUpdateFromBlock(blck)
+			assert.NoError(t, err)
+		}
+	}
+
+	h1 := newHost(t, "1181")
+	h2 := newHost(t, "1182")
+	h3 := newHost(t, "1183")
+	t.Cleanup(func() {
+		h1.Close()
+		h2.Close()
+		h3.Close()
+	})
+
+	err = h2.Connect(context.TODO(), peer.AddrInfo{ID: h1.ID(), Addrs: h1.Addrs()})
+	assert.NoError(t, err)
+	err = h2.Connect(context.TODO(), peer.AddrInfo{ID: h3.ID(), Addrs: h3.Addrs()})
+	assert.NoError(t, err)
+
+	_, err = New(chains[0], h1)
+	assert.NoError(t, err)
+	protocol2, err := New(chains[1], h2)
+	assert.NoError(t, err)
+	_, err = New(chains[2], h3)
+	assert.NoError(t, err)
+
+	// nothing to sync without remote peers
+	err = protocol2.Sync(context.TODO(), func(blck block.Block) error { return nil })
+	assert.NoError(t, err)
+
+	for _, id := range []peer.ID{h1.ID(), h3.ID()} {
+		remote, err := NewRemotePeer(h2, id)
+		assert.NoError(t, err)
+		_, err = remote.GetHeight(context.TODO())
+		assert.NoError(t, err)
+		protocol2.AddRemotePeer(remote)
+	}
+
+	// headers
+	headersResponse, err := protocol2.GetRemotePeers()[0].DownloadHeadersRange(context.TODO(), &messages.BlockDownloadRequestProto{From: 1, To: 10})
+	assert.NoError(t, err)
+	assert.False(t, headersResponse.Error)
+	assert.Len(t, headersResponse.Headers, 10)
+	assert.Equal(t, blocks[0].Hash, headersResponse.Headers[0].Hash)
+
+	headersResponse, err = protocol2.GetRemotePeers()[0].DownloadHeadersRange(context.TODO(), &messages.BlockDownloadRequestProto{From: 1, To: maxHeadersPerRequest + 1})
+	assert.NoError(t, err)
+	assert.True(t, headersResponse.Error)
+
+	// blocks are handled in order
+	handled := uint64(0)
+	err = protocol2.Sync(context.TODO(), func(blck block.Block) error {
+		handled++
+		assert.Equal(t, handled, blck.Number)
+		return chains[1].PutBlockPool(blck)
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(250), handled)
+	assert.Equal(t, uint64(250), chains[1].GetHeight())
+	assert.Equal(t, chains[0].GetLastBlockHash(), chains[1].GetLastBlockHash())
+
+	// handler errors stop the sync
+	err = chains[0].PerformStateUpdateFromBlock(validChain(t, blocks[len(blocks)-1].Hash, 251, 1)[0])
+	assert.NoError(t, err)
+	for _, remote := range protocol2.GetRemotePeers() {
+		_, err = remote.GetHeight(context.TODO())
+		assert.NoError(t, err)
+	}
+	err = protocol2.Sync(context.TODO(), func(blck block.Block) error {
+		return errors.New("handler error")
+	})
+	assert.EqualError(t, err, "failed to download blocks: handler error")
+
+	// the sync stops without an error when none of the peers can serve the next blocks
+	h1.Close()
+	err = protocol2.Sync(context.TODO(), func(blck block.Block) error {
+		return chains[1].PutBlockPoolAndWait(blck)
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(250), chains[1].GetHeight())
+}
+
+func TestValidateHeaders(t *testing.T) {
+	headers := protoHeaders(t, validChain(t, []byte{1}, 1, 3))
+	otherHeaders := protoHeaders(t, validChain(t, []byte{2}, 1, 2))
+
+	cases := map[string]struct {
+		request  *messages.BlockDownloadRequestProto
+		response *messages.BlockHeadersResponseProto
+		expLen   int
+		expErr   string
+	}{
+		"error response": {
+			request:  &messages.BlockDownloadRequestProto{From: 1, To: 3},
+			response: &messages.BlockHeadersResponseProto{Error: true},
+			expErr:   "peer failed to serve the headers",
+		},
+		"empty response": {
+			request:  &messages.BlockDownloadRequestProto{From: 1, To: 3},
+			response: &messages.BlockHeadersResponseProto{},
+			expErr:   "response doesn't contain any header",
+		},
+		"too many headers": {
+			request:  &messages.BlockDownloadRequestProto{From: 1, To: 2},
+			response: &messages.BlockHeadersResponseProto{Headers: headers},
+			expErr:   "response contains 3 headers which is more than requested",
+		},
+		"wrong number": {
+			request:  &messages.BlockDownloadRequestProto{From: 2, To: 4},
+			response: &messages.BlockHeadersResponseProto{Headers: headers},
+			expErr:   "expected header 2 but got 1",
+		},
+		"not linked": {
+			request:  &messages.BlockDownloadRequestProto{From: 1, To: 3},
+			response: &messages.BlockHeadersResponseProto{Headers: []*block.ProtoBlockHeader{headers[0], otherHeaders[1]}},
+			expErr:   "header 2 is not linked to the previous header",
+		},
+		"success": {
+			request:  &messages.BlockDownloadRequestProto{From: 1, To: 3},
+			response: &messages.BlockHeadersResponseProto{Headers: headers},
+			expLen:   3,
+		},
+	}
+
+	for name, tt := range cases {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			validated, err := validateHeaders(tt.request, tt.response)
+			if tt.expErr != "" {
+				assert.EqualError(t, err, tt.expErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, validated, tt.expLen)
+			}
+		})
+	}
+}
+
+func protoHeaders(t *testing.T, blocks []block.Block) []*block.ProtoBlockHeader {
+	headers := make([]*block.ProtoBlockHeader, 0, len(blocks))
+	for _, blck := range blocks {
+		header, err := blck.Header()
+		assert.NoError(t, err)
+		headers = append(headers, block.ToProtoBlockHeader(header))
+	}
+	return headers
+}
+
+// generate a chain of blocks signed by a new verifier
+func validChain(t *testing.T, previousBlockHash []byte, from uint64, count int) []block.Block {
+	kp, err := ffgcrypto.GenerateKeyPair()
+	assert.NoError(t, err)
+	pubKeyBytes, err := kp.PublicKey.Raw()
+	assert.NoError(t, err)
+	block.SetBlockVerifiers(block.Verifier{
+		Address:   kp.Address,
+		PublicKey: hexutil.Encode(pubKeyBytes),
+	})
+
+	mainChain, err := hexutil.Decode("0x01")
+	assert.NoError(t, err)
+
+	blocks := make([]block.Block, 0, count)
+	for i := 0; i < count; i++ {
+		number := from + uint64(i)
+		reward, err := block.GetReward(number)
+		assert.NoError(t, err)
+
+		coinbasetx := transaction.Transaction{
+			PublicKey:       pubKeyBytes,
+			Nounce:          []byte{0},
+			Data:            []byte{1},
+			From:            kp.Address,
+			To:              kp.Address,
+			Chain:           mainChain,
+			Value:           hexutil.EncodeBig(reward),
+			TransactionFees: "0x0",
+		}
+		err = coinbasetx.Sign(kp.PrivateKey)
+		assert.NoError(t, err)
+
+		b := block.Block{
+			Timestamp:         time.Now().Unix(),
+			Data:              []byte{1},
+			PreviousBlockHash: previousBlockHash,
+			Transactions:      []transaction.Transaction{coinbasetx},
+			Number:            number,
+		}
+		err = b.Sign(kp.PrivateKey)
+		assert.NoError(t, err)
+
+		blocks = append(blocks, b)
+		previousBlockHash = b.Hash
+	}
+	return blocks
+}
+
 func newHost(t *testing.T, port string) host.Host {
 	priv, _, err := crypto.GenerateKeyPair(crypto.Secp256k1, 256)
 	assert.NoError(t, err)
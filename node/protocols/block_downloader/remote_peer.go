@@ -13,6 +13,7 @@ import (
 	"github.com/filefilego/filefilego/node/protocols/messages"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -25,6 +26,9 @@ type RemotePeer struct {
 	host   host.Host
 	peer   peer.ID
 	height uint64
+	// lowestBlockNumber is the oldest block a pruned peer can serve.
+	lowestBlockNumber uint64
+	pruned            bool
 }
 
 // NewRemotePeer creates a new remote peer.
@@ -50,11 +54,50 @@ func (rp *RemotePeer) CurrentHeight() uint64 {
 	return rp.height
 }
 
+// LowestBlockNumber returns the number of the oldest block the peer can serve.
+func (rp *RemotePeer) LowestBlockNumber() uint64 {
+	return rp.lowestBlockNumber
+}
+
+// IsPruned returns true if the peer removes old blocks.
+func (rp *RemotePeer) IsPruned() bool {
+	return rp.pruned
+}
+
+// CanServe checks if the peer stores the blocks starting from the given block number.
+func (rp *RemotePeer) CanServe(from uint64) bool {
+	return from >= rp.lowestBlockNumber
+}
+
 // DownloadBlocksRange downloads a range of blocks
 func (rp *RemotePeer) DownloadBlocksRange(ctx context.Context, request *messages.BlockDownloadRequestProto) (*messages.BlockDownloadResponseProto, error) {
-	s, err := rp.host.NewStream(ctx, rp.peer, BlockDownloaderProtocolID)
+	response := messages.BlockDownloadResponseProto{}
+	if err := rp.sendRequest(ctx, BlockDownloaderProtocolID, request, &response); err != nil {
+		return nil, err
+	}
+
+	rp.height = response.NodeHeight
+
+	return &response, nil
+}
+
+// DownloadHeadersRange downloads the headers of a range of blocks.
+func (rp *RemotePeer) DownloadHeadersRange(ctx context.Context, request *messages.BlockDownloadRequestProto) (*messages.BlockHeadersResponseProto, error) {
+	response := messages.BlockHeadersResponseProto{}
+	if err := rp.sendRequest(ctx, BlockHeadersProtocolID, request, &response); err != nil {
+		return nil, err
+	}
+
+	rp.height = response.NodeHeight
+
+	return &response, nil
+}
+
+// sendRequest writes a length prefixed request to a new stream of the protocol and reads the length prefixed response.
+func (rp *RemotePeer) sendRequest(ctx context.Context, protocolID protocol.ID, request *messages.BlockDownloadRequestProto, response proto.Message) error {
+	s, err := rp.host.NewStream(ctx, rp.peer, protocolID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create new download stream to remote peer: %w", err)
+		return fmt.Errorf("failed to create new download stream to remote peer: %w", err)
 	}
 	c := bufio.NewReader(s)
 	defer s.Close()
@@ -62,17 +105,17 @@ func (rp *RemotePeer) DownloadBlocksRange(ctx context.Context, request *messages
 	future := time.Now().Add(deadlineTimeInSecond * time.Second)
 	err = s.SetDeadline(future)
 	if err != nil {
-		return nil, fmt.Errorf("failed to set block download stream deadline: %w", err)
+		return fmt.Errorf("failed to set block download stream deadline: %w", err)
 	}
 
 	requestBytes, err := proto.Marshal(request)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal protobuf block request message: %w", err)
+		return fmt.Errorf("failed to marshal protobuf block request message: %w", err)
 	}
 
 	requestBufferSize := 8 + len(requestBytes)
 	if requestBufferSize > 2*common.KB {
-		return nil, fmt.Errorf("request size is too large for a block request with size: %d", requestBufferSize)
+		return fmt.Errorf("request size is too large for a block request with size: %d", requestBufferSize)
 	}
 
 	requestPayloadWithLength := make([]byte, requestBufferSize)
@@ -80,31 +123,31 @@ func (rp *RemotePeer) DownloadBlocksRange(ctx context.Context, request *messages
 	copy(requestPayloadWithLength[8:], requestBytes)
 	_, err = s.Write(requestPayloadWithLength)
 	if err != nil {
-		return nil, fmt.Errorf("failed to write data to download stream: %w", err)
+		return fmt.Errorf("failed to write data to download stream: %w", err)
 	}
 
 	responsePayloadLength := make([]byte, 8)
-	_, err = c.Read(responsePayloadLength)
+	_, err = io.ReadFull(c, responsePayloadLength)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read block response length: %w", err)
+		return fmt.Errorf("failed to read block response length: %w", err)
 	}
 
-	responseSize := int64(binary.LittleEndian.Uint64(responsePayloadLength))
+	responseSize := binary.LittleEndian.Uint64(responsePayloadLength)
+	if responseSize > 64*common.MB {
+		return fmt.Errorf("block response size %d is too large", responseSize)
+	}
 	buf := make([]byte, responseSize)
 
 	_, err = io.ReadFull(c, buf)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read block response payload: %w", err)
+		return fmt.Errorf("failed to read block response payload: %w", err)
 	}
 
-	response := messages.BlockDownloadResponseProto{}
-	if err := proto.Unmarshal(buf, &response); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal block download response: %w", err)
+	if err := proto.Unmarshal(buf, response); err != nil {
+		return fmt.Errorf("failed to unmarshal block download response: %w", err)
 	}
 
-	rp.height = response.NodeHeight
-
-	return &response, nil
+	return nil
 }
 
 // GetHeight gets remote peers blockchain height.
@@ -137,6 +180,8 @@ func (rp *RemotePeer) GetHeight(ctx context.Context) (*messages.BlockchainHeight
 		return nil, fmt.Errorf("failed to unmarshal data retrieved from the remote peer: %w", err)
 	}
 	rp.height = response.Height
+	rp.lowestBlockNumber = response.LowestBlockNumber
+	rp.pruned = response.Pruned
 
 	return &response, nil
 }
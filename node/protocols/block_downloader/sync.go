@@ -0,0 +1,492 @@
+package blockdownloader
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/filefilego/filefilego/block"
+	"github.com/filefilego/filefilego/node/protocols/messages"
+	"github.com/libp2p/go-libp2p/core/peer"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// maxHeadersPerRequest is the maximum number of headers served in a single response.
+	maxHeadersPerRequest = 2000
+	// syncWindowSize is the number of blocks whose headers are validated before downloading their bodies.
+	syncWindowSize = 2000
+
+	minRangeSize        = 10
+	defaultRangeSize    = 100
+	maxRangeSize        = 500
+	targetRangeDuration = 2 * time.Second
+
+	// maxPeerFailures is the number of consecutive network failures after which a peer is dropped.
+	maxPeerFailures = 3
+)
+
+// errInvalidResponse is returned when a peer serves data which doesn't match the requested range.
+var errInvalidResponse = errors.New("invalid response")
+
+// errNoRemotePeers is returned when none of the remote peers can serve the next blocks.
+var errNoRemotePeers = errors.New("no remote peers could serve the blocks")
+
+// BlockHandler is called with the downloaded blocks in order.
+type BlockHandler func(blck block.Block) error
+
+// peerStats keeps the download performance of a remote peer.
+type peerStats struct {
+	rangeSize uint64
+	failures  int
+	// throughput is a moving average of the downloaded blocks per second.
+	throughput float64
+}
+
+type blockRange struct {
+	from uint64
+	to   uint64
+}
+
+type downloadedRange struct {
+	from   uint64
+	blocks []block.Block
+}
+
+// Sync downloads the blocks up to the heighest block number of the remote peers.
+// the headers of a window are downloaded and validated first, then the bodies are downloaded
+// from all the remote peers concurrently and passed to the handler in order.
+// the handler should apply the blocks before it returns. the sync stops when none of the remote peers can serve the next blocks.
+func (bd *Protocol) Sync(ctx context.Context, handler BlockHandler) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		localHeight := bd.blockchain.GetHeight()
+		target := bd.GetHeighestBlockNumberFromPeers()
+		if localHeight >= target {
+			return nil
+		}
+
+		from := localHeight + 1
+		to := target
+		if to-from >= syncWindowSize {
+			to = from + syncWindowSize - 1
+		}
+
+		headers, err := bd.downloadHeaders(ctx, from, to)
+		if errors.Is(err, errNoRemotePeers) {
+			log.Warn("no remote peers to download headers from")
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to download headers: %w", err)
+		}
+
+		err = bd.downloadBodies(ctx, headers, handler)
+		if errors.Is(err, errNoRemotePeers) {
+			log.Warn("no remote peers to download blocks from")
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to download blocks: %w", err)
+		}
+
+		if bd.blockchain.GetHeight() <= localHeight {
+			return fmt.Errorf("downloaded blocks didn't extend the local chain at height %d", localHeight)
+		}
+	}
+}
+
+// downloadHeaders downloads and validates the headers of a range from the best peer.
+// the range may be shortened if it contains blocks of verifiers which are not known yet.
+func (bd *Protocol) downloadHeaders(ctx context.Context, from, to uint64) ([]block.Header, error) {
+	for {
+		remote := bd.bestPeer(from)
+		if remote == nil {
+			return nil, errNoRemotePeers
+		}
+
+		request := messages.BlockDownloadRequestProto{
+			From: from,
+			To:   to,
+		}
+		if request.To > remote.CurrentHeight() {
+			request.To = remote.CurrentHeight()
+		}
+
+		response, err := remote.DownloadHeadersRange(ctx, &request)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			log.Warnf("failed to download headers from peer %s: %v", remote.GetPeerID().String(), err)
+			bd.peerFailed(remote)
+			continue
+		}
+
+		headers, err := validateHeaders(&request, response)
+		if err != nil {
+			log.Warnf("invalid headers from peer %s: %v", remote.GetPeerID().String(), err)
+			bd.RemoveRemotePeer(remote)
+			continue
+		}
+
+		return headers, nil
+	}
+}
+
+// validateHeaders checks that the headers are consecutive, linked by their hashes and signed by verifiers.
+func validateHeaders(request *messages.BlockDownloadRequestProto, response *messages.BlockHeadersResponseProto) ([]block.Header, error) {
+	if response.Error {
+		return nil, errors.New("peer failed to serve the headers")
+	}
+
+	if len(response.Headers) == 0 {
+		return nil, errors.New("response doesn't contain any header")
+	}
+
+	if uint64(len(response.Headers)) > request.To-request.From+1 {
+		return nil, fmt.Errorf("response contains %d headers which is more than requested", len(response.Headers))
+	}
+
+	headers := make([]block.Header, 0, len(response.Headers))
+	for i, ph := range response.Headers {
+		header := block.ProtoBlockHeaderToHeader(ph)
+		if header.Number != request.From+uint64(i) {
+			return nil, fmt.Errorf("expected header %d but got %d", request.From+uint64(i), header.Number)
+		}
+
+		if i > 0 && !bytes.Equal(header.PreviousBlockHash, headers[i-1].Hash) {
+			return nil, fmt.Errorf("header %d is not linked to the previous header", header.Number)
+		}
+
+		if err := header.Validate(); err != nil {
+			// the verifier set may change in the previous blocks, so the rest is validated in the next window
+			if errors.Is(err, block.ErrUnknownVerifier) && i > 0 {
+				break
+			}
+			return nil, fmt.Errorf("failed to validate header %d: %w", header.Number, err)
+		}
+
+		headers = append(headers, header)
+	}
+
+	return headers, nil
+}
+
+// downloadBodies downloads the blocks of the headers from all the remote peers and passes them to the handler in order.
+func (bd *Protocol) downloadBodies(ctx context.Context, headers []block.Header, handler BlockHandler) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	from := headers[0].Number
+	last := headers[len(headers)-1].Number
+	scheduler := newRangeScheduler(from, last)
+	defer scheduler.close()
+
+	results := make(chan downloadedRange)
+	wg := sync.WaitGroup{}
+	for _, remote := range bd.GetRemotePeers() {
+		wg.Add(1)
+		go func(remote *RemotePeer) {
+			defer wg.Done()
+			bd.downloadRanges(ctx, remote, headers, scheduler, results)
+		}(remote)
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	// ranges arrive out of order, so they are kept until the previous blocks are handled
+	pending := make(map[uint64][]block.Block)
+	next := from
+	for result := range results {
+		pending[result.from] = result.blocks
+		for {
+			blocks, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+
+			for _, blck := range blocks {
+				if err := handler(blck); err != nil {
+					return err
+				}
+			}
+			next += uint64(len(blocks))
+		}
+
+		if next > last {
+			return nil
+		}
+	}
+
+	if next == from {
+		return errNoRemotePeers
+	}
+
+	return nil
+}
+
+// downloadRanges downloads ranges assigned by the scheduler from a remote peer until there is no more work.
+func (bd *Protocol) downloadRanges(ctx context.Context, remote *RemotePeer, headers []block.Header, scheduler *rangeScheduler, results chan<- downloadedRange) {
+	for {
+		r, ok := scheduler.next(remote, bd.rangeSizeOf(remote))
+		if !ok {
+			return
+		}
+
+		started := time.Now()
+		blocks, err := downloadRange(ctx, remote, r, headers)
+		scheduler.done(r, err == nil)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+
+			log.Warnf("failed to download blocks %d to %d from peer %s: %v", r.from, r.to, remote.GetPeerID().String(), err)
+			if errors.Is(err, errInvalidResponse) {
+				bd.RemoveRemotePeer(remote)
+				return
+			}
+
+			if removed := bd.peerFailed(remote); removed {
+				return
+			}
+			continue
+		}
+
+		bd.peerSucceeded(remote, len(blocks), time.Since(started))
+
+		select {
+		case results <- downloadedRange{from: r.from, blocks: blocks}:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// downloadRange downloads a range of blocks and checks them against their headers.
+func downloadRange(ctx context.Context, remote *RemotePeer, r blockRange, headers []block.Header) ([]block.Block, error) {
+	response, err := remote.DownloadBlocksRange(ctx, &messages.BlockDownloadRequestProto{
+		From: r.from,
+		To:   r.to,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Error {
+		return nil, fmt.Errorf("%w: peer failed to serve the blocks", errInvalidResponse)
+	}
+
+	if uint64(len(response.Blocks)) != r.to-r.from+1 {
+		return nil, fmt.Errorf("%w: expected %d blocks but got %d", errInvalidResponse, r.to-r.from+1, len(response.Blocks))
+	}
+
+	offset := r.from - headers[0].Number
+	blocks := make([]block.Block, 0, len(response.Blocks))
+	for i, pb := range response.Blocks {
+		blck := block.ProtoBlockToBlock(pb)
+		if err := headers[offset+uint64(i)].MatchesBlock(blck); err != nil {
+			return nil, fmt.Errorf("%w: block %d doesn't match its header: %s", errInvalidResponse, r.from+uint64(i), err.Error())
+		}
+		blocks = append(blocks, blck)
+	}
+
+	return blocks, nil
+}
+
+// bestPeer returns the remote peer with the highest throughput which can serve the given block number.
+func (bd *Protocol) bestPeer(from uint64) *RemotePeer {
+	var best *RemotePeer
+	bestThroughput := float64(-1)
+	for _, remote := range bd.GetRemotePeers() {
+		if !remote.CanServe(from) || remote.CurrentHeight() < from {
+			continue
+		}
+
+		throughput := bd.statsOf(remote.GetPeerID()).throughput
+		if throughput > bestThroughput {
+			best = remote
+			bestThroughput = throughput
+		}
+	}
+	return best
+}
+
+func (bd *Protocol) rangeSizeOf(remote *RemotePeer) uint64 {
+	return bd.statsOf(remote.GetPeerID()).rangeSize
+}
+
+// statsOf returns a copy of the stats of a peer.
+func (bd *Protocol) statsOf(id peer.ID) peerStats {
+	bd.statsMu.Lock()
+	defer bd.statsMu.Unlock()
+
+	return *bd.peerStatsLocked(id)
+}
+
+func (bd *Protocol) peerStatsLocked(id peer.ID) *peerStats {
+	stats, ok := bd.stats[id]
+	if !ok {
+		stats = &peerStats{rangeSize: defaultRangeSize}
+		bd.stats[id] = stats
+	}
+	return stats
+}
+
+// peerSucceeded grows the range size of a peer which downloads faster than the target duration and shrinks it otherwise.
+func (bd *Protocol) peerSucceeded(remote *RemotePeer, blocks int, elapsed time.Duration) {
+	bd.statsMu.Lock()
+	defer bd.statsMu.Unlock()
+
+	stats := bd.peerStatsLocked(remote.GetPeerID())
+	stats.failures = 0
+
+	seconds := elapsed.Seconds()
+	if seconds > 0 {
+		rate := float64(blocks) / seconds
+		if stats.throughput == 0 {
+			stats.throughput = rate
+		} else {
+			stats.throughput = 0.7*stats.throughput + 0.3*rate
+		}
+	}
+
+	if elapsed < targetRangeDuration {
+		stats.rangeSize *= 2
+	} else {
+		stats.rangeSize /= 2
+	}
+	stats.rangeSize = clampRangeSize(stats.rangeSize)
+}
+
+// peerFailed shrinks the range size of a peer and removes it after too many failures.
+func (bd *Protocol) peerFailed(remote *RemotePeer) bool {
+	bd.statsMu.Lock()
+	stats := bd.peerStatsLocked(remote.GetPeerID())
+	stats.failures++
+	stats.rangeSize = clampRangeSize(stats.rangeSize / 2)
+	failures := stats.failures
+	bd.statsMu.Unlock()
+
+	if failures >= maxPeerFailures {
+		bd.RemoveRemotePeer(remote)
+		return true
+	}
+	return false
+}
+
+func clampRangeSize(size uint64) uint64 {
+	if size < minRangeSize {
+		return minRangeSize
+	}
+	if size > maxRangeSize {
+		return maxRangeSize
+	}
+	return size
+}
+
+// rangeScheduler assigns disjoint block ranges to the download workers and reassigns the failed ones.
+type rangeScheduler struct {
+	mu       sync.Mutex
+	cond     *sync.Cond
+	cursor   uint64
+	last     uint64
+	retries  []blockRange
+	inFlight int
+	closed   bool
+}
+
+func newRangeScheduler(from, last uint64) *rangeScheduler {
+	s := &rangeScheduler{
+		cursor:  from,
+		last:    last,
+		retries: make([]blockRange, 0),
+	}
+	s.cond = sync.NewCond(&s.mu)
+	return s
+}
+
+// next returns a range of at most size blocks which the remote peer can serve.
+// it waits while other ranges are in flight since they may fail and be reassigned.
+func (s *rangeScheduler) next(remote *RemotePeer, size uint64) (blockRange, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for {
+		if s.closed {
+			return blockRange{}, false
+		}
+
+		for i, r := range s.retries {
+			if !canServeRange(remote, r.from) {
+				continue
+			}
+			s.retries = append(s.retries[:i], s.retries[i+1:]...)
+			clipped := clipRange(r, remote, size)
+			if clipped.to < r.to {
+				s.retries = append(s.retries, blockRange{from: clipped.to + 1, to: r.to})
+			}
+			s.inFlight++
+			return clipped, true
+		}
+
+		if s.cursor <= s.last && canServeRange(remote, s.cursor) {
+			r := clipRange(blockRange{from: s.cursor, to: s.last}, remote, size)
+			s.cursor = r.to + 1
+			s.inFlight++
+			return r, true
+		}
+
+		if s.inFlight == 0 {
+			return blockRange{}, false
+		}
+		s.cond.Wait()
+	}
+}
+
+// clipRange shortens the range to the size and the height of the peer.
+func clipRange(r blockRange, remote *RemotePeer, size uint64) blockRange {
+	to := r.to
+	if r.from+size-1 < to {
+		to = r.from + size - 1
+	}
+	if remote.CurrentHeight() < to {
+		to = remote.CurrentHeight()
+	}
+	return blockRange{from: r.from, to: to}
+}
+
+// done marks a range as finished and queues it again if it failed.
+func (s *rangeScheduler) done(r blockRange, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.inFlight--
+	if !ok {
+		s.retries = append(s.retries, r)
+	}
+	s.cond.Broadcast()
+}
+
+func (s *rangeScheduler) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+	s.cond.Broadcast()
+}
+
+func canServeRange(remote *RemotePeer, from uint64) bool {
+	return remote.CanServe(from) && remote.CurrentHeight() >= from
+}
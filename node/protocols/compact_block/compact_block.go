@@ -0,0 +1,193 @@
+package compactblock
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	"github.com/filefilego/filefilego/block"
+	"github.com/filefilego/filefilego/node/protocols/messages"
+	"github.com/filefilego/filefilego/transaction"
+)
+
+// ShortIDLength is the length of a short transaction id.
+const ShortIDLength = 8
+
+// ShortID returns the short id of a transaction hash in a block.
+// the id is salted with the block hash, so transactions which collide in one block don't collide in the others.
+func ShortID(blockHash, txHash []byte) []byte {
+	data := make([]byte, 0, len(blockHash)+len(txHash))
+	data = append(data, blockHash...)
+	data = append(data, txHash...)
+	hash := sha256.Sum256(data)
+	return hash[:ShortIDLength]
+}
+
+// NewCompactBlock creates a compact block from a block.
+func NewCompactBlock(b block.Block) (*messages.CompactBlockProto, error) {
+	header, err := b.Header()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block header: %w", err)
+	}
+
+	compact := &messages.CompactBlockProto{
+		Header:              block.ToProtoBlockHeader(header),
+		Coinbase:            transaction.ToProtoTransaction(b.Transactions[0]),
+		ShortTransactionIds: make([][]byte, 0, len(b.Transactions)-1),
+	}
+
+	for _, tx := range b.Transactions[1:] {
+		compact.ShortTransactionIds = append(compact.ShortTransactionIds, ShortID(b.Hash, tx.Hash))
+	}
+	return compact, nil
+}
+
+// ValidateCompactBlock validates the header of a compact block and its coinbase transaction.
+// the transactions can only be validated after the block is rebuilt.
+func ValidateCompactBlock(compact *messages.CompactBlockProto) error {
+	if compact.GetHeader() == nil {
+		return errors.New("compact block doesn't contain a header")
+	}
+
+	if compact.GetCoinbase() == nil {
+		return errors.New("compact block doesn't contain a coinbase transaction")
+	}
+
+	header := block.ProtoBlockHeaderToHeader(compact.GetHeader())
+	if !bytes.Equal(header.VerifierPublicKey, compact.GetCoinbase().GetPublicKey()) {
+		return errors.New("coinbase transaction isn't signed by the block verifier")
+	}
+
+	for _, shortID := range compact.GetShortTransactionIds() {
+		if len(shortID) != ShortIDLength {
+			return fmt.Errorf("short transaction id with size %d should be %d bytes", len(shortID), ShortIDLength)
+		}
+	}
+
+	if err := header.Validate(); err != nil {
+		return fmt.Errorf("failed to validate header: %w", err)
+	}
+	return nil
+}
+
+// PartialBlock is a block which is being rebuilt from a compact block.
+type PartialBlock struct {
+	header       block.Header
+	shortIDs     [][]byte
+	transactions []*transaction.Transaction
+}
+
+// NewPartialBlock creates a partial block from a compact block with only the coinbase transaction filled.
+func NewPartialBlock(compact *messages.CompactBlockProto) (*PartialBlock, error) {
+	if err := ValidateCompactBlock(compact); err != nil {
+		return nil, err
+	}
+
+	coinbase := transaction.ProtoTransactionToTransaction(compact.GetCoinbase())
+	p := &PartialBlock{
+		header:       block.ProtoBlockHeaderToHeader(compact.GetHeader()),
+		shortIDs:     make([][]byte, 0, len(compact.GetShortTransactionIds())+1),
+		transactions: make([]*transaction.Transaction, len(compact.GetShortTransactionIds())+1),
+	}
+
+	p.shortIDs = append(p.shortIDs, ShortID(p.header.Hash, coinbase.Hash))
+	p.shortIDs = append(p.shortIDs, compact.GetShortTransactionIds()...)
+	p.transactions[0] = &coinbase
+	return p, nil
+}
+
+// Hash returns the hash of the block.
+func (p *PartialBlock) Hash() []byte {
+	return p.header.Hash
+}
+
+// FillFromPool fills the transactions which are in the mempool.
+func (p *PartialBlock) FillFromPool(pool []transaction.Transaction) {
+	poolTxs := make(map[string]transaction.Transaction, len(pool))
+	for _, tx := range pool {
+		poolTxs[string(ShortID(p.header.Hash, tx.Hash))] = tx
+	}
+
+	for i := 1; i < len(p.transactions); i++ {
+		if p.transactions[i] != nil {
+			continue
+		}
+
+		tx, ok := poolTxs[string(p.shortIDs[i])]
+		if ok {
+			tx := tx
+			p.transactions[i] = &tx
+		}
+	}
+}
+
+// Missing returns the indexes of the transactions which are not filled yet.
+func (p *PartialBlock) Missing() []uint32 {
+	missing := make([]uint32, 0)
+	for i, tx := range p.transactions {
+		if tx == nil {
+			missing = append(missing, uint32(i))
+		}
+	}
+	return missing
+}
+
+// Indexes returns the indexes of all the transactions except the coinbase.
+func (p *PartialBlock) Indexes() []uint32 {
+	indexes := make([]uint32, 0, len(p.transactions)-1)
+	for i := 1; i < len(p.transactions); i++ {
+		indexes = append(indexes, uint32(i))
+	}
+	return indexes
+}
+
+// Fill fills the transactions of the given indexes.
+func (p *PartialBlock) Fill(indexes []uint32, txs []transaction.Transaction) error {
+	if len(indexes) != len(txs) {
+		return fmt.Errorf("got %d transactions for %d indexes", len(txs), len(indexes))
+	}
+
+	for i, idx := range indexes {
+		if int(idx) >= len(p.transactions) {
+			return fmt.Errorf("transaction index %d is out of range", idx)
+		}
+
+		if !bytes.Equal(ShortID(p.header.Hash, txs[i].Hash), p.shortIDs[idx]) {
+			return fmt.Errorf("transaction at index %d doesn't match the short id", idx)
+		}
+	}
+
+	for i, idx := range indexes {
+		tx := txs[i]
+		p.transactions[idx] = &tx
+	}
+	return nil
+}
+
+// Block returns the rebuilt block after checking it matches the header.
+func (p *PartialBlock) Block() (block.Block, error) {
+	if missing := p.Missing(); len(missing) > 0 {
+		return block.Block{}, fmt.Errorf("block is missing %d transactions", len(missing))
+	}
+
+	b := block.Block{
+		Hash:              p.header.Hash,
+		MerkleHash:        p.header.MerkleHash,
+		Signature:         p.header.Signature,
+		Timestamp:         p.header.Timestamp,
+		Data:              p.header.Data,
+		PreviousBlockHash: p.header.PreviousBlockHash,
+		Number:            p.header.Number,
+		StateRoot:         p.header.StateRoot,
+		Transactions:      make([]transaction.Transaction, len(p.transactions)),
+	}
+	for i, tx := range p.transactions {
+		b.Transactions[i] = *tx
+	}
+
+	if err := p.header.MatchesBlock(b); err != nil {
+		return block.Block{}, err
+	}
+	return b, nil
+}
@@ -0,0 +1,175 @@
+package compactblock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/filefilego/filefilego/block"
+	"github.com/filefilego/filefilego/common/hexutil"
+	ffgcrypto "github.com/filefilego/filefilego/crypto"
+	"github.com/filefilego/filefilego/node/protocols/messages"
+	"github.com/filefilego/filefilego/transaction"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateCompactBlock(t *testing.T) {
+	b := validBlock(t, 3)
+	compact, err := NewCompactBlock(b)
+	assert.NoError(t, err)
+	assert.Len(t, compact.ShortTransactionIds, 3)
+
+	cases := map[string]struct {
+		compact *messages.CompactBlockProto
+		expErr  string
+	}{
+		"no header": {
+			compact: &messages.CompactBlockProto{},
+			expErr:  "compact block doesn't contain a header",
+		},
+		"no coinbase": {
+			compact: &messages.CompactBlockProto{Header: compact.Header},
+			expErr:  "compact block doesn't contain a coinbase transaction",
+		},
+		"coinbase of another verifier": {
+			compact: &messages.CompactBlockProto{Header: compact.Header, Coinbase: transaction.ToProtoTransaction(b.Transactions[1])},
+			expErr:  "coinbase transaction isn't signed by the block verifier",
+		},
+		"invalid short id": {
+			compact: &messages.CompactBlockProto{Header: compact.Header, Coinbase: compact.Coinbase, ShortTransactionIds: [][]byte{{1}}},
+			expErr:  "short transaction id with size 1 should be 8 bytes",
+		},
+		"invalid header": {
+			compact: &messages.CompactBlockProto{Header: &block.ProtoBlockHeader{VerifierPublicKey: compact.Coinbase.PublicKey}, Coinbase: compact.Coinbase},
+			expErr:  "failed to validate header: hash is empty",
+		},
+		"success": {
+			compact: compact,
+		},
+	}
+
+	for name, tt := range cases {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			err := ValidateCompactBlock(tt.compact)
+			if tt.expErr != "" {
+				assert.EqualError(t, err, tt.expErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestShortID(t *testing.T) {
+	txHash := []byte{1, 2, 3}
+	shortID := ShortID([]byte{1}, txHash)
+	assert.Len(t, shortID, ShortIDLength)
+	assert.Equal(t, shortID, ShortID([]byte{1}, txHash))
+
+	// the same transaction has a different short id in another block
+	assert.NotEqual(t, shortID, ShortID([]byte{2}, txHash))
+}
+
+func TestPartialBlock(t *testing.T) {
+	b := validBlock(t, 3)
+	compact, err := NewCompactBlock(b)
+	assert.NoError(t, err)
+
+	partial, err := NewPartialBlock(compact)
+	assert.NoError(t, err)
+	assert.Equal(t, b.Hash, partial.Hash())
+	assert.Equal(t, []uint32{1, 2, 3}, partial.Missing())
+	assert.Equal(t, []uint32{1, 2, 3}, partial.Indexes())
+
+	_, err = partial.Block()
+	assert.EqualError(t, err, "block is missing 3 transactions")
+
+	// the mempool contains some of the transactions
+	unrelatedTx, _ := validTransaction(t)
+	partial.FillFromPool([]transaction.Transaction{b.Transactions[2], *unrelatedTx})
+	assert.Equal(t, []uint32{1, 3}, partial.Missing())
+
+	// the rest are fetched from a peer
+	err = partial.Fill([]uint32{1}, []transaction.Transaction{})
+	assert.EqualError(t, err, "got 0 transactions for 1 indexes")
+	err = partial.Fill([]uint32{4}, []transaction.Transaction{b.Transactions[1]})
+	assert.EqualError(t, err, "transaction index 4 is out of range")
+	err = partial.Fill([]uint32{1, 3}, []transaction.Transaction{b.Transactions[3], b.Transactions[1]})
+	assert.EqualError(t, err, "transaction at index 1 doesn't match the short id")
+	assert.Equal(t, []uint32{1, 3}, partial.Missing())
+
+	err = partial.Fill([]uint32{1, 3}, []transaction.Transaction{b.Transactions[1], b.Transactions[3]})
+	assert.NoError(t, err)
+	assert.Empty(t, partial.Missing())
+
+	rebuilt, err := partial.Block()
+	assert.NoError(t, err)
+	assert.Equal(t, b, rebuilt)
+
+	// a mempool transaction with a colliding short id doesn't match the header
+	partial, err = NewPartialBlock(compact)
+	assert.NoError(t, err)
+	colliding := b.Transactions[1]
+	colliding.Data = []byte{9}
+	partial.FillFromPool([]transaction.Transaction{colliding, b.Transactions[2], b.Transactions[3]})
+	assert.Empty(t, partial.Missing())
+	_, err = partial.Block()
+	assert.EqualError(t, err, "block transactions don't match the header")
+}
+
+// generate a signed block of a verifier with the given number of transactions besides the coinbase.
+func validBlock(t *testing.T, txs int) block.Block {
+	coinbasetx, kp := validTransaction(t)
+	err := coinbasetx.Sign(kp.PrivateKey)
+	assert.NoError(t, err)
+	block.SetBlockVerifiers(block.Verifier{Address: kp.Address})
+
+	b := block.Block{
+		Timestamp:         time.Now().Unix(),
+		Data:              []byte{1},
+		PreviousBlockHash: []byte{1, 1},
+		Transactions:      []transaction.Transaction{*coinbasetx},
+		Number:            1,
+	}
+
+	for i := 0; i < txs; i++ {
+		tx, txKp := validTransaction(t)
+		tx.TransactionFees = "0x1"
+		tx.Value = "0x1"
+		tx.Nounce = []byte{byte(i + 1)}
+		err := tx.Sign(txKp.PrivateKey)
+		assert.NoError(t, err)
+		b.Transactions = append(b.Transactions, *tx)
+	}
+
+	err = b.Sign(kp.PrivateKey)
+	assert.NoError(t, err)
+	return b
+}
+
+// generate a keypair and use it to create a tx
+func validTransaction(t *testing.T) (*transaction.Transaction, ffgcrypto.KeyPair) {
+	keypair, err := ffgcrypto.GenerateKeyPair()
+	assert.NoError(t, err)
+
+	pkyData, err := keypair.PublicKey.Raw()
+	assert.NoError(t, err)
+
+	mainChain, err := hexutil.Decode("0x01")
+	assert.NoError(t, err)
+
+	addr, err := ffgcrypto.RawPublicToAddress(pkyData)
+	assert.NoError(t, err)
+
+	tx := transaction.Transaction{
+		PublicKey:       pkyData,
+		Nounce:          []byte{0},
+		Data:            []byte{1},
+		From:            addr,
+		To:              addr,
+		Chain:           mainChain,
+		Value:           "0x22b1c8c1227a00000",
+		TransactionFees: "0x0",
+	}
+	return &tx, keypair
+}
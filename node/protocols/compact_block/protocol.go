@@ -0,0 +1,206 @@
+package compactblock
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/filefilego/filefilego/block"
+	"github.com/filefilego/filefilego/common"
+	"github.com/filefilego/filefilego/node/protocols/messages"
+	"github.com/filefilego/filefilego/transaction"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// BlockTransactionsProtocolID is the protocol which returns the transactions of a recently relayed block.
+	BlockTransactionsProtocolID = "/ffg/block_transactions/1.0.0"
+
+	// maxRelayedBlocks is the number of recently relayed blocks which are kept to serve their transactions.
+	maxRelayedBlocks = 64
+
+	maxRequestSize       = 256 * common.KB
+	maxResponseSize      = 64 * common.MB
+	deadlineTimeInSecond = 10
+)
+
+// Interface defines the compact block relay functionality.
+type Interface interface {
+	PutBlock(b block.Block)
+	GetBlock(hash []byte) (block.Block, bool)
+	RequestTransactions(ctx context.Context, peerID peer.ID, request *messages.BlockTransactionsRequestProto) (*messages.BlockTransactionsResponseProto, error)
+}
+
+// Protocol keeps the recently relayed blocks and serves their transactions to the peers rebuilding them.
+type Protocol struct {
+	host host.Host
+
+	blocks      map[string]block.Block
+	blocksOrder []string
+	mu          sync.RWMutex
+}
+
+// New creates a compact block protocol.
+func New(h host.Host) (*Protocol, error) {
+	if h == nil {
+		return nil, errors.New("host is nil")
+	}
+
+	p := &Protocol{
+		host:        h,
+		blocks:      make(map[string]block.Block),
+		blocksOrder: make([]string, 0, maxRelayedBlocks),
+	}
+
+	p.host.SetStreamHandler(BlockTransactionsProtocolID, p.onBlockTransactionsRequest)
+
+	return p, nil
+}
+
+// PutBlock keeps a relayed block, the oldest block is evicted when the limit is reached.
+func (p *Protocol) PutBlock(b block.Block) {
+	key := string(b.Hash)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.blocks[key]; ok {
+		return
+	}
+
+	if len(p.blocksOrder) >= maxRelayedBlocks {
+		delete(p.blocks, p.blocksOrder[0])
+		p.blocksOrder = p.blocksOrder[1:]
+	}
+
+	p.blocks[key] = b
+	p.blocksOrder = append(p.blocksOrder, key)
+}
+
+// GetBlock returns a relayed block.
+func (p *Protocol) GetBlock(hash []byte) (block.Block, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	b, ok := p.blocks[string(hash)]
+	return b, ok
+}
+
+// onBlockTransactionsRequest handles the block transactions request.
+func (p *Protocol) onBlockTransactionsRequest(s network.Stream) {
+	defer s.Close()
+
+	request := messages.BlockTransactionsRequestProto{}
+	if err := readMessage(bufio.NewReader(s), maxRequestSize, &request); err != nil {
+		log.Errorf("failed to read block transactions request: %v", err)
+		return
+	}
+
+	response := messages.BlockTransactionsResponseProto{
+		BlockHash:    request.BlockHash,
+		Transactions: make([]*transaction.ProtoTransaction, 0, len(request.Indexes)),
+	}
+
+	b, ok := p.GetBlock(request.BlockHash)
+	if !ok {
+		response.Error = true
+	} else {
+		for _, idx := range request.Indexes {
+			if int(idx) >= len(b.Transactions) {
+				response.Error = true
+				response.Transactions = []*transaction.ProtoTransaction{}
+				break
+			}
+			response.Transactions = append(response.Transactions, transaction.ToProtoTransaction(b.Transactions[idx]))
+		}
+	}
+
+	if err := writeMessage(s, maxResponseSize, &response); err != nil {
+		log.Errorf("failed to write block transactions response: %v", err)
+	}
+}
+
+// RequestTransactions requests the transactions of a block from a peer.
+func (p *Protocol) RequestTransactions(ctx context.Context, peerID peer.ID, request *messages.BlockTransactionsRequestProto) (*messages.BlockTransactionsResponseProto, error) {
+	s, err := p.host.NewStream(ctx, peerID, BlockTransactionsProtocolID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new block transactions stream to remote peer: %w", err)
+	}
+	defer s.Close()
+
+	future := time.Now().Add(deadlineTimeInSecond * time.Second)
+	err = s.SetDeadline(future)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set block transactions stream deadline: %w", err)
+	}
+
+	if err := writeMessage(s, maxRequestSize, request); err != nil {
+		return nil, fmt.Errorf("failed to write block transactions request: %w", err)
+	}
+
+	response := messages.BlockTransactionsResponseProto{}
+	if err := readMessage(bufio.NewReader(s), maxResponseSize, &response); err != nil {
+		return nil, fmt.Errorf("failed to read block transactions response: %w", err)
+	}
+
+	if response.Error {
+		return nil, errors.New("remote peer failed to serve the block transactions")
+	}
+
+	if len(response.Transactions) != len(request.Indexes) {
+		return nil, fmt.Errorf("got %d transactions for %d requested", len(response.Transactions), len(request.Indexes))
+	}
+	return &response, nil
+}
+
+// readMessage reads a length prefixed message.
+func readMessage(r io.Reader, maxSize uint64, msg proto.Message) error {
+	msgLengthBuffer := make([]byte, 8)
+	if _, err := io.ReadFull(r, msgLengthBuffer); err != nil {
+		return fmt.Errorf("failed to read from stream: %w", err)
+	}
+
+	lengthPrefix := binary.LittleEndian.Uint64(msgLengthBuffer)
+	if lengthPrefix > maxSize {
+		return fmt.Errorf("message size %d is too large", lengthPrefix)
+	}
+
+	buf := make([]byte, lengthPrefix)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return fmt.Errorf("failed to read from stream to buffer: %w", err)
+	}
+
+	if err := proto.Unmarshal(buf, msg); err != nil {
+		return fmt.Errorf("failed to unmarshall data from stream: %w", err)
+	}
+	return nil
+}
+
+// writeMessage writes a length prefixed message.
+func writeMessage(w io.Writer, maxSize uint64, msg proto.Message) error {
+	payload, err := proto.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	if uint64(len(payload)) > maxSize {
+		return fmt.Errorf("message size %d is too large", len(payload))
+	}
+
+	payloadEnvelope := make([]byte, 8+len(payload))
+	binary.LittleEndian.PutUint64(payloadEnvelope, uint64(len(payload)))
+	copy(payloadEnvelope[8:], payload)
+	if _, err := w.Write(payloadEnvelope); err != nil {
+		return fmt.Errorf("failed to write envelope data to stream: %w", err)
+	}
+	return nil
+}
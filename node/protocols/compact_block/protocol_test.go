@@ -0,0 +1,125 @@
+package compactblock
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/filefilego/filefilego/block"
+	"github.com/filefilego/filefilego/node/protocols/messages"
+	"github.com/filefilego/filefilego/transaction"
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/net/connmgr"
+	"github.com/libp2p/go-libp2p/p2p/security/noise"
+	libp2ptls "github.com/libp2p/go-libp2p/p2p/security/tls"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	h := newHost(t, "1191")
+	t.Cleanup(func() {
+		h.Close()
+	})
+
+	cases := map[string]struct {
+		host   host.Host
+		expErr string
+	}{
+		"no host": {
+			expErr: "host is nil",
+		},
+		"success": {
+			host: h,
+		},
+	}
+
+	for name, tt := range cases {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			protocol, err := New(tt.host)
+			if tt.expErr != "" {
+				assert.Nil(t, protocol)
+				assert.EqualError(t, err, tt.expErr)
+			} else {
+				assert.NotNil(t, protocol)
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestProtocolMethods(t *testing.T) {
+	h1 := newHost(t, "1192")
+	h2 := newHost(t, "1193")
+	t.Cleanup(func() {
+		h1.Close()
+		h2.Close()
+	})
+
+	p1, err := New(h1)
+	assert.NoError(t, err)
+	p2, err := New(h2)
+	assert.NoError(t, err)
+
+	// the oldest relayed blocks are evicted
+	for i := 0; i <= maxRelayedBlocks; i++ {
+		p1.PutBlock(block.Block{Hash: []byte(fmt.Sprintf("%d", i))})
+	}
+	_, ok := p1.GetBlock([]byte("0"))
+	assert.False(t, ok)
+	_, ok = p1.GetBlock([]byte(fmt.Sprintf("%d", maxRelayedBlocks)))
+	assert.True(t, ok)
+	assert.Len(t, p1.blocksOrder, maxRelayedBlocks)
+
+	b := validBlock(t, 2)
+	p1.PutBlock(b)
+	p1.PutBlock(b)
+	assert.Len(t, p1.blocksOrder, maxRelayedBlocks)
+
+	h2.Peerstore().AddAddrs(h1.ID(), h1.Addrs(), time.Hour)
+	err = h2.Connect(context.TODO(), peer.AddrInfo{ID: h1.ID(), Addrs: h1.Addrs()})
+	assert.NoError(t, err)
+
+	// unknown block
+	_, err = p2.RequestTransactions(context.TODO(), h1.ID(), &messages.BlockTransactionsRequestProto{BlockHash: []byte{1}, Indexes: []uint32{1}})
+	assert.EqualError(t, err, "remote peer failed to serve the block transactions")
+
+	// out of range index
+	_, err = p2.RequestTransactions(context.TODO(), h1.ID(), &messages.BlockTransactionsRequestProto{BlockHash: b.Hash, Indexes: []uint32{3}})
+	assert.EqualError(t, err, "remote peer failed to serve the block transactions")
+
+	response, err := p2.RequestTransactions(context.TODO(), h1.ID(), &messages.BlockTransactionsRequestProto{BlockHash: b.Hash, Indexes: []uint32{2, 1}})
+	assert.NoError(t, err)
+	assert.Equal(t, b.Hash, response.BlockHash)
+	assert.Len(t, response.Transactions, 2)
+	assert.Equal(t, b.Transactions[2], transaction.ProtoTransactionToTransaction(response.Transactions[0]))
+	assert.Equal(t, b.Transactions[1], transaction.ProtoTransactionToTransaction(response.Transactions[1]))
+}
+
+func newHost(t *testing.T, port string) host.Host {
+	priv, _, err := crypto.GenerateKeyPair(crypto.Secp256k1, 256)
+	assert.NoError(t, err)
+	connManager, err := connmgr.NewConnManager(
+		100,
+		400,
+		connmgr.WithGracePeriod(time.Minute),
+	)
+	assert.NoError(t, err)
+
+	host, err := libp2p.New(libp2p.Identity(priv),
+		libp2p.ListenAddrStrings(fmt.Sprintf("/ip4/127.0.0.1/tcp/%s", port)),
+		libp2p.Ping(false),
+		libp2p.Security(libp2ptls.ID, libp2ptls.New),
+		libp2p.Security(noise.ID, noise.New),
+		libp2p.DefaultTransports,
+		libp2p.ConnectionManager(connManager),
+		libp2p.NATPortMap(),
+		libp2p.EnableNATService(),
+	)
+	assert.NoError(t, err)
+	return host
+}
@@ -243,6 +243,7 @@ func (d *Protocol) handleIncomingContractVerifierAcceptance(s network.Stream) {
 	downloadContract.ContractHash = make([]byte, len(contractHash))
 	copy(downloadContract.ContractHash, contractHash)
 
+	// the contract is signed with the node identity key since the file requester authenticates the verifier by its peer id.
 	sig, err := messages.SignDownloadContractProto(d.host.Peerstore().PrivKey(d.host.ID()), &downloadContract)
 	if err != nil {
 		log.Errorf("failed to get the sign download contract in handleIncomingContractVerifierAcceptance: %v", err)
@@ -372,7 +373,12 @@ func (d *Protocol) handleIncomingContractTransfer(s network.Stream) {
 		return
 	}
 
-	verifiers := block.GetBlockVerifiers()
+	verifiers, err := block.GetBlockVerifiers()
+	if err != nil {
+		log.Errorf("failed to get verifiers in handleIncomingContractTransfer stream: %v", err)
+		return
+	}
+
 	foundVerifier := false
 	for _, v := range verifiers {
 		if v.Address == verifierAddr {
@@ -572,6 +578,11 @@ func (d *Protocol) releaseFees(contractHash []byte) error {
 		return fmt.Errorf("failed to get file hosters address from contract: %w", err)
 	}
 
+	// escrowed fees are paid to the file hoster by the blockchain once the release transaction is applied
+	if _, err := d.blockchain.GetContractEscrow(contractHash); err == nil {
+		fileHosterFees = big.NewInt(0)
+	}
+
 	// get the biggest nounce from mempool or blockchain
 	mempoolNounce := d.blockchain.GetNounceFromMemPool(verifierAddr)
 	addrState, err := d.blockchain.GetAddressState(verifierAddr)
@@ -630,6 +641,7 @@ func (d *Protocol) releaseFees(contractHash []byte) error {
 		Chain:           chainID,
 	}
 
+	// the fees are released from the address of the node identity key which signed the contract, not the validator key.
 	err = tx.Sign(d.host.Peerstore().PrivKey(d.host.ID()))
 	if err != nil {
 		return fmt.Errorf("failed to sign file hoster fees release transaction: %w", err)
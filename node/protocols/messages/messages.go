@@ -338,3 +338,59 @@ func VerifyDataQueryResponse(publicKey crypto.PubKey, response DataQueryResponse
 	}
 	return ok, nil
 }
+
+// VerifyDownloadContractInTransactionData verifies that a contract of a transaction data payload matches its contract hash and is signed by its verifier.
+func VerifyDownloadContractInTransactionData(contract *DownloadContractInTransactionDataProto) error {
+	publicKeyVerifier, err := ffgcrypto.PublicKeyFromBytes(contract.VerifierPublicKey)
+	if err != nil {
+		return fmt.Errorf("failed to get the public key of the verifier: %w", err)
+	}
+
+	downloadContract := &DownloadContractProto{
+		FileHosterResponse: &DataQueryResponseProto{
+			PublicKey: contract.FileHosterNodePublicKey,
+			Signature: contract.FileHosterSignature,
+		},
+		FileRequesterNodePublicKey: contract.FileRequesterNodePublicKey,
+		FileHashesNeeded:           contract.FileHashesNeeded,
+		FileHashesNeededSizes:      contract.FileHashesNeededSizes,
+		VerifierPublicKey:          contract.VerifierPublicKey,
+		VerifierFees:               contract.VerifierFees,
+		ContractHash:               contract.ContractHash,
+	}
+
+	if !bytes.Equal(GetDownloadContractHash(downloadContract), contract.ContractHash) {
+		return errors.New("contract hash doesn't match the contract data")
+	}
+
+	fileHahes := []byte{}
+	for _, v := range contract.FileHashesNeeded {
+		fileHahes = append(fileHahes, v...)
+	}
+
+	fileSizes := []byte{}
+	for _, v := range contract.FileHashesNeededSizes {
+		intToByte := big.NewInt(0).SetUint64(v).Bytes()
+		fileSizes = append(fileSizes, intToByte...)
+	}
+
+	data := bytes.Join(
+		[][]byte{
+			[]byte(contract.VerifierFees),
+			contract.ContractHash,
+			contract.FileRequesterNodePublicKey,
+			contract.VerifierPublicKey,
+			contract.FileHosterNodePublicKey,
+			contract.FileHosterSignature,
+			fileHahes,
+			fileSizes,
+		},
+		[]byte{},
+	)
+
+	ok, err := publicKeyVerifier.Verify(data, contract.VerifierSignature)
+	if err != nil || !ok {
+		return errors.New("contract is not signed by its verifier")
+	}
+	return nil
+}
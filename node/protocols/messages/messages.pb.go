@@ -33,6 +33,8 @@ type GossipPayload struct {
 	//	*GossipPayload_Blocks
 	//	*GossipPayload_Transaction
 	//	*GossipPayload_Query
+	//	*GossipPayload_CheckpointAttestation
+	//	*GossipPayload_CompactBlock
 	Message isGossipPayload_Message `protobuf_oneof:"message"`
 }
 
@@ -96,6 +98,20 @@ func (x *GossipPayload) GetQuery() *DataQueryRequestProto {
 	return nil
 }
 
+func (x *GossipPayload) GetCheckpointAttestation() *CheckpointAttestationProto {
+	if x, ok := x.GetMessage().(*GossipPayload_CheckpointAttestation); ok {
+		return x.CheckpointAttestation
+	}
+	return nil
+}
+
+func (x *GossipPayload) GetCompactBlock() *CompactBlockProto {
+	if x, ok := x.GetMessage().(*GossipPayload_CompactBlock); ok {
+		return x.CompactBlock
+	}
+	return nil
+}
+
 type isGossipPayload_Message interface {
 	isGossipPayload_Message()
 }
@@ -112,12 +128,282 @@ type GossipPayload_Query struct {
 	Query *DataQueryRequestProto `protobuf:"bytes,3,opt,name=query,proto3,oneof"`
 }
 
+type GossipPayload_CheckpointAttestation struct {
+	CheckpointAttestation *CheckpointAttestationProto `protobuf:"bytes,4,opt,name=checkpoint_attestation,json=checkpointAttestation,proto3,oneof"`
+}
+
+type GossipPayload_CompactBlock struct {
+	CompactBlock *CompactBlockProto `protobuf:"bytes,5,opt,name=compact_block,json=compactBlock,proto3,oneof"`
+}
+
 func (*GossipPayload_Blocks) isGossipPayload_Message() {}
 
 func (*GossipPayload_Transaction) isGossipPayload_Message() {}
 
 func (*GossipPayload_Query) isGossipPayload_Message() {}
 
+func (*GossipPayload_CheckpointAttestation) isGossipPayload_Message() {}
+
+func (*GossipPayload_CompactBlock) isGossipPayload_Message() {}
+
+// CompactBlockProto is a block header with the short ids of its transactions.
+// the coinbase transaction is never in the mempool so it's sent in full.
+type CompactBlockProto struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Header   *block.ProtoBlockHeader       `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Coinbase *transaction.ProtoTransaction `protobuf:"bytes,2,opt,name=coinbase,proto3" json:"coinbase,omitempty"`
+	// short_transaction_ids contains the short ids of the transactions after the coinbase.
+	ShortTransactionIds [][]byte `protobuf:"bytes,3,rep,name=short_transaction_ids,json=shortTransactionIds,proto3" json:"short_transaction_ids,omitempty"`
+}
+
+func (x *CompactBlockProto) Reset() {
+	*x = CompactBlockProto{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_node_protocols_messages_messages_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CompactBlockProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompactBlockProto) ProtoMessage() {}
+
+func (x *CompactBlockProto) ProtoReflect() protoreflect.Message {
+	mi := &file_node_protocols_messages_messages_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompactBlockProto.ProtoReflect.Descriptor instead.
+func (*CompactBlockProto) Descriptor() ([]byte, []int) {
+	return file_node_protocols_messages_messages_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CompactBlockProto) GetHeader() *block.ProtoBlockHeader {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *CompactBlockProto) GetCoinbase() *transaction.ProtoTransaction {
+	if x != nil {
+		return x.Coinbase
+	}
+	return nil
+}
+
+func (x *CompactBlockProto) GetShortTransactionIds() [][]byte {
+	if x != nil {
+		return x.ShortTransactionIds
+	}
+	return nil
+}
+
+// BlockTransactionsRequestProto requests the transactions of a block by their index.
+type BlockTransactionsRequestProto struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockHash []byte   `protobuf:"bytes,1,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	Indexes   []uint32 `protobuf:"varint,2,rep,packed,name=indexes,proto3" json:"indexes,omitempty"`
+}
+
+func (x *BlockTransactionsRequestProto) Reset() {
+	*x = BlockTransactionsRequestProto{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_node_protocols_messages_messages_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlockTransactionsRequestProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockTransactionsRequestProto) ProtoMessage() {}
+
+func (x *BlockTransactionsRequestProto) ProtoReflect() protoreflect.Message {
+	mi := &file_node_protocols_messages_messages_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockTransactionsRequestProto.ProtoReflect.Descriptor instead.
+func (*BlockTransactionsRequestProto) Descriptor() ([]byte, []int) {
+	return file_node_protocols_messages_messages_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BlockTransactionsRequestProto) GetBlockHash() []byte {
+	if x != nil {
+		return x.BlockHash
+	}
+	return nil
+}
+
+func (x *BlockTransactionsRequestProto) GetIndexes() []uint32 {
+	if x != nil {
+		return x.Indexes
+	}
+	return nil
+}
+
+// BlockTransactionsResponseProto represents the requested transactions of a block.
+type BlockTransactionsResponseProto struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockHash    []byte                          `protobuf:"bytes,1,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	Error        bool                            `protobuf:"varint,2,opt,name=error,proto3" json:"error,omitempty"`
+	Transactions []*transaction.ProtoTransaction `protobuf:"bytes,3,rep,name=transactions,proto3" json:"transactions,omitempty"`
+}
+
+func (x *BlockTransactionsResponseProto) Reset() {
+	*x = BlockTransactionsResponseProto{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_node_protocols_messages_messages_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlockTransactionsResponseProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockTransactionsResponseProto) ProtoMessage() {}
+
+func (x *BlockTransactionsResponseProto) ProtoReflect() protoreflect.Message {
+	mi := &file_node_protocols_messages_messages_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockTransactionsResponseProto.ProtoReflect.Descriptor instead.
+func (*BlockTransactionsResponseProto) Descriptor() ([]byte, []int) {
+	return file_node_protocols_messages_messages_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BlockTransactionsResponseProto) GetBlockHash() []byte {
+	if x != nil {
+		return x.BlockHash
+	}
+	return nil
+}
+
+func (x *BlockTransactionsResponseProto) GetError() bool {
+	if x != nil {
+		return x.Error
+	}
+	return false
+}
+
+func (x *BlockTransactionsResponseProto) GetTransactions() []*transaction.ProtoTransaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+// CheckpointAttestationProto is a verifier's signature over a block number and hash which it considers final.
+type CheckpointAttestationProto struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockNumber uint64 `protobuf:"varint,1,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	BlockHash   []byte `protobuf:"bytes,2,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	PublicKey   []byte `protobuf:"bytes,3,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	Signature   []byte `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (x *CheckpointAttestationProto) Reset() {
+	*x = CheckpointAttestationProto{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_node_protocols_messages_messages_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CheckpointAttestationProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckpointAttestationProto) ProtoMessage() {}
+
+func (x *CheckpointAttestationProto) ProtoReflect() protoreflect.Message {
+	mi := &file_node_protocols_messages_messages_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckpointAttestationProto.ProtoReflect.Descriptor instead.
+func (*CheckpointAttestationProto) Descriptor() ([]byte, []int) {
+	return file_node_protocols_messages_messages_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CheckpointAttestationProto) GetBlockNumber() uint64 {
+	if x != nil {
+		return x.BlockNumber
+	}
+	return 0
+}
+
+func (x *CheckpointAttestationProto) GetBlockHash() []byte {
+	if x != nil {
+		return x.BlockHash
+	}
+	return nil
+}
+
+func (x *CheckpointAttestationProto) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+func (x *CheckpointAttestationProto) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
 // ProtoBlocks is the proto representation of blocks envelope.
 type ProtoBlocks struct {
 	state         protoimpl.MessageState
@@ -130,7 +416,7 @@ type ProtoBlocks struct {
 func (x *ProtoBlocks) Reset() {
 	*x = ProtoBlocks{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_node_protocols_messages_messages_proto_msgTypes[1]
+		mi := &file_node_protocols_messages_messages_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -143,7 +429,7 @@ func (x *ProtoBlocks) String() string {
 func (*ProtoBlocks) ProtoMessage() {}
 
 func (x *ProtoBlocks) ProtoReflect() protoreflect.Message {
-	mi := &file_node_protocols_messages_messages_proto_msgTypes[1]
+	mi := &file_node_protocols_messages_messages_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -156,7 +442,7 @@ func (x *ProtoBlocks) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProtoBlocks.ProtoReflect.Descriptor instead.
 func (*ProtoBlocks) Descriptor() ([]byte, []int) {
-	return file_node_protocols_messages_messages_proto_rawDescGZIP(), []int{1}
+	return file_node_protocols_messages_messages_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ProtoBlocks) GetBlocks() []*block.ProtoBlock {
@@ -181,7 +467,7 @@ type DataQueryRequestProto struct {
 func (x *DataQueryRequestProto) Reset() {
 	*x = DataQueryRequestProto{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_node_protocols_messages_messages_proto_msgTypes[2]
+		mi := &file_node_protocols_messages_messages_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -194,7 +480,7 @@ func (x *DataQueryRequestProto) String() string {
 func (*DataQueryRequestProto) ProtoMessage() {}
 
 func (x *DataQueryRequestProto) ProtoReflect() protoreflect.Message {
-	mi := &file_node_protocols_messages_messages_proto_msgTypes[2]
+	mi := &file_node_protocols_messages_messages_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -207,7 +493,7 @@ func (x *DataQueryRequestProto) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DataQueryRequestProto.ProtoReflect.Descriptor instead.
 func (*DataQueryRequestProto) Descriptor() ([]byte, []int) {
-	return file_node_protocols_messages_messages_proto_rawDescGZIP(), []int{2}
+	return file_node_protocols_messages_messages_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *DataQueryRequestProto) GetFileHashes() [][]byte {
@@ -260,7 +546,7 @@ type DataQueryResponseProto struct {
 func (x *DataQueryResponseProto) Reset() {
 	*x = DataQueryResponseProto{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_node_protocols_messages_messages_proto_msgTypes[3]
+		mi := &file_node_protocols_messages_messages_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -273,7 +559,7 @@ func (x *DataQueryResponseProto) String() string {
 func (*DataQueryResponseProto) ProtoMessage() {}
 
 func (x *DataQueryResponseProto) ProtoReflect() protoreflect.Message {
-	mi := &file_node_protocols_messages_messages_proto_msgTypes[3]
+	mi := &file_node_protocols_messages_messages_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -286,7 +572,7 @@ func (x *DataQueryResponseProto) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DataQueryResponseProto.ProtoReflect.Descriptor instead.
 func (*DataQueryResponseProto) Descriptor() ([]byte, []int) {
-	return file_node_protocols_messages_messages_proto_rawDescGZIP(), []int{3}
+	return file_node_protocols_messages_messages_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *DataQueryResponseProto) GetFromPeerAddr() string {
@@ -364,7 +650,7 @@ type DataQueryResponseTransferProto struct {
 func (x *DataQueryResponseTransferProto) Reset() {
 	*x = DataQueryResponseTransferProto{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_node_protocols_messages_messages_proto_msgTypes[4]
+		mi := &file_node_protocols_messages_messages_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -377,7 +663,7 @@ func (x *DataQueryResponseTransferProto) String() string {
 func (*DataQueryResponseTransferProto) ProtoMessage() {}
 
 func (x *DataQueryResponseTransferProto) ProtoReflect() protoreflect.Message {
-	mi := &file_node_protocols_messages_messages_proto_msgTypes[4]
+	mi := &file_node_protocols_messages_messages_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -390,7 +676,7 @@ func (x *DataQueryResponseTransferProto) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DataQueryResponseTransferProto.ProtoReflect.Descriptor instead.
 func (*DataQueryResponseTransferProto) Descriptor() ([]byte, []int) {
-	return file_node_protocols_messages_messages_proto_rawDescGZIP(), []int{4}
+	return file_node_protocols_messages_messages_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *DataQueryResponseTransferProto) GetHash() []byte {
@@ -412,7 +698,7 @@ type DataQueryResponseTransferResultProto struct {
 func (x *DataQueryResponseTransferResultProto) Reset() {
 	*x = DataQueryResponseTransferResultProto{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_node_protocols_messages_messages_proto_msgTypes[5]
+		mi := &file_node_protocols_messages_messages_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -425,7 +711,7 @@ func (x *DataQueryResponseTransferResultProto) String() string {
 func (*DataQueryResponseTransferResultProto) ProtoMessage() {}
 
 func (x *DataQueryResponseTransferResultProto) ProtoReflect() protoreflect.Message {
-	mi := &file_node_protocols_messages_messages_proto_msgTypes[5]
+	mi := &file_node_protocols_messages_messages_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -438,7 +724,7 @@ func (x *DataQueryResponseTransferResultProto) ProtoReflect() protoreflect.Messa
 
 // Deprecated: Use DataQueryResponseTransferResultProto.ProtoReflect.Descriptor instead.
 func (*DataQueryResponseTransferResultProto) Descriptor() ([]byte, []int) {
-	return file_node_protocols_messages_messages_proto_rawDescGZIP(), []int{5}
+	return file_node_protocols_messages_messages_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *DataQueryResponseTransferResultProto) GetResponses() []*DataQueryResponseProto {
@@ -449,18 +735,21 @@ func (x *DataQueryResponseTransferResultProto) GetResponses() []*DataQueryRespon
 }
 
 // BlockchainHeightResponseProto represents the height of a node's blockchain.
+// pruned nodes only serve the blocks starting from the lowest block number.
 type BlockchainHeightResponseProto struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Height uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Height            uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	LowestBlockNumber uint64 `protobuf:"varint,2,opt,name=lowest_block_number,json=lowestBlockNumber,proto3" json:"lowest_block_number,omitempty"`
+	Pruned            bool   `protobuf:"varint,3,opt,name=pruned,proto3" json:"pruned,omitempty"`
 }
 
 func (x *BlockchainHeightResponseProto) Reset() {
 	*x = BlockchainHeightResponseProto{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_node_protocols_messages_messages_proto_msgTypes[6]
+		mi := &file_node_protocols_messages_messages_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -473,7 +762,7 @@ func (x *BlockchainHeightResponseProto) String() string {
 func (*BlockchainHeightResponseProto) ProtoMessage() {}
 
 func (x *BlockchainHeightResponseProto) ProtoReflect() protoreflect.Message {
-	mi := &file_node_protocols_messages_messages_proto_msgTypes[6]
+	mi := &file_node_protocols_messages_messages_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -486,7 +775,7 @@ func (x *BlockchainHeightResponseProto) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BlockchainHeightResponseProto.ProtoReflect.Descriptor instead.
 func (*BlockchainHeightResponseProto) Descriptor() ([]byte, []int) {
-	return file_node_protocols_messages_messages_proto_rawDescGZIP(), []int{6}
+	return file_node_protocols_messages_messages_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *BlockchainHeightResponseProto) GetHeight() uint64 {
@@ -496,6 +785,20 @@ func (x *BlockchainHeightResponseProto) GetHeight() uint64 {
 	return 0
 }
 
+func (x *BlockchainHeightResponseProto) GetLowestBlockNumber() uint64 {
+	if x != nil {
+		return x.LowestBlockNumber
+	}
+	return 0
+}
+
+func (x *BlockchainHeightResponseProto) GetPruned() bool {
+	if x != nil {
+		return x.Pruned
+	}
+	return false
+}
+
 // BlockDownloadRequestProto represents a block download request range.
 type BlockDownloadRequestProto struct {
 	state         protoimpl.MessageState
@@ -509,7 +812,7 @@ type BlockDownloadRequestProto struct {
 func (x *BlockDownloadRequestProto) Reset() {
 	*x = BlockDownloadRequestProto{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_node_protocols_messages_messages_proto_msgTypes[7]
+		mi := &file_node_protocols_messages_messages_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -522,7 +825,7 @@ func (x *BlockDownloadRequestProto) String() string {
 func (*BlockDownloadRequestProto) ProtoMessage() {}
 
 func (x *BlockDownloadRequestProto) ProtoReflect() protoreflect.Message {
-	mi := &file_node_protocols_messages_messages_proto_msgTypes[7]
+	mi := &file_node_protocols_messages_messages_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -535,7 +838,7 @@ func (x *BlockDownloadRequestProto) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BlockDownloadRequestProto.ProtoReflect.Descriptor instead.
 func (*BlockDownloadRequestProto) Descriptor() ([]byte, []int) {
-	return file_node_protocols_messages_messages_proto_rawDescGZIP(), []int{7}
+	return file_node_protocols_messages_messages_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *BlockDownloadRequestProto) GetFrom() uint64 {
@@ -568,7 +871,7 @@ type BlockDownloadResponseProto struct {
 func (x *BlockDownloadResponseProto) Reset() {
 	*x = BlockDownloadResponseProto{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_node_protocols_messages_messages_proto_msgTypes[8]
+		mi := &file_node_protocols_messages_messages_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -581,7 +884,7 @@ func (x *BlockDownloadResponseProto) String() string {
 func (*BlockDownloadResponseProto) ProtoMessage() {}
 
 func (x *BlockDownloadResponseProto) ProtoReflect() protoreflect.Message {
-	mi := &file_node_protocols_messages_messages_proto_msgTypes[8]
+	mi := &file_node_protocols_messages_messages_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -594,7 +897,7 @@ func (x *BlockDownloadResponseProto) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BlockDownloadResponseProto.ProtoReflect.Descriptor instead.
 func (*BlockDownloadResponseProto) Descriptor() ([]byte, []int) {
-	return file_node_protocols_messages_messages_proto_rawDescGZIP(), []int{8}
+	return file_node_protocols_messages_messages_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *BlockDownloadResponseProto) GetFrom() uint64 {
@@ -632,6 +935,438 @@ func (x *BlockDownloadResponseProto) GetBlocks() []*block.ProtoBlock {
 	return nil
 }
 
+// BlockHeadersResponseProto represents the headers of a block range.
+type BlockHeadersResponseProto struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	From       uint64                    `protobuf:"varint,1,opt,name=from,proto3" json:"from,omitempty"`
+	To         uint64                    `protobuf:"varint,2,opt,name=to,proto3" json:"to,omitempty"`
+	Error      bool                      `protobuf:"varint,3,opt,name=error,proto3" json:"error,omitempty"`
+	NodeHeight uint64                    `protobuf:"varint,4,opt,name=node_height,json=nodeHeight,proto3" json:"node_height,omitempty"`
+	Headers    []*block.ProtoBlockHeader `protobuf:"bytes,5,rep,name=headers,proto3" json:"headers,omitempty"`
+}
+
+func (x *BlockHeadersResponseProto) Reset() {
+	*x = BlockHeadersResponseProto{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_node_protocols_messages_messages_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlockHeadersResponseProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockHeadersResponseProto) ProtoMessage() {}
+
+func (x *BlockHeadersResponseProto) ProtoReflect() protoreflect.Message {
+	mi := &file_node_protocols_messages_messages_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockHeadersResponseProto.ProtoReflect.Descriptor instead.
+func (*BlockHeadersResponseProto) Descriptor() ([]byte, []int) {
+	return file_node_protocols_messages_messages_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *BlockHeadersResponseProto) GetFrom() uint64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *BlockHeadersResponseProto) GetTo() uint64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *BlockHeadersResponseProto) GetError() bool {
+	if x != nil {
+		return x.Error
+	}
+	return false
+}
+
+func (x *BlockHeadersResponseProto) GetNodeHeight() uint64 {
+	if x != nil {
+		return x.NodeHeight
+	}
+	return 0
+}
+
+func (x *BlockHeadersResponseProto) GetHeaders() []*block.ProtoBlockHeader {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+// SnapshotManifestProto represents a state snapshot signed by a verifier.
+type SnapshotManifestProto struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Height      uint64   `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	BlockHash   []byte   `protobuf:"bytes,2,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	ChunkHashes [][]byte `protobuf:"bytes,3,rep,name=chunk_hashes,json=chunkHashes,proto3" json:"chunk_hashes,omitempty"`
+	PublicKey   []byte   `protobuf:"bytes,4,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	Signature   []byte   `protobuf:"bytes,5,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (x *SnapshotManifestProto) Reset() {
+	*x = SnapshotManifestProto{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_node_protocols_messages_messages_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SnapshotManifestProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotManifestProto) ProtoMessage() {}
+
+func (x *SnapshotManifestProto) ProtoReflect() protoreflect.Message {
+	mi := &file_node_protocols_messages_messages_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotManifestProto.ProtoReflect.Descriptor instead.
+func (*SnapshotManifestProto) Descriptor() ([]byte, []int) {
+	return file_node_protocols_messages_messages_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SnapshotManifestProto) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *SnapshotManifestProto) GetBlockHash() []byte {
+	if x != nil {
+		return x.BlockHash
+	}
+	return nil
+}
+
+func (x *SnapshotManifestProto) GetChunkHashes() [][]byte {
+	if x != nil {
+		return x.ChunkHashes
+	}
+	return nil
+}
+
+func (x *SnapshotManifestProto) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+func (x *SnapshotManifestProto) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+// SnapshotManifestResponseProto represents the response of a snapshot manifest request.
+type SnapshotManifestResponseProto struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Error    bool                   `protobuf:"varint,1,opt,name=error,proto3" json:"error,omitempty"`
+	Manifest *SnapshotManifestProto `protobuf:"bytes,2,opt,name=manifest,proto3" json:"manifest,omitempty"`
+}
+
+func (x *SnapshotManifestResponseProto) Reset() {
+	*x = SnapshotManifestResponseProto{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_node_protocols_messages_messages_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SnapshotManifestResponseProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotManifestResponseProto) ProtoMessage() {}
+
+func (x *SnapshotManifestResponseProto) ProtoReflect() protoreflect.Message {
+	mi := &file_node_protocols_messages_messages_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotManifestResponseProto.ProtoReflect.Descriptor instead.
+func (*SnapshotManifestResponseProto) Descriptor() ([]byte, []int) {
+	return file_node_protocols_messages_messages_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SnapshotManifestResponseProto) GetError() bool {
+	if x != nil {
+		return x.Error
+	}
+	return false
+}
+
+func (x *SnapshotManifestResponseProto) GetManifest() *SnapshotManifestProto {
+	if x != nil {
+		return x.Manifest
+	}
+	return nil
+}
+
+// SnapshotRecordProto represents a database record of a state snapshot.
+type SnapshotRecordProto struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key   []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *SnapshotRecordProto) Reset() {
+	*x = SnapshotRecordProto{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_node_protocols_messages_messages_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SnapshotRecordProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotRecordProto) ProtoMessage() {}
+
+func (x *SnapshotRecordProto) ProtoReflect() protoreflect.Message {
+	mi := &file_node_protocols_messages_messages_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotRecordProto.ProtoReflect.Descriptor instead.
+func (*SnapshotRecordProto) Descriptor() ([]byte, []int) {
+	return file_node_protocols_messages_messages_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SnapshotRecordProto) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *SnapshotRecordProto) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+// SnapshotChunkProto represents a chunk of a state snapshot.
+type SnapshotChunkProto struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Records []*SnapshotRecordProto `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+}
+
+func (x *SnapshotChunkProto) Reset() {
+	*x = SnapshotChunkProto{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_node_protocols_messages_messages_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SnapshotChunkProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotChunkProto) ProtoMessage() {}
+
+func (x *SnapshotChunkProto) ProtoReflect() protoreflect.Message {
+	mi := &file_node_protocols_messages_messages_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotChunkProto.ProtoReflect.Descriptor instead.
+func (*SnapshotChunkProto) Descriptor() ([]byte, []int) {
+	return file_node_protocols_messages_messages_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SnapshotChunkProto) GetRecords() []*SnapshotRecordProto {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+// SnapshotChunkRequestProto represents a snapshot chunk request.
+type SnapshotChunkRequestProto struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Height     uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	ChunkIndex uint64 `protobuf:"varint,2,opt,name=chunk_index,json=chunkIndex,proto3" json:"chunk_index,omitempty"`
+}
+
+func (x *SnapshotChunkRequestProto) Reset() {
+	*x = SnapshotChunkRequestProto{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_node_protocols_messages_messages_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SnapshotChunkRequestProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotChunkRequestProto) ProtoMessage() {}
+
+func (x *SnapshotChunkRequestProto) ProtoReflect() protoreflect.Message {
+	mi := &file_node_protocols_messages_messages_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotChunkRequestProto.ProtoReflect.Descriptor instead.
+func (*SnapshotChunkRequestProto) Descriptor() ([]byte, []int) {
+	return file_node_protocols_messages_messages_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SnapshotChunkRequestProto) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *SnapshotChunkRequestProto) GetChunkIndex() uint64 {
+	if x != nil {
+		return x.ChunkIndex
+	}
+	return 0
+}
+
+// SnapshotChunkResponseProto represents a snapshot chunk response.
+type SnapshotChunkResponseProto struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Error bool   `protobuf:"varint,1,opt,name=error,proto3" json:"error,omitempty"`
+	Data  []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *SnapshotChunkResponseProto) Reset() {
+	*x = SnapshotChunkResponseProto{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_node_protocols_messages_messages_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SnapshotChunkResponseProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotChunkResponseProto) ProtoMessage() {}
+
+func (x *SnapshotChunkResponseProto) ProtoReflect() protoreflect.Message {
+	mi := &file_node_protocols_messages_messages_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotChunkResponseProto.ProtoReflect.Descriptor instead.
+func (*SnapshotChunkResponseProto) Descriptor() ([]byte, []int) {
+	return file_node_protocols_messages_messages_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SnapshotChunkResponseProto) GetError() bool {
+	if x != nil {
+		return x.Error
+	}
+	return false
+}
+
+func (x *SnapshotChunkResponseProto) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
 // DownloadContractProto represents a download contract.
 type DownloadContractProto struct {
 	state         protoimpl.MessageState
@@ -652,7 +1387,7 @@ type DownloadContractProto struct {
 func (x *DownloadContractProto) Reset() {
 	*x = DownloadContractProto{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_node_protocols_messages_messages_proto_msgTypes[9]
+		mi := &file_node_protocols_messages_messages_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -665,7 +1400,7 @@ func (x *DownloadContractProto) String() string {
 func (*DownloadContractProto) ProtoMessage() {}
 
 func (x *DownloadContractProto) ProtoReflect() protoreflect.Message {
-	mi := &file_node_protocols_messages_messages_proto_msgTypes[9]
+	mi := &file_node_protocols_messages_messages_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -678,7 +1413,7 @@ func (x *DownloadContractProto) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DownloadContractProto.ProtoReflect.Descriptor instead.
 func (*DownloadContractProto) Descriptor() ([]byte, []int) {
-	return file_node_protocols_messages_messages_proto_rawDescGZIP(), []int{9}
+	return file_node_protocols_messages_messages_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *DownloadContractProto) GetFileHosterResponse() *DataQueryResponseProto {
@@ -749,12 +1484,17 @@ type DownloadContractInTransactionDataProto struct {
 	VerifierPublicKey          []byte `protobuf:"bytes,4,opt,name=verifier_public_key,json=verifierPublicKey,proto3" json:"verifier_public_key,omitempty"`
 	VerifierFees               string `protobuf:"bytes,5,opt,name=verifier_fees,json=verifierFees,proto3" json:"verifier_fees,omitempty"`
 	FileHosterFees             string `protobuf:"bytes,6,opt,name=file_hoster_fees,json=fileHosterFees,proto3" json:"file_hoster_fees,omitempty"`
+	// the rest of the contract signed by the verifier, so the contract hash and the signature can be verified on chain.
+	FileHosterSignature   []byte   `protobuf:"bytes,8,opt,name=file_hoster_signature,json=fileHosterSignature,proto3" json:"file_hoster_signature,omitempty"`
+	FileHashesNeeded      [][]byte `protobuf:"bytes,9,rep,name=file_hashes_needed,json=fileHashesNeeded,proto3" json:"file_hashes_needed,omitempty"`
+	FileHashesNeededSizes []uint64 `protobuf:"varint,10,rep,packed,name=file_hashes_needed_sizes,json=fileHashesNeededSizes,proto3" json:"file_hashes_needed_sizes,omitempty"`
+	VerifierSignature     []byte   `protobuf:"bytes,11,opt,name=verifier_signature,json=verifierSignature,proto3" json:"verifier_signature,omitempty"`
 }
 
 func (x *DownloadContractInTransactionDataProto) Reset() {
 	*x = DownloadContractInTransactionDataProto{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_node_protocols_messages_messages_proto_msgTypes[10]
+		mi := &file_node_protocols_messages_messages_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -767,7 +1507,7 @@ func (x *DownloadContractInTransactionDataProto) String() string {
 func (*DownloadContractInTransactionDataProto) ProtoMessage() {}
 
 func (x *DownloadContractInTransactionDataProto) ProtoReflect() protoreflect.Message {
-	mi := &file_node_protocols_messages_messages_proto_msgTypes[10]
+	mi := &file_node_protocols_messages_messages_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -780,7 +1520,7 @@ func (x *DownloadContractInTransactionDataProto) ProtoReflect() protoreflect.Mes
 
 // Deprecated: Use DownloadContractInTransactionDataProto.ProtoReflect.Descriptor instead.
 func (*DownloadContractInTransactionDataProto) Descriptor() ([]byte, []int) {
-	return file_node_protocols_messages_messages_proto_rawDescGZIP(), []int{10}
+	return file_node_protocols_messages_messages_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *DownloadContractInTransactionDataProto) GetContractHash() []byte {
@@ -794,35 +1534,63 @@ func (x *DownloadContractInTransactionDataProto) GetFileRequesterNodePublicKey()
 	if x != nil {
 		return x.FileRequesterNodePublicKey
 	}
-	return nil
+	return nil
+}
+
+func (x *DownloadContractInTransactionDataProto) GetFileHosterNodePublicKey() []byte {
+	if x != nil {
+		return x.FileHosterNodePublicKey
+	}
+	return nil
+}
+
+func (x *DownloadContractInTransactionDataProto) GetVerifierPublicKey() []byte {
+	if x != nil {
+		return x.VerifierPublicKey
+	}
+	return nil
+}
+
+func (x *DownloadContractInTransactionDataProto) GetVerifierFees() string {
+	if x != nil {
+		return x.VerifierFees
+	}
+	return ""
+}
+
+func (x *DownloadContractInTransactionDataProto) GetFileHosterFees() string {
+	if x != nil {
+		return x.FileHosterFees
+	}
+	return ""
 }
 
-func (x *DownloadContractInTransactionDataProto) GetFileHosterNodePublicKey() []byte {
+func (x *DownloadContractInTransactionDataProto) GetFileHosterSignature() []byte {
 	if x != nil {
-		return x.FileHosterNodePublicKey
+		return x.FileHosterSignature
 	}
 	return nil
 }
 
-func (x *DownloadContractInTransactionDataProto) GetVerifierPublicKey() []byte {
+func (x *DownloadContractInTransactionDataProto) GetFileHashesNeeded() [][]byte {
 	if x != nil {
-		return x.VerifierPublicKey
+		return x.FileHashesNeeded
 	}
 	return nil
 }
 
-func (x *DownloadContractInTransactionDataProto) GetVerifierFees() string {
+func (x *DownloadContractInTransactionDataProto) GetFileHashesNeededSizes() []uint64 {
 	if x != nil {
-		return x.VerifierFees
+		return x.FileHashesNeededSizes
 	}
-	return ""
+	return nil
 }
 
-func (x *DownloadContractInTransactionDataProto) GetFileHosterFees() string {
+func (x *DownloadContractInTransactionDataProto) GetVerifierSignature() []byte {
 	if x != nil {
-		return x.FileHosterFees
+		return x.VerifierSignature
 	}
-	return ""
+	return nil
 }
 
 // DownloadContractsHashesProto contains a list of contracts hashes which will be send as a transaction data payload.
@@ -837,7 +1605,7 @@ type DownloadContractsHashesProto struct {
 func (x *DownloadContractsHashesProto) Reset() {
 	*x = DownloadContractsHashesProto{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_node_protocols_messages_messages_proto_msgTypes[11]
+		mi := &file_node_protocols_messages_messages_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -850,7 +1618,7 @@ func (x *DownloadContractsHashesProto) String() string {
 func (*DownloadContractsHashesProto) ProtoMessage() {}
 
 func (x *DownloadContractsHashesProto) ProtoReflect() protoreflect.Message {
-	mi := &file_node_protocols_messages_messages_proto_msgTypes[11]
+	mi := &file_node_protocols_messages_messages_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -863,7 +1631,7 @@ func (x *DownloadContractsHashesProto) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DownloadContractsHashesProto.ProtoReflect.Descriptor instead.
 func (*DownloadContractsHashesProto) Descriptor() ([]byte, []int) {
-	return file_node_protocols_messages_messages_proto_rawDescGZIP(), []int{11}
+	return file_node_protocols_messages_messages_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *DownloadContractsHashesProto) GetContracts() []*DownloadContractInTransactionDataProto {
@@ -887,7 +1655,7 @@ type MerkleTreeNodesOfFileContractProto struct {
 func (x *MerkleTreeNodesOfFileContractProto) Reset() {
 	*x = MerkleTreeNodesOfFileContractProto{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_node_protocols_messages_messages_proto_msgTypes[12]
+		mi := &file_node_protocols_messages_messages_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -900,7 +1668,7 @@ func (x *MerkleTreeNodesOfFileContractProto) String() string {
 func (*MerkleTreeNodesOfFileContractProto) ProtoMessage() {}
 
 func (x *MerkleTreeNodesOfFileContractProto) ProtoReflect() protoreflect.Message {
-	mi := &file_node_protocols_messages_messages_proto_msgTypes[12]
+	mi := &file_node_protocols_messages_messages_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -913,7 +1681,7 @@ func (x *MerkleTreeNodesOfFileContractProto) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use MerkleTreeNodesOfFileContractProto.ProtoReflect.Descriptor instead.
 func (*MerkleTreeNodesOfFileContractProto) Descriptor() ([]byte, []int) {
-	return file_node_protocols_messages_messages_proto_rawDescGZIP(), []int{12}
+	return file_node_protocols_messages_messages_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *MerkleTreeNodesOfFileContractProto) GetContractHash() []byte {
@@ -951,7 +1719,7 @@ type KeyIVProto struct {
 func (x *KeyIVProto) Reset() {
 	*x = KeyIVProto{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_node_protocols_messages_messages_proto_msgTypes[13]
+		mi := &file_node_protocols_messages_messages_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -964,7 +1732,7 @@ func (x *KeyIVProto) String() string {
 func (*KeyIVProto) ProtoMessage() {}
 
 func (x *KeyIVProto) ProtoReflect() protoreflect.Message {
-	mi := &file_node_protocols_messages_messages_proto_msgTypes[13]
+	mi := &file_node_protocols_messages_messages_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -977,7 +1745,7 @@ func (x *KeyIVProto) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use KeyIVProto.ProtoReflect.Descriptor instead.
 func (*KeyIVProto) Descriptor() ([]byte, []int) {
-	return file_node_protocols_messages_messages_proto_rawDescGZIP(), []int{13}
+	return file_node_protocols_messages_messages_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *KeyIVProto) GetContractHash() []byte {
@@ -1013,7 +1781,7 @@ type KeyIVRequestsProto struct {
 func (x *KeyIVRequestsProto) Reset() {
 	*x = KeyIVRequestsProto{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_node_protocols_messages_messages_proto_msgTypes[14]
+		mi := &file_node_protocols_messages_messages_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1026,7 +1794,7 @@ func (x *KeyIVRequestsProto) String() string {
 func (*KeyIVRequestsProto) ProtoMessage() {}
 
 func (x *KeyIVRequestsProto) ProtoReflect() protoreflect.Message {
-	mi := &file_node_protocols_messages_messages_proto_msgTypes[14]
+	mi := &file_node_protocols_messages_messages_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1039,7 +1807,7 @@ func (x *KeyIVRequestsProto) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use KeyIVRequestsProto.ProtoReflect.Descriptor instead.
 func (*KeyIVRequestsProto) Descriptor() ([]byte, []int) {
-	return file_node_protocols_messages_messages_proto_rawDescGZIP(), []int{14}
+	return file_node_protocols_messages_messages_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *KeyIVRequestsProto) GetKeyIvs() []*KeyIVProto {
@@ -1061,7 +1829,7 @@ type KeyIVRandomizedFileSegmentsEnvelopeProto struct {
 func (x *KeyIVRandomizedFileSegmentsEnvelopeProto) Reset() {
 	*x = KeyIVRandomizedFileSegmentsEnvelopeProto{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_node_protocols_messages_messages_proto_msgTypes[15]
+		mi := &file_node_protocols_messages_messages_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1074,7 +1842,7 @@ func (x *KeyIVRandomizedFileSegmentsEnvelopeProto) String() string {
 func (*KeyIVRandomizedFileSegmentsEnvelopeProto) ProtoMessage() {}
 
 func (x *KeyIVRandomizedFileSegmentsEnvelopeProto) ProtoReflect() protoreflect.Message {
-	mi := &file_node_protocols_messages_messages_proto_msgTypes[15]
+	mi := &file_node_protocols_messages_messages_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1087,7 +1855,7 @@ func (x *KeyIVRandomizedFileSegmentsEnvelopeProto) ProtoReflect() protoreflect.M
 
 // Deprecated: Use KeyIVRandomizedFileSegmentsEnvelopeProto.ProtoReflect.Descriptor instead.
 func (*KeyIVRandomizedFileSegmentsEnvelopeProto) Descriptor() ([]byte, []int) {
-	return file_node_protocols_messages_messages_proto_rawDescGZIP(), []int{15}
+	return file_node_protocols_messages_messages_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *KeyIVRandomizedFileSegmentsEnvelopeProto) GetKeyIvRandomizedFileSegments() []*KeyIVRandomizedFileSegmentsProto {
@@ -1121,7 +1889,7 @@ type KeyIVRandomizedFileSegmentsProto struct {
 func (x *KeyIVRandomizedFileSegmentsProto) Reset() {
 	*x = KeyIVRandomizedFileSegmentsProto{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_node_protocols_messages_messages_proto_msgTypes[16]
+		mi := &file_node_protocols_messages_messages_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1134,7 +1902,7 @@ func (x *KeyIVRandomizedFileSegmentsProto) String() string {
 func (*KeyIVRandomizedFileSegmentsProto) ProtoMessage() {}
 
 func (x *KeyIVRandomizedFileSegmentsProto) ProtoReflect() protoreflect.Message {
-	mi := &file_node_protocols_messages_messages_proto_msgTypes[16]
+	mi := &file_node_protocols_messages_messages_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1147,7 +1915,7 @@ func (x *KeyIVRandomizedFileSegmentsProto) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use KeyIVRandomizedFileSegmentsProto.ProtoReflect.Descriptor instead.
 func (*KeyIVRandomizedFileSegmentsProto) Descriptor() ([]byte, []int) {
-	return file_node_protocols_messages_messages_proto_rawDescGZIP(), []int{16}
+	return file_node_protocols_messages_messages_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *KeyIVRandomizedFileSegmentsProto) GetFileSize() uint64 {
@@ -1241,7 +2009,7 @@ type FileTransferInfoProto struct {
 func (x *FileTransferInfoProto) Reset() {
 	*x = FileTransferInfoProto{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_node_protocols_messages_messages_proto_msgTypes[17]
+		mi := &file_node_protocols_messages_messages_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1254,7 +2022,7 @@ func (x *FileTransferInfoProto) String() string {
 func (*FileTransferInfoProto) ProtoMessage() {}
 
 func (x *FileTransferInfoProto) ProtoReflect() protoreflect.Message {
-	mi := &file_node_protocols_messages_messages_proto_msgTypes[17]
+	mi := &file_node_protocols_messages_messages_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1267,7 +2035,7 @@ func (x *FileTransferInfoProto) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FileTransferInfoProto.ProtoReflect.Descriptor instead.
 func (*FileTransferInfoProto) Descriptor() ([]byte, []int) {
-	return file_node_protocols_messages_messages_proto_rawDescGZIP(), []int{17}
+	return file_node_protocols_messages_messages_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *FileTransferInfoProto) GetContractHash() []byte {
@@ -1305,6 +2073,95 @@ func (x *FileTransferInfoProto) GetTo() int64 {
 	return 0
 }
 
+// PeerRecordProto represents a known peer of the address book.
+type PeerRecordProto struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PeerId    []byte   `protobuf:"bytes,1,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"`
+	Addrs     [][]byte `protobuf:"bytes,2,rep,name=addrs,proto3" json:"addrs,omitempty"`
+	LastSeen  int64    `protobuf:"varint,3,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
+	Successes uint64   `protobuf:"varint,4,opt,name=successes,proto3" json:"successes,omitempty"`
+	Failures  uint64   `protobuf:"varint,5,opt,name=failures,proto3" json:"failures,omitempty"`
+	// protocols are the ffg protocols advertised by the peer.
+	Protocols []string `protobuf:"bytes,6,rep,name=protocols,proto3" json:"protocols,omitempty"`
+}
+
+func (x *PeerRecordProto) Reset() {
+	*x = PeerRecordProto{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_node_protocols_messages_messages_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PeerRecordProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerRecordProto) ProtoMessage() {}
+
+func (x *PeerRecordProto) ProtoReflect() protoreflect.Message {
+	mi := &file_node_protocols_messages_messages_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerRecordProto.ProtoReflect.Descriptor instead.
+func (*PeerRecordProto) Descriptor() ([]byte, []int) {
+	return file_node_protocols_messages_messages_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *PeerRecordProto) GetPeerId() []byte {
+	if x != nil {
+		return x.PeerId
+	}
+	return nil
+}
+
+func (x *PeerRecordProto) GetAddrs() [][]byte {
+	if x != nil {
+		return x.Addrs
+	}
+	return nil
+}
+
+func (x *PeerRecordProto) GetLastSeen() int64 {
+	if x != nil {
+		return x.LastSeen
+	}
+	return 0
+}
+
+func (x *PeerRecordProto) GetSuccesses() uint64 {
+	if x != nil {
+		return x.Successes
+	}
+	return 0
+}
+
+func (x *PeerRecordProto) GetFailures() uint64 {
+	if x != nil {
+		return x.Failures
+	}
+	return 0
+}
+
+func (x *PeerRecordProto) GetProtocols() []string {
+	if x != nil {
+		return x.Protocols
+	}
+	return nil
+}
+
 var File_node_protocols_messages_messages_proto protoreflect.FileDescriptor
 
 var file_node_protocols_messages_messages_proto_rawDesc = []byte{
@@ -1314,7 +2171,7 @@ var file_node_protocols_messages_messages_proto_rawDesc = []byte{
 	0x65, 0x73, 0x1a, 0x1d, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2f,
 	0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x1a, 0x11, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x2f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x22, 0xc7, 0x01, 0x0a, 0x0d, 0x47, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x50,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0xea, 0x02, 0x0a, 0x0d, 0x47, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x50,
 	0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x2f, 0x0a, 0x06, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73,
 	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
 	0x73, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x48, 0x00, 0x52,
@@ -1326,199 +2183,322 @@ var file_node_protocols_messages_messages_proto_rawDesc = []byte{
 	0x65, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6d, 0x65, 0x73, 0x73,
 	0x61, 0x67, 0x65, 0x73, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65,
 	0x71, 0x75, 0x65, 0x73, 0x74, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x48, 0x00, 0x52, 0x05, 0x71, 0x75,
-	0x65, 0x72, 0x79, 0x42, 0x09, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x38,
-	0x0a, 0x0b, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x12, 0x29, 0x0a,
-	0x06, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e,
-	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
-	0x52, 0x06, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x22, 0x90, 0x01, 0x0a, 0x15, 0x44, 0x61, 0x74,
-	0x61, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x50, 0x72, 0x6f,
-	0x74, 0x6f, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x65,
-	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0a, 0x66, 0x69, 0x6c, 0x65, 0x48, 0x61, 0x73,
-	0x68, 0x65, 0x73, 0x12, 0x24, 0x0a, 0x0e, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x70, 0x65, 0x65, 0x72,
-	0x5f, 0x61, 0x64, 0x64, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x66, 0x72, 0x6f,
-	0x6d, 0x50, 0x65, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73,
-	0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x1c, 0x0a,
-	0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0xf9, 0x02, 0x0a, 0x16,
-	0x44, 0x61, 0x74, 0x61, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x24, 0x0a, 0x0e, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x70,
-	0x65, 0x65, 0x72, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
-	0x66, 0x72, 0x6f, 0x6d, 0x50, 0x65, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72, 0x12, 0x22, 0x0a, 0x0d,
-	0x66, 0x65, 0x65, 0x73, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0b, 0x66, 0x65, 0x65, 0x73, 0x50, 0x65, 0x72, 0x42, 0x79, 0x74, 0x65,
-	0x12, 0x35, 0x0a, 0x17, 0x68, 0x61, 0x73, 0x68, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x71, 0x75,
-	0x65, 0x72, 0x79, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x14, 0x68, 0x61, 0x73, 0x68, 0x44, 0x61, 0x74, 0x61, 0x51, 0x75, 0x65, 0x72, 0x79,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x75, 0x62, 0x6c, 0x69,
-	0x63, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x70, 0x75, 0x62,
-	0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74,
-	0x75, 0x72, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61,
-	0x74, 0x75, 0x72, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x68, 0x61, 0x73,
-	0x68, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0a, 0x66, 0x69, 0x6c, 0x65, 0x48,
-	0x61, 0x73, 0x68, 0x65, 0x73, 0x12, 0x2a, 0x0a, 0x11, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x68, 0x61,
-	0x73, 0x68, 0x65, 0x73, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x04,
-	0x52, 0x0f, 0x66, 0x69, 0x6c, 0x65, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x53, 0x69, 0x7a, 0x65,
-	0x73, 0x12, 0x36, 0x0a, 0x17, 0x75, 0x6e, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65,
-	0x5f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x18, 0x08, 0x20, 0x03,
-	0x28, 0x0c, 0x52, 0x15, 0x75, 0x6e, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x46,
-	0x69, 0x6c, 0x65, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d,
-	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69,
-	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0x34, 0x0a, 0x1e, 0x44, 0x61, 0x74, 0x61, 0x51,
-	0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x54, 0x72, 0x61, 0x6e,
-	0x73, 0x66, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73,
-	0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x22, 0x66, 0x0a,
-	0x24, 0x44, 0x61, 0x74, 0x61, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
-	0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x3e, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x73, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x52, 0x09, 0x72, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x73, 0x22, 0x37, 0x0a, 0x1d, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x63, 0x68,
-	0x61, 0x69, 0x6e, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0x3f,
-	0x0a, 0x19, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x66,
-	0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12,
-	0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x74, 0x6f, 0x22,
-	0xa2, 0x01, 0x0a, 0x1a, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61,
-	0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x12,
-	0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x66, 0x72,
-	0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02,
-	0x74, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x6e, 0x6f, 0x64, 0x65,
-	0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x6e,
-	0x6f, 0x64, 0x65, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x29, 0x0a, 0x06, 0x62, 0x6c, 0x6f,
-	0x63, 0x6b, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x62, 0x6c, 0x6f, 0x63,
+	0x65, 0x72, 0x79, 0x12, 0x5d, 0x0a, 0x16, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e,
+	0x74, 0x5f, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x43,
+	0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x48, 0x00, 0x52, 0x15, 0x63, 0x68, 0x65,
+	0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x42, 0x0a, 0x0d, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x5f, 0x62, 0x6c,
+	0x6f, 0x63, 0x6b, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x48, 0x00, 0x52, 0x0c, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x63,
+	0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x09, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x22, 0xb3, 0x01, 0x0a, 0x11, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x2f, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x2e,
+	0x50, 0x72, 0x6f, 0x74, 0x6f, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x52, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x39, 0x0a, 0x08, 0x63, 0x6f, 0x69, 0x6e,
+	0x62, 0x61, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x74, 0x72, 0x61,
+	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x63, 0x6f, 0x69, 0x6e, 0x62,
+	0x61, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x15, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x5f, 0x74, 0x72, 0x61,
+	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x0c, 0x52, 0x13, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x73, 0x22, 0x58, 0x0a, 0x1d, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x63,
+	0x6b, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x62, 0x6c,
+	0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x12, 0x18, 0x0a, 0x07, 0x69, 0x6e, 0x64, 0x65, 0x78,
+	0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x07, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x65,
+	0x73, 0x22, 0x98, 0x01, 0x0a, 0x1e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x50,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x68, 0x61,
+	0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x48,
+	0x61, 0x73, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x41, 0x0a, 0x0c, 0x74, 0x72, 0x61,
+	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1d, 0x2e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x50, 0x72,
+	0x6f, 0x74, 0x6f, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c,
+	0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x9b, 0x01, 0x0a,
+	0x1a, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x41, 0x74, 0x74, 0x65, 0x73,
+	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x21, 0x0a, 0x0c, 0x62,
+	0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0b, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1d,
+	0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x09, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x12, 0x1d, 0x0a,
+	0x0a, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x1c, 0x0a, 0x09,
+	0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x38, 0x0a, 0x0b, 0x50, 0x72,
+	0x6f, 0x74, 0x6f, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x12, 0x29, 0x0a, 0x06, 0x62, 0x6c, 0x6f,
+	0x63, 0x6b, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x62, 0x6c, 0x6f, 0x63,
 	0x6b, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x06, 0x62, 0x6c,
-	0x6f, 0x63, 0x6b, 0x73, 0x22, 0xbf, 0x03, 0x0a, 0x15, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61,
-	0x64, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x52,
-	0x0a, 0x14, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x68, 0x6f, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x72, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x51, 0x75, 0x65, 0x72,
-	0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x52, 0x12,
-	0x66, 0x69, 0x6c, 0x65, 0x48, 0x6f, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x42, 0x0a, 0x1e, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x65, 0x72, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63,
-	0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x1a, 0x66, 0x69, 0x6c, 0x65,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x72, 0x4e, 0x6f, 0x64, 0x65, 0x50, 0x75, 0x62,
-	0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x2c, 0x0a, 0x12, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x68,
-	0x61, 0x73, 0x68, 0x65, 0x73, 0x5f, 0x6e, 0x65, 0x65, 0x64, 0x65, 0x64, 0x18, 0x03, 0x20, 0x03,
-	0x28, 0x0c, 0x52, 0x10, 0x66, 0x69, 0x6c, 0x65, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x4e, 0x65,
-	0x65, 0x64, 0x65, 0x64, 0x12, 0x37, 0x0a, 0x18, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x68, 0x61, 0x73,
-	0x68, 0x65, 0x73, 0x5f, 0x6e, 0x65, 0x65, 0x64, 0x65, 0x64, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x73,
-	0x18, 0x04, 0x20, 0x03, 0x28, 0x04, 0x52, 0x15, 0x66, 0x69, 0x6c, 0x65, 0x48, 0x61, 0x73, 0x68,
-	0x65, 0x73, 0x4e, 0x65, 0x65, 0x64, 0x65, 0x64, 0x53, 0x69, 0x7a, 0x65, 0x73, 0x12, 0x2e, 0x0a,
-	0x13, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x72, 0x5f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63,
-	0x5f, 0x6b, 0x65, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x11, 0x76, 0x65, 0x72, 0x69,
-	0x66, 0x69, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x23, 0x0a,
-	0x0d, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x72, 0x5f, 0x66, 0x65, 0x65, 0x73, 0x18, 0x06,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x72, 0x46, 0x65,
-	0x65, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x5f, 0x68,
-	0x61, 0x73, 0x68, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x72,
-	0x61, 0x63, 0x74, 0x48, 0x61, 0x73, 0x68, 0x12, 0x2d, 0x0a, 0x12, 0x76, 0x65, 0x72, 0x69, 0x66,
-	0x69, 0x65, 0x72, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x08, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x11, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x72, 0x53, 0x69, 0x67,
-	0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0xce, 0x02, 0x0a, 0x26, 0x44, 0x6f, 0x77, 0x6e, 0x6c,
-	0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x49, 0x6e, 0x54, 0x72, 0x61,
-	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x50, 0x72, 0x6f, 0x74,
-	0x6f, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x5f, 0x68, 0x61,
-	0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61,
-	0x63, 0x74, 0x48, 0x61, 0x73, 0x68, 0x12, 0x42, 0x0a, 0x1e, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x72,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x70, 0x75,
-	0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x1a,
-	0x66, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x72, 0x4e, 0x6f, 0x64,
-	0x65, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x3c, 0x0a, 0x1b, 0x66, 0x69,
-	0x6c, 0x65, 0x5f, 0x68, 0x6f, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x70,
-	0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52,
-	0x17, 0x66, 0x69, 0x6c, 0x65, 0x48, 0x6f, 0x73, 0x74, 0x65, 0x72, 0x4e, 0x6f, 0x64, 0x65, 0x50,
-	0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x2e, 0x0a, 0x13, 0x76, 0x65, 0x72, 0x69,
-	0x66, 0x69, 0x65, 0x72, 0x5f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x11, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x72, 0x50,
-	0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x23, 0x0a, 0x0d, 0x76, 0x65, 0x72, 0x69,
-	0x66, 0x69, 0x65, 0x72, 0x5f, 0x66, 0x65, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0c, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x72, 0x46, 0x65, 0x65, 0x73, 0x12, 0x28, 0x0a,
-	0x10, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x68, 0x6f, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x66, 0x65, 0x65,
-	0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x66, 0x69, 0x6c, 0x65, 0x48, 0x6f, 0x73,
-	0x74, 0x65, 0x72, 0x46, 0x65, 0x65, 0x73, 0x22, 0x6e, 0x0a, 0x1c, 0x44, 0x6f, 0x77, 0x6e, 0x6c,
-	0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x48, 0x61, 0x73, 0x68,
-	0x65, 0x73, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x4e, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x74, 0x72,
-	0x61, 0x63, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x6d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x43, 0x6f,
-	0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x49, 0x6e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x52, 0x09, 0x63, 0x6f,
-	0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x22, 0x92, 0x01, 0x0a, 0x22, 0x4d, 0x65, 0x72, 0x6b,
-	0x6c, 0x65, 0x54, 0x72, 0x65, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x4f, 0x66, 0x46, 0x69, 0x6c,
-	0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x23,
-	0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x48,
-	0x61, 0x73, 0x68, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x68, 0x61, 0x73, 0x68,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x48, 0x61, 0x73, 0x68,
-	0x12, 0x2a, 0x0a, 0x11, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x74, 0x72, 0x65, 0x65, 0x5f,
-	0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0f, 0x6d, 0x65, 0x72,
-	0x6b, 0x6c, 0x65, 0x54, 0x72, 0x65, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x22, 0x81, 0x01, 0x0a,
-	0x0a, 0x4b, 0x65, 0x79, 0x49, 0x56, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x23, 0x0a, 0x0d, 0x63,
-	0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x48, 0x61, 0x73, 0x68,
-	0x12, 0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x48, 0x61, 0x73, 0x68, 0x12, 0x31, 0x0a,
-	0x15, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x72, 0x6f, 0x6f,
-	0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x12, 0x66, 0x69,
-	0x6c, 0x65, 0x4d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x48, 0x61, 0x73, 0x68,
-	0x22, 0x43, 0x0a, 0x12, 0x4b, 0x65, 0x79, 0x49, 0x56, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x73, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x2d, 0x0a, 0x07, 0x6b, 0x65, 0x79, 0x5f, 0x69, 0x76,
-	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x73, 0x2e, 0x4b, 0x65, 0x79, 0x49, 0x56, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x52, 0x06, 0x6b,
-	0x65, 0x79, 0x49, 0x76, 0x73, 0x22, 0x9c, 0x01, 0x0a, 0x28, 0x4b, 0x65, 0x79, 0x49, 0x56, 0x52,
-	0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x69, 0x7a, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x67,
-	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x45, 0x6e, 0x76, 0x65, 0x6c, 0x6f, 0x70, 0x65, 0x50, 0x72, 0x6f,
-	0x74, 0x6f, 0x12, 0x70, 0x0a, 0x1f, 0x6b, 0x65, 0x79, 0x5f, 0x69, 0x76, 0x5f, 0x72, 0x61, 0x6e,
-	0x64, 0x6f, 0x6d, 0x69, 0x7a, 0x65, 0x64, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x73, 0x65, 0x67,
-	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x6d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x4b, 0x65, 0x79, 0x49, 0x56, 0x52, 0x61, 0x6e, 0x64,
-	0x6f, 0x6d, 0x69, 0x7a, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e,
-	0x74, 0x73, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x52, 0x1b, 0x6b, 0x65, 0x79, 0x49, 0x76, 0x52, 0x61,
-	0x6e, 0x64, 0x6f, 0x6d, 0x69, 0x7a, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x67, 0x6d,
-	0x65, 0x6e, 0x74, 0x73, 0x22, 0x8b, 0x03, 0x0a, 0x20, 0x4b, 0x65, 0x79, 0x49, 0x56, 0x52, 0x61,
-	0x6e, 0x64, 0x6f, 0x6d, 0x69, 0x7a, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x67, 0x6d,
-	0x65, 0x6e, 0x74, 0x73, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c,
-	0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x66, 0x69,
-	0x6c, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61,
-	0x63, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x63,
-	0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x48, 0x61, 0x73, 0x68, 0x12, 0x1b, 0x0a, 0x09, 0x66,
-	0x69, 0x6c, 0x65, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08,
-	0x66, 0x69, 0x6c, 0x65, 0x48, 0x61, 0x73, 0x68, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x76,
-	0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x69, 0x76, 0x12, 0x28, 0x0a, 0x10, 0x6d, 0x65,
-	0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x06,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x52, 0x6f, 0x6f, 0x74,
-	0x48, 0x61, 0x73, 0x68, 0x12, 0x27, 0x0a, 0x0f, 0x65, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x69,
-	0x6f, 0x6e, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x65,
-	0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x2f, 0x0a,
-	0x13, 0x72, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x69, 0x7a, 0x65, 0x64, 0x5f, 0x73, 0x65, 0x67, 0x6d,
-	0x65, 0x6e, 0x74, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x05, 0x52, 0x12, 0x72, 0x61, 0x6e, 0x64,
-	0x6f, 0x6d, 0x69, 0x7a, 0x65, 0x64, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x4c,
-	0x0a, 0x23, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x5f, 0x72, 0x61, 0x77,
-	0x5f, 0x75, 0x6e, 0x65, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x64, 0x5f, 0x73, 0x65, 0x67,
-	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x1f, 0x74, 0x6f, 0x74,
-	0x61, 0x6c, 0x53, 0x69, 0x7a, 0x65, 0x52, 0x61, 0x77, 0x55, 0x6e, 0x65, 0x6e, 0x63, 0x72, 0x79,
-	0x70, 0x74, 0x65, 0x64, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05,
-	0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72,
-	0x6f, 0x72, 0x22, 0x9a, 0x01, 0x0a, 0x15, 0x46, 0x69, 0x6c, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73,
-	0x66, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x23, 0x0a, 0x0d,
+	0x6f, 0x63, 0x6b, 0x73, 0x22, 0x90, 0x01, 0x0a, 0x15, 0x44, 0x61, 0x74, 0x61, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x1f,
+	0x0a, 0x0b, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0c, 0x52, 0x0a, 0x66, 0x69, 0x6c, 0x65, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x12,
+	0x24, 0x0a, 0x0e, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x61, 0x64, 0x64,
+	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x66, 0x72, 0x6f, 0x6d, 0x50, 0x65, 0x65,
+	0x72, 0x41, 0x64, 0x64, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0xf9, 0x02, 0x0a, 0x16, 0x44, 0x61, 0x74, 0x61,
+	0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x50, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x24, 0x0a, 0x0e, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x70, 0x65, 0x65, 0x72, 0x5f,
+	0x61, 0x64, 0x64, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x66, 0x72, 0x6f, 0x6d,
+	0x50, 0x65, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72, 0x12, 0x22, 0x0a, 0x0d, 0x66, 0x65, 0x65, 0x73,
+	0x5f, 0x70, 0x65, 0x72, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x66, 0x65, 0x65, 0x73, 0x50, 0x65, 0x72, 0x42, 0x79, 0x74, 0x65, 0x12, 0x35, 0x0a, 0x17,
+	0x68, 0x61, 0x73, 0x68, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x5f,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x14, 0x68,
+	0x61, 0x73, 0x68, 0x44, 0x61, 0x74, 0x61, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65,
+	0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b,
+	0x65, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x18,
+	0x06, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0a, 0x66, 0x69, 0x6c, 0x65, 0x48, 0x61, 0x73, 0x68, 0x65,
+	0x73, 0x12, 0x2a, 0x0a, 0x11, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73,
+	0x5f, 0x73, 0x69, 0x7a, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x04, 0x52, 0x0f, 0x66, 0x69,
+	0x6c, 0x65, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x53, 0x69, 0x7a, 0x65, 0x73, 0x12, 0x36, 0x0a,
+	0x17, 0x75, 0x6e, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x66, 0x69, 0x6c,
+	0x65, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x15,
+	0x75, 0x6e, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x48,
+	0x61, 0x73, 0x68, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x22, 0x34, 0x0a, 0x1e, 0x44, 0x61, 0x74, 0x61, 0x51, 0x75, 0x65, 0x72, 0x79,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72,
+	0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x22, 0x66, 0x0a, 0x24, 0x44, 0x61, 0x74,
+	0x61, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x50, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x3e, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e,
+	0x44, 0x61, 0x74, 0x61, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x52, 0x09, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x73, 0x22, 0x7f, 0x0a, 0x1d, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x48,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x50, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x2e, 0x0a, 0x13, 0x6c, 0x6f,
+	0x77, 0x65, 0x73, 0x74, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x11, 0x6c, 0x6f, 0x77, 0x65, 0x73, 0x74, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x72,
+	0x75, 0x6e, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x70, 0x72, 0x75, 0x6e,
+	0x65, 0x64, 0x22, 0x3f, 0x0a, 0x19, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x44, 0x6f, 0x77, 0x6e, 0x6c,
+	0x6f, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x66,
+	0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x02, 0x74, 0x6f, 0x22, 0xa2, 0x01, 0x0a, 0x1a, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x44, 0x6f, 0x77,
+	0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x50, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x1f, 0x0a, 0x0b,
+	0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0a, 0x6e, 0x6f, 0x64, 0x65, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x29, 0x0a,
+	0x06, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e,
+	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
+	0x52, 0x06, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x22, 0xa9, 0x01, 0x0a, 0x19, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x12, 0x1f, 0x0a, 0x0b, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x6e, 0x6f, 0x64, 0x65, 0x48, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x12, 0x31, 0x0a, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x05, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x17, 0x2e, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x07, 0x68, 0x65, 0x61,
+	0x64, 0x65, 0x72, 0x73, 0x22, 0xae, 0x01, 0x0a, 0x15, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
+	0x74, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x16,
+	0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06,
+	0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f,
+	0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x62, 0x6c, 0x6f, 0x63,
+	0x6b, 0x48, 0x61, 0x73, 0x68, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x5f, 0x68,
+	0x61, 0x73, 0x68, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0b, 0x63, 0x68, 0x75,
+	0x6e, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x75, 0x62, 0x6c,
+	0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x70, 0x75,
+	0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x72, 0x0a, 0x1d, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
+	0x74, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x3b, 0x0a, 0x08,
+	0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f,
+	0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x52,
+	0x08, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x22, 0x3d, 0x0a, 0x13, 0x53, 0x6e, 0x61,
+	0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x50, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x4d, 0x0a, 0x12, 0x53, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x37,
+	0x0a, 0x07, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1d, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73,
+	0x68, 0x6f, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x52, 0x07,
+	0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x22, 0x54, 0x0a, 0x19, 0x53, 0x6e, 0x61, 0x70, 0x73,
+	0x68, 0x6f, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x50,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1f, 0x0a, 0x0b,
+	0x63, 0x68, 0x75, 0x6e, 0x6b, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0a, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x22, 0x46, 0x0a,
+	0x1a, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0xbf, 0x03, 0x0a, 0x15, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f,
+	0x61, 0x64, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x52, 0x0a, 0x14, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x68, 0x6f, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x72,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x52,
+	0x12, 0x66, 0x69, 0x6c, 0x65, 0x48, 0x6f, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x42, 0x0a, 0x1e, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x72, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x70, 0x75, 0x62, 0x6c, 0x69,
+	0x63, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x1a, 0x66, 0x69, 0x6c,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x72, 0x4e, 0x6f, 0x64, 0x65, 0x50, 0x75,
+	0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x2c, 0x0a, 0x12, 0x66, 0x69, 0x6c, 0x65, 0x5f,
+	0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x5f, 0x6e, 0x65, 0x65, 0x64, 0x65, 0x64, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x0c, 0x52, 0x10, 0x66, 0x69, 0x6c, 0x65, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x4e,
+	0x65, 0x65, 0x64, 0x65, 0x64, 0x12, 0x37, 0x0a, 0x18, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x68, 0x61,
+	0x73, 0x68, 0x65, 0x73, 0x5f, 0x6e, 0x65, 0x65, 0x64, 0x65, 0x64, 0x5f, 0x73, 0x69, 0x7a, 0x65,
+	0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x04, 0x52, 0x15, 0x66, 0x69, 0x6c, 0x65, 0x48, 0x61, 0x73,
+	0x68, 0x65, 0x73, 0x4e, 0x65, 0x65, 0x64, 0x65, 0x64, 0x53, 0x69, 0x7a, 0x65, 0x73, 0x12, 0x2e,
+	0x0a, 0x13, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x72, 0x5f, 0x70, 0x75, 0x62, 0x6c, 0x69,
+	0x63, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x11, 0x76, 0x65, 0x72,
+	0x69, 0x66, 0x69, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x23,
+	0x0a, 0x0d, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x72, 0x5f, 0x66, 0x65, 0x65, 0x73, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x72, 0x46,
+	0x65, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x5f,
+	0x68, 0x61, 0x73, 0x68, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x74,
+	0x72, 0x61, 0x63, 0x74, 0x48, 0x61, 0x73, 0x68, 0x12, 0x2d, 0x0a, 0x12, 0x76, 0x65, 0x72, 0x69,
+	0x66, 0x69, 0x65, 0x72, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x11, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x72, 0x53, 0x69,
+	0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x9e, 0x04, 0x0a, 0x26, 0x44, 0x6f, 0x77, 0x6e,
+	0x6c, 0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x49, 0x6e, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x50, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x5f, 0x68,
+	0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x72,
+	0x61, 0x63, 0x74, 0x48, 0x61, 0x73, 0x68, 0x12, 0x42, 0x0a, 0x1e, 0x66, 0x69, 0x6c, 0x65, 0x5f,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x70,
+	0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x1a, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x72, 0x4e, 0x6f,
+	0x64, 0x65, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x3c, 0x0a, 0x1b, 0x66,
+	0x69, 0x6c, 0x65, 0x5f, 0x68, 0x6f, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x5f,
+	0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x17, 0x66, 0x69, 0x6c, 0x65, 0x48, 0x6f, 0x73, 0x74, 0x65, 0x72, 0x4e, 0x6f, 0x64, 0x65,
+	0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x2e, 0x0a, 0x13, 0x76, 0x65, 0x72,
+	0x69, 0x66, 0x69, 0x65, 0x72, 0x5f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x11, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x72,
+	0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x23, 0x0a, 0x0d, 0x76, 0x65, 0x72,
+	0x69, 0x66, 0x69, 0x65, 0x72, 0x5f, 0x66, 0x65, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0c, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x72, 0x46, 0x65, 0x65, 0x73, 0x12, 0x28,
+	0x0a, 0x10, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x68, 0x6f, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x66, 0x65,
+	0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x66, 0x69, 0x6c, 0x65, 0x48, 0x6f,
+	0x73, 0x74, 0x65, 0x72, 0x46, 0x65, 0x65, 0x73, 0x12, 0x32, 0x0a, 0x15, 0x66, 0x69, 0x6c, 0x65,
+	0x5f, 0x68, 0x6f, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x13, 0x66, 0x69, 0x6c, 0x65, 0x48, 0x6f, 0x73,
+	0x74, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x2c, 0x0a, 0x12,
+	0x66, 0x69, 0x6c, 0x65, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x5f, 0x6e, 0x65, 0x65, 0x64,
+	0x65, 0x64, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x10, 0x66, 0x69, 0x6c, 0x65, 0x48, 0x61,
+	0x73, 0x68, 0x65, 0x73, 0x4e, 0x65, 0x65, 0x64, 0x65, 0x64, 0x12, 0x37, 0x0a, 0x18, 0x66, 0x69,
+	0x6c, 0x65, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x5f, 0x6e, 0x65, 0x65, 0x64, 0x65, 0x64,
+	0x5f, 0x73, 0x69, 0x7a, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x04, 0x52, 0x15, 0x66, 0x69,
+	0x6c, 0x65, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x4e, 0x65, 0x65, 0x64, 0x65, 0x64, 0x53, 0x69,
+	0x7a, 0x65, 0x73, 0x12, 0x2d, 0x0a, 0x12, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x72, 0x5f,
+	0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x11, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x4a, 0x04, 0x08, 0x07, 0x10, 0x08, 0x22, 0x6e, 0x0a, 0x1c, 0x44, 0x6f, 0x77, 0x6e,
+	0x6c, 0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x48, 0x61, 0x73,
+	0x68, 0x65, 0x73, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x4e, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x74,
+	0x72, 0x61, 0x63, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x43,
+	0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x49, 0x6e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x52, 0x09, 0x63,
+	0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x22, 0x92, 0x01, 0x0a, 0x22, 0x4d, 0x65, 0x72,
+	0x6b, 0x6c, 0x65, 0x54, 0x72, 0x65, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x4f, 0x66, 0x46, 0x69,
+	0x6c, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x23, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74,
+	0x48, 0x61, 0x73, 0x68, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x68, 0x61, 0x73,
+	0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x48, 0x61, 0x73,
+	0x68, 0x12, 0x2a, 0x0a, 0x11, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x74, 0x72, 0x65, 0x65,
+	0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0f, 0x6d, 0x65,
+	0x72, 0x6b, 0x6c, 0x65, 0x54, 0x72, 0x65, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x22, 0x81, 0x01,
+	0x0a, 0x0a, 0x4b, 0x65, 0x79, 0x49, 0x56, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x23, 0x0a, 0x0d,
 	0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20,
 	0x01, 0x28, 0x0c, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x48, 0x61, 0x73,
 	0x68, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x48, 0x61, 0x73, 0x68, 0x12, 0x1b,
-	0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x04, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x66,
-	0x72, 0x6f, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12,
-	0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x74, 0x6f, 0x42,
-	0x3a, 0x5a, 0x38, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x66, 0x69,
-	0x6c, 0x65, 0x66, 0x69, 0x6c, 0x65, 0x67, 0x6f, 0x2f, 0x66, 0x69, 0x6c, 0x65, 0x66, 0x69, 0x6c,
-	0x65, 0x67, 0x6f, 0x2f, 0x6e, 0x6f, 0x64, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f,
-	0x6c, 0x73, 0x2f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x33,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x48, 0x61, 0x73, 0x68, 0x12, 0x31,
+	0x0a, 0x15, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x72, 0x6f,
+	0x6f, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x12, 0x66,
+	0x69, 0x6c, 0x65, 0x4d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x48, 0x61, 0x73,
+	0x68, 0x22, 0x43, 0x0a, 0x12, 0x4b, 0x65, 0x79, 0x49, 0x56, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x73, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x2d, 0x0a, 0x07, 0x6b, 0x65, 0x79, 0x5f, 0x69,
+	0x76, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x73, 0x2e, 0x4b, 0x65, 0x79, 0x49, 0x56, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x52, 0x06,
+	0x6b, 0x65, 0x79, 0x49, 0x76, 0x73, 0x22, 0x9c, 0x01, 0x0a, 0x28, 0x4b, 0x65, 0x79, 0x49, 0x56,
+	0x52, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x69, 0x7a, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x53, 0x65,
+	0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x45, 0x6e, 0x76, 0x65, 0x6c, 0x6f, 0x70, 0x65, 0x50, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x70, 0x0a, 0x1f, 0x6b, 0x65, 0x79, 0x5f, 0x69, 0x76, 0x5f, 0x72, 0x61,
+	0x6e, 0x64, 0x6f, 0x6d, 0x69, 0x7a, 0x65, 0x64, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x73, 0x65,
+	0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x4b, 0x65, 0x79, 0x49, 0x56, 0x52, 0x61, 0x6e,
+	0x64, 0x6f, 0x6d, 0x69, 0x7a, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x67, 0x6d, 0x65,
+	0x6e, 0x74, 0x73, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x52, 0x1b, 0x6b, 0x65, 0x79, 0x49, 0x76, 0x52,
+	0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x69, 0x7a, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x67,
+	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x8b, 0x03, 0x0a, 0x20, 0x4b, 0x65, 0x79, 0x49, 0x56, 0x52,
+	0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x69, 0x7a, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x67,
+	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x69,
+	0x6c, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x66,
+	0x69, 0x6c, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x72,
+	0x61, 0x63, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c,
+	0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x48, 0x61, 0x73, 0x68, 0x12, 0x1b, 0x0a, 0x09,
+	0x66, 0x69, 0x6c, 0x65, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x08, 0x66, 0x69, 0x6c, 0x65, 0x48, 0x61, 0x73, 0x68, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x76, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x69, 0x76, 0x12, 0x28, 0x0a, 0x10, 0x6d,
+	0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x52, 0x6f, 0x6f,
+	0x74, 0x48, 0x61, 0x73, 0x68, 0x12, 0x27, 0x0a, 0x0f, 0x65, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e,
+	0x65, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x2f,
+	0x0a, 0x13, 0x72, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x69, 0x7a, 0x65, 0x64, 0x5f, 0x73, 0x65, 0x67,
+	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x05, 0x52, 0x12, 0x72, 0x61, 0x6e,
+	0x64, 0x6f, 0x6d, 0x69, 0x7a, 0x65, 0x64, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12,
+	0x4c, 0x0a, 0x23, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x5f, 0x72, 0x61,
+	0x77, 0x5f, 0x75, 0x6e, 0x65, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x64, 0x5f, 0x73, 0x65,
+	0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x1f, 0x74, 0x6f,
+	0x74, 0x61, 0x6c, 0x53, 0x69, 0x7a, 0x65, 0x52, 0x61, 0x77, 0x55, 0x6e, 0x65, 0x6e, 0x63, 0x72,
+	0x79, 0x70, 0x74, 0x65, 0x64, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x14, 0x0a,
+	0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x22, 0x9a, 0x01, 0x0a, 0x15, 0x46, 0x69, 0x6c, 0x65, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x66, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x23, 0x0a,
+	0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x48, 0x61,
+	0x73, 0x68, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x48, 0x61, 0x73, 0x68, 0x12,
+	0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x66, 0x72, 0x6f, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d,
+	0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x74, 0x6f,
+	0x22, 0xb5, 0x01, 0x0a, 0x0f, 0x50, 0x65, 0x65, 0x72, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x50,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x65, 0x65, 0x72, 0x49, 0x64, 0x12, 0x14, 0x0a,
+	0x05, 0x61, 0x64, 0x64, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x05, 0x61, 0x64,
+	0x64, 0x72, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73, 0x65, 0x65, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x65, 0x65, 0x6e,
+	0x12, 0x1c, 0x0a, 0x09, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x09, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x1a,
+	0x0a, 0x08, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x08, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x73, 0x42, 0x3a, 0x5a, 0x38, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x66, 0x69, 0x6c, 0x65, 0x66, 0x69, 0x6c, 0x65, 0x67,
+	0x6f, 0x2f, 0x66, 0x69, 0x6c, 0x65, 0x66, 0x69, 0x6c, 0x65, 0x67, 0x6f, 0x2f, 0x6e, 0x6f, 0x64,
+	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x73, 0x2f, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1533,45 +2513,66 @@ func file_node_protocols_messages_messages_proto_rawDescGZIP() []byte {
 	return file_node_protocols_messages_messages_proto_rawDescData
 }
 
-var file_node_protocols_messages_messages_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_node_protocols_messages_messages_proto_msgTypes = make([]protoimpl.MessageInfo, 30)
 var file_node_protocols_messages_messages_proto_goTypes = []interface{}{
 	(*GossipPayload)(nil),                            // 0: messages.GossipPayload
-	(*ProtoBlocks)(nil),                              // 1: messages.ProtoBlocks
-	(*DataQueryRequestProto)(nil),                    // 2: messages.DataQueryRequestProto
-	(*DataQueryResponseProto)(nil),                   // 3: messages.DataQueryResponseProto
-	(*DataQueryResponseTransferProto)(nil),           // 4: messages.DataQueryResponseTransferProto
-	(*DataQueryResponseTransferResultProto)(nil),     // 5: messages.DataQueryResponseTransferResultProto
-	(*BlockchainHeightResponseProto)(nil),            // 6: messages.BlockchainHeightResponseProto
-	(*BlockDownloadRequestProto)(nil),                // 7: messages.BlockDownloadRequestProto
-	(*BlockDownloadResponseProto)(nil),               // 8: messages.BlockDownloadResponseProto
-	(*DownloadContractProto)(nil),                    // 9: messages.DownloadContractProto
-	(*DownloadContractInTransactionDataProto)(nil),   // 10: messages.DownloadContractInTransactionDataProto
-	(*DownloadContractsHashesProto)(nil),             // 11: messages.DownloadContractsHashesProto
-	(*MerkleTreeNodesOfFileContractProto)(nil),       // 12: messages.MerkleTreeNodesOfFileContractProto
-	(*KeyIVProto)(nil),                               // 13: messages.KeyIVProto
-	(*KeyIVRequestsProto)(nil),                       // 14: messages.KeyIVRequestsProto
-	(*KeyIVRandomizedFileSegmentsEnvelopeProto)(nil), // 15: messages.KeyIVRandomizedFileSegmentsEnvelopeProto
-	(*KeyIVRandomizedFileSegmentsProto)(nil),         // 16: messages.KeyIVRandomizedFileSegmentsProto
-	(*FileTransferInfoProto)(nil),                    // 17: messages.FileTransferInfoProto
-	(*transaction.ProtoTransaction)(nil),             // 18: transaction.ProtoTransaction
-	(*block.ProtoBlock)(nil),                         // 19: block.ProtoBlock
+	(*CompactBlockProto)(nil),                        // 1: messages.CompactBlockProto
+	(*BlockTransactionsRequestProto)(nil),            // 2: messages.BlockTransactionsRequestProto
+	(*BlockTransactionsResponseProto)(nil),           // 3: messages.BlockTransactionsResponseProto
+	(*CheckpointAttestationProto)(nil),               // 4: messages.CheckpointAttestationProto
+	(*ProtoBlocks)(nil),                              // 5: messages.ProtoBlocks
+	(*DataQueryRequestProto)(nil),                    // 6: messages.DataQueryRequestProto
+	(*DataQueryResponseProto)(nil),                   // 7: messages.DataQueryResponseProto
+	(*DataQueryResponseTransferProto)(nil),           // 8: messages.DataQueryResponseTransferProto
+	(*DataQueryResponseTransferResultProto)(nil),     // 9: messages.DataQueryResponseTransferResultProto
+	(*BlockchainHeightResponseProto)(nil),            // 10: messages.BlockchainHeightResponseProto
+	(*BlockDownloadRequestProto)(nil),                // 11: messages.BlockDownloadRequestProto
+	(*BlockDownloadResponseProto)(nil),               // 12: messages.BlockDownloadResponseProto
+	(*BlockHeadersResponseProto)(nil),                // 13: messages.BlockHeadersResponseProto
+	(*SnapshotManifestProto)(nil),                    // 14: messages.SnapshotManifestProto
+	(*SnapshotManifestResponseProto)(nil),            // 15: messages.SnapshotManifestResponseProto
+	(*SnapshotRecordProto)(nil),                      // 16: messages.SnapshotRecordProto
+	(*SnapshotChunkProto)(nil),                       // 17: messages.SnapshotChunkProto
+	(*SnapshotChunkRequestProto)(nil),                // 18: messages.SnapshotChunkRequestProto
+	(*SnapshotChunkResponseProto)(nil),               // 19: messages.SnapshotChunkResponseProto
+	(*DownloadContractProto)(nil),                    // 20: messages.DownloadContractProto
+	(*DownloadContractInTransactionDataProto)(nil),   // 21: messages.DownloadContractInTransactionDataProto
+	(*DownloadContractsHashesProto)(nil),             // 22: messages.DownloadContractsHashesProto
+	(*MerkleTreeNodesOfFileContractProto)(nil),       // 23: messages.MerkleTreeNodesOfFileContractProto
+	(*KeyIVProto)(nil),                               // 24: messages.KeyIVProto
+	(*KeyIVRequestsProto)(nil),                       // 25: messages.KeyIVRequestsProto
+	(*KeyIVRandomizedFileSegmentsEnvelopeProto)(nil), // 26: messages.KeyIVRandomizedFileSegmentsEnvelopeProto
+	(*KeyIVRandomizedFileSegmentsProto)(nil),         // 27: messages.KeyIVRandomizedFileSegmentsProto
+	(*FileTransferInfoProto)(nil),                    // 28: messages.FileTransferInfoProto
+	(*PeerRecordProto)(nil),                          // 29: messages.PeerRecordProto
+	(*transaction.ProtoTransaction)(nil),             // 30: transaction.ProtoTransaction
+	(*block.ProtoBlockHeader)(nil),                   // 31: block.ProtoBlockHeader
+	(*block.ProtoBlock)(nil),                         // 32: block.ProtoBlock
 }
 var file_node_protocols_messages_messages_proto_depIdxs = []int32{
-	1,  // 0: messages.GossipPayload.blocks:type_name -> messages.ProtoBlocks
-	18, // 1: messages.GossipPayload.transaction:type_name -> transaction.ProtoTransaction
-	2,  // 2: messages.GossipPayload.query:type_name -> messages.DataQueryRequestProto
-	19, // 3: messages.ProtoBlocks.blocks:type_name -> block.ProtoBlock
-	3,  // 4: messages.DataQueryResponseTransferResultProto.responses:type_name -> messages.DataQueryResponseProto
-	19, // 5: messages.BlockDownloadResponseProto.blocks:type_name -> block.ProtoBlock
-	3,  // 6: messages.DownloadContractProto.file_hoster_response:type_name -> messages.DataQueryResponseProto
-	10, // 7: messages.DownloadContractsHashesProto.contracts:type_name -> messages.DownloadContractInTransactionDataProto
-	13, // 8: messages.KeyIVRequestsProto.key_ivs:type_name -> messages.KeyIVProto
-	16, // 9: messages.KeyIVRandomizedFileSegmentsEnvelopeProto.key_iv_randomized_file_segments:type_name -> messages.KeyIVRandomizedFileSegmentsProto
-	10, // [10:10] is the sub-list for method output_type
-	10, // [10:10] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	5,  // 0: messages.GossipPayload.blocks:type_name -> messages.ProtoBlocks
+	30, // 1: messages.GossipPayload.transaction:type_name -> transaction.ProtoTransaction
+	6,  // 2: messages.GossipPayload.query:type_name -> messages.DataQueryRequestProto
+	4,  // 3: messages.GossipPayload.checkpoint_attestation:type_name -> messages.CheckpointAttestationProto
+	1,  // 4: messages.GossipPayload.compact_block:type_name -> messages.CompactBlockProto
+	31, // 5: messages.CompactBlockProto.header:type_name -> block.ProtoBlockHeader
+	30, // 6: messages.CompactBlockProto.coinbase:type_name -> transaction.ProtoTransaction
+	30, // 7: messages.BlockTransactionsResponseProto.transactions:type_name -> transaction.ProtoTransaction
+	32, // 8: messages.ProtoBlocks.blocks:type_name -> block.ProtoBlock
+	7,  // 9: messages.DataQueryResponseTransferResultProto.responses:type_name -> messages.DataQueryResponseProto
+	32, // 10: messages.BlockDownloadResponseProto.blocks:type_name -> block.ProtoBlock
+	31, // 11: messages.BlockHeadersResponseProto.headers:type_name -> block.ProtoBlockHeader
+	14, // 12: messages.SnapshotManifestResponseProto.manifest:type_name -> messages.SnapshotManifestProto
+	16, // 13: messages.SnapshotChunkProto.records:type_name -> messages.SnapshotRecordProto
+	7,  // 14: messages.DownloadContractProto.file_hoster_response:type_name -> messages.DataQueryResponseProto
+	21, // 15: messages.DownloadContractsHashesProto.contracts:type_name -> messages.DownloadContractInTransactionDataProto
+	24, // 16: messages.KeyIVRequestsProto.key_ivs:type_name -> messages.KeyIVProto
+	27, // 17: messages.KeyIVRandomizedFileSegmentsEnvelopeProto.key_iv_randomized_file_segments:type_name -> messages.KeyIVRandomizedFileSegmentsProto
+	18, // [18:18] is the sub-list for method output_type
+	18, // [18:18] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
 }
 
 func init() { file_node_protocols_messages_messages_proto_init() }
@@ -1593,7 +2594,7 @@ func file_node_protocols_messages_messages_proto_init() {
 			}
 		}
 		file_node_protocols_messages_messages_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProtoBlocks); i {
+			switch v := v.(*CompactBlockProto); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1605,7 +2606,7 @@ func file_node_protocols_messages_messages_proto_init() {
 			}
 		}
 		file_node_protocols_messages_messages_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DataQueryRequestProto); i {
+			switch v := v.(*BlockTransactionsRequestProto); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1617,7 +2618,7 @@ func file_node_protocols_messages_messages_proto_init() {
 			}
 		}
 		file_node_protocols_messages_messages_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DataQueryResponseProto); i {
+			switch v := v.(*BlockTransactionsResponseProto); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1629,7 +2630,7 @@ func file_node_protocols_messages_messages_proto_init() {
 			}
 		}
 		file_node_protocols_messages_messages_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DataQueryResponseTransferProto); i {
+			switch v := v.(*CheckpointAttestationProto); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1641,7 +2642,7 @@ func file_node_protocols_messages_messages_proto_init() {
 			}
 		}
 		file_node_protocols_messages_messages_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DataQueryResponseTransferResultProto); i {
+			switch v := v.(*ProtoBlocks); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1653,7 +2654,7 @@ func file_node_protocols_messages_messages_proto_init() {
 			}
 		}
 		file_node_protocols_messages_messages_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BlockchainHeightResponseProto); i {
+			switch v := v.(*DataQueryRequestProto); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1665,7 +2666,7 @@ func file_node_protocols_messages_messages_proto_init() {
 			}
 		}
 		file_node_protocols_messages_messages_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BlockDownloadRequestProto); i {
+			switch v := v.(*DataQueryResponseProto); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1677,7 +2678,7 @@ func file_node_protocols_messages_messages_proto_init() {
 			}
 		}
 		file_node_protocols_messages_messages_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BlockDownloadResponseProto); i {
+			switch v := v.(*DataQueryResponseTransferProto); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1689,7 +2690,7 @@ func file_node_protocols_messages_messages_proto_init() {
 			}
 		}
 		file_node_protocols_messages_messages_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DownloadContractProto); i {
+			switch v := v.(*DataQueryResponseTransferResultProto); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1701,7 +2702,7 @@ func file_node_protocols_messages_messages_proto_init() {
 			}
 		}
 		file_node_protocols_messages_messages_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DownloadContractInTransactionDataProto); i {
+			switch v := v.(*BlockchainHeightResponseProto); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1713,7 +2714,7 @@ func file_node_protocols_messages_messages_proto_init() {
 			}
 		}
 		file_node_protocols_messages_messages_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DownloadContractsHashesProto); i {
+			switch v := v.(*BlockDownloadRequestProto); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1725,7 +2726,7 @@ func file_node_protocols_messages_messages_proto_init() {
 			}
 		}
 		file_node_protocols_messages_messages_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MerkleTreeNodesOfFileContractProto); i {
+			switch v := v.(*BlockDownloadResponseProto); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1737,7 +2738,7 @@ func file_node_protocols_messages_messages_proto_init() {
 			}
 		}
 		file_node_protocols_messages_messages_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*KeyIVProto); i {
+			switch v := v.(*BlockHeadersResponseProto); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1749,7 +2750,7 @@ func file_node_protocols_messages_messages_proto_init() {
 			}
 		}
 		file_node_protocols_messages_messages_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*KeyIVRequestsProto); i {
+			switch v := v.(*SnapshotManifestProto); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1761,7 +2762,7 @@ func file_node_protocols_messages_messages_proto_init() {
 			}
 		}
 		file_node_protocols_messages_messages_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*KeyIVRandomizedFileSegmentsEnvelopeProto); i {
+			switch v := v.(*SnapshotManifestResponseProto); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1773,7 +2774,7 @@ func file_node_protocols_messages_messages_proto_init() {
 			}
 		}
 		file_node_protocols_messages_messages_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*KeyIVRandomizedFileSegmentsProto); i {
+			switch v := v.(*SnapshotRecordProto); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1785,6 +2786,138 @@ func file_node_protocols_messages_messages_proto_init() {
 			}
 		}
 		file_node_protocols_messages_messages_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SnapshotChunkProto); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_node_protocols_messages_messages_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SnapshotChunkRequestProto); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_node_protocols_messages_messages_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SnapshotChunkResponseProto); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_node_protocols_messages_messages_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DownloadContractProto); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_node_protocols_messages_messages_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DownloadContractInTransactionDataProto); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_node_protocols_messages_messages_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DownloadContractsHashesProto); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_node_protocols_messages_messages_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MerkleTreeNodesOfFileContractProto); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_node_protocols_messages_messages_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeyIVProto); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_node_protocols_messages_messages_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeyIVRequestsProto); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_node_protocols_messages_messages_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeyIVRandomizedFileSegmentsEnvelopeProto); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_node_protocols_messages_messages_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeyIVRandomizedFileSegmentsProto); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_node_protocols_messages_messages_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*FileTransferInfoProto); i {
 			case 0:
 				return &v.state
@@ -1796,11 +2929,25 @@ func file_node_protocols_messages_messages_proto_init() {
 				return nil
 			}
 		}
+		file_node_protocols_messages_messages_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PeerRecordProto); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_node_protocols_messages_messages_proto_msgTypes[0].OneofWrappers = []interface{}{
 		(*GossipPayload_Blocks)(nil),
 		(*GossipPayload_Transaction)(nil),
 		(*GossipPayload_Query)(nil),
+		(*GossipPayload_CheckpointAttestation)(nil),
+		(*GossipPayload_CompactBlock)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1808,7 +2955,7 @@ func file_node_protocols_messages_messages_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_node_protocols_messages_messages_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   18,
+			NumMessages:   30,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
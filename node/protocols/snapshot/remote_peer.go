@@ -0,0 +1,147 @@
+package snapshot
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/filefilego/filefilego/common"
+	"github.com/filefilego/filefilego/node/protocols/messages"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	deadlineTimeInSecond = 30
+
+	// maxManifestResponseSize is the maximum size of a manifest response.
+	maxManifestResponseSize = 16 * common.MB
+
+	// maxChunkResponseSize is the maximum size of a chunk response.
+	maxChunkResponseSize = 64 * common.MB
+)
+
+// RemotePeer represents a peer which serves snapshots.
+type RemotePeer struct {
+	host host.Host
+	peer peer.ID
+}
+
+// NewRemotePeer creates a new remote peer.
+func NewRemotePeer(h host.Host, peer peer.ID) (*RemotePeer, error) {
+	if h == nil {
+		return nil, errors.New("host is nil")
+	}
+
+	return &RemotePeer{
+		host: h,
+		peer: peer,
+	}, nil
+}
+
+// GetPeerID returns the peer id.
+func (rp *RemotePeer) GetPeerID() peer.ID {
+	return rp.peer
+}
+
+// GetManifest gets the manifest of the latest snapshot of the remote peer.
+func (rp *RemotePeer) GetManifest(ctx context.Context) (*messages.SnapshotManifestProto, error) {
+	s, err := rp.host.NewStream(ctx, rp.peer, SnapshotManifestProtocolID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a new snapshot manifest stream to remote peer: %w", err)
+	}
+	defer s.Close()
+
+	future := time.Now().Add(deadlineTimeInSecond * time.Second)
+	err = s.SetDeadline(future)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set snapshot manifest stream deadline: %w", err)
+	}
+
+	// just send a single byte to trigger the stream handling logic on the other side
+	_, err = s.Write([]byte("M"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to write data into stream: %w", err)
+	}
+
+	buf, err := io.ReadAll(io.LimitReader(s, maxManifestResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read all data from stream: %w", err)
+	}
+
+	response := messages.SnapshotManifestResponseProto{}
+	if err := proto.Unmarshal(buf, &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal data retrieved from the remote peer: %w", err)
+	}
+
+	if response.Error || response.Manifest == nil {
+		return nil, errors.New("remote peer doesn't have a snapshot")
+	}
+
+	return response.Manifest, nil
+}
+
+// DownloadChunk downloads a chunk of the snapshot at the given height.
+func (rp *RemotePeer) DownloadChunk(ctx context.Context, height, index uint64) ([]byte, error) {
+	s, err := rp.host.NewStream(ctx, rp.peer, SnapshotChunkProtocolID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new snapshot chunk stream to remote peer: %w", err)
+	}
+	c := bufio.NewReader(s)
+	defer s.Close()
+
+	future := time.Now().Add(deadlineTimeInSecond * time.Second)
+	err = s.SetDeadline(future)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set snapshot chunk stream deadline: %w", err)
+	}
+
+	requestBytes, err := proto.Marshal(&messages.SnapshotChunkRequestProto{
+		Height:     height,
+		ChunkIndex: index,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal protobuf snapshot chunk request message: %w", err)
+	}
+
+	requestPayloadWithLength := make([]byte, 8+len(requestBytes))
+	binary.LittleEndian.PutUint64(requestPayloadWithLength, uint64(len(requestBytes)))
+	copy(requestPayloadWithLength[8:], requestBytes)
+	_, err = s.Write(requestPayloadWithLength)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write data to snapshot chunk stream: %w", err)
+	}
+
+	responsePayloadLength := make([]byte, 8)
+	_, err = io.ReadFull(c, responsePayloadLength)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot chunk response length: %w", err)
+	}
+
+	responseSize := binary.LittleEndian.Uint64(responsePayloadLength)
+	if responseSize > maxChunkResponseSize {
+		return nil, fmt.Errorf("snapshot chunk response size is too large: %d", responseSize)
+	}
+
+	buf := make([]byte, responseSize)
+	_, err = io.ReadFull(c, buf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot chunk response payload: %w", err)
+	}
+
+	response := messages.SnapshotChunkResponseProto{}
+	if err := proto.Unmarshal(buf, &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal snapshot chunk response: %w", err)
+	}
+
+	if response.Error {
+		return nil, fmt.Errorf("remote peer doesn't have chunk %d of snapshot %d", index, height)
+	}
+
+	return response.Data, nil
+}
@@ -0,0 +1,366 @@
+package snapshot
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/filefilego/filefilego/block"
+	"github.com/filefilego/filefilego/common"
+	ffgcrypto "github.com/filefilego/filefilego/crypto"
+	"github.com/filefilego/filefilego/node/protocols/messages"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
+)
+
+// SnapshotManifestProtocolID is the protocol which returns the manifest of the latest snapshot of a node.
+const SnapshotManifestProtocolID = "/ffg/snapshot_manifest/1.0.0"
+
+// SnapshotChunkProtocolID is the protocol which returns a chunk of a snapshot.
+const SnapshotChunkProtocolID = "/ffg/snapshot_chunk/1.0.0"
+
+// Blockchain defines the blockchain functionality required to create and import snapshots.
+type Blockchain interface {
+	GetHeight() uint64
+	ExportSnapshot(fn func(key, value []byte) error) (uint64, []byte, error)
+	ImportSnapshotRecords(records []*messages.SnapshotRecordProto) error
+	FinalizeSnapshotImport(height uint64, blockHash []byte) error
+	DiscardSnapshotImport() error
+}
+
+// ManifestSigner signs snapshot manifests.
+type ManifestSigner interface {
+	SignSnapshotManifest(manifest *messages.SnapshotManifestProto) error
+}
+
+// Interface defines the snapshot protocol functionality.
+type Interface interface {
+	CreateSnapshot(signer ManifestSigner) (*messages.SnapshotManifestProto, error)
+	FastSync(ctx context.Context, peers []peer.ID) (uint64, error)
+}
+
+// Protocol implements the snapshot functionality.
+type Protocol struct {
+	blockchain Blockchain
+	host       host.Host
+	store      *Store
+}
+
+// New creates a snapshot protocol.
+func New(bchain Blockchain, h host.Host, store *Store) (*Protocol, error) {
+	if bchain == nil {
+		return nil, errors.New("blockchain is nil")
+	}
+
+	if h == nil {
+		return nil, errors.New("host is nil")
+	}
+
+	if store == nil {
+		return nil, errors.New("store is nil")
+	}
+
+	p := &Protocol{
+		blockchain: bchain,
+		host:       h,
+		store:      store,
+	}
+
+	p.host.SetStreamHandler(SnapshotManifestProtocolID, p.onManifestRequest)
+	p.host.SetStreamHandler(SnapshotChunkProtocolID, p.onChunkRequest)
+
+	return p, nil
+}
+
+// CreateSnapshot creates a snapshot of the current blockchain state signed by the given signer.
+func (p *Protocol) CreateSnapshot(signer ManifestSigner) (*messages.SnapshotManifestProto, error) {
+	return p.store.Create(p.blockchain, signer)
+}
+
+// FastSync downloads the latest valid snapshot from the peers and imports it into the blockchain.
+// it returns the height of the blockchain after the import.
+func (p *Protocol) FastSync(ctx context.Context, peers []peer.ID) (uint64, error) {
+	var manifest *messages.SnapshotManifestProto
+	sources := make([]*RemotePeer, 0)
+	for _, peerID := range peers {
+		if peerID == p.host.ID() {
+			continue
+		}
+
+		remotePeer, err := NewRemotePeer(p.host, peerID)
+		if err != nil {
+			continue
+		}
+
+		m, err := remotePeer.GetManifest(ctx)
+		if err != nil {
+			log.Debugf("failed to get snapshot manifest from peer %s: %v", peerID.String(), err)
+			continue
+		}
+
+		if err := VerifyManifest(m); err != nil {
+			log.Warnf("invalid snapshot manifest from peer %s: %v", peerID.String(), err)
+			continue
+		}
+
+		switch {
+		case manifest == nil || m.Height > manifest.Height:
+			manifest = m
+			sources = []*RemotePeer{remotePeer}
+		case sameSnapshot(m, manifest):
+			sources = append(sources, remotePeer)
+		}
+	}
+
+	if manifest == nil {
+		return 0, errors.New("no valid snapshot found on peers")
+	}
+
+	if manifest.Height <= p.blockchain.GetHeight() {
+		return p.blockchain.GetHeight(), nil
+	}
+
+	log.Infof("importing snapshot at height %d from %d peers", manifest.Height, len(sources))
+	if err := p.importSnapshot(ctx, manifest, sources); err != nil {
+		if discardErr := p.blockchain.DiscardSnapshotImport(); discardErr != nil {
+			log.Errorf("failed to discard snapshot import: %v", discardErr)
+		}
+		return 0, err
+	}
+
+	return manifest.Height, nil
+}
+
+// importSnapshot downloads, verifies and imports the chunks of a snapshot.
+// the downloaded snapshot is kept in the store so it can be served to other peers.
+func (p *Protocol) importSnapshot(ctx context.Context, manifest *messages.SnapshotManifestProto, sources []*RemotePeer) error {
+	w, err := p.store.newWriter()
+	if err != nil {
+		return err
+	}
+	defer w.discard()
+
+	for i, hash := range manifest.ChunkHashes {
+		data, err := downloadChunk(ctx, sources, manifest.Height, uint64(i), hash)
+		if err != nil {
+			return err
+		}
+
+		chunk := messages.SnapshotChunkProto{}
+		if err := proto.Unmarshal(data, &chunk); err != nil {
+			return fmt.Errorf("failed to unmarshal snapshot chunk: %w", err)
+		}
+
+		if err := p.blockchain.ImportSnapshotRecords(chunk.Records); err != nil {
+			return fmt.Errorf("failed to import snapshot chunk %d: %w", i, err)
+		}
+
+		if err := w.writeChunk(data); err != nil {
+			return err
+		}
+	}
+
+	if err := p.blockchain.FinalizeSnapshotImport(manifest.Height, manifest.BlockHash); err != nil {
+		return fmt.Errorf("failed to finalize snapshot import: %w", err)
+	}
+
+	if err := p.store.commit(w, manifest); err != nil {
+		log.Warnf("failed to store downloaded snapshot: %v", err)
+	}
+	return nil
+}
+
+// downloadChunk downloads a chunk from one of the sources and verifies its hash.
+func downloadChunk(ctx context.Context, sources []*RemotePeer, height, index uint64, hash []byte) ([]byte, error) {
+	for i := range sources {
+		// spread the chunks over the sources
+		source := sources[(int(index)+i)%len(sources)]
+		data, err := source.DownloadChunk(ctx, height, index)
+		if err != nil {
+			log.Debugf("failed to download snapshot chunk %d from peer %s: %v", index, source.GetPeerID().String(), err)
+			continue
+		}
+
+		if !bytes.Equal(ffgcrypto.Sha256(data), hash) {
+			log.Warnf("snapshot chunk %d from peer %s doesn't match the manifest", index, source.GetPeerID().String())
+			continue
+		}
+		return data, nil
+	}
+	return nil, fmt.Errorf("failed to download snapshot chunk %d", index)
+}
+
+// sameSnapshot checks if two manifests describe the same snapshot.
+func sameSnapshot(a, b *messages.SnapshotManifestProto) bool {
+	if a.Height != b.Height || !bytes.Equal(a.BlockHash, b.BlockHash) || len(a.ChunkHashes) != len(b.ChunkHashes) {
+		return false
+	}
+
+	for i := range a.ChunkHashes {
+		if !bytes.Equal(a.ChunkHashes[i], b.ChunkHashes[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// manifestSignatureDomain is the domain tag of the snapshot manifest signatures.
+var manifestSignatureDomain = []byte("filefilego/snapshot-manifest/v1")
+
+// manifestHash returns the hash of the manifest fields which are signed.
+func manifestHash(manifest *messages.SnapshotManifestProto) []byte {
+	heightBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(heightBytes, manifest.Height)
+
+	data := make([]byte, 0, len(manifestSignatureDomain)+8+len(manifest.BlockHash)+len(manifest.ChunkHashes)*32)
+	data = append(data, manifestSignatureDomain...)
+	data = append(data, heightBytes...)
+	data = append(data, manifest.BlockHash...)
+	for _, h := range manifest.ChunkHashes {
+		data = append(data, h...)
+	}
+	return ffgcrypto.Sha256(data)
+}
+
+// SignManifest signs a snapshot manifest.
+func SignManifest(manifest *messages.SnapshotManifestProto, privateKey crypto.PrivKey) error {
+	publicKey, err := privateKey.GetPublic().Raw()
+	if err != nil {
+		return fmt.Errorf("failed to get public key: %w", err)
+	}
+
+	sig, err := privateKey.Sign(manifestHash(manifest))
+	if err != nil {
+		return fmt.Errorf("failed to sign snapshot manifest: %w", err)
+	}
+
+	manifest.PublicKey = publicKey
+	manifest.Signature = sig
+	return nil
+}
+
+// VerifyManifest verifies that a snapshot manifest is signed by a verifier of the snapshot height.
+func VerifyManifest(manifest *messages.SnapshotManifestProto) error {
+	if len(manifest.BlockHash) == 0 {
+		return errors.New("block hash is empty")
+	}
+
+	if len(manifest.ChunkHashes) == 0 {
+		return errors.New("snapshot doesn't contain any chunk")
+	}
+
+	addr, err := ffgcrypto.RawPublicToAddress(manifest.PublicKey)
+	if err != nil {
+		return fmt.Errorf("failed to get address from public key: %w", err)
+	}
+
+	isVerifier, err := block.IsValidVerifier(addr, manifest.Height)
+	if err != nil {
+		return fmt.Errorf("failed to check snapshot verifier: %w", err)
+	}
+
+	if !isVerifier {
+		return fmt.Errorf("snapshot is not signed by a verifier: %s", addr)
+	}
+
+	publicKey, err := ffgcrypto.PublicKeyFromBytes(manifest.PublicKey)
+	if err != nil {
+		return fmt.Errorf("failed to get public key: %w", err)
+	}
+
+	ok, err := publicKey.Verify(manifestHash(manifest), manifest.Signature)
+	if err != nil || !ok {
+		return errors.New("failed to verify snapshot signature")
+	}
+	return nil
+}
+
+// onManifestRequest handles the snapshot manifest request.
+func (p *Protocol) onManifestRequest(s network.Stream) {
+	defer s.Close()
+
+	response := messages.SnapshotManifestResponseProto{}
+	manifest, err := p.store.LatestManifest()
+	if err != nil {
+		response.Error = true
+	} else {
+		response.Manifest = manifest
+	}
+
+	data, err := proto.Marshal(&response)
+	if err != nil {
+		log.Errorf("failed to marshal snapshot manifest response: %v", err)
+		return
+	}
+
+	n, err := s.Write(data)
+	if err != nil || n != len(data) {
+		log.Errorf("failed to write to snapshot manifest stream: %v", err)
+	}
+}
+
+// onChunkRequest handles the snapshot chunk request.
+func (p *Protocol) onChunkRequest(s network.Stream) {
+	c := bufio.NewReader(s)
+	defer s.Close()
+
+	// read the first 8 bytes to determine the size of the message
+	msgLengthBuffer := make([]byte, 8)
+	_, err := io.ReadFull(c, msgLengthBuffer)
+	if err != nil {
+		log.Errorf("failed to read from snapshot chunk stream: %v", err)
+		return
+	}
+
+	lengthPrefix := binary.LittleEndian.Uint64(msgLengthBuffer)
+	if lengthPrefix > 2*common.KB {
+		log.Errorf("snapshot chunk request size is too large: %d", lengthPrefix)
+		return
+	}
+
+	buf := make([]byte, lengthPrefix)
+	_, err = io.ReadFull(c, buf)
+	if err != nil {
+		log.Errorf("failed to read from stream to buffer: %v", err)
+		return
+	}
+
+	request := messages.SnapshotChunkRequestProto{}
+	if err := proto.Unmarshal(buf, &request); err != nil {
+		log.Errorf("failed to unmarshall data from stream: %v", err)
+		return
+	}
+
+	response := messages.SnapshotChunkResponseProto{}
+	data, err := p.store.Chunk(request.Height, request.ChunkIndex)
+	if err != nil {
+		response.Error = true
+	} else {
+		response.Data = data
+	}
+
+	payload, err := proto.Marshal(&response)
+	if err != nil {
+		log.Errorf("failed to marshal snapshot chunk response: %v", err)
+		return
+	}
+
+	payloadEnvelope := make([]byte, 8+len(payload))
+	binary.LittleEndian.PutUint64(payloadEnvelope, uint64(len(payload)))
+	copy(payloadEnvelope[8:], payload)
+	n, err := s.Write(payloadEnvelope)
+	if err != nil {
+		log.Errorf("failed to write envelope data to stream: %v", err)
+	}
+	if n != len(payloadEnvelope) {
+		log.Errorf("failed to write the envelope size %d to stream, wrote: %d ", len(payloadEnvelope), n)
+	}
+}
@@ -0,0 +1,329 @@
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/filefilego/filefilego/block"
+	"github.com/filefilego/filefilego/common/hexutil"
+	ffgcrypto "github.com/filefilego/filefilego/crypto"
+	"github.com/filefilego/filefilego/node/protocols/messages"
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	connmgr "github.com/libp2p/go-libp2p/p2p/net/connmgr"
+	noise "github.com/libp2p/go-libp2p/p2p/security/noise"
+	libp2ptls "github.com/libp2p/go-libp2p/p2p/security/tls"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	h := newHost(t, "1184")
+	store, err := NewStore(t.TempDir())
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		h.Close()
+	})
+
+	cases := map[string]struct {
+		blockchain Blockchain
+		host       host.Host
+		store      *Store
+		expErr     string
+	}{
+		"no blockchain": {
+			expErr: "blockchain is nil",
+		},
+		"no host": {
+			blockchain: &blockchainStub{},
+			expErr:     "host is nil",
+		},
+		"no store": {
+			blockchain: &blockchainStub{},
+			host:       h,
+			expErr:     "store is nil",
+		},
+		"success": {
+			blockchain: &blockchainStub{},
+			host:       h,
+			store:      store,
+		},
+	}
+
+	for name, tt := range cases {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			protocol, err := New(tt.blockchain, tt.host, tt.store)
+			if tt.expErr != "" {
+				assert.Nil(t, protocol)
+				assert.EqualError(t, err, tt.expErr)
+			} else {
+				assert.NotNil(t, protocol)
+			}
+		})
+	}
+}
+
+func TestStore(t *testing.T) {
+	_, err := NewStore("")
+	assert.EqualError(t, err, "directory is empty")
+
+	dir := t.TempDir()
+	store, err := NewStore(dir)
+	assert.NoError(t, err)
+
+	_, err = store.LatestManifest()
+	assert.EqualError(t, err, "no snapshot available")
+
+	kp := newVerifierKeyPair(t)
+	bchain := newBlockchainStub(10, 3)
+	for i := uint64(1); i <= snapshotsToKeep+1; i++ {
+		bchain.height = i
+		manifest, err := store.Create(bchain, &keySigner{key: kp.PrivateKey})
+		assert.NoError(t, err)
+		assert.Equal(t, i, manifest.Height)
+		assert.Len(t, manifest.ChunkHashes, 1)
+	}
+
+	latest, err := store.LatestManifest()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(snapshotsToKeep+1), latest.Height)
+	assert.NoError(t, VerifyManifest(latest))
+
+	chunk, err := store.Chunk(latest.Height, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, latest.ChunkHashes[0], ffgcrypto.Sha256(chunk))
+
+	// only the latest snapshots are kept
+	heights, err := store.heights()
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{2, 3}, heights)
+	_, err = store.Chunk(1, 0)
+	assert.Error(t, err)
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, entries, snapshotsToKeep)
+}
+
+func TestVerifyManifest(t *testing.T) {
+	verifier := newVerifierKeyPair(t)
+	other, err := ffgcrypto.GenerateKeyPair()
+	assert.NoError(t, err)
+
+	cases := map[string]struct {
+		manifest *messages.SnapshotManifestProto
+		key      crypto.PrivKey
+		tamper   func(m *messages.SnapshotManifestProto)
+		expErr   string
+	}{
+		"empty block hash": {
+			manifest: &messages.SnapshotManifestProto{Height: 1, ChunkHashes: [][]byte{{1}}},
+			key:      verifier.PrivateKey,
+			expErr:   "block hash is empty",
+		},
+		"no chunks": {
+			manifest: &messages.SnapshotManifestProto{Height: 1, BlockHash: []byte{1}},
+			key:      verifier.PrivateKey,
+			expErr:   "snapshot doesn't contain any chunk",
+		},
+		"not a verifier": {
+			manifest: &messages.SnapshotManifestProto{Height: 1, BlockHash: []byte{1}, ChunkHashes: [][]byte{{1}}},
+			key:      other.PrivateKey,
+			expErr:   "snapshot is not signed by a verifier: " + other.Address,
+		},
+		"tampered": {
+			manifest: &messages.SnapshotManifestProto{Height: 1, BlockHash: []byte{1}, ChunkHashes: [][]byte{{1}}},
+			key:      verifier.PrivateKey,
+			tamper: func(m *messages.SnapshotManifestProto) {
+				m.ChunkHashes[0] = []byte{2}
+			},
+			expErr: "failed to verify snapshot signature",
+		},
+		"success": {
+			manifest: &messages.SnapshotManifestProto{Height: 1, BlockHash: []byte{1}, ChunkHashes: [][]byte{{1}}},
+			key:      verifier.PrivateKey,
+		},
+	}
+
+	for name, tt := range cases {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			assert.NoError(t, SignManifest(tt.manifest, tt.key))
+			if tt.tamper != nil {
+				tt.tamper(tt.manifest)
+			}
+			err := VerifyManifest(tt.manifest)
+			if tt.expErr != "" {
+				assert.EqualError(t, err, tt.expErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestFastSync(t *testing.T) {
+	h1 := newHost(t, "1185")
+	h2 := newHost(t, "1186")
+	h3 := newHost(t, "1187")
+	t.Cleanup(func() {
+		h1.Close()
+		h2.Close()
+		h3.Close()
+	})
+
+	assert.NoError(t, h3.Connect(context.TODO(), peer.AddrInfo{ID: h1.ID(), Addrs: h1.Addrs()}))
+	assert.NoError(t, h3.Connect(context.TODO(), peer.AddrInfo{ID: h2.ID(), Addrs: h2.Addrs()}))
+
+	kp := newVerifierKeyPair(t)
+
+	// h1 serves a snapshot, h2 doesn't have any
+	store1, err := NewStore(t.TempDir())
+	assert.NoError(t, err)
+	bchain1 := newBlockchainStub(5, 50)
+	protocol1, err := New(bchain1, h1, store1)
+	assert.NoError(t, err)
+	manifest, err := protocol1.CreateSnapshot(&keySigner{key: kp.PrivateKey})
+	assert.NoError(t, err)
+
+	store2, err := NewStore(t.TempDir())
+	assert.NoError(t, err)
+	_, err = New(newBlockchainStub(0, 0), h2, store2)
+	assert.NoError(t, err)
+
+	store3, err := NewStore(t.TempDir())
+	assert.NoError(t, err)
+	bchain3 := &blockchainStub{records: make(map[string][]byte)}
+	protocol3, err := New(bchain3, h3, store3)
+	assert.NoError(t, err)
+
+	remote, err := NewRemotePeer(h3, h2.ID())
+	assert.NoError(t, err)
+	_, err = remote.GetManifest(context.TODO())
+	assert.EqualError(t, err, "remote peer doesn't have a snapshot")
+
+	height, err := protocol3.FastSync(context.TODO(), []peer.ID{h3.ID(), h2.ID(), h1.ID()})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(5), height)
+	assert.Equal(t, uint64(5), bchain3.height)
+	assert.Equal(t, bchain1.records, bchain3.records)
+
+	// the downloaded snapshot is served to other peers
+	latest, err := store3.LatestManifest()
+	assert.NoError(t, err)
+	assert.Equal(t, manifest.ChunkHashes, latest.ChunkHashes)
+
+	// a failed import is discarded
+	bchain3 = &blockchainStub{records: make(map[string][]byte), importErr: errors.New("import failed")}
+	protocol3, err = New(bchain3, h3, store3)
+	assert.NoError(t, err)
+	_, err = protocol3.FastSync(context.TODO(), []peer.ID{h1.ID()})
+	assert.EqualError(t, err, "failed to import snapshot chunk 0: import failed")
+	assert.True(t, bchain3.discarded)
+
+	_, err = protocol3.FastSync(context.TODO(), []peer.ID{h2.ID()})
+	assert.EqualError(t, err, "no valid snapshot found on peers")
+}
+
+type blockchainStub struct {
+	height    uint64
+	blockHash []byte
+	records   map[string][]byte
+	importErr error
+	discarded bool
+}
+
+func newBlockchainStub(height uint64, totalRecords int) *blockchainStub {
+	stub := &blockchainStub{
+		height:    height,
+		blockHash: []byte{byte(height)},
+		records:   make(map[string][]byte),
+	}
+	for i := 0; i < totalRecords; i++ {
+		stub.records[fmt.Sprintf("key%d", i)] = []byte{byte(i)}
+	}
+	return stub
+}
+
+func (s *blockchainStub) GetHeight() uint64 {
+	return s.height
+}
+
+func (s *blockchainStub) ExportSnapshot(fn func(key, value []byte) error) (uint64, []byte, error) {
+	for k, v := range s.records {
+		if err := fn([]byte(k), v); err != nil {
+			return 0, nil, err
+		}
+	}
+	return s.height, s.blockHash, nil
+}
+
+func (s *blockchainStub) ImportSnapshotRecords(records []*messages.SnapshotRecordProto) error {
+	if s.importErr != nil {
+		return s.importErr
+	}
+	for _, r := range records {
+		s.records[string(r.Key)] = r.Value
+	}
+	return nil
+}
+
+func (s *blockchainStub) FinalizeSnapshotImport(height uint64, blockHash []byte) error {
+	s.height = height
+	s.blockHash = blockHash
+	return nil
+}
+
+func (s *blockchainStub) DiscardSnapshotImport() error {
+	s.discarded = true
+	s.records = make(map[string][]byte)
+	return nil
+}
+
+// newVerifierKeyPair creates a key pair which is registered as a block verifier.
+func newVerifierKeyPair(t *testing.T) ffgcrypto.KeyPair {
+	kp, err := ffgcrypto.GenerateKeyPair()
+	assert.NoError(t, err)
+	pubKeyBytes, err := kp.PublicKey.Raw()
+	assert.NoError(t, err)
+	block.SetBlockVerifiers(block.Verifier{
+		Address:   kp.Address,
+		PublicKey: hexutil.Encode(pubKeyBytes),
+	})
+	return kp
+}
+
+func newHost(t *testing.T, port string) host.Host {
+	priv, _, err := crypto.GenerateKeyPair(crypto.Secp256k1, 256)
+	assert.NoError(t, err)
+	connManager, err := connmgr.NewConnManager(
+		100,
+		400,
+		connmgr.WithGracePeriod(time.Minute),
+	)
+	assert.NoError(t, err)
+
+	host, err := libp2p.New(libp2p.Identity(priv),
+		libp2p.ListenAddrStrings(fmt.Sprintf("/ip4/127.0.0.1/tcp/%s", port)),
+		libp2p.Ping(false),
+		libp2p.Security(libp2ptls.ID, libp2ptls.New),
+		libp2p.Security(noise.ID, noise.New),
+		libp2p.DefaultTransports,
+		libp2p.ConnectionManager(connManager),
+	)
+	assert.NoError(t, err)
+	return host
+}
+
+type keySigner struct {
+	key crypto.PrivKey
+}
+
+func (k *keySigner) SignSnapshotManifest(manifest *messages.SnapshotManifestProto) error {
+	return SignManifest(manifest, k.key)
+}
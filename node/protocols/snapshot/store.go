@@ -0,0 +1,244 @@
+package snapshot
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/filefilego/filefilego/common"
+	ffgcrypto "github.com/filefilego/filefilego/crypto"
+	"github.com/filefilego/filefilego/node/protocols/messages"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	manifestFileName = "manifest"
+	chunkFilePrefix  = "chunk_"
+	tempDirPattern   = "tmp_"
+
+	// maxChunkSize is the size of the records after which a new chunk is started.
+	maxChunkSize = 4 * common.MB
+
+	// snapshotsToKeep is the number of snapshots kept on disk.
+	snapshotsToKeep = 2
+)
+
+// Store keeps the latest snapshots on disk.
+// each snapshot is stored in a directory named after its height containing the manifest and the chunks.
+type Store struct {
+	dir string
+	mu  sync.RWMutex
+}
+
+// NewStore creates a new snapshot store in the given directory.
+func NewStore(dir string) (*Store, error) {
+	if dir == "" {
+		return nil, errors.New("directory is empty")
+	}
+
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return nil, fmt.Errorf("failed to create snapshots directory: %w", err)
+	}
+
+	return &Store{dir: dir}, nil
+}
+
+// Create exports the state of the blockchain into a new snapshot signed by the given signer.
+func (s *Store) Create(bchain Blockchain, signer ManifestSigner) (*messages.SnapshotManifestProto, error) {
+	w, err := s.newWriter()
+	if err != nil {
+		return nil, err
+	}
+	defer w.discard()
+
+	chunk := &messages.SnapshotChunkProto{}
+	chunkSize := 0
+	flush := func() error {
+		data, err := proto.Marshal(chunk)
+		if err != nil {
+			return fmt.Errorf("failed to marshal snapshot chunk: %w", err)
+		}
+		chunk = &messages.SnapshotChunkProto{}
+		chunkSize = 0
+		return w.writeChunk(data)
+	}
+
+	height, blockHash, err := bchain.ExportSnapshot(func(key, value []byte) error {
+		chunk.Records = append(chunk.Records, &messages.SnapshotRecordProto{
+			Key:   append([]byte{}, key...),
+			Value: append([]byte{}, value...),
+		})
+		chunkSize += len(key) + len(value)
+		if chunkSize < maxChunkSize {
+			return nil
+		}
+		return flush()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to export snapshot: %w", err)
+	}
+
+	if len(chunk.Records) > 0 {
+		if err := flush(); err != nil {
+			return nil, err
+		}
+	}
+
+	manifest := &messages.SnapshotManifestProto{
+		Height:      height,
+		BlockHash:   blockHash,
+		ChunkHashes: w.hashes,
+	}
+	if err := signer.SignSnapshotManifest(manifest); err != nil {
+		return nil, fmt.Errorf("failed to sign snapshot manifest: %w", err)
+	}
+
+	if err := s.commit(w, manifest); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// LatestManifest returns the manifest of the latest snapshot.
+func (s *Store) LatestManifest() (*messages.SnapshotManifestProto, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	heights, err := s.heights()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(heights) == 0 {
+		return nil, errors.New("no snapshot available")
+	}
+
+	data, err := os.ReadFile(filepath.Join(s.snapshotDir(heights[len(heights)-1]), manifestFileName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot manifest: %w", err)
+	}
+
+	manifest := messages.SnapshotManifestProto{}
+	if err := proto.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal snapshot manifest: %w", err)
+	}
+	return &manifest, nil
+}
+
+// Chunk returns a chunk of the snapshot at the given height.
+func (s *Store) Chunk(height, index uint64) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	data, err := os.ReadFile(filepath.Join(s.snapshotDir(height), chunkFileName(index)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot chunk: %w", err)
+	}
+	return data, nil
+}
+
+// newWriter creates a writer which writes the chunks of a snapshot into a temporary directory.
+func (s *Store) newWriter() (*writer, error) {
+	dir, err := os.MkdirTemp(s.dir, tempDirPattern)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary snapshot directory: %w", err)
+	}
+	return &writer{dir: dir}, nil
+}
+
+// commit writes the manifest of the snapshot and moves it into the store.
+// older snapshots are removed.
+func (s *Store) commit(w *writer, manifest *messages.SnapshotManifestProto) error {
+	data, err := proto.Marshal(manifest)
+	if err != nil {
+		return fmt.Errorf("failed to marshal snapshot manifest: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(w.dir, manifestFileName), data, 0o600); err != nil {
+		return fmt.Errorf("failed to write snapshot manifest: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	target := s.snapshotDir(manifest.Height)
+	if err := os.RemoveAll(target); err != nil {
+		return fmt.Errorf("failed to remove existing snapshot: %w", err)
+	}
+
+	if err := os.Rename(w.dir, target); err != nil {
+		return fmt.Errorf("failed to move snapshot: %w", err)
+	}
+
+	heights, err := s.heights()
+	if err != nil {
+		return err
+	}
+
+	for len(heights) > snapshotsToKeep {
+		if err := os.RemoveAll(s.snapshotDir(heights[0])); err != nil {
+			return fmt.Errorf("failed to remove old snapshot: %w", err)
+		}
+		heights = heights[1:]
+	}
+	return nil
+}
+
+// heights returns the heights of the stored snapshots in ascending order.
+func (s *Store) heights() ([]uint64, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshots directory: %w", err)
+	}
+
+	heights := make([]uint64, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		height, err := strconv.ParseUint(entry.Name(), 10, 64)
+		if err != nil {
+			continue
+		}
+		heights = append(heights, height)
+	}
+
+	sort.Slice(heights, func(i, j int) bool {
+		return heights[i] < heights[j]
+	})
+	return heights, nil
+}
+
+func (s *Store) snapshotDir(height uint64) string {
+	return filepath.Join(s.dir, strconv.FormatUint(height, 10))
+}
+
+func chunkFileName(index uint64) string {
+	return chunkFilePrefix + strconv.FormatUint(index, 10)
+}
+
+// writer writes the chunks of a snapshot into a temporary directory.
+type writer struct {
+	dir    string
+	hashes [][]byte
+}
+
+// writeChunk writes the next chunk of the snapshot.
+func (w *writer) writeChunk(data []byte) error {
+	err := os.WriteFile(filepath.Join(w.dir, chunkFileName(uint64(len(w.hashes)))), data, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to write snapshot chunk: %w", err)
+	}
+	w.hashes = append(w.hashes, ffgcrypto.Sha256(data))
+	return nil
+}
+
+// discard removes the temporary directory if the snapshot was not committed.
+func (w *writer) discard() {
+	os.RemoveAll(w.dir)
+}
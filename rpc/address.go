@@ -2,12 +2,15 @@ package rpc
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/filefilego/filefilego/blockchain"
 	"github.com/filefilego/filefilego/common"
 	"github.com/filefilego/filefilego/common/hexutil"
+	"github.com/filefilego/filefilego/crypto"
 	"github.com/filefilego/filefilego/keystore"
+	"github.com/filefilego/filefilego/transaction"
 )
 
 const zeroHex = "0x0"
@@ -145,3 +148,41 @@ func (api *AddressAPI) Balance(r *http.Request, args *BalanceOfAddressArgs, resp
 
 	return nil
 }
+
+// CreateMultiSigArgs arguments required for creating a multi-signature address.
+type CreateMultiSigArgs struct {
+	Threshold  int      `json:"threshold"`
+	PublicKeys []string `json:"public_keys"`
+}
+
+// CreateMultiSigResponse represents a multi-signature address and the public key used in its transactions.
+type CreateMultiSigResponse struct {
+	Address   string `json:"address"`
+	PublicKey string `json:"public_key"`
+}
+
+// CreateMultiSig creates a multi-signature address which requires threshold of the public keys to sign its transactions.
+func (api *AddressAPI) CreateMultiSig(r *http.Request, args *CreateMultiSigArgs, response *CreateMultiSigResponse) error {
+	publicKeys := make([][]byte, len(args.PublicKeys))
+	for i, v := range args.PublicKeys {
+		publicKey, err := hexutil.Decode(v)
+		if err != nil {
+			return fmt.Errorf("failed to decode public key: %w", err)
+		}
+		publicKeys[i] = publicKey
+	}
+
+	multiSigPublicKey, err := transaction.NewMultiSigPublicKey(args.Threshold, publicKeys)
+	if err != nil {
+		return fmt.Errorf("failed to create multi-signature public key: %w", err)
+	}
+
+	address, err := crypto.RawPublicToAddress(multiSigPublicKey)
+	if err != nil {
+		return fmt.Errorf("failed to get address: %w", err)
+	}
+
+	response.Address = address
+	response.PublicKey = hexutil.Encode(multiSigPublicKey)
+	return nil
+}
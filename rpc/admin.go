@@ -0,0 +1,150 @@
+package rpc
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/filefilego/filefilego/keystore"
+	"github.com/filefilego/filefilego/node"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// PeerBanner defines the functionality to manage the banned peers of a node.
+type PeerBanner interface {
+	BanPeer(id peer.ID, reason string) error
+	UnbanPeer(id peer.ID) bool
+	BannedPeers() []node.BannedPeer
+}
+
+// AdminAPI represents the admin rpc service.
+type AdminAPI struct {
+	keystore keystore.KeyAuthorizer
+	banner   PeerBanner
+}
+
+// NewAdminAPI creates a new admin API to be served using JSONRPC.
+func NewAdminAPI(keystore keystore.KeyAuthorizer, banner PeerBanner) (*AdminAPI, error) {
+	if keystore == nil {
+		return nil, errors.New("keystore is nil")
+	}
+
+	if banner == nil {
+		return nil, errors.New("peer banner is nil")
+	}
+
+	return &AdminAPI{
+		keystore: keystore,
+		banner:   banner,
+	}, nil
+}
+
+// BannedPeersArgs represent the function args.
+type BannedPeersArgs struct {
+	AccessToken string `json:"access_token"`
+}
+
+// JSONBannedPeer represents a banned peer.
+type JSONBannedPeer struct {
+	PeerID string `json:"peer_id"`
+	Reason string `json:"reason"`
+	Until  int64  `json:"until"`
+}
+
+// BannedPeersResponse represent the function response.
+type BannedPeersResponse struct {
+	Peers []JSONBannedPeer `json:"peers"`
+}
+
+// BannedPeers returns the banned peers.
+func (api *AdminAPI) BannedPeers(r *http.Request, args *BannedPeersArgs, response *BannedPeersResponse) error {
+	if err := api.authorize(args.AccessToken); err != nil {
+		return err
+	}
+
+	bannedPeers := api.banner.BannedPeers()
+	response.Peers = make([]JSONBannedPeer, len(bannedPeers))
+	for i, v := range bannedPeers {
+		response.Peers[i] = JSONBannedPeer{
+			PeerID: v.PeerID.String(),
+			Reason: v.Reason,
+			Until:  v.Until.Unix(),
+		}
+	}
+
+	return nil
+}
+
+// BanPeerArgs represent the function args.
+type BanPeerArgs struct {
+	AccessToken string `json:"access_token"`
+	PeerID      string `json:"peer_id"`
+	Reason      string `json:"reason"`
+}
+
+// BanPeerResponse represent the function response.
+type BanPeerResponse struct {
+	Success bool `json:"success"`
+}
+
+// BanPeer bans a peer and disconnects from it.
+func (api *AdminAPI) BanPeer(r *http.Request, args *BanPeerArgs, response *BanPeerResponse) error {
+	if err := api.authorize(args.AccessToken); err != nil {
+		return err
+	}
+
+	peerID, err := peer.Decode(args.PeerID)
+	if err != nil {
+		return fmt.Errorf("failed to decode peer id: %w", err)
+	}
+
+	reason := args.Reason
+	if reason == "" {
+		reason = "banned by admin"
+	}
+
+	if err := api.banner.BanPeer(peerID, reason); err != nil {
+		return fmt.Errorf("failed to ban peer: %w", err)
+	}
+
+	response.Success = true
+	return nil
+}
+
+// UnbanPeerArgs represent the function args.
+type UnbanPeerArgs struct {
+	AccessToken string `json:"access_token"`
+	PeerID      string `json:"peer_id"`
+}
+
+// UnbanPeerResponse represent the function response.
+type UnbanPeerResponse struct {
+	Success bool `json:"success"`
+}
+
+// UnbanPeer removes a peer from the ban list.
+func (api *AdminAPI) UnbanPeer(r *http.Request, args *UnbanPeerArgs, response *UnbanPeerResponse) error {
+	if err := api.authorize(args.AccessToken); err != nil {
+		return err
+	}
+
+	peerID, err := peer.Decode(args.PeerID)
+	if err != nil {
+		return fmt.Errorf("failed to decode peer id: %w", err)
+	}
+
+	response.Success = api.banner.UnbanPeer(peerID)
+	return nil
+}
+
+func (api *AdminAPI) authorize(accessToken string) error {
+	if accessToken == "" {
+		return errors.New("access token is empty")
+	}
+
+	ok, _, err := api.keystore.Authorized(accessToken)
+	if err != nil || !ok {
+		return fmt.Errorf("failed to authorize access token %v", err)
+	}
+	return nil
+}
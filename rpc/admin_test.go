@@ -0,0 +1,117 @@
+package rpc
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/filefilego/filefilego/keystore"
+	"github.com/filefilego/filefilego/node"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAdminAPI(t *testing.T) {
+	t.Parallel()
+	cases := map[string]struct {
+		keystore keystore.KeyAuthorizer
+		banner   PeerBanner
+		expErr   string
+	}{
+		"no keystore": {
+			expErr: "keystore is nil",
+		},
+		"no banner": {
+			keystore: &keystore.Store{},
+			expErr:   "peer banner is nil",
+		},
+		"success": {
+			keystore: &keystore.Store{},
+			banner:   &node.Node{},
+		},
+	}
+
+	for name, tt := range cases {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			api, err := NewAdminAPI(tt.keystore, tt.banner)
+			if tt.expErr != "" {
+				assert.Nil(t, api)
+				assert.EqualError(t, err, tt.expErr)
+			} else {
+				assert.NotNil(t, api)
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAdminAPIMethods(t *testing.T) {
+	peerID := "16Uiu2HAm2edbaX9YyMauXDjdhcdF34P59zg29xtP9nmeS7MJNbxo"
+	banner := &peerBannerStub{}
+	authorizer := &keyAuthorizerStub{ok: true}
+	api, err := NewAdminAPI(authorizer, banner)
+	assert.NoError(t, err)
+
+	// access token is required
+	err = api.BannedPeers(&http.Request{}, &BannedPeersArgs{}, &BannedPeersResponse{})
+	assert.EqualError(t, err, "access token is empty")
+
+	// BanPeer
+	err = api.BanPeer(&http.Request{}, &BanPeerArgs{AccessToken: "123", PeerID: "wrong"}, &BanPeerResponse{})
+	assert.ErrorContains(t, err, "failed to decode peer id")
+
+	banResponse := &BanPeerResponse{}
+	err = api.BanPeer(&http.Request{}, &BanPeerArgs{AccessToken: "123", PeerID: peerID}, banResponse)
+	assert.NoError(t, err)
+	assert.True(t, banResponse.Success)
+	assert.Equal(t, "banned by admin", banner.reason)
+
+	banner.err = errors.New("node can't ban itself")
+	err = api.BanPeer(&http.Request{}, &BanPeerArgs{AccessToken: "123", PeerID: peerID, Reason: "spam"}, &BanPeerResponse{})
+	assert.EqualError(t, err, "failed to ban peer: node can't ban itself")
+
+	// BannedPeers
+	id, err := peer.Decode(peerID)
+	assert.NoError(t, err)
+	until := time.Now().Add(time.Hour)
+	banner.bannedPeers = []node.BannedPeer{{PeerID: id, Reason: "spam", Until: until}}
+	bannedResponse := &BannedPeersResponse{}
+	err = api.BannedPeers(&http.Request{}, &BannedPeersArgs{AccessToken: "123"}, bannedResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, []JSONBannedPeer{{PeerID: peerID, Reason: "spam", Until: until.Unix()}}, bannedResponse.Peers)
+
+	// UnbanPeer
+	banner.unbanned = true
+	unbanResponse := &UnbanPeerResponse{}
+	err = api.UnbanPeer(&http.Request{}, &UnbanPeerArgs{AccessToken: "123", PeerID: peerID}, unbanResponse)
+	assert.NoError(t, err)
+	assert.True(t, unbanResponse.Success)
+
+	// unauthorized
+	authorizer.ok = false
+	err = api.UnbanPeer(&http.Request{}, &UnbanPeerArgs{AccessToken: "123", PeerID: peerID}, &UnbanPeerResponse{})
+	assert.EqualError(t, err, "failed to authorize access token <nil>")
+}
+
+type peerBannerStub struct {
+	reason      string
+	err         error
+	unbanned    bool
+	bannedPeers []node.BannedPeer
+}
+
+func (p *peerBannerStub) BanPeer(id peer.ID, reason string) error {
+	p.reason = reason
+	return p.err
+}
+
+func (p *peerBannerStub) UnbanPeer(id peer.ID) bool {
+	return p.unbanned
+}
+
+func (p *peerBannerStub) BannedPeers() []node.BannedPeer {
+	return p.bannedPeers
+}
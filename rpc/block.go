@@ -1,11 +1,14 @@
 package rpc
 
 import (
+	"bytes"
 	"errors"
 	"net/http"
 
+	"github.com/filefilego/filefilego/block"
 	"github.com/filefilego/filefilego/blockchain"
 	"github.com/filefilego/filefilego/common/hexutil"
+	"google.golang.org/protobuf/proto"
 )
 
 // BlockAPI represents the block rpc service.
@@ -39,6 +42,8 @@ type JSONBlock struct {
 	Signature         string            `json:"signature"`
 	MerkleHash        string            `json:"merkle_hash"`
 	Transactions      []JSONTransaction `json:"transactions"`
+	// Finalized is true if a quorum of verifiers attested the block or one of its descendants.
+	Finalized bool `json:"finalized"`
 }
 
 // GetByNumber gets a block by number.
@@ -57,19 +62,9 @@ func (api *BlockAPI) GetByNumber(r *http.Request, args *GetByNumberArgs, respons
 	response.Timestamp = validBlock.Timestamp
 	response.Transactions = make([]JSONTransaction, len(validBlock.Transactions))
 	for i, v := range validBlock.Transactions {
-		response.Transactions[i] = JSONTransaction{
-			Hash:            hexutil.Encode(v.Hash),
-			Signature:       hexutil.Encode(v.Signature),
-			PublicKey:       hexutil.Encode(v.PublicKey),
-			Nounce:          hexutil.EncodeUint64BytesToHexString(v.Nounce),
-			Data:            hexutil.Encode(v.Data),
-			From:            v.From,
-			To:              v.To,
-			Value:           v.Value,
-			TransactionFees: v.TransactionFees,
-			Chain:           hexutil.Encode(v.Chain),
-		}
+		response.Transactions[i] = toJSONTransaction(v)
 	}
+	response.Finalized = api.isFinalized(*validBlock)
 
 	return nil
 }
@@ -100,22 +95,22 @@ func (api *BlockAPI) GetByHash(r *http.Request, args *GetByHashArgs, response *J
 	response.Timestamp = validBlock.Timestamp
 	response.Transactions = make([]JSONTransaction, len(validBlock.Transactions))
 	for i, v := range validBlock.Transactions {
-		response.Transactions[i] = JSONTransaction{
-			Hash:            hexutil.Encode(v.Hash),
-			Signature:       hexutil.Encode(v.Signature),
-			PublicKey:       hexutil.Encode(v.PublicKey),
-			Nounce:          hexutil.EncodeUint64BytesToHexString(v.Nounce),
-			Data:            hexutil.Encode(v.Data),
-			From:            v.From,
-			To:              v.To,
-			Value:           v.Value,
-			TransactionFees: v.TransactionFees,
-			Chain:           hexutil.Encode(v.Chain),
-		}
+		response.Transactions[i] = toJSONTransaction(v)
 	}
+	response.Finalized = api.isFinalized(validBlock)
 	return nil
 }
 
+// isFinalized checks if the block is a finalized block of the chain.
+func (api *BlockAPI) isFinalized(blck block.Block) bool {
+	if !api.blockchain.IsFinalized(blck.Number) {
+		return false
+	}
+
+	canonical, err := api.blockchain.GetBlockByNumber(blck.Number)
+	return err == nil && bytes.Equal(canonical.Hash, blck.Hash)
+}
+
 // EmptyArgs
 type EmptyArgs struct{}
 
@@ -134,3 +129,64 @@ func (api *BlockAPI) Pool(r *http.Request, args *EmptyArgs, response *PoolRespon
 
 	return nil
 }
+
+// DoubleSignEvidenceArgs represents the args of rpc request.
+type DoubleSignEvidenceArgs struct {
+	// Address is the verifier address, the evidence of all verifiers is returned if empty.
+	Address string `json:"address"`
+}
+
+// JSONDoubleSignEvidence represents the evidence of a verifier which signed two different blocks with the same number.
+type JSONDoubleSignEvidence struct {
+	Verifier         string `json:"verifier"`
+	BlockNumber      uint64 `json:"block_number"`
+	FirstBlockHash   string `json:"first_block_hash"`
+	SecondBlockHash  string `json:"second_block_hash"`
+	CommittedInBlock uint64 `json:"committed_in_block"`
+	// Evidence is the serialized evidence which can be used as the payload of a DOUBLE_SIGN_EVIDENCE transaction.
+	Evidence string `json:"evidence"`
+}
+
+// DoubleSignEvidenceResponse represents the response of rpc request.
+type DoubleSignEvidenceResponse struct {
+	Evidence []JSONDoubleSignEvidence `json:"evidence"`
+}
+
+// DoubleSignEvidence gets the double sign evidence of the verifiers.
+func (api *BlockAPI) DoubleSignEvidence(r *http.Request, args *DoubleSignEvidenceArgs, response *DoubleSignEvidenceResponse) error {
+	items, err := api.blockchain.GetDoubleSignEvidence(args.Address)
+	if err != nil {
+		return err
+	}
+
+	response.Evidence = make([]JSONDoubleSignEvidence, 0, len(items))
+	for _, item := range items {
+		first, err := block.UnmarshalProtoBlock(item.FirstBlock)
+		if err != nil {
+			return err
+		}
+
+		second, err := block.UnmarshalProtoBlock(item.SecondBlock)
+		if err != nil {
+			return err
+		}
+
+		// the committed block is not part of the evidence payload
+		payload := proto.Clone(item).(*blockchain.DoubleSignEvidenceProto)
+		payload.CommittedInBlock = 0
+		data, err := proto.Marshal(payload)
+		if err != nil {
+			return err
+		}
+
+		response.Evidence = append(response.Evidence, JSONDoubleSignEvidence{
+			Verifier:         item.Verifier,
+			BlockNumber:      item.BlockNumber,
+			FirstBlockHash:   hexutil.Encode(first.Hash),
+			SecondBlockHash:  hexutil.Encode(second.Hash),
+			CommittedInBlock: item.CommittedInBlock,
+			Evidence:         hexutil.Encode(data),
+		})
+	}
+	return nil
+}
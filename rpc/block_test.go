@@ -53,6 +53,7 @@ func TestBlockAPIMethods(t *testing.T) {
 	assert.Equal(t, hexutil.Encode(genesisblockValid.Signature), response.Signature)
 	assert.Equal(t, genesisblockValid.Number, response.Number)
 	assert.Equal(t, genesisblockValid.Timestamp, response.Timestamp)
+	assert.True(t, response.Finalized)
 	assert.Len(t, response.Transactions, 1)
 	assert.Equal(t, hexutil.Encode(genesisblockValid.Transactions[0].Chain), response.Transactions[0].Chain)
 	assert.Equal(t, hexutil.Encode(genesisblockValid.Transactions[0].Data), response.Transactions[0].Data)
@@ -80,6 +81,7 @@ func TestBlockAPIMethods(t *testing.T) {
 	assert.Equal(t, hexutil.Encode(genesisblockValid.Signature), response2.Signature)
 	assert.Equal(t, genesisblockValid.Number, response2.Number)
 	assert.Equal(t, genesisblockValid.Timestamp, response2.Timestamp)
+	assert.True(t, response2.Finalized)
 	assert.Len(t, response2.Transactions, 1)
 	assert.Equal(t, hexutil.Encode(genesisblockValid.Transactions[0].Chain), response2.Transactions[0].Chain)
 	assert.Equal(t, hexutil.Encode(genesisblockValid.Transactions[0].Data), response2.Transactions[0].Data)
@@ -114,4 +116,10 @@ func TestBlockAPIMethods(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, response3.BlockHashes, 1)
 	assert.Equal(t, "0x01", response3.BlockHashes[0])
+
+	// DoubleSignEvidence
+	response4 := &DoubleSignEvidenceResponse{}
+	err = api.DoubleSignEvidence(&http.Request{}, &DoubleSignEvidenceArgs{}, response4)
+	assert.NoError(t, err)
+	assert.Empty(t, response4.Evidence)
 }
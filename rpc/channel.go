@@ -83,104 +83,79 @@ func (api *ChannelAPI) CreateNodeItemsTxDataPayload(r *http.Request, args *Creat
 		return errors.New("empty node items")
 	}
 
-	nodesEnvelope := blockchain.NodeItems{
-		Nodes: make([]*blockchain.NodeItem, 0),
+	nodes, err := transformJSONToNodeItems(args.Nodes)
+	if err != nil {
+		return err
 	}
 
-	for _, v := range args.Nodes {
-		item := blockchain.NodeItem{
-			Name:       v.Name,
-			Enabled:    v.Enabled,
-			NodeType:   blockchain.NodeItemType(v.NodeType),
-			Timestamp:  v.Timestamp,
-			Admins:     make([][]byte, 0),
-			Posters:    make([][]byte, 0),
-			Attributes: make([][]byte, 0),
-		}
-
-		if v.NodeHash != "" {
-			nodeHash, err := hexutil.Decode(v.NodeHash)
-			if err != nil {
-				return fmt.Errorf("failed to decode node hash: %w", err)
-			}
-			item.NodeHash = nodeHash
-		}
+	totalFeesRequired := blockchain.CalculateChannelActionsFees(nodes)
+	dataPayloadBytes, err := nodeItemsTxDataPayload(transaction.DataType_CREATE_NODE, nodes)
+	if err != nil {
+		return err
+	}
 
-		if v.ParentHash != "" {
-			parentHash, err := hexutil.Decode(v.ParentHash)
-			if err != nil {
-				return fmt.Errorf("failed to decode parent hash: %w", err)
-			}
-			item.ParentHash = parentHash
-		}
+	response.TransactionDataPayloadHex = hexutil.Encode(dataPayloadBytes)
+	response.TotalFeesRequired = hexutil.EncodeBig(totalFeesRequired)
 
-		if v.Owner != "" {
-			owner, err := hexutil.Decode(v.Owner)
-			if err != nil {
-				return fmt.Errorf("failed to decode owner: %w", err)
-			}
-			item.Owner = owner
-		}
+	return nil
+}
 
-		if v.MerkleRoot != "" {
-			merkleRoot, err := hexutil.Decode(v.MerkleRoot)
-			if err != nil {
-				return fmt.Errorf("failed to decode merkle root hash: %w", err)
-			}
-			item.MerkleRoot = merkleRoot
-		}
+// UpdateNodeItemsTxDataPayloadArgs is an update channel node item request payload.
+// The nodes are identified by their node hash and their mutable fields are replaced by the given values:
+// enabled, description, content type, attributes, admins and posters.
+type UpdateNodeItemsTxDataPayloadArgs struct {
+	Nodes []NodeItemJSON `json:"nodes"`
+}
 
-		if v.FileHash != "" {
-			fileHash, err := hexutil.Decode(v.FileHash)
-			if err != nil {
-				return fmt.Errorf("failed to decode file hash: %w", err)
-			}
-			item.FileHash = fileHash
-		}
+// UpdateNodeItemsTxDataPayloadResponse is a response which contains the transaction data payload for updating the channel node items.
+// It contains the required fees for updating the provided node items.
+type UpdateNodeItemsTxDataPayloadResponse struct {
+	TransactionDataPayloadHex string `json:"transaction_data_payload_hex"`
+	TotalFeesRequired         string `json:"total_fees_required"`
+}
 
-		if v.ContentType != "" {
-			item.ContentType = &v.ContentType
-		}
+// UpdateNodeItemsTxDataPayload returns the transaction data payload for updating existing channel node items.
+func (api *ChannelAPI) UpdateNodeItemsTxDataPayload(r *http.Request, args *UpdateNodeItemsTxDataPayloadArgs, response *UpdateNodeItemsTxDataPayloadResponse) error {
+	if len(args.Nodes) == 0 {
+		return errors.New("empty node items")
+	}
 
-		if v.Description != "" {
-			item.Description = &v.Description
-		}
+	nodes, err := transformJSONToNodeItems(args.Nodes)
+	if err != nil {
+		return err
+	}
 
-		if v.Size != 0 {
-			item.Size = &v.Size
+	for _, v := range nodes {
+		if len(v.NodeHash) == 0 {
+			return errors.New("node hash is empty")
 		}
+	}
 
-		for _, v := range v.Admins {
-			adm, err := hexutil.Decode(v)
-			if err != nil {
-				return fmt.Errorf("failed to decode admin address: %w", err)
-			}
-			item.Admins = append(item.Admins, adm)
-		}
+	totalFeesRequired := blockchain.CalculateChannelUpdateFees(nodes)
+	dataPayloadBytes, err := nodeItemsTxDataPayload(transaction.DataType_UPDATE_NODE, nodes)
+	if err != nil {
+		return err
+	}
 
-		for _, v := range v.Posters {
-			poster, err := hexutil.Decode(v)
-			if err != nil {
-				return fmt.Errorf("failed to decode poster address: %w", err)
-			}
-			item.Posters = append(item.Posters, poster)
-		}
+	response.TransactionDataPayloadHex = hexutil.Encode(dataPayloadBytes)
+	response.TotalFeesRequired = hexutil.EncodeBig(totalFeesRequired)
 
-		for _, v := range v.Attributes {
-			item.Attributes = append(item.Attributes, []byte(v))
-		}
+	return nil
+}
 
-		nodesEnvelope.Nodes = append(nodesEnvelope.Nodes, &item)
+// nodeItemsTxDataPayload creates a transaction data payload of the given type containing the node items.
+func nodeItemsTxDataPayload(dataType transaction.DataType, nodes []*blockchain.NodeItem) ([]byte, error) {
+	nodesEnvelope := blockchain.NodeItems{
+		Nodes: nodes,
 	}
 
-	totalFeesRequired := blockchain.CalculateChannelActionsFees(nodesEnvelope.Nodes)
 	nodeEnvelopeBytes, err := proto.Marshal(&nodesEnvelope)
 	if err != nil {
-		return fmt.Errorf("failed to marshal nodes envelope: %w", err)
+		return nil, fmt.Errorf("failed to marshal nodes envelope: %w", err)
 	}
 
 	dataPayload := transaction.DataPayload{
-		Type:    transaction.DataType_CREATE_NODE,
+		Type:    dataType,
 		Payload: make([]byte, len(nodeEnvelopeBytes)),
 	}
 
@@ -188,13 +163,10 @@ func (api *ChannelAPI) CreateNodeItemsTxDataPayload(r *http.Request, args *Creat
 
 	dataPayloadBytes, err := proto.Marshal(&dataPayload)
 	if err != nil {
-		return fmt.Errorf("failed to marshal transaction data payload: %w", err)
+		return nil, fmt.Errorf("failed to marshal transaction data payload: %w", err)
 	}
 
-	response.TransactionDataPayloadHex = hexutil.Encode(dataPayloadBytes)
-	response.TotalFeesRequired = hexutil.EncodeBig(totalFeesRequired)
-
-	return nil
+	return dataPayloadBytes, nil
 }
 
 // SearchArgs is a search args.
@@ -365,3 +337,95 @@ func transformNodeItemToJSON(item *blockchain.NodeItem) NodeItemJSON {
 
 	return transformed
 }
+
+func transformJSONToNodeItems(nodes []NodeItemJSON) ([]*blockchain.NodeItem, error) {
+	items := make([]*blockchain.NodeItem, 0, len(nodes))
+	for _, v := range nodes {
+		v := v
+		item := blockchain.NodeItem{
+			Name:       v.Name,
+			Enabled:    v.Enabled,
+			NodeType:   blockchain.NodeItemType(v.NodeType),
+			Timestamp:  v.Timestamp,
+			Admins:     make([][]byte, 0),
+			Posters:    make([][]byte, 0),
+			Attributes: make([][]byte, 0),
+		}
+
+		if v.NodeHash != "" {
+			nodeHash, err := hexutil.Decode(v.NodeHash)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode node hash: %w", err)
+			}
+			item.NodeHash = nodeHash
+		}
+
+		if v.ParentHash != "" {
+			parentHash, err := hexutil.Decode(v.ParentHash)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode parent hash: %w", err)
+			}
+			item.ParentHash = parentHash
+		}
+
+		if v.Owner != "" {
+			owner, err := hexutil.Decode(v.Owner)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode owner: %w", err)
+			}
+			item.Owner = owner
+		}
+
+		if v.MerkleRoot != "" {
+			merkleRoot, err := hexutil.Decode(v.MerkleRoot)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode merkle root hash: %w", err)
+			}
+			item.MerkleRoot = merkleRoot
+		}
+
+		if v.FileHash != "" {
+			fileHash, err := hexutil.Decode(v.FileHash)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode file hash: %w", err)
+			}
+			item.FileHash = fileHash
+		}
+
+		if v.ContentType != "" {
+			item.ContentType = &v.ContentType
+		}
+
+		if v.Description != "" {
+			item.Description = &v.Description
+		}
+
+		if v.Size != 0 {
+			item.Size = &v.Size
+		}
+
+		for _, v := range v.Admins {
+			adm, err := hexutil.Decode(v)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode admin address: %w", err)
+			}
+			item.Admins = append(item.Admins, adm)
+		}
+
+		for _, v := range v.Posters {
+			poster, err := hexutil.Decode(v)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode poster address: %w", err)
+			}
+			item.Posters = append(item.Posters, poster)
+		}
+
+		for _, v := range v.Attributes {
+			item.Attributes = append(item.Attributes, []byte(v))
+		}
+
+		items = append(items, &item)
+	}
+
+	return items, nil
+}
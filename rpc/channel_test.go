@@ -4,9 +4,12 @@ import (
 	"testing"
 
 	"github.com/filefilego/filefilego/blockchain"
+	"github.com/filefilego/filefilego/common/hexutil"
 	"github.com/filefilego/filefilego/search"
 	"github.com/filefilego/filefilego/storage"
+	"github.com/filefilego/filefilego/transaction"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
 )
 
 func TestNewChannelAPI(t *testing.T) {
@@ -46,3 +49,42 @@ func TestNewChannelAPI(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateNodeItemsTxDataPayload(t *testing.T) {
+	api, err := NewChannelAPI(&blockchain.Blockchain{}, &search.BleveSearch{})
+	assert.NoError(t, err)
+
+	response := &UpdateNodeItemsTxDataPayloadResponse{}
+	err = api.UpdateNodeItemsTxDataPayload(nil, &UpdateNodeItemsTxDataPayloadArgs{}, response)
+	assert.EqualError(t, err, "empty node items")
+
+	err = api.UpdateNodeItemsTxDataPayload(nil, &UpdateNodeItemsTxDataPayloadArgs{Nodes: []NodeItemJSON{{Description: "no hash"}}}, response)
+	assert.EqualError(t, err, "node hash is empty")
+
+	args := &UpdateNodeItemsTxDataPayloadArgs{
+		Nodes: []NodeItemJSON{
+			{NodeHash: "0x01", Description: "first", Enabled: true},
+			{NodeHash: "0x02", Description: "second", Posters: []string{"0x03"}},
+		},
+	}
+	err = api.UpdateNodeItemsTxDataPayload(nil, args, response)
+	assert.NoError(t, err)
+	assert.Equal(t, hexutil.EncodeBig(blockchain.CalculateChannelUpdateFees(make([]*blockchain.NodeItem, 2))), response.TotalFeesRequired)
+
+	payloadBytes, err := hexutil.Decode(response.TransactionDataPayloadHex)
+	assert.NoError(t, err)
+	dataPayload := transaction.DataPayload{}
+	err = proto.Unmarshal(payloadBytes, &dataPayload)
+	assert.NoError(t, err)
+	assert.Equal(t, transaction.DataType_UPDATE_NODE, dataPayload.Type)
+
+	nodes := blockchain.NodeItems{}
+	err = proto.Unmarshal(dataPayload.Payload, &nodes)
+	assert.NoError(t, err)
+	assert.Len(t, nodes.Nodes, 2)
+	assert.Equal(t, []byte{1}, nodes.Nodes[0].NodeHash)
+	assert.Equal(t, "first", nodes.Nodes[0].GetDescription())
+	assert.True(t, nodes.Nodes[0].Enabled)
+	assert.Equal(t, "second", nodes.Nodes[1].GetDescription())
+	assert.Equal(t, [][]byte{{3}}, nodes.Nodes[1].Posters)
+}
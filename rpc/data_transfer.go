@@ -303,7 +303,10 @@ func (api *DataTransferAPI) RequestDataQueryResponseFromVerifiers(r *http.Reques
 		return fmt.Errorf("failed to decode data query request hash: %w", err)
 	}
 
-	verfiers := block.GetBlockVerifiers()
+	verfiers, err := block.GetBlockVerifiers()
+	if err != nil {
+		return fmt.Errorf("failed to get verifiers: %w", err)
+	}
 	peerIDs := make([]peer.ID, 0)
 	for _, v := range verfiers {
 		publicKey, err := ffgcrypto.PublicKeyFromHex(v.PublicKey)
@@ -966,6 +969,10 @@ func (api *DataTransferAPI) CreateTransactionsWithDataPayloadFromContractHashes(
 			VerifierPublicKey:          downloadContract.VerifierPublicKey,
 			VerifierFees:               downloadContract.VerifierFees,
 			FileHosterFees:             downloadContract.FileHosterResponse.FeesPerByte,
+			FileHosterSignature:        downloadContract.FileHosterResponse.Signature,
+			FileHashesNeeded:           downloadContract.FileHashesNeeded,
+			FileHashesNeededSizes:      downloadContract.FileHashesNeededSizes,
+			VerifierSignature:          downloadContract.VerifierSignature,
 		}
 
 		contractsEnvelope := &messages.DownloadContractsHashesProto{
@@ -1101,7 +1108,10 @@ func (api *DataTransferAPI) CreateContractsFromDataQueryResponses(r *http.Reques
 	}
 
 	// find all verifiers
-	verfiers := block.GetBlockVerifiers()
+	verfiers, err := block.GetBlockVerifiers()
+	if err != nil {
+		return fmt.Errorf("failed to get verifiers: %w", err)
+	}
 	peerIDs := make([]peer.ID, 0)
 	for _, v := range verfiers {
 		publicKey, err := ffgcrypto.PublicKeyFromHex(v.PublicKey)
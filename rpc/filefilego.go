@@ -69,7 +69,10 @@ func (api *FilefilegoAPI) Stats(r *http.Request, args *EmptyArgs, response *Stat
 	response.PeerCount = api.node.Peers().Len()
 	response.PeerID = api.node.GetID()
 	response.StorageEnabled = api.conf.Global.Storage
-	allVerifiers := block.GetBlockVerifiers()
+	allVerifiers, err := block.GetBlockVerifiers()
+	if err != nil {
+		return fmt.Errorf("failed to get verifiers: %w", err)
+	}
 	response.Verifiers = make([]verifier, len(allVerifiers))
 
 	for i, v := range allVerifiers {
@@ -16,6 +16,7 @@ import (
 	"github.com/filefilego/filefilego/database"
 	"github.com/filefilego/filefilego/node"
 	blockdownloader "github.com/filefilego/filefilego/node/protocols/block_downloader"
+	compactblock "github.com/filefilego/filefilego/node/protocols/compact_block"
 	dataquery "github.com/filefilego/filefilego/node/protocols/data_query"
 	"github.com/filefilego/filefilego/search"
 	"github.com/filefilego/filefilego/storage"
@@ -226,6 +227,9 @@ func createNode(t *testing.T, port string, searchDB string, blockchainDBPath str
 	gossip, err := pubsub.NewGossipSub(bgCtx, host, optsPS...)
 	assert.NoError(t, err)
 
+	banList, err := node.NewBanList(node.DefaultMaxOffences, node.DefaultBanDuration)
+	assert.NoError(t, err)
+
 	db, err := leveldb.OpenFile(blockchainDBPath, nil)
 	assert.NoError(t, err)
 
@@ -244,7 +248,13 @@ func createNode(t *testing.T, port string, searchDB string, blockchainDBPath str
 	blockDownloader, err := blockdownloader.New(bchain, host)
 	assert.NoError(t, err)
 
-	node, err := node.New(&ffgconfig.Config{}, host, kademliaDHT, routingDiscovery, gossip, searchEngine, &storage.Storage{}, bchain, dataQueryProtocol, blockDownloader)
+	compactBlockProtocol, err := compactblock.New(host)
+	assert.NoError(t, err)
+
+	addressBook, err := node.NewAddressBook(blockchainDB, node.DefaultAddressBookMaxAge, node.DefaultAddressBookMaxPeers)
+	assert.NoError(t, err)
+
+	node, err := node.New(&ffgconfig.Config{}, host, kademliaDHT, routingDiscovery, gossip, searchEngine, &storage.Storage{}, bchain, dataQueryProtocol, blockDownloader, compactBlockProtocol, banList, addressBook)
 	assert.NoError(t, err)
 	return node, bchain, searchEngine, host
 }
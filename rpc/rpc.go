@@ -17,3 +17,6 @@ const ChannelServiceNamespace = "channel"
 
 // DataTransferServiceNamespace is the namespace for data transfer service rpc.
 const DataTransferServiceNamespace = "data_transfer"
+
+// AdminServiceNamespace is the namespace for admin service rpc.
+const AdminServiceNamespace = "admin"
@@ -5,10 +5,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math/big"
 	"net/http"
 
+	"github.com/filefilego/filefilego/blockchain"
 	"github.com/filefilego/filefilego/common/hexutil"
 	"github.com/filefilego/filefilego/keystore"
+	"github.com/filefilego/filefilego/mempool"
 	"github.com/filefilego/filefilego/node/protocols/messages"
 	"github.com/filefilego/filefilego/transaction"
 	"google.golang.org/protobuf/proto"
@@ -18,8 +21,9 @@ import (
 type Blockchain interface {
 	PutMemPool(tx transaction.Transaction) error
 	GetTransactionsFromPool() []transaction.Transaction
-	GetAddressTransactions(address []byte) ([]transaction.Transaction, []uint64, error)
+	QueryAddressTransactions(address []byte, query blockchain.AddressTransactionsQuery) (blockchain.AddressTransactionsPage, error)
 	GetTransactionByHash(hash []byte) ([]transaction.Transaction, []uint64, error)
+	IsFinalized(blockNumber uint64) bool
 }
 
 // NetworkMessagePublisher is a pub sub message broadcaster.
@@ -30,6 +34,8 @@ type NetworkMessagePublisher interface {
 // TransactionsResponse represents a response with block and transaction
 type TransactionsResponse struct {
 	Transactions []JSONBlockTransaction `json:"transactions"`
+	// NextCursor is set when there are more transactions to query.
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 // TransactionResponse represents a response with a transaction.
@@ -41,6 +47,7 @@ type TransactionResponse struct {
 type JSONBlockTransaction struct {
 	BlockNumber uint64          `json:"block_number"`
 	Transaction JSONTransaction `json:"transaction"`
+	Finalized   bool            `json:"finalized"`
 }
 
 // JSONTransaction represents a json transaction.
@@ -56,6 +63,8 @@ type JSONTransaction struct {
 	Value           string `json:"value"`
 	TransactionFees string `json:"transaction_fees"`
 	Chain           string `json:"chain"`
+	ValidAfter      string `json:"valid_after,omitempty"`
+	ValidUntil      string `json:"valid_until,omitempty"`
 }
 
 // TransactionAPI represents the transaction rpc service.
@@ -64,10 +73,12 @@ type TransactionAPI struct {
 	publisher      NetworkMessagePublisher
 	blockchain     Blockchain
 	superLightNode bool
+	// minRelayFeePerByte is the minimum fees per byte of the transactions sent to the network.
+	minRelayFeePerByte *big.Int
 }
 
 // NewTransactionAPI creates a new transaction API to be served using JSONRPC.
-func NewTransactionAPI(keystore keystore.KeyAuthorizer, publisher NetworkMessagePublisher, blockchain Blockchain, superLightNode bool) (*TransactionAPI, error) {
+func NewTransactionAPI(keystore keystore.KeyAuthorizer, publisher NetworkMessagePublisher, blockchain Blockchain, superLightNode bool, minRelayFeePerByte *big.Int) (*TransactionAPI, error) {
 	if keystore == nil {
 		return nil, errors.New("keystore is nil")
 	}
@@ -81,10 +92,11 @@ func NewTransactionAPI(keystore keystore.KeyAuthorizer, publisher NetworkMessage
 	}
 
 	return &TransactionAPI{
-		keystore:       keystore,
-		publisher:      publisher,
-		blockchain:     blockchain,
-		superLightNode: superLightNode,
+		keystore:           keystore,
+		publisher:          publisher,
+		blockchain:         blockchain,
+		superLightNode:     superLightNode,
+		minRelayFeePerByte: minRelayFeePerByte,
 	}, nil
 }
 
@@ -95,44 +107,68 @@ type SendRawTransactionArgs struct {
 
 // SendRawTransaction sends a raw transaction.
 func (api *TransactionAPI) SendRawTransaction(r *http.Request, args *SendRawTransactionArgs, response *TransactionResponse) error {
+	tx, err := ParseRawTransaction(args.RawTransaction)
+	if err != nil {
+		return err
+	}
+
+	ok, err := tx.Validate()
+	if err != nil {
+		return fmt.Errorf("failed to validate raw transaction: %w", err)
+	}
+
+	if !ok {
+		return errors.New("failed to validate raw transaction with false result")
+	}
+
+	return api.validateBroadcastTxSetResponse(r.Context(), &tx, response)
+}
+
+// ParseRawTransaction parses a json transaction.
+func ParseRawTransaction(rawTransaction string) (transaction.Transaction, error) {
 	jsonTX := JSONTransaction{}
-	if err := json.Unmarshal([]byte(args.RawTransaction), &jsonTX); err != nil {
-		return fmt.Errorf("failed to unmarshal transaction: %w", err)
+	if err := json.Unmarshal([]byte(rawTransaction), &jsonTX); err != nil {
+		return transaction.Transaction{}, fmt.Errorf("failed to unmarshal transaction: %w", err)
 	}
 
 	txHash, err := hexutil.Decode(jsonTX.Hash)
 	if err != nil {
-		return fmt.Errorf("failed to decode transaction hash: %w", err)
+		return transaction.Transaction{}, fmt.Errorf("failed to decode transaction hash: %w", err)
 	}
 
 	txSig, err := hexutil.Decode(jsonTX.Signature)
 	if err != nil {
-		return fmt.Errorf("failed to decode transaction signature: %w", err)
+		return transaction.Transaction{}, fmt.Errorf("failed to decode transaction signature: %w", err)
 	}
 
 	txPublicKey, err := hexutil.Decode(jsonTX.PublicKey)
 	if err != nil {
-		return fmt.Errorf("failed to decode transaction public key: %w", err)
+		return transaction.Transaction{}, fmt.Errorf("failed to decode transaction public key: %w", err)
 	}
 
 	txNounce, err := hexutil.DecodeUint64(jsonTX.Nounce)
 	if err != nil {
-		return fmt.Errorf("failed to decode transaction nounce: %w", err)
+		return transaction.Transaction{}, fmt.Errorf("failed to decode transaction nounce: %w", err)
 	}
 
 	txNounceBytes := hexutil.EncodeUint64ToBytes(txNounce)
 
 	txData, err := hexutil.Decode(jsonTX.Data)
 	if err != nil {
-		return fmt.Errorf("failed to decode transaction nounce: %w", err)
+		return transaction.Transaction{}, fmt.Errorf("failed to decode transaction nounce: %w", err)
 	}
 
 	txChain, err := hexutil.Decode(jsonTX.Chain)
 	if err != nil {
-		return fmt.Errorf("failed to decode transaction chain: %w", err)
+		return transaction.Transaction{}, fmt.Errorf("failed to decode transaction chain: %w", err)
 	}
 
-	tx := transaction.Transaction{
+	validAfter, validUntil, err := decodeValidityWindow(jsonTX.ValidAfter, jsonTX.ValidUntil)
+	if err != nil {
+		return transaction.Transaction{}, err
+	}
+
+	return transaction.Transaction{
 		Hash:            txHash,
 		Signature:       txSig,
 		PublicKey:       txPublicKey,
@@ -143,18 +179,31 @@ func (api *TransactionAPI) SendRawTransaction(r *http.Request, args *SendRawTran
 		Value:           jsonTX.Value,
 		TransactionFees: jsonTX.TransactionFees,
 		Chain:           txChain,
-	}
+		ValidAfter:      validAfter,
+		ValidUntil:      validUntil,
+	}, nil
+}
 
-	ok, err := tx.Validate()
-	if err != nil {
-		return fmt.Errorf("failed to validate raw transaction: %w", err)
+// decodeValidityWindow decodes the optional validity window of a transaction.
+func decodeValidityWindow(validAfter, validUntil string) (uint64, uint64, error) {
+	after := uint64(0)
+	if validAfter != "" {
+		v, err := hexutil.DecodeUint64(validAfter)
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to decode transaction valid after: %w", err)
+		}
+		after = v
 	}
 
-	if !ok {
-		return errors.New("failed to validate raw transaction with false result")
+	until := uint64(0)
+	if validUntil != "" {
+		v, err := hexutil.DecodeUint64(validUntil)
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to decode transaction valid until: %w", err)
+		}
+		until = v
 	}
-
-	return api.validateBroadcastTxSetResponse(r.Context(), &tx, response)
+	return after, until, nil
 }
 
 func (api *TransactionAPI) validateBroadcastTxSetResponse(ctx context.Context, tx *transaction.Transaction, response *TransactionResponse) error {
@@ -163,6 +212,11 @@ func (api *TransactionAPI) validateBroadcastTxSetResponse(ctx context.Context, t
 		return fmt.Errorf("failed to validate transaction: %w", err)
 	}
 
+	// super light nodes don't have a mempool but shouldn't relay underpriced transactions either
+	if err := mempool.ValidateRelayFee(*tx, api.minRelayFeePerByte); err != nil {
+		return fmt.Errorf("failed to validate transaction fees: %w", err)
+	}
+
 	if !api.superLightNode {
 		if err := api.blockchain.PutMemPool(*tx); err != nil {
 			return fmt.Errorf("failed to insert transaction from rpc method to mempool: %w", err)
@@ -197,6 +251,9 @@ type SendTransactionArgs struct {
 	To              string `json:"to"`
 	Value           string `json:"value"`
 	TransactionFees string `json:"transaction_fees"`
+	// ValidAfter and ValidUntil are the optional block numbers of the validity window.
+	ValidAfter string `json:"valid_after"`
+	ValidUntil string `json:"valid_until"`
 }
 
 // SendTransaction sends a transaction.
@@ -232,6 +289,11 @@ func (api *TransactionAPI) SendTransaction(r *http.Request, args *SendTransactio
 		return fmt.Errorf("failed to get public key of unlocked account: %w", err)
 	}
 
+	validAfter, validUntil, err := decodeValidityWindow(args.ValidAfter, args.ValidUntil)
+	if err != nil {
+		return err
+	}
+
 	tx := transaction.Transaction{
 		PublicKey:       publicKeyBytes,
 		Nounce:          txNounceBytes,
@@ -241,6 +303,8 @@ func (api *TransactionAPI) SendTransaction(r *http.Request, args *SendTransactio
 		Value:           args.Value,
 		TransactionFees: args.TransactionFees,
 		Chain:           mainChain,
+		ValidAfter:      validAfter,
+		ValidUntil:      validUntil,
 	}
 
 	if err := tx.Sign(unlockedKey.Key.PrivateKey); err != nil {
@@ -250,6 +314,162 @@ func (api *TransactionAPI) SendTransaction(r *http.Request, args *SendTransactio
 	return api.validateBroadcastTxSetResponse(r.Context(), &tx, response)
 }
 
+// JSONBatchTransfer is a transfer to one recipient of a batch transfer.
+type JSONBatchTransfer struct {
+	To    string `json:"to"`
+	Value string `json:"value"`
+}
+
+// SendBatchTransactionArgs represents the arguments for sending a batch transfer using the client keystore mechanism.
+type SendBatchTransactionArgs struct {
+	AccessToken     string              `json:"access_token"`
+	Nounce          string              `json:"nounce"`
+	From            string              `json:"from"`
+	Transfers       []JSONBatchTransfer `json:"transfers"`
+	TransactionFees string              `json:"transaction_fees"`
+	ValidAfter      string              `json:"valid_after"`
+	ValidUntil      string              `json:"valid_until"`
+}
+
+// SendBatchTransaction sends the transfers to many recipients in a single transaction with one nounce and fee.
+func (api *TransactionAPI) SendBatchTransaction(r *http.Request, args *SendBatchTransactionArgs, response *TransactionResponse) error {
+	batchData := &BatchTransferDataResponse{}
+	if err := api.BatchTransferData(r, &BatchTransferDataArgs{Transfers: args.Transfers}, batchData); err != nil {
+		return err
+	}
+
+	return api.SendTransaction(r, &SendTransactionArgs{
+		AccessToken:     args.AccessToken,
+		Nounce:          args.Nounce,
+		Data:            batchData.Data,
+		From:            args.From,
+		To:              args.From,
+		Value:           batchData.Value,
+		TransactionFees: args.TransactionFees,
+		ValidAfter:      args.ValidAfter,
+		ValidUntil:      args.ValidUntil,
+	}, response)
+}
+
+// BatchTransferDataArgs represents the transfers of a batch transfer.
+type BatchTransferDataArgs struct {
+	Transfers []JSONBatchTransfer `json:"transfers"`
+}
+
+// BatchTransferDataResponse contains the data and value of a batch transfer transaction.
+// the transaction is sent from and to the sender's address.
+type BatchTransferDataResponse struct {
+	Data  string `json:"data"`
+	Value string `json:"value"`
+}
+
+// BatchTransferData builds the data payload and the value of a batch transfer transaction, which can be signed offline.
+func (api *TransactionAPI) BatchTransferData(r *http.Request, args *BatchTransferDataArgs, response *BatchTransferDataResponse) error {
+	transfers := make([]transaction.BatchTransfer, len(args.Transfers))
+	for i, v := range args.Transfers {
+		transfers[i] = transaction.BatchTransfer{To: v.To, Value: v.Value}
+	}
+
+	data, total, err := transaction.NewBatchTransferData(transfers)
+	if err != nil {
+		return fmt.Errorf("failed to create batch transfer: %w", err)
+	}
+
+	response.Data = hexutil.Encode(data)
+	response.Value = hexutil.EncodeBig(total)
+	return nil
+}
+
+// JSONPartialSignature is a signature of one of the keys of a multi-signature account.
+type JSONPartialSignature struct {
+	PublicKey string `json:"public_key"`
+	Signature string `json:"signature"`
+}
+
+// SignMultiSigTransactionArgs represents the arguments for signing a multi-signature transaction with an unlocked key.
+type SignMultiSigTransactionArgs struct {
+	AccessToken    string `json:"access_token"`
+	RawTransaction string `json:"raw_transaction"`
+}
+
+// SignMultiSigTransaction returns the partial signature of an unlocked key for a multi-signature transaction.
+func (api *TransactionAPI) SignMultiSigTransaction(r *http.Request, args *SignMultiSigTransactionArgs, response *JSONPartialSignature) error {
+	if args.AccessToken == "" {
+		return errors.New("access token is empty")
+	}
+
+	ok, unlockedKey, err := api.keystore.Authorized(args.AccessToken)
+	if err != nil || !ok {
+		return errors.New("unauthorized access")
+	}
+
+	tx, err := ParseRawTransaction(args.RawTransaction)
+	if err != nil {
+		return err
+	}
+
+	sig, err := tx.PartialSign(unlockedKey.Key.PrivateKey)
+	if err != nil {
+		return fmt.Errorf("failed to sign multi-signature transaction: %w", err)
+	}
+
+	*response = ToJSONPartialSignature(sig)
+	return nil
+}
+
+// CombineMultiSigTransactionArgs represents the arguments for combining the partial signatures of a multi-signature transaction.
+type CombineMultiSigTransactionArgs struct {
+	RawTransaction string                 `json:"raw_transaction"`
+	Signatures     []JSONPartialSignature `json:"signatures"`
+}
+
+// CombineMultiSigTransactionResponse contains the signed raw transaction which can be sent with SendRawTransaction.
+type CombineMultiSigTransactionResponse struct {
+	RawTransaction string `json:"raw_transaction"`
+}
+
+// CombineMultiSigTransaction combines the partial signatures of a multi-signature transaction.
+func (api *TransactionAPI) CombineMultiSigTransaction(r *http.Request, args *CombineMultiSigTransactionArgs, response *CombineMultiSigTransactionResponse) error {
+	tx, err := ParseRawTransaction(args.RawTransaction)
+	if err != nil {
+		return err
+	}
+
+	signatures := make([]transaction.PartialSignature, len(args.Signatures))
+	for i, v := range args.Signatures {
+		publicKey, err := hexutil.Decode(v.PublicKey)
+		if err != nil {
+			return fmt.Errorf("failed to decode public key: %w", err)
+		}
+
+		sig, err := hexutil.Decode(v.Signature)
+		if err != nil {
+			return fmt.Errorf("failed to decode signature: %w", err)
+		}
+		signatures[i] = transaction.PartialSignature{PublicKey: publicKey, Signature: sig}
+	}
+
+	if err := tx.CombineSignatures(signatures); err != nil {
+		return fmt.Errorf("failed to combine signatures: %w", err)
+	}
+
+	data, err := json.Marshal(toJSONTransaction(tx))
+	if err != nil {
+		return fmt.Errorf("failed to marshal transaction: %w", err)
+	}
+
+	response.RawTransaction = string(data)
+	return nil
+}
+
+// ToJSONPartialSignature converts a partial signature to its json representation.
+func ToJSONPartialSignature(sig transaction.PartialSignature) JSONPartialSignature {
+	return JSONPartialSignature{
+		PublicKey: hexutil.Encode(sig.PublicKey),
+		Signature: hexutil.Encode(sig.Signature),
+	}
+}
+
 // MemPoolResponse represents the mempool hashes.
 type MemPoolResponse struct {
 	TransactionHashes []string `json:"transaction_hashes"`
@@ -289,6 +509,7 @@ func (api *TransactionAPI) Receipt(r *http.Request, args *ReceiptArgs, response
 		receipt := JSONBlockTransaction{
 			BlockNumber: blockNumbers[i],
 			Transaction: jtx,
+			Finalized:   api.blockchain.IsFinalized(blockNumbers[i]),
 		}
 		response.Transactions = append(response.Transactions, receipt)
 	}
@@ -299,34 +520,102 @@ func (api *TransactionAPI) Receipt(r *http.Request, args *ReceiptArgs, response
 // ByAddressArgs get transactions by address arguments.
 type ByAddressArgs struct {
 	Address string `json:"address"`
+	// Limit is the page size.
+	Limit int `json:"limit"`
+	// Cursor is the next cursor of a previous page.
+	Cursor string `json:"cursor"`
+	// Direction is "asc" for the oldest transactions first or "desc" for the newest first.
+	Direction string `json:"direction"`
+	FromBlock uint64 `json:"from_block"`
+	ToBlock   uint64 `json:"to_block"`
+	// Filter is "sent" or "received", all the transactions are returned if empty.
+	Filter string `json:"filter"`
+	// DataType is the data payload type of the transactions e.g. "CREATE_NODE".
+	DataType string `json:"data_type"`
 }
 
-// ByAddress gets the list of transactions by address.
+// ByAddress gets a page of the transactions of an address.
 func (api *TransactionAPI) ByAddress(r *http.Request, args *ByAddressArgs, response *TransactionsResponse) error {
 	addressBytes, err := hexutil.Decode(args.Address)
 	if err != nil {
 		return err
 	}
 
-	transactions, blockNumbers, err := api.blockchain.GetAddressTransactions(addressBytes)
+	query, err := toAddressTransactionsQuery(args)
+	if err != nil {
+		return err
+	}
+
+	page, err := api.blockchain.QueryAddressTransactions(addressBytes, query)
 	if err != nil {
 		return err
 	}
 	response.Transactions = make([]JSONBlockTransaction, 0)
-	for i, tx := range transactions {
+	for i, tx := range page.Transactions {
 		jtx := toJSONTransaction(tx)
 		receipt := JSONBlockTransaction{
-			BlockNumber: blockNumbers[i],
+			BlockNumber: page.BlockNumbers[i],
 			Transaction: jtx,
+			Finalized:   api.blockchain.IsFinalized(page.BlockNumbers[i]),
 		}
 		response.Transactions = append(response.Transactions, receipt)
 	}
 
+	if len(page.NextCursor) > 0 {
+		response.NextCursor = hexutil.Encode(page.NextCursor)
+	}
+
 	return nil
 }
 
+func toAddressTransactionsQuery(args *ByAddressArgs) (blockchain.AddressTransactionsQuery, error) {
+	query := blockchain.AddressTransactionsQuery{
+		Limit:     args.Limit,
+		FromBlock: args.FromBlock,
+		ToBlock:   args.ToBlock,
+	}
+
+	if args.Cursor != "" {
+		cursor, err := hexutil.Decode(args.Cursor)
+		if err != nil {
+			return query, fmt.Errorf("failed to decode cursor: %w", err)
+		}
+		query.Cursor = cursor
+	}
+
+	switch args.Direction {
+	case "", "asc":
+	case "desc":
+		query.Descending = true
+	default:
+		return query, fmt.Errorf("unknown direction %s", args.Direction)
+	}
+
+	switch args.Filter {
+	case "":
+		query.Flow = blockchain.AllTransactions
+	case "sent":
+		query.Flow = blockchain.SentTransactions
+	case "received":
+		query.Flow = blockchain.ReceivedTransactions
+	default:
+		return query, fmt.Errorf("unknown filter %s", args.Filter)
+	}
+
+	if args.DataType != "" {
+		dataType, ok := transaction.DataType_value[args.DataType]
+		if !ok {
+			return query, fmt.Errorf("unknown data type %s", args.DataType)
+		}
+		txDataType := transaction.DataType(dataType)
+		query.DataType = &txDataType
+	}
+
+	return query, nil
+}
+
 func toJSONTransaction(t transaction.Transaction) JSONTransaction {
-	return JSONTransaction{
+	jsonTx := JSONTransaction{
 		Hash:            hexutil.Encode(t.Hash),
 		Signature:       hexutil.Encode(t.Signature),
 		PublicKey:       hexutil.Encode(t.PublicKey),
@@ -338,4 +627,13 @@ func toJSONTransaction(t transaction.Transaction) JSONTransaction {
 		TransactionFees: t.TransactionFees,
 		Chain:           hexutil.Encode(t.Chain),
 	}
+
+	if t.ValidAfter > 0 {
+		jsonTx.ValidAfter = hexutil.EncodeUint64(t.ValidAfter)
+	}
+
+	if t.ValidUntil > 0 {
+		jsonTx.ValidUntil = hexutil.EncodeUint64(t.ValidUntil)
+	}
+	return jsonTx
 }
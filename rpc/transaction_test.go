@@ -3,11 +3,13 @@ package rpc
 import (
 	"context"
 	"encoding/json"
+	"math/big"
 	"net/http"
 	"testing"
 
 	"github.com/filefilego/filefilego/blockchain"
 	"github.com/filefilego/filefilego/common/hexutil"
+	ffgcrypto "github.com/filefilego/filefilego/crypto"
 	"github.com/filefilego/filefilego/keystore"
 	"github.com/filefilego/filefilego/node"
 	transaction "github.com/filefilego/filefilego/transaction"
@@ -45,7 +47,7 @@ func TestNewTransactionAPI(t *testing.T) {
 		tt := tt
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
-			api, err := NewTransactionAPI(tt.keystore, tt.publisher, tt.blockchain, false)
+			api, err := NewTransactionAPI(tt.keystore, tt.publisher, tt.blockchain, false, nil)
 			if tt.expErr != "" {
 				assert.Nil(t, api)
 				assert.EqualError(t, err, tt.expErr)
@@ -69,7 +71,7 @@ func TestTransactionAPIMethods(t *testing.T) {
 	ks := keyAuthorizerStub{ok: true, key: unlockedKey}
 
 	bchain := &blockchainStub{}
-	transactionAPI, err := NewTransactionAPI(&ks, &networkMessagePublisherStub{}, bchain, false)
+	transactionAPI, err := NewTransactionAPI(&ks, &networkMessagePublisherStub{}, bchain, false, nil)
 	assert.NoError(t, err)
 
 	validTx, kp := validTransaction(t)
@@ -106,6 +108,12 @@ func TestTransactionAPIMethods(t *testing.T) {
 	err = transactionAPI.SendRawTransaction(&http.Request{}, sendRawArgs2, sendRawResponse2)
 	assert.NoError(t, err)
 
+	// the raw tx doesn't pay the minimum relay fee
+	transactionAPI.minRelayFeePerByte = big.NewInt(1)
+	err = transactionAPI.SendRawTransaction(&http.Request{}, sendRawArgs2, sendRawResponse2)
+	assert.ErrorContains(t, err, "failed to validate transaction fees: transaction fees 1 are lower than the minimum relay fee")
+	transactionAPI.minRelayFeePerByte = nil
+
 	// wrong access token
 	sendTransactionArgs := &SendTransactionArgs{
 		AccessToken:     accessToken,
@@ -147,6 +155,13 @@ func TestTransactionAPIMethods(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, jsonTx, transactionsResponse.Transactions[0].Transaction)
 	assert.Equal(t, uint64(5), transactionsResponse.Transactions[0].BlockNumber)
+	assert.False(t, transactionsResponse.Transactions[0].Finalized)
+
+	// transactions of finalized blocks are flagged
+	bchain.finalizedNumber = 5
+	err = transactionAPI.Receipt(&http.Request{}, receiptArgs, transactionsResponse)
+	assert.NoError(t, err)
+	assert.True(t, transactionsResponse.Transactions[0].Finalized)
 
 	// ByAddress
 	// empty address
@@ -160,6 +175,166 @@ func TestTransactionAPIMethods(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, jsonTx, byAddressResponse.Transactions[0].Transaction)
 	assert.Equal(t, uint64(5), byAddressResponse.Transactions[0].BlockNumber)
+	assert.Empty(t, byAddressResponse.NextCursor)
+
+	// the next cursor is returned and the filters are passed to the blockchain
+	bchain.addressTransactionsNextCursor = []byte{1, 2}
+	byAddressArgs.Cursor = "0x0102"
+	byAddressArgs.Limit = 10
+	byAddressArgs.Direction = "desc"
+	byAddressArgs.Filter = "sent"
+	byAddressArgs.DataType = "CREATE_NODE"
+	err = transactionAPI.ByAddress(&http.Request{}, byAddressArgs, byAddressResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, "0x0102", byAddressResponse.NextCursor)
+	createNode := transaction.DataType_CREATE_NODE
+	assert.Equal(t, blockchain.AddressTransactionsQuery{
+		Limit:      10,
+		Cursor:     []byte{1, 2},
+		Descending: true,
+		Flow:       blockchain.SentTransactions,
+		DataType:   &createNode,
+	}, bchain.addressTransactionsQuery)
+}
+
+func TestTransactionAPIMultiSig(t *testing.T) {
+	keys := make([]*keystore.Key, 3)
+	publicKeys := make([]string, len(keys))
+	for i := range keys {
+		k, err := keystore.NewKey()
+		assert.NoError(t, err)
+		keys[i] = k
+		publicKeys[i], err = ffgcrypto.PublicKeyToHex(k.PublicKey)
+		assert.NoError(t, err)
+	}
+
+	addressAPI := &AddressAPI{}
+	multiSigResponse := &CreateMultiSigResponse{}
+	err := addressAPI.CreateMultiSig(&http.Request{}, &CreateMultiSigArgs{Threshold: 4, PublicKeys: publicKeys}, multiSigResponse)
+	assert.EqualError(t, err, "failed to create multi-signature public key: threshold 4 should be between 1 and the number of public keys 3")
+	err = addressAPI.CreateMultiSig(&http.Request{}, &CreateMultiSigArgs{Threshold: 2, PublicKeys: publicKeys}, multiSigResponse)
+	assert.NoError(t, err)
+
+	ks := keyAuthorizerStub{ok: true, key: keystore.UnlockedKey{Key: keys[0], JWT: "123"}}
+	transactionAPI, err := NewTransactionAPI(&ks, &networkMessagePublisherStub{}, &blockchainStub{}, false, nil)
+	assert.NoError(t, err)
+
+	unsignedTx := JSONTransaction{
+		Hash:            "0x",
+		Signature:       "0x",
+		PublicKey:       multiSigResponse.PublicKey,
+		Nounce:          "0x1",
+		Data:            "0x",
+		From:            multiSigResponse.Address,
+		To:              keys[0].Address,
+		Value:           "0x1",
+		TransactionFees: "0x1",
+		Chain:           transaction.ChainID,
+	}
+	rawTx, err := json.Marshal(unsignedTx)
+	assert.NoError(t, err)
+
+	// the first signature comes from an unlocked key
+	sig0 := &JSONPartialSignature{}
+	err = transactionAPI.SignMultiSigTransaction(&http.Request{}, &SignMultiSigTransactionArgs{AccessToken: "123", RawTransaction: string(rawTx)}, sig0)
+	assert.NoError(t, err)
+
+	// the second signature is made offline
+	tx, err := ParseRawTransaction(string(rawTx))
+	assert.NoError(t, err)
+	sig1, err := tx.PartialSign(keys[1].PrivateKey)
+	assert.NoError(t, err)
+
+	combineArgs := &CombineMultiSigTransactionArgs{RawTransaction: string(rawTx), Signatures: []JSONPartialSignature{*sig0}}
+	combineResponse := &CombineMultiSigTransactionResponse{}
+	err = transactionAPI.CombineMultiSigTransaction(&http.Request{}, combineArgs, combineResponse)
+	assert.EqualError(t, err, "failed to combine signatures: got 1 signatures but 2 are required")
+
+	combineArgs.Signatures = append(combineArgs.Signatures, ToJSONPartialSignature(sig1))
+	err = transactionAPI.CombineMultiSigTransaction(&http.Request{}, combineArgs, combineResponse)
+	assert.NoError(t, err)
+
+	sendRawResponse := &TransactionResponse{}
+	err = transactionAPI.SendRawTransaction(&http.Request{}, &SendRawTransactionArgs{RawTransaction: combineResponse.RawTransaction}, sendRawResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, multiSigResponse.Address, sendRawResponse.Transaction.From)
+}
+
+func TestTransactionAPIBatchTransfer(t *testing.T) {
+	key, err := keystore.NewKey()
+	assert.NoError(t, err)
+	ks := keyAuthorizerStub{ok: true, key: keystore.UnlockedKey{Key: key, JWT: "123"}}
+	transactionAPI, err := NewTransactionAPI(&ks, &networkMessagePublisherStub{}, &blockchainStub{}, false, nil)
+	assert.NoError(t, err)
+
+	transfers := []JSONBatchTransfer{
+		{To: "0x0101010101010101010101010101010101010101", Value: "0x1"},
+		{To: "0x0202020202020202020202020202020202020202", Value: "0x2"},
+	}
+
+	dataResponse := &BatchTransferDataResponse{}
+	err = transactionAPI.BatchTransferData(&http.Request{}, &BatchTransferDataArgs{}, dataResponse)
+	assert.EqualError(t, err, "failed to create batch transfer: batch transfer is empty")
+	err = transactionAPI.BatchTransferData(&http.Request{}, &BatchTransferDataArgs{Transfers: transfers}, dataResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, "0x3", dataResponse.Value)
+
+	response := &TransactionResponse{}
+	err = transactionAPI.SendBatchTransaction(&http.Request{}, &SendBatchTransactionArgs{
+		AccessToken:     "123",
+		Nounce:          "0x1",
+		From:            key.Address,
+		Transfers:       transfers,
+		TransactionFees: "0x1",
+	}, response)
+	assert.NoError(t, err)
+	assert.Equal(t, key.Address, response.Transaction.To)
+	assert.Equal(t, "0x3", response.Transaction.Value)
+	assert.Equal(t, dataResponse.Data, response.Transaction.Data)
+}
+
+func TestToAddressTransactionsQuery(t *testing.T) {
+	cases := map[string]struct {
+		args   ByAddressArgs
+		expErr string
+	}{
+		"invalid cursor": {
+			args:   ByAddressArgs{Cursor: "0x0"},
+			expErr: "failed to decode cursor: failed to decode hex string: encoding/hex: odd length hex string",
+		},
+		"unknown direction": {
+			args:   ByAddressArgs{Direction: "up"},
+			expErr: "unknown direction up",
+		},
+		"unknown filter": {
+			args:   ByAddressArgs{Filter: "burned"},
+			expErr: "unknown filter burned",
+		},
+		"unknown data type": {
+			args:   ByAddressArgs{DataType: "SOMETHING"},
+			expErr: "unknown data type SOMETHING",
+		},
+		"success": {
+			args: ByAddressArgs{Direction: "asc", Filter: "received", FromBlock: 1, ToBlock: 2},
+		},
+	}
+
+	for name, tt := range cases {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			query, err := toAddressTransactionsQuery(&tt.args)
+			if tt.expErr != "" {
+				assert.EqualError(t, err, tt.expErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, blockchain.AddressTransactionsQuery{
+					FromBlock: 1,
+					ToBlock:   2,
+					Flow:      blockchain.ReceivedTransactions,
+				}, query)
+			}
+		})
+	}
 }
 
 type keyAuthorizerStub struct {
@@ -187,10 +362,15 @@ type blockchainStub struct {
 	// GetTransactionsFromPool
 	mempool []transaction.Transaction
 
-	// GetAddressTransactions
+	// QueryAddressTransactions
 	addressTransactions             []transaction.Transaction
 	addressTransactionsBlockNumbers []uint64
+	addressTransactionsNextCursor   []byte
+	addressTransactionsQuery        blockchain.AddressTransactionsQuery
 	addressTransactionsErr          error
+
+	// IsFinalized
+	finalizedNumber uint64
 }
 
 func (b *blockchainStub) PutMemPool(tx transaction.Transaction) error {
@@ -201,10 +381,19 @@ func (b *blockchainStub) GetTransactionsFromPool() []transaction.Transaction {
 	return b.mempool
 }
 
-func (b *blockchainStub) GetAddressTransactions(address []byte) ([]transaction.Transaction, []uint64, error) {
-	return b.addressTransactions, b.addressTransactionsBlockNumbers, b.addressTransactionsErr
+func (b *blockchainStub) QueryAddressTransactions(address []byte, query blockchain.AddressTransactionsQuery) (blockchain.AddressTransactionsPage, error) {
+	b.addressTransactionsQuery = query
+	return blockchain.AddressTransactionsPage{
+		Transactions: b.addressTransactions,
+		BlockNumbers: b.addressTransactionsBlockNumbers,
+		NextCursor:   b.addressTransactionsNextCursor,
+	}, b.addressTransactionsErr
 }
 
 func (b *blockchainStub) GetTransactionByHash(hash []byte) ([]transaction.Transaction, []uint64, error) {
 	return b.addressTransactions, b.addressTransactionsBlockNumbers, b.addressTransactionsErr
 }
+
+func (b *blockchainStub) IsFinalized(blockNumber uint64) bool {
+	return blockNumber <= b.finalizedNumber
+}
@@ -12,6 +12,9 @@ import (
 	"github.com/microcosm-cc/bluemonday"
 )
 
+// clearBatchSize is the number of items deleted at once when clearing the index.
+const clearBatchSize = 1000
+
 // NewBleeveSearch is used to represent internals of bleve.
 type BleveSearch struct {
 	index bleve.Index
@@ -99,6 +102,33 @@ func (b *BleveSearch) Index(item IndexItem) error {
 	return b.index.Index(item.Hash, item)
 }
 
+// Delete an item from the index.
+func (b *BleveSearch) Delete(hash string) error {
+	return b.index.Delete(hash)
+}
+
+// Clear removes every item from the index.
+func (b *BleveSearch) Clear() error {
+	for {
+		searchRequest := bleve.NewSearchRequestOptions(bleve.NewMatchAllQuery(), clearBatchSize, 0, false)
+		cursor, err := b.index.Search(searchRequest)
+		if err != nil {
+			return fmt.Errorf("failed to list indexed items: %w", err)
+		}
+		if len(cursor.Hits) == 0 {
+			return nil
+		}
+
+		batch := b.index.NewBatch()
+		for _, v := range cursor.Hits {
+			batch.Delete(v.ID)
+		}
+		if err := b.index.Batch(batch); err != nil {
+			return fmt.Errorf("failed to delete indexed items: %w", err)
+		}
+	}
+}
+
 // prepareIndexingText takes care of inputs with dates and versions and makes them indexable
 func prepareIndexingText(name string) string {
 	versionsAndDates := []string{}
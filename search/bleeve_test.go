@@ -83,6 +83,52 @@ func TestBleeveSearch(t *testing.T) {
 	}
 }
 
+func TestBleeveDelete(t *testing.T) {
+	bleveEngine, err := NewBleveSearch("delete.bin")
+	assert.Nil(t, err)
+	t.Cleanup(func() {
+		bleveEngine.Close()
+		os.RemoveAll("delete.bin")
+	})
+	indexItem(t, bleveEngine)
+
+	results, err := bleveEngine.Search(context.TODO(), "title", 10, 0, AnyTermRequired)
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+
+	err = bleveEngine.Delete("233")
+	assert.NoError(t, err)
+
+	results, err = bleveEngine.Search(context.TODO(), "title", 10, 0, AnyTermRequired)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"123"}, results)
+
+	// deleting a non existing item is not an error
+	err = bleveEngine.Delete("999")
+	assert.NoError(t, err)
+}
+
+func TestBleeveClear(t *testing.T) {
+	bleveEngine, err := NewBleveSearch("clear.bin")
+	assert.Nil(t, err)
+	t.Cleanup(func() {
+		bleveEngine.Close()
+		os.RemoveAll("clear.bin")
+	})
+	indexItem(t, bleveEngine)
+
+	err = bleveEngine.Clear()
+	assert.NoError(t, err)
+
+	results, err := bleveEngine.Search(context.TODO(), "title", 10, 0, AnyTermRequired)
+	assert.NoError(t, err)
+	assert.Empty(t, results)
+
+	// clearing an empty index is not an error
+	err = bleveEngine.Clear()
+	assert.NoError(t, err)
+}
+
 func indexItem(t *testing.T, bleveEngine *BleveSearch) {
 	err := bleveEngine.Index(IndexItem{
 		Hash:        "123",
@@ -28,6 +28,8 @@ type IndexItem struct {
 // IndexSearcher provides searching and indexing functionality.
 type IndexSearcher interface {
 	Index(item IndexItem) error
+	Delete(hash string) error
+	Clear() error
 	Search(ctx context.Context, query string, size, currentPage int, searchType Type) ([]string, error)
 	Close() error
 }
@@ -63,6 +65,16 @@ func (s *Search) Index(item IndexItem) error {
 	return s.engine.Index(item)
 }
 
+// Delete removes an item from the index.
+func (s *Search) Delete(hash string) error {
+	return s.engine.Delete(hash)
+}
+
+// Clear removes every item from the index.
+func (s *Search) Clear() error {
+	return s.engine.Clear()
+}
+
 // Close implements closing the db.
 func (s *Search) Close() error {
 	return s.engine.Close()
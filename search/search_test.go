@@ -137,6 +137,14 @@ func (e engineStub) Index(item IndexItem) error {
 	return e.indexingErr
 }
 
+func (e engineStub) Delete(hash string) error {
+	return e.indexingErr
+}
+
+func (e engineStub) Clear() error {
+	return e.indexingErr
+}
+
 func (e engineStub) Close() error {
 	return e.closingErr
 }
@@ -0,0 +1,134 @@
+package signer
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// challengeSize is the size of the random challenge of the handshake.
+	challengeSize = 32
+
+	// minSecretSize is the minimum size of the shared secret between the node and the signer.
+	minSecretSize = 16
+
+	// maxMessageSize is the maximum size of a request or response, which must fit a block.
+	maxMessageSize = 64 * 1024 * 1024
+)
+
+// the node and the signer prove that they know the shared secret by signing each other's random challenge.
+// the roles are part of the mac, so a response can't be reflected back to its sender.
+var (
+	clientRole = []byte("client")
+	serverRole = []byte("server")
+)
+
+// ValidateSecret checks the shared secret between the node and the signer.
+func ValidateSecret(secret []byte) error {
+	if len(secret) < minSecretSize {
+		return fmt.Errorf("signer secret should be at least %d bytes", minSecretSize)
+	}
+	return nil
+}
+
+// ReadSecret reads the shared secret from a file.
+func ReadSecret(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signer secret: %w", err)
+	}
+
+	secret := bytes.TrimSpace(data)
+	if err := ValidateSecret(secret); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+// newChallenge creates a random challenge.
+func newChallenge() ([]byte, error) {
+	challenge := make([]byte, challengeSize)
+	if _, err := rand.Read(challenge); err != nil {
+		return nil, fmt.Errorf("failed to create challenge: %w", err)
+	}
+	return challenge, nil
+}
+
+// challengeMAC returns the proof of a role for a challenge.
+func challengeMAC(secret, role, challenge []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(role)
+	mac.Write(challenge)
+	return mac.Sum(nil)
+}
+
+// verifyChallengeMAC checks the proof of a role for a challenge.
+func verifyChallengeMAC(secret, role, challenge, proof []byte) error {
+	if !hmac.Equal(challengeMAC(secret, role, challenge), proof) {
+		return errors.New("failed to authenticate signer connection")
+	}
+	return nil
+}
+
+// writeMessage writes a length prefixed proto message.
+func writeMessage(w io.Writer, msg proto.Message) error {
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	return writeFrame(w, data)
+}
+
+// readMessage reads a length prefixed proto message.
+func readMessage(r *bufio.Reader, msg proto.Message) error {
+	data, err := readFrame(r, maxMessageSize)
+	if err != nil {
+		return err
+	}
+
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return fmt.Errorf("failed to unmarshal message: %w", err)
+	}
+	return nil
+}
+
+// writeFrame writes data prefixed by its length.
+func writeFrame(w io.Writer, data []byte) error {
+	payloadWithLength := make([]byte, 8+len(data))
+	binary.LittleEndian.PutUint64(payloadWithLength, uint64(len(data)))
+	copy(payloadWithLength[8:], data)
+	if _, err := w.Write(payloadWithLength); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	return nil
+}
+
+// readFrame reads data prefixed by its length.
+func readFrame(r *bufio.Reader, maxSize uint64) ([]byte, error) {
+	lengthBuf := make([]byte, 8)
+	if _, err := io.ReadFull(r, lengthBuf); err != nil {
+		return nil, fmt.Errorf("failed to read message length: %w", err)
+	}
+
+	length := binary.LittleEndian.Uint64(lengthBuf)
+	if length > maxSize {
+		return nil, fmt.Errorf("message size is too large: %d", length)
+	}
+
+	buf := make([]byte, length)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return nil, fmt.Errorf("failed to read message: %w", err)
+	}
+	return buf, nil
+}
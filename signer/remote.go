@@ -0,0 +1,256 @@
+package signer
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/filefilego/filefilego/block"
+	"github.com/filefilego/filefilego/node/protocols/messages"
+	"github.com/filefilego/filefilego/transaction"
+	"google.golang.org/protobuf/proto"
+)
+
+// requestTimeout is the maximum duration of a request to the remote signer.
+const requestTimeout = 10 * time.Second
+
+// RemoteSigner signs through a signer daemon listening on a unix socket.
+// the connection is authenticated in both directions with the shared secret and reopened after a failure.
+type RemoteSigner struct {
+	socketPath string
+	secret     []byte
+
+	conn      net.Conn
+	reader    *bufio.Reader
+	publicKey []byte
+	mu        sync.Mutex
+}
+
+// NewRemoteSigner creates a remote signer.
+func NewRemoteSigner(socketPath string, secret []byte) (*RemoteSigner, error) {
+	if socketPath == "" {
+		return nil, errors.New("socket path is empty")
+	}
+
+	if err := ValidateSecret(secret); err != nil {
+		return nil, err
+	}
+
+	return &RemoteSigner{
+		socketPath: socketPath,
+		secret:     secret,
+	}, nil
+}
+
+// PublicKey returns the raw public key of the remote signer.
+func (s *RemoteSigner) PublicKey() ([]byte, error) {
+	s.mu.Lock()
+	publicKey := s.publicKey
+	s.mu.Unlock()
+	if publicKey != nil {
+		return publicKey, nil
+	}
+
+	publicKey, err := s.call(&SignRequestProto{Method: SignMethod_PUBLIC_KEY})
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.publicKey = publicKey
+	s.mu.Unlock()
+	return publicKey, nil
+}
+
+// SignBlock signs a block with the remote signer.
+func (s *RemoteSigner) SignBlock(blck *block.Block) error {
+	if _, err := hashBlock(blck); err != nil {
+		return err
+	}
+
+	payload, err := block.MarshalProtoBlock(block.ToProtoBlock(*blck))
+	if err != nil {
+		return fmt.Errorf("failed to marshal block: %w", err)
+	}
+
+	sig, err := s.call(&SignRequestProto{Method: SignMethod_SIGN_BLOCK, Payload: payload})
+	if err != nil {
+		return fmt.Errorf("failed to sign block: %w", err)
+	}
+
+	blck.Signature = sig
+	return nil
+}
+
+// DiscardBlock forgets the last signed block in the remote signer.
+func (s *RemoteSigner) DiscardBlock(blck *block.Block) error {
+	payload, err := block.MarshalProtoBlock(block.ToProtoBlock(*blck))
+	if err != nil {
+		return fmt.Errorf("failed to marshal block: %w", err)
+	}
+
+	if _, err := s.call(&SignRequestProto{Method: SignMethod_DISCARD_BLOCK, Payload: payload}); err != nil {
+		return fmt.Errorf("failed to discard block: %w", err)
+	}
+	return nil
+}
+
+// SignCoinbaseTransaction signs the coinbase transaction of a block with the remote signer.
+func (s *RemoteSigner) SignCoinbaseTransaction(tx *transaction.Transaction, blockNumber uint64) error {
+	hash, err := tx.CalculateHash()
+	if err != nil {
+		return fmt.Errorf("failed to get transactionHash: %w", err)
+	}
+	tx.Hash = hash
+
+	payload, err := proto.Marshal(transaction.ToProtoTransaction(*tx))
+	if err != nil {
+		return fmt.Errorf("failed to marshal transaction: %w", err)
+	}
+
+	sig, err := s.call(&SignRequestProto{Method: SignMethod_SIGN_COINBASE_TRANSACTION, Payload: payload, BlockNumber: blockNumber})
+	if err != nil {
+		return fmt.Errorf("failed to sign transaction: %w", err)
+	}
+
+	tx.Signature = sig
+	return nil
+}
+
+// SignCheckpoint signs a checkpoint attestation with the remote signer.
+func (s *RemoteSigner) SignCheckpoint(blockNumber uint64, blockHash []byte) ([]byte, error) {
+	payload, err := proto.Marshal(&messages.CheckpointAttestationProto{
+		BlockNumber: blockNumber,
+		BlockHash:   blockHash,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal checkpoint: %w", err)
+	}
+
+	sig, err := s.call(&SignRequestProto{Method: SignMethod_SIGN_CHECKPOINT, Payload: payload})
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign checkpoint: %w", err)
+	}
+	return sig, nil
+}
+
+// SignSnapshotManifest signs a snapshot manifest with the remote signer.
+func (s *RemoteSigner) SignSnapshotManifest(manifest *messages.SnapshotManifestProto) error {
+	publicKey, err := s.PublicKey()
+	if err != nil {
+		return fmt.Errorf("failed to get public key: %w", err)
+	}
+
+	payload, err := proto.Marshal(manifest)
+	if err != nil {
+		return fmt.Errorf("failed to marshal snapshot manifest: %w", err)
+	}
+
+	sig, err := s.call(&SignRequestProto{Method: SignMethod_SIGN_SNAPSHOT_MANIFEST, Payload: payload})
+	if err != nil {
+		return fmt.Errorf("failed to sign snapshot manifest: %w", err)
+	}
+
+	manifest.PublicKey = publicKey
+	manifest.Signature = sig
+	return nil
+}
+
+// Close closes the connection to the remote signer.
+func (s *RemoteSigner) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+// call sends a request to the remote signer and returns the response payload.
+func (s *RemoteSigner) call(request *SignRequestProto) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		if err := s.connect(); err != nil {
+			return nil, err
+		}
+	}
+
+	response := SignResponseProto{}
+	err := s.conn.SetDeadline(time.Now().Add(requestTimeout))
+	if err == nil {
+		err = writeMessage(s.conn, request)
+	}
+	if err == nil {
+		err = readMessage(s.reader, &response)
+	}
+	if err != nil {
+		// the connection can't be reused after a partial request or response.
+		s.conn.Close()
+		s.conn = nil
+		return nil, fmt.Errorf("failed to call remote signer: %w", err)
+	}
+
+	if response.Error != "" {
+		return nil, errors.New(response.Error)
+	}
+	return response.Payload, nil
+}
+
+// connect opens and authenticates a connection to the remote signer.
+func (s *RemoteSigner) connect() error {
+	conn, err := net.DialTimeout("unix", s.socketPath, requestTimeout)
+	if err != nil {
+		return fmt.Errorf("failed to connect to remote signer: %w", err)
+	}
+
+	r := bufio.NewReader(conn)
+	if err := s.authenticate(conn, r); err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to authenticate remote signer: %w", err)
+	}
+
+	s.conn = conn
+	s.reader = r
+	return nil
+}
+
+// authenticate performs the client side of the handshake.
+func (s *RemoteSigner) authenticate(conn net.Conn, r *bufio.Reader) error {
+	if err := conn.SetDeadline(time.Now().Add(handshakeTimeout)); err != nil {
+		return fmt.Errorf("failed to set handshake deadline: %w", err)
+	}
+
+	serverChallenge, err := readFrame(r, challengeSize)
+	if err != nil {
+		return err
+	}
+
+	if err := writeFrame(conn, challengeMAC(s.secret, clientRole, serverChallenge)); err != nil {
+		return err
+	}
+
+	challenge, err := newChallenge()
+	if err != nil {
+		return err
+	}
+
+	if err := writeFrame(conn, challenge); err != nil {
+		return err
+	}
+
+	proof, err := readFrame(r, sha256.Size)
+	if err != nil {
+		return err
+	}
+
+	return verifyChallengeMAC(s.secret, serverRole, challenge, proof)
+}
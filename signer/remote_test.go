@@ -0,0 +1,82 @@
+package signer
+
+import (
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/filefilego/filefilego/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRemoteSigner(t *testing.T) {
+	cases := map[string]struct {
+		socketPath string
+		secret     []byte
+		expErr     string
+	}{
+		"empty socket path": {
+			secret: []byte("0123456789abcdef"),
+			expErr: "socket path is empty",
+		},
+		"short secret": {
+			socketPath: "signer.sock",
+			secret:     []byte("secret"),
+			expErr:     "signer secret should be at least 16 bytes",
+		},
+		"success": {
+			socketPath: "signer.sock",
+			secret:     []byte("0123456789abcdef"),
+		},
+	}
+
+	for name, tt := range cases {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			signer, err := NewRemoteSigner(tt.socketPath, tt.secret)
+			if tt.expErr != "" {
+				assert.EqualError(t, err, tt.expErr)
+				assert.Nil(t, signer)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, signer)
+			}
+		})
+	}
+}
+
+func TestRemoteSigner(t *testing.T) {
+	kp, err := crypto.GenerateKeyPair()
+	assert.NoError(t, err)
+	localSigner, err := NewLocalSigner(kp.PrivateKey, "")
+	assert.NoError(t, err)
+	secret := []byte("0123456789abcdef")
+	server, err := NewServer(localSigner, secret)
+	assert.NoError(t, err)
+
+	socketPath := filepath.Join(t.TempDir(), "signer.sock")
+	listener, err := net.Listen("unix", socketPath)
+	assert.NoError(t, err)
+	done := make(chan error)
+	go func() {
+		done <- server.Serve(listener)
+	}()
+
+	// a node with another secret can't use the signer
+	wrongSigner, err := NewRemoteSigner(socketPath, []byte("fedcba9876543210"))
+	assert.NoError(t, err)
+	_, err = wrongSigner.PublicKey()
+	assert.ErrorContains(t, err, "failed to authenticate remote signer")
+
+	remoteSigner, err := NewRemoteSigner(socketPath, secret)
+	assert.NoError(t, err)
+	testSigner(t, remoteSigner, kp)
+
+	// the connection is opened again after it's closed
+	assert.NoError(t, remoteSigner.Close())
+	_, err = remoteSigner.SignCheckpoint(20, []byte{1})
+	assert.EqualError(t, err, "failed to sign checkpoint: a different checkpoint was already signed at height 20")
+
+	assert.NoError(t, listener.Close())
+	assert.NoError(t, <-done)
+}
@@ -0,0 +1,194 @@
+package signer
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	"github.com/filefilego/filefilego/block"
+	"github.com/filefilego/filefilego/node/protocols/messages"
+	"github.com/filefilego/filefilego/transaction"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
+)
+
+// handshakeTimeout is the time a connection has to authenticate.
+const handshakeTimeout = 10 * time.Second
+
+// Server serves a signer to the nodes which know the shared secret.
+type Server struct {
+	signer Signer
+	secret []byte
+}
+
+// NewServer creates a signer server.
+func NewServer(signer Signer, secret []byte) (*Server, error) {
+	if signer == nil {
+		return nil, errors.New("signer is nil")
+	}
+
+	if err := ValidateSecret(secret); err != nil {
+		return nil, err
+	}
+
+	return &Server{
+		signer: signer,
+		secret: secret,
+	}, nil
+}
+
+// Serve accepts connections until the listener is closed.
+func (s *Server) Serve(listener net.Listener) error {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return fmt.Errorf("failed to accept signer connection: %w", err)
+		}
+
+		go s.handleConn(conn)
+	}
+}
+
+// handleConn authenticates a connection and serves its requests one by one.
+func (s *Server) handleConn(conn net.Conn) {
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	if err := s.authenticate(conn, r); err != nil {
+		log.Warnf("signer connection rejected: %v", err)
+		return
+	}
+
+	for {
+		request := SignRequestProto{}
+		if err := readMessage(r, &request); err != nil {
+			if !errors.Is(err, io.EOF) {
+				log.Warnf("failed to read signer request: %v", err)
+			}
+			return
+		}
+
+		response := SignResponseProto{}
+		payload, err := s.sign(&request)
+		if err != nil {
+			log.Warnf("refused %s request: %v", request.Method, err)
+			response.Error = err.Error()
+		} else {
+			response.Payload = payload
+		}
+
+		if err := writeMessage(conn, &response); err != nil {
+			log.Warnf("failed to write signer response: %v", err)
+			return
+		}
+	}
+}
+
+// authenticate performs the server side of the handshake.
+func (s *Server) authenticate(conn net.Conn, r *bufio.Reader) error {
+	if err := conn.SetDeadline(time.Now().Add(handshakeTimeout)); err != nil {
+		return fmt.Errorf("failed to set handshake deadline: %w", err)
+	}
+
+	challenge, err := newChallenge()
+	if err != nil {
+		return err
+	}
+
+	if err := writeFrame(conn, challenge); err != nil {
+		return err
+	}
+
+	proof, err := readFrame(r, sha256.Size)
+	if err != nil {
+		return err
+	}
+
+	if err := verifyChallengeMAC(s.secret, clientRole, challenge, proof); err != nil {
+		return err
+	}
+
+	clientChallenge, err := readFrame(r, challengeSize)
+	if err != nil {
+		return err
+	}
+
+	if err := writeFrame(conn, challengeMAC(s.secret, serverRole, clientChallenge)); err != nil {
+		return err
+	}
+
+	return conn.SetDeadline(time.Time{})
+}
+
+// sign performs a request with the signer.
+func (s *Server) sign(request *SignRequestProto) ([]byte, error) {
+	switch request.Method {
+	case SignMethod_PUBLIC_KEY:
+		return s.signer.PublicKey()
+
+	case SignMethod_SIGN_BLOCK:
+		protoBlock, err := block.UnmarshalProtoBlock(request.Payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal block: %w", err)
+		}
+
+		blck := block.ProtoBlockToBlock(protoBlock)
+		if err := s.signer.SignBlock(&blck); err != nil {
+			return nil, err
+		}
+		log.Infof("signed block %d", blck.Number)
+		return blck.Signature, nil
+
+	case SignMethod_DISCARD_BLOCK:
+		protoBlock, err := block.UnmarshalProtoBlock(request.Payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal block: %w", err)
+		}
+
+		blck := block.ProtoBlockToBlock(protoBlock)
+		if err := s.signer.DiscardBlock(&blck); err != nil {
+			return nil, err
+		}
+		log.Warnf("discarded unpublished block %d", blck.Number)
+		return nil, nil
+
+	case SignMethod_SIGN_COINBASE_TRANSACTION:
+		protoTx := transaction.ProtoTransaction{}
+		if err := proto.Unmarshal(request.Payload, &protoTx); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal transaction: %w", err)
+		}
+
+		tx := transaction.ProtoTransactionToTransaction(&protoTx)
+		if err := s.signer.SignCoinbaseTransaction(&tx, request.BlockNumber); err != nil {
+			return nil, err
+		}
+		return tx.Signature, nil
+
+	case SignMethod_SIGN_CHECKPOINT:
+		checkpoint := messages.CheckpointAttestationProto{}
+		if err := proto.Unmarshal(request.Payload, &checkpoint); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal checkpoint: %w", err)
+		}
+		return s.signer.SignCheckpoint(checkpoint.BlockNumber, checkpoint.BlockHash)
+
+	case SignMethod_SIGN_SNAPSHOT_MANIFEST:
+		manifest := messages.SnapshotManifestProto{}
+		if err := proto.Unmarshal(request.Payload, &manifest); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal snapshot manifest: %w", err)
+		}
+
+		if err := s.signer.SignSnapshotManifest(&manifest); err != nil {
+			return nil, err
+		}
+		return manifest.Signature, nil
+	}
+
+	return nil, fmt.Errorf("unknown sign method %d", request.Method)
+}
@@ -0,0 +1,301 @@
+package signer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/filefilego/filefilego/block"
+	"github.com/filefilego/filefilego/blockchain"
+	"github.com/filefilego/filefilego/common/hexutil"
+	ffgcrypto "github.com/filefilego/filefilego/crypto"
+	"github.com/filefilego/filefilego/node/protocols/messages"
+	"github.com/filefilego/filefilego/node/protocols/snapshot"
+	"github.com/filefilego/filefilego/transaction"
+	"github.com/libp2p/go-libp2p/core/crypto"
+)
+
+// DefaultStateFileName is the name of the file which keeps the last signed heights.
+const DefaultStateFileName = "signer_state.json"
+
+// Signer signs the blocks and messages of a verifier, so the private key can be kept outside of the network-facing process.
+// the signer computes the signed hashes itself and refuses to sign two different blocks or checkpoints at the same height.
+// the only transactions it signs are the coinbase transactions of the verifier, so the key can't be used to spend its funds.
+// the download contracts and fee releases of a data verifier are not signed by the signer. they are signed with the node identity key,
+// which libp2p keeps in the process to authenticate connections and from which the peers derive the peer id of the data verifier.
+type Signer interface {
+	PublicKey() ([]byte, error)
+	SignBlock(blck *block.Block) error
+	DiscardBlock(blck *block.Block) error
+	SignCoinbaseTransaction(tx *transaction.Transaction, blockNumber uint64) error
+	SignCheckpoint(blockNumber uint64, blockHash []byte) ([]byte, error)
+	SignSnapshotManifest(manifest *messages.SnapshotManifestProto) error
+}
+
+// signedHeight is the last height signed by the signer.
+type signedHeight struct {
+	Number uint64 `json:"number"`
+	Hash   string `json:"hash"`
+}
+
+// signerState is the double sign protection state of the signer.
+// the block signed before the last one is kept, so the last block can be discarded if it was never published.
+type signerState struct {
+	Block         *signedHeight `json:"block,omitempty"`
+	PreviousBlock *signedHeight `json:"previous_block,omitempty"`
+	Checkpoint    *signedHeight `json:"checkpoint,omitempty"`
+}
+
+// LocalSigner signs with a private key of the process.
+type LocalSigner struct {
+	privateKey crypto.PrivKey
+	statePath  string
+	state      signerState
+	mu         sync.Mutex
+}
+
+// NewLocalSigner creates a signer with a private key.
+// the last signed heights are persisted in the state file, so the protection survives restarts.
+// if the state path is empty, the state is kept in memory.
+func NewLocalSigner(privateKey crypto.PrivKey, statePath string) (*LocalSigner, error) {
+	if privateKey == nil {
+		return nil, errors.New("privateKey is nil")
+	}
+
+	s := &LocalSigner{
+		privateKey: privateKey,
+		statePath:  statePath,
+	}
+
+	if statePath == "" {
+		return s, nil
+	}
+
+	data, err := os.ReadFile(statePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signer state: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &s.state); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal signer state: %w", err)
+	}
+	return s, nil
+}
+
+// PublicKey returns the raw public key of the signer.
+func (s *LocalSigner) PublicKey() ([]byte, error) {
+	return s.privateKey.GetPublic().Raw()
+}
+
+// SignBlock signs a block unless a different block was signed at the same or a higher height.
+func (s *LocalSigner) SignBlock(blck *block.Block) error {
+	hash, err := hashBlock(blck)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := checkSignedHeight("block", s.state.Block, blck.Number, hash); err != nil {
+		return err
+	}
+
+	sig, err := s.privateKey.Sign(blck.SigningHash())
+	if err != nil {
+		return fmt.Errorf("failed to sign block: %w", err)
+	}
+
+	previous := s.state.Block
+	if previous != nil && previous.Number == blck.Number {
+		previous = s.state.PreviousBlock
+	}
+
+	err = s.saveState(signerState{
+		Block:         &signedHeight{Number: blck.Number, Hash: hexutil.Encode(hash)},
+		PreviousBlock: previous,
+		Checkpoint:    s.state.Checkpoint,
+	})
+	if err != nil {
+		return err
+	}
+
+	blck.Signature = sig
+	return nil
+}
+
+// DiscardBlock forgets the last signed block, so another block can be signed at its height.
+// it must only be called for a block which was never published, e.g. when the block couldn't be applied.
+func (s *LocalSigner) DiscardBlock(blck *block.Block) error {
+	hash, err := hashBlock(blck)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	last := s.state.Block
+	if last == nil || last.Number != blck.Number || last.Hash != hexutil.Encode(hash) {
+		return fmt.Errorf("block %d is not the last signed block", blck.Number)
+	}
+
+	return s.saveState(signerState{
+		Block:      s.state.PreviousBlock,
+		Checkpoint: s.state.Checkpoint,
+	})
+}
+
+// SignCoinbaseTransaction signs the coinbase transaction of a block.
+func (s *LocalSigner) SignCoinbaseTransaction(tx *transaction.Transaction, blockNumber uint64) error {
+	publicKey, err := s.PublicKey()
+	if err != nil {
+		return fmt.Errorf("failed to get public key: %w", err)
+	}
+
+	if err := validateCoinbaseTransaction(*tx, publicKey, blockNumber); err != nil {
+		return err
+	}
+	return tx.Sign(s.privateKey)
+}
+
+// SignCheckpoint signs a checkpoint attestation unless a different block was attested at the same or a higher height.
+func (s *LocalSigner) SignCheckpoint(blockNumber uint64, blockHash []byte) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := checkSignedHeight("checkpoint", s.state.Checkpoint, blockNumber, blockHash); err != nil {
+		return nil, err
+	}
+
+	sig, err := s.privateKey.Sign(blockchain.CheckpointHash(blockNumber, blockHash))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign checkpoint: %w", err)
+	}
+
+	err = s.saveState(signerState{
+		Block:         s.state.Block,
+		PreviousBlock: s.state.PreviousBlock,
+		Checkpoint:    &signedHeight{Number: blockNumber, Hash: hexutil.Encode(blockHash)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sig, nil
+}
+
+// SignSnapshotManifest signs a snapshot manifest.
+func (s *LocalSigner) SignSnapshotManifest(manifest *messages.SnapshotManifestProto) error {
+	return snapshot.SignManifest(manifest, s.privateKey)
+}
+
+// hashBlock sets the merkle hash and the hash of a block.
+func hashBlock(blck *block.Block) ([]byte, error) {
+	merkleHash, err := blck.GetMerkleHash()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block's merkle hash: %w", err)
+	}
+	blck.MerkleHash = merkleHash
+
+	hash, err := blck.GetBlockHash()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block's hash: %w", err)
+	}
+	blck.Hash = hash
+	return hash, nil
+}
+
+// validateCoinbaseTransaction checks that a transaction only pays the block reward to the signer itself.
+func validateCoinbaseTransaction(tx transaction.Transaction, publicKey []byte, blockNumber uint64) error {
+	if !bytes.Equal(tx.PublicKey, publicKey) {
+		return errors.New("coinbase transaction public key doesn't match the signer")
+	}
+
+	address, err := ffgcrypto.RawPublicToAddress(publicKey)
+	if err != nil {
+		return fmt.Errorf("failed to get address of signer: %w", err)
+	}
+
+	if tx.From != address || tx.To != address {
+		return errors.New("coinbase transaction should be sent from and to the signer address")
+	}
+
+	if len(tx.Data) > 0 {
+		return errors.New("coinbase transaction should not contain data")
+	}
+
+	if hexutil.DecodeBigFromBytesToUint64(tx.Nounce) != 0 {
+		return errors.New("coinbase transaction should have a zero nounce")
+	}
+
+	fees, err := hexutil.DecodeBig(tx.TransactionFees)
+	if err != nil {
+		return fmt.Errorf("failed to decode transaction fees: %w", err)
+	}
+
+	if fees.Sign() != 0 {
+		return errors.New("coinbase transaction fee should be zero")
+	}
+
+	value, err := hexutil.DecodeBig(tx.Value)
+	if err != nil {
+		return fmt.Errorf("failed to decode transaction value: %w", err)
+	}
+
+	reward, err := block.GetReward(blockNumber)
+	if err != nil {
+		return fmt.Errorf("failed to get block reward: %w", err)
+	}
+
+	if value.Cmp(reward) != 0 {
+		return fmt.Errorf("coinbase transaction value %s should be the block reward %s", value.Text(10), reward.Text(10))
+	}
+	return nil
+}
+
+// saveState persists the state before the signature is released.
+func (s *LocalSigner) saveState(state signerState) error {
+	if s.statePath != "" {
+		data, err := json.Marshal(state)
+		if err != nil {
+			return fmt.Errorf("failed to marshal signer state: %w", err)
+		}
+
+		// the state is replaced atomically so a crash can't leave a partial file.
+		tmpPath := filepath.Join(filepath.Dir(s.statePath), "."+filepath.Base(s.statePath)+".tmp")
+		if err := os.WriteFile(tmpPath, data, 0o600); err != nil {
+			return fmt.Errorf("failed to write signer state: %w", err)
+		}
+
+		if err := os.Rename(tmpPath, s.statePath); err != nil {
+			return fmt.Errorf("failed to replace signer state: %w", err)
+		}
+	}
+
+	s.state = state
+	return nil
+}
+
+// checkSignedHeight rejects signing a different hash at the last signed height or signing below it.
+// signing the same hash again is allowed.
+func checkSignedHeight(kind string, last *signedHeight, number uint64, hash []byte) error {
+	if last == nil {
+		return nil
+	}
+
+	if number < last.Number {
+		return fmt.Errorf("%s %d is below the last signed %s %d", kind, number, kind, last.Number)
+	}
+
+	if number == last.Number && last.Hash != hexutil.Encode(hash) {
+		return fmt.Errorf("a different %s was already signed at height %d", kind, number)
+	}
+	return nil
+}
@@ -0,0 +1,315 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.17.3
+// source: signer/signer.proto
+
+package signer
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// SignMethod represents the operation requested from a remote signer.
+type SignMethod int32
+
+const (
+	SignMethod_PUBLIC_KEY                SignMethod = 0
+	SignMethod_SIGN_BLOCK                SignMethod = 1
+	SignMethod_SIGN_COINBASE_TRANSACTION SignMethod = 2
+	SignMethod_SIGN_CHECKPOINT           SignMethod = 3
+	SignMethod_SIGN_SNAPSHOT_MANIFEST    SignMethod = 4
+	SignMethod_DISCARD_BLOCK             SignMethod = 5
+)
+
+// Enum value maps for SignMethod.
+var (
+	SignMethod_name = map[int32]string{
+		0: "PUBLIC_KEY",
+		1: "SIGN_BLOCK",
+		2: "SIGN_COINBASE_TRANSACTION",
+		3: "SIGN_CHECKPOINT",
+		4: "SIGN_SNAPSHOT_MANIFEST",
+		5: "DISCARD_BLOCK",
+	}
+	SignMethod_value = map[string]int32{
+		"PUBLIC_KEY":                0,
+		"SIGN_BLOCK":                1,
+		"SIGN_COINBASE_TRANSACTION": 2,
+		"SIGN_CHECKPOINT":           3,
+		"SIGN_SNAPSHOT_MANIFEST":    4,
+		"DISCARD_BLOCK":             5,
+	}
+)
+
+func (x SignMethod) Enum() *SignMethod {
+	p := new(SignMethod)
+	*p = x
+	return p
+}
+
+func (x SignMethod) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SignMethod) Descriptor() protoreflect.EnumDescriptor {
+	return file_signer_signer_proto_enumTypes[0].Descriptor()
+}
+
+func (SignMethod) Type() protoreflect.EnumType {
+	return &file_signer_signer_proto_enumTypes[0]
+}
+
+func (x SignMethod) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SignMethod.Descriptor instead.
+func (SignMethod) EnumDescriptor() ([]byte, []int) {
+	return file_signer_signer_proto_rawDescGZIP(), []int{0}
+}
+
+// SignRequestProto represents a request to a remote signer.
+type SignRequestProto struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Method SignMethod `protobuf:"varint,1,opt,name=method,proto3,enum=signer.SignMethod" json:"method,omitempty"`
+	// payload is the proto message to be signed.
+	Payload []byte `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+	// block_number is the number of the block which contains the coinbase transaction.
+	BlockNumber uint64 `protobuf:"varint,3,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+}
+
+func (x *SignRequestProto) Reset() {
+	*x = SignRequestProto{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_signer_signer_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignRequestProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignRequestProto) ProtoMessage() {}
+
+func (x *SignRequestProto) ProtoReflect() protoreflect.Message {
+	mi := &file_signer_signer_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignRequestProto.ProtoReflect.Descriptor instead.
+func (*SignRequestProto) Descriptor() ([]byte, []int) {
+	return file_signer_signer_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SignRequestProto) GetMethod() SignMethod {
+	if x != nil {
+		return x.Method
+	}
+	return SignMethod_PUBLIC_KEY
+}
+
+func (x *SignRequestProto) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *SignRequestProto) GetBlockNumber() uint64 {
+	if x != nil {
+		return x.BlockNumber
+	}
+	return 0
+}
+
+// SignResponseProto represents the response of a remote signer.
+type SignResponseProto struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// payload is the public key or the signature.
+	Payload []byte `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	Error   string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *SignResponseProto) Reset() {
+	*x = SignResponseProto{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_signer_signer_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignResponseProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignResponseProto) ProtoMessage() {}
+
+func (x *SignResponseProto) ProtoReflect() protoreflect.Message {
+	mi := &file_signer_signer_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignResponseProto.ProtoReflect.Descriptor instead.
+func (*SignResponseProto) Descriptor() ([]byte, []int) {
+	return file_signer_signer_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SignResponseProto) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *SignResponseProto) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+var File_signer_signer_proto protoreflect.FileDescriptor
+
+var file_signer_signer_proto_rawDesc = []byte{
+	0x0a, 0x13, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x2f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x22, 0x7b, 0x0a,
+	0x10, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x50, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x2a, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x12, 0x2e, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x4d,
+	0x65, 0x74, 0x68, 0x6f, 0x64, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x18, 0x0a,
+	0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07,
+	0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x6c, 0x6f, 0x63, 0x6b,
+	0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x62,
+	0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x43, 0x0a, 0x11, 0x53, 0x69,
+	0x67, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x18, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x2a,
+	0x8f, 0x01, 0x0a, 0x0a, 0x53, 0x69, 0x67, 0x6e, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x0e,
+	0x0a, 0x0a, 0x50, 0x55, 0x42, 0x4c, 0x49, 0x43, 0x5f, 0x4b, 0x45, 0x59, 0x10, 0x00, 0x12, 0x0e,
+	0x0a, 0x0a, 0x53, 0x49, 0x47, 0x4e, 0x5f, 0x42, 0x4c, 0x4f, 0x43, 0x4b, 0x10, 0x01, 0x12, 0x1d,
+	0x0a, 0x19, 0x53, 0x49, 0x47, 0x4e, 0x5f, 0x43, 0x4f, 0x49, 0x4e, 0x42, 0x41, 0x53, 0x45, 0x5f,
+	0x54, 0x52, 0x41, 0x4e, 0x53, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x02, 0x12, 0x13, 0x0a,
+	0x0f, 0x53, 0x49, 0x47, 0x4e, 0x5f, 0x43, 0x48, 0x45, 0x43, 0x4b, 0x50, 0x4f, 0x49, 0x4e, 0x54,
+	0x10, 0x03, 0x12, 0x1a, 0x0a, 0x16, 0x53, 0x49, 0x47, 0x4e, 0x5f, 0x53, 0x4e, 0x41, 0x50, 0x53,
+	0x48, 0x4f, 0x54, 0x5f, 0x4d, 0x41, 0x4e, 0x49, 0x46, 0x45, 0x53, 0x54, 0x10, 0x04, 0x12, 0x11,
+	0x0a, 0x0d, 0x44, 0x49, 0x53, 0x43, 0x41, 0x52, 0x44, 0x5f, 0x42, 0x4c, 0x4f, 0x43, 0x4b, 0x10,
+	0x05, 0x42, 0x29, 0x5a, 0x27, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x66, 0x69, 0x6c, 0x65, 0x66, 0x69, 0x6c, 0x65, 0x67, 0x6f, 0x2f, 0x66, 0x69, 0x6c, 0x65, 0x66,
+	0x69, 0x6c, 0x65, 0x67, 0x6f, 0x2f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_signer_signer_proto_rawDescOnce sync.Once
+	file_signer_signer_proto_rawDescData = file_signer_signer_proto_rawDesc
+)
+
+func file_signer_signer_proto_rawDescGZIP() []byte {
+	file_signer_signer_proto_rawDescOnce.Do(func() {
+		file_signer_signer_proto_rawDescData = protoimpl.X.CompressGZIP(file_signer_signer_proto_rawDescData)
+	})
+	return file_signer_signer_proto_rawDescData
+}
+
+var file_signer_signer_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_signer_signer_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_signer_signer_proto_goTypes = []interface{}{
+	(SignMethod)(0),           // 0: signer.SignMethod
+	(*SignRequestProto)(nil),  // 1: signer.SignRequestProto
+	(*SignResponseProto)(nil), // 2: signer.SignResponseProto
+}
+var file_signer_signer_proto_depIdxs = []int32{
+	0, // 0: signer.SignRequestProto.method:type_name -> signer.SignMethod
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_signer_signer_proto_init() }
+func file_signer_signer_proto_init() {
+	if File_signer_signer_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_signer_signer_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignRequestProto); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_signer_signer_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignResponseProto); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_signer_signer_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_signer_signer_proto_goTypes,
+		DependencyIndexes: file_signer_signer_proto_depIdxs,
+		EnumInfos:         file_signer_signer_proto_enumTypes,
+		MessageInfos:      file_signer_signer_proto_msgTypes,
+	}.Build()
+	File_signer_signer_proto = out.File
+	file_signer_signer_proto_rawDesc = nil
+	file_signer_signer_proto_goTypes = nil
+	file_signer_signer_proto_depIdxs = nil
+}
@@ -0,0 +1,189 @@
+package signer
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/filefilego/filefilego/block"
+	"github.com/filefilego/filefilego/blockchain"
+	"github.com/filefilego/filefilego/common/hexutil"
+	"github.com/filefilego/filefilego/crypto"
+	"github.com/filefilego/filefilego/node/protocols/messages"
+	"github.com/filefilego/filefilego/node/protocols/snapshot"
+	"github.com/filefilego/filefilego/transaction"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLocalSigner(t *testing.T) {
+	signer, err := NewLocalSigner(nil, "")
+	assert.EqualError(t, err, "privateKey is nil")
+	assert.Nil(t, signer)
+}
+
+func TestLocalSigner(t *testing.T) {
+	kp, err := crypto.GenerateKeyPair()
+	assert.NoError(t, err)
+	statePath := filepath.Join(t.TempDir(), DefaultStateFileName)
+	signer, err := NewLocalSigner(kp.PrivateKey, statePath)
+	assert.NoError(t, err)
+
+	testSigner(t, signer, kp)
+
+	// the last signed heights survive a restart
+	restarted, err := NewLocalSigner(kp.PrivateKey, statePath)
+	assert.NoError(t, err)
+	other := unsignedBlock(t, kp, 5)
+	other.Data = []byte{3}
+	assert.EqualError(t, restarted.SignBlock(&other), "a different block was already signed at height 5")
+	_, err = restarted.SignCheckpoint(20, []byte{2})
+	assert.EqualError(t, err, "a different checkpoint was already signed at height 20")
+}
+
+// testSigner checks the signatures and the double sign protection of a signer.
+func testSigner(t *testing.T, signer Signer, kp crypto.KeyPair) {
+	publicKey, err := signer.PublicKey()
+	assert.NoError(t, err)
+	expectedPublicKey, err := kp.PublicKey.Raw()
+	assert.NoError(t, err)
+	assert.Equal(t, expectedPublicKey, publicKey)
+
+	blck := unsignedBlock(t, kp, 5)
+	assert.NoError(t, signer.SignBlock(&blck))
+	assert.NoError(t, blck.VerifyWithPublicKey(kp.PublicKey))
+
+	// the same block can be signed again
+	again := unsignedBlock(t, kp, 5)
+	assert.NoError(t, signer.SignBlock(&again))
+	assert.Equal(t, blck.Hash, again.Hash)
+
+	cases := map[string]struct {
+		number uint64
+		data   []byte
+		expErr string
+	}{
+		"different block at the same height": {
+			number: 5,
+			data:   []byte{2},
+			expErr: "a different block was already signed at height 5",
+		},
+		"lower height": {
+			number: 4,
+			expErr: "block 4 is below the last signed block 5",
+		},
+	}
+
+	for name, tt := range cases {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			other := unsignedBlock(t, kp, tt.number)
+			other.Data = tt.data
+			assert.ErrorContains(t, signer.SignBlock(&other), tt.expErr)
+			assert.Empty(t, other.Signature)
+		})
+	}
+
+	// an unpublished block can be discarded, so another block can be signed at its height
+	other := unsignedBlock(t, kp, 5)
+	other.Data = []byte{2}
+	assert.ErrorContains(t, signer.DiscardBlock(&other), "block 5 is not the last signed block")
+	assert.NoError(t, signer.DiscardBlock(&blck))
+	assert.NoError(t, signer.SignBlock(&other))
+	assert.NoError(t, other.VerifyWithPublicKey(kp.PublicKey))
+
+	reward, err := block.GetReward(5)
+	assert.NoError(t, err)
+	coinbase := blck.Transactions[0]
+	coinbase.Signature = nil
+	coinbase.Value = hexutil.EncodeBig(reward)
+	assert.NoError(t, signer.SignCoinbaseTransaction(&coinbase, 5))
+	assert.NoError(t, coinbase.VerifyWithPublicKey(kp.PublicKey))
+
+	// only the coinbase transactions of the signer are signed
+	otherKp, err := crypto.GenerateKeyPair()
+	assert.NoError(t, err)
+	otherPublicKey, err := otherKp.PublicKey.Raw()
+	assert.NoError(t, err)
+	txCases := map[string]struct {
+		update func(tx *transaction.Transaction)
+		expErr string
+	}{
+		"other public key": {
+			update: func(tx *transaction.Transaction) { tx.PublicKey = otherPublicKey },
+			expErr: "coinbase transaction public key doesn't match the signer",
+		},
+		"transfer to another address": {
+			update: func(tx *transaction.Transaction) { tx.To = otherKp.Address },
+			expErr: "coinbase transaction should be sent from and to the signer address",
+		},
+		"data": {
+			update: func(tx *transaction.Transaction) { tx.Data = []byte{1} },
+			expErr: "coinbase transaction should not contain data",
+		},
+		"non zero nounce": {
+			update: func(tx *transaction.Transaction) { tx.Nounce = []byte{1} },
+			expErr: "coinbase transaction should have a zero nounce",
+		},
+		"fee": {
+			update: func(tx *transaction.Transaction) { tx.TransactionFees = "0x1" },
+			expErr: "coinbase transaction fee should be zero",
+		},
+		"value other than the block reward": {
+			update: func(tx *transaction.Transaction) { tx.Value = "0x1" },
+			expErr: "coinbase transaction value 1 should be the block reward " + reward.Text(10),
+		},
+	}
+
+	for name, tt := range txCases {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			tx := coinbase
+			tx.Signature = nil
+			tt.update(&tx)
+			assert.ErrorContains(t, signer.SignCoinbaseTransaction(&tx, 5), tt.expErr)
+			assert.Empty(t, tx.Signature)
+		})
+	}
+
+	sig, err := signer.SignCheckpoint(10, []byte{1})
+	assert.NoError(t, err)
+	ok, err := kp.PublicKey.Verify(blockchain.CheckpointHash(10, []byte{1}), sig)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	_, err = signer.SignCheckpoint(10, []byte{2})
+	assert.ErrorContains(t, err, "a different checkpoint was already signed at height 10")
+
+	// a block signature doesn't count as a checkpoint and the other way around
+	_, err = signer.SignCheckpoint(20, blck.Hash)
+	assert.NoError(t, err)
+
+	manifest := &messages.SnapshotManifestProto{Height: 5, BlockHash: blck.Hash, ChunkHashes: [][]byte{{1}}}
+	assert.NoError(t, signer.SignSnapshotManifest(manifest))
+	assert.Equal(t, expectedPublicKey, manifest.PublicKey)
+	verifier := block.Verifier{Address: kp.Address, PublicKey: hexutil.Encode(expectedPublicKey)}
+	block.SetBlockVerifiers(verifier)
+	assert.NoError(t, snapshot.VerifyManifest(manifest))
+}
+
+func unsignedBlock(t *testing.T, kp crypto.KeyPair, number uint64) block.Block {
+	publicKey, err := kp.PublicKey.Raw()
+	assert.NoError(t, err)
+	coinbase := transaction.Transaction{
+		PublicKey:       publicKey,
+		Nounce:          []byte{0},
+		From:            kp.Address,
+		To:              kp.Address,
+		Value:           "0x1",
+		TransactionFees: "0x0",
+		Chain:           []byte{1},
+	}
+	assert.NoError(t, coinbase.Sign(kp.PrivateKey))
+
+	return block.Block{
+		Timestamp:         time.Unix(1000, 0).Unix(),
+		Data:              []byte{1},
+		PreviousBlockHash: []byte{1},
+		Transactions:      []transaction.Transaction{coinbase},
+		Number:            number,
+	}
+}
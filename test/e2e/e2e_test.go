@@ -37,11 +37,13 @@ import (
 	"github.com/filefilego/filefilego/keystore"
 	"github.com/filefilego/filefilego/node"
 	blockdownloader "github.com/filefilego/filefilego/node/protocols/block_downloader"
+	compactblock "github.com/filefilego/filefilego/node/protocols/compact_block"
 	dataquery "github.com/filefilego/filefilego/node/protocols/data_query"
 	dataverification "github.com/filefilego/filefilego/node/protocols/data_verification"
 	"github.com/filefilego/filefilego/node/protocols/messages"
 	internalrpc "github.com/filefilego/filefilego/rpc"
 	"github.com/filefilego/filefilego/search"
+	"github.com/filefilego/filefilego/signer"
 	"github.com/filefilego/filefilego/storage"
 	"github.com/filefilego/filefilego/transaction"
 	"github.com/filefilego/filefilego/validator"
@@ -420,6 +422,19 @@ func TestE2E(t *testing.T) {
 	VerifierPublicKeyBytes, err := hexutil.Decode(downloadContract.Contract.VerifierPublicKey)
 	assert.NoError(t, err)
 
+	fileHosterSignature, err := hexutil.Decode(downloadContract.Contract.FileHosterResponse.Signature)
+	assert.NoError(t, err)
+
+	verifierSignature, err := hexutil.Decode(downloadContract.Contract.VerifierSignature)
+	assert.NoError(t, err)
+
+	fileHashesNeeded := make([][]byte, 0, len(downloadContract.Contract.FileHashesNeeded))
+	for _, fileHash := range downloadContract.Contract.FileHashesNeeded {
+		fileHashBytes, err := hexutil.DecodeNoPrefix(fileHash)
+		assert.NoError(t, err)
+		fileHashesNeeded = append(fileHashesNeeded, fileHashBytes)
+	}
+
 	dcinTX := &messages.DownloadContractInTransactionDataProto{
 		ContractHash:               contractHashBytes,
 		FileRequesterNodePublicKey: fileRequesterNodePublicKey,
@@ -427,6 +442,10 @@ func TestE2E(t *testing.T) {
 		VerifierPublicKey:          VerifierPublicKeyBytes,
 		VerifierFees:               downloadContract.Contract.VerifierFees,
 		FileHosterFees:             downloadContract.Contract.FileHosterResponse.FeesPerByte,
+		FileHosterSignature:        fileHosterSignature,
+		FileHashesNeeded:           fileHashesNeeded,
+		FileHashesNeededSizes:      downloadContract.Contract.FileHashesNeededSizes,
+		VerifierSignature:          verifierSignature,
 	}
 
 	contractsEnvelope := &messages.DownloadContractsHashesProto{
@@ -622,7 +641,10 @@ func TestE2E(t *testing.T) {
 	assert.Len(t, mempoolTxs, 1)
 	assert.Equal(t, dataverifierAddr, mempoolTxs[0].From)
 	assert.Equal(t, kpN1.Address, mempoolTxs[0].To)
-	assert.Equal(t, hexutil.EncodeBig(fileHosterFees), mempoolTxs[0].Value)
+	// the file hoster fees are paid from the escrow of the contract
+	assert.Equal(t, "0x0", mempoolTxs[0].Value)
+	_, err = v1Bchain.GetContractEscrow(contractHashBytes)
+	assert.NoError(t, err)
 
 	// seal block
 	sealedBlock4, err := validator.SealBlock(time.Now().Unix())
@@ -642,6 +664,9 @@ func TestE2E(t *testing.T) {
 	mempoolTxs = v1Bchain.GetTransactionsFromPool()
 	assert.Len(t, mempoolTxs, 0)
 
+	_, err = v1Bchain.GetContractEscrow(contractHashBytes)
+	assert.Error(t, err)
+
 	// the balance of file hoster 1 should be equal to the total fees in the contract.
 	n1Balance, err := v1Client.Balance(context.TODO(), kpN1.Address)
 	assert.NoError(t, err)
@@ -726,13 +751,26 @@ func createNode(t *testing.T, dbName string, conf *config.Config, isVerifier boo
 	assert.NoError(t, err)
 	routingDiscovery := drouting.NewRoutingDiscovery(kademliaDHT)
 
-	optsPS := []pubsub.Option{pubsub.WithMessageSigning(true), pubsub.WithMaxMessageSize(conf.P2P.GossipMaxMessageSize)} // 10 MB
+	banList, err := node.NewBanList(node.DefaultMaxOffences, node.DefaultBanDuration)
+	assert.NoError(t, err)
+	gossipTopics := node.NewGossipTopics("ffgnet", "")
+	if conf.P2P.GossipLegacyTopic {
+		gossipTopics.Legacy = "ffgnet_pubsub"
+	}
+	optsPS := []pubsub.Option{
+		pubsub.WithMessageSigning(true),
+		pubsub.WithMaxMessageSize(conf.P2P.GossipMaxMessageSize), // 10 MB
+		pubsub.WithPeerScore(node.PeerScoreParams(banList, gossipTopics.Names()...), node.PeerScoreThresholds()),
+		pubsub.WithBlacklist(banList),
+	}
 	gossip, err := pubsub.NewGossipSub(ctx, host, optsPS...)
 	assert.NoError(t, err)
 	db, err := leveldb.OpenFile(filepath.Join(conf.Global.DataDir, dbName), nil)
 	assert.NoError(t, err)
 	globalDB, err := database.New(db)
 	assert.NoError(t, err)
+	addressBook, err := node.NewAddressBook(globalDB, node.DefaultAddressBookMaxAge, node.DefaultAddressBookMaxPeers)
+	assert.NoError(t, err)
 	s := rpc.NewServer()
 	s.RegisterCodec(json.NewCodec(), "application/json")
 
@@ -753,7 +791,7 @@ func createNode(t *testing.T, dbName string, conf *config.Config, isVerifier boo
 		bchain, err = blockchain.New(globalDB, &search.Search{}, genesisblockValid.Hash)
 		assert.NoError(t, err)
 
-		ffgNode, err = node.New(conf, host, kademliaDHT, routingDiscovery, gossip, &search.Search{}, &storage.Storage{}, bchain, &dataquery.Protocol{}, &blockdownloader.Protocol{})
+		ffgNode, err = node.New(conf, host, kademliaDHT, routingDiscovery, gossip, &search.Search{}, &storage.Storage{}, bchain, &dataquery.Protocol{}, &blockdownloader.Protocol{}, &compactblock.Protocol{}, banList, addressBook)
 		assert.NoError(t, err)
 	} else {
 		// full node dependencies setup
@@ -770,6 +808,8 @@ func createNode(t *testing.T, dbName string, conf *config.Config, isVerifier boo
 
 		bchain, err = blockchain.New(globalDB, searchEngine, genesisblockValid.Hash)
 		assert.NoError(t, err)
+		// the test network locks the contract fees in escrows from the start
+		bchain.SetContractEscrowActivationHeight(0)
 
 		start := time.Now()
 		err = bchain.InitOrLoad(conf.Global.VerifyBlocks)
@@ -780,29 +820,35 @@ func createNode(t *testing.T, dbName string, conf *config.Config, isVerifier boo
 		blockDownloaderProtocol, err := blockdownloader.New(bchain, host)
 		assert.NoError(t, err)
 
-		ffgNode, err = node.New(conf, host, kademliaDHT, routingDiscovery, gossip, searchEngine, storageEngine, bchain, dataQueryProtocol, blockDownloaderProtocol)
+		compactBlockProtocol, err := compactblock.New(host)
+		assert.NoError(t, err)
+
+		ffgNode, err = node.New(conf, host, kademliaDHT, routingDiscovery, gossip, searchEngine, storageEngine, bchain, dataQueryProtocol, blockDownloaderProtocol, compactBlockProtocol, banList, addressBook)
 		assert.NoError(t, err)
 
 		// validator node
 		if conf.Global.Validator && !conf.Global.SuperLightNode {
-			blockValidator, err = validator.New(ffgNode, bchain, kp.PrivateKey)
+			localSigner, err := signer.NewLocalSigner(kp.PrivateKey, "")
+			assert.NoError(t, err)
+			blockValidator, err = validator.New(ffgNode, bchain, localSigner)
 			assert.NoError(t, err)
 		}
 	}
 
+	_, err = ffgNode.ConnectToKnownPeers(ctx, 50)
+	assert.NoError(t, err)
+
 	// advertise
 	ffgNode.Advertise(ctx, "ffgnet")
 	err = ffgNode.DiscoverPeers(ctx, "ffgnet")
 	assert.NoError(t, err)
 	// listen for pubsub messages
-	err = ffgNode.JoinPubSubNetwork(ctx, "ffgnet_pubsub")
+	err = ffgNode.JoinPubSubNetwork(ctx, gossipTopics)
 	assert.NoError(t, err)
 
-	// if full node, then hanlde incoming block, transactions, and data queries
-	if !conf.Global.SuperLightNode {
-		err = ffgNode.HandleIncomingMessages(ctx, "ffgnet_pubsub")
-		assert.NoError(t, err)
-	}
+	// subscribe to the topics required by the node's mode
+	err = ffgNode.HandleIncomingMessages(ctx)
+	assert.NoError(t, err)
 
 	// bootstrap
 	err = ffgNode.Bootstrap(ctx, conf.P2P.Bootstraper.Nodes)
@@ -833,7 +879,7 @@ func createNode(t *testing.T, dbName string, conf *config.Config, isVerifier boo
 	}
 
 	if contains(conf.RPC.EnabledServices, internalrpc.TransactionServiceNamespace) {
-		transactionAPI, err := internalrpc.NewTransactionAPI(keyst, ffgNode, bchain, conf.Global.SuperLightNode)
+		transactionAPI, err := internalrpc.NewTransactionAPI(keyst, ffgNode, bchain, conf.Global.SuperLightNode, nil)
 		assert.NoError(t, err)
 		err = s.RegisterService(transactionAPI, internalrpc.TransactionServiceNamespace)
 		assert.NoError(t, err)
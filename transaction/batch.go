@@ -0,0 +1,133 @@
+package transaction
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/filefilego/filefilego/common/hexutil"
+	"google.golang.org/protobuf/proto"
+)
+
+// MaxBatchTransfers is the maximum number of transfers of a batch transfer transaction.
+const MaxBatchTransfers = 256
+
+// addressSize is the size of an address in bytes.
+const addressSize = 20
+
+// BatchTransfer is a transfer to one recipient of a batch transfer transaction.
+type BatchTransfer struct {
+	To    string
+	Value string
+}
+
+// NewBatchTransferData returns the data payload of a batch transfer transaction and the total value of the transfers.
+// the total value should be used as the value of the transaction, and the transaction is sent to the sender's address.
+func NewBatchTransferData(transfers []BatchTransfer) ([]byte, *big.Int, error) {
+	batch := BatchTransferProto{Transfers: make([]*BatchTransferItemProto, len(transfers))}
+	for i, t := range transfers {
+		batch.Transfers[i] = &BatchTransferItemProto{To: t.To, Value: t.Value}
+	}
+
+	total, err := validateBatchTransfer(&batch)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	payload, err := proto.Marshal(&batch)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal batch transfer: %w", err)
+	}
+
+	data, err := proto.Marshal(&DataPayload{Type: DataType_BATCH_TRANSFER, Payload: payload})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal data payload: %w", err)
+	}
+	return data, total, nil
+}
+
+// IsBatchTransfer checks if the data payload of the transaction is a batch transfer.
+func (tx Transaction) IsBatchTransfer() bool {
+	_, ok := tx.batchTransferPayload()
+	return ok
+}
+
+// BatchTransfers returns the validated transfers of a batch transfer transaction.
+// the value of the transaction must be the total value of the transfers and the transaction must be sent to the sender's address.
+func (tx Transaction) BatchTransfers() ([]BatchTransfer, error) {
+	payload, ok := tx.batchTransferPayload()
+	if !ok {
+		return nil, errors.New("transaction is not a batch transfer")
+	}
+
+	batch := BatchTransferProto{}
+	if err := proto.Unmarshal(payload, &batch); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal batch transfer: %w", err)
+	}
+
+	total, err := validateBatchTransfer(&batch)
+	if err != nil {
+		return nil, err
+	}
+
+	if tx.To != tx.From {
+		return nil, errors.New("batch transfer should be sent to the sender's address")
+	}
+
+	txValue, err := hexutil.DecodeBig(tx.Value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode transaction value: %w", err)
+	}
+
+	if txValue.Cmp(total) != 0 {
+		return nil, fmt.Errorf("transaction value %s doesn't match the total value of the transfers %s", txValue.Text(10), total.Text(10))
+	}
+
+	transfers := make([]BatchTransfer, len(batch.Transfers))
+	for i, t := range batch.Transfers {
+		transfers[i] = BatchTransfer{To: t.To, Value: t.Value}
+	}
+	return transfers, nil
+}
+
+func (tx Transaction) batchTransferPayload() ([]byte, bool) {
+	if len(tx.Data) == 0 {
+		return nil, false
+	}
+
+	dataPayload := DataPayload{}
+	if err := proto.Unmarshal(tx.Data, &dataPayload); err != nil {
+		return nil, false
+	}
+	return dataPayload.Payload, dataPayload.Type == DataType_BATCH_TRANSFER
+}
+
+// validateBatchTransfer validates the transfers and returns their total value.
+func validateBatchTransfer(batch *BatchTransferProto) (*big.Int, error) {
+	if len(batch.Transfers) == 0 {
+		return nil, errors.New("batch transfer is empty")
+	}
+
+	if len(batch.Transfers) > MaxBatchTransfers {
+		return nil, fmt.Errorf("number of transfers %d is greater than %d", len(batch.Transfers), MaxBatchTransfers)
+	}
+
+	total := big.NewInt(0)
+	for i, t := range batch.Transfers {
+		to, err := hexutil.Decode(t.To)
+		if err != nil || len(to) != addressSize {
+			return nil, fmt.Errorf("transfer %d has an invalid address", i)
+		}
+
+		value, err := hexutil.DecodeBig(t.Value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode value of transfer %d: %w", i, err)
+		}
+
+		if value.Sign() < 0 {
+			return nil, fmt.Errorf("value of transfer %d is negative", i)
+		}
+		total.Add(total, value)
+	}
+	return total, nil
+}
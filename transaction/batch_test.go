@@ -0,0 +1,110 @@
+package transaction
+
+import (
+	"testing"
+
+	"github.com/filefilego/filefilego/common/hexutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBatchTransferData(t *testing.T) {
+	t.Parallel()
+
+	recipient := "0xdd9a374e8dce9d656073ec153580301b7d2c3850"
+	tooMany := make([]BatchTransfer, MaxBatchTransfers+1)
+	for i := range tooMany {
+		tooMany[i] = BatchTransfer{To: recipient, Value: "0x1"}
+	}
+
+	cases := map[string]struct {
+		transfers []BatchTransfer
+		expTotal  string
+		expErr    string
+	}{
+		"empty transfers": {
+			expErr: "batch transfer is empty",
+		},
+		"too many transfers": {
+			transfers: tooMany,
+			expErr:    "number of transfers 257 is greater than 256",
+		},
+		"invalid address": {
+			transfers: []BatchTransfer{{To: "0x01", Value: "0x1"}},
+			expErr:    "transfer 0 has an invalid address",
+		},
+		"invalid value": {
+			transfers: []BatchTransfer{{To: recipient, Value: "0x1"}, {To: recipient, Value: "1"}},
+			expErr:    "failed to decode value of transfer 1: hex string without 0x prefix",
+		},
+		"success": {
+			transfers: []BatchTransfer{{To: recipient, Value: "0x1"}, {To: recipient, Value: "0x2"}},
+			expTotal:  "0x3",
+		},
+	}
+
+	for name, tt := range cases {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			data, total, err := NewBatchTransferData(tt.transfers)
+			if tt.expErr != "" {
+				assert.EqualError(t, err, tt.expErr)
+				assert.Nil(t, data)
+				assert.Nil(t, total)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expTotal, hexutil.EncodeBig(total))
+				tx := Transaction{Data: data}
+				assert.True(t, tx.IsBatchTransfer())
+			}
+		})
+	}
+}
+
+func TestBatchTransfers(t *testing.T) {
+	t.Parallel()
+
+	sender := "0x958ef8e7e9c6d4ce25b24b2b61b671d813d77472"
+	transfers := []BatchTransfer{
+		{To: "0xdd9a374e8dce9d656073ec153580301b7d2c3850", Value: "0x1"},
+		{To: "0xbd372b1188350a99d433cec50f80f058bb9a614c", Value: "0x2"},
+	}
+	data, _, err := NewBatchTransferData(transfers)
+	assert.NoError(t, err)
+
+	cases := map[string]struct {
+		tx     Transaction
+		expErr string
+	}{
+		"not a batch transfer": {
+			tx:     Transaction{From: sender, To: sender, Value: "0x3"},
+			expErr: "transaction is not a batch transfer",
+		},
+		"not sent to the sender": {
+			tx:     Transaction{Data: data, From: sender, To: transfers[0].To, Value: "0x3"},
+			expErr: "batch transfer should be sent to the sender's address",
+		},
+		"value doesn't match the total": {
+			tx:     Transaction{Data: data, From: sender, To: sender, Value: "0x4"},
+			expErr: "transaction value 4 doesn't match the total value of the transfers 3",
+		},
+		"success": {
+			tx: Transaction{Data: data, From: sender, To: sender, Value: "0x3"},
+		},
+	}
+
+	for name, tt := range cases {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := tt.tx.BatchTransfers()
+			if tt.expErr != "" {
+				assert.EqualError(t, err, tt.expErr)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, transfers, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,268 @@
+package transaction
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+
+	ffgcrypto "github.com/filefilego/filefilego/crypto"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"google.golang.org/protobuf/proto"
+)
+
+// MaxMultiSigKeys is the maximum number of keys of a multi-signature account.
+const MaxMultiSigKeys = 16
+
+// multiSigKeyType prefixes the public key of a multi-signature account.
+// single public keys are compressed secp256k1 keys which start with 0x02 or 0x03.
+const multiSigKeyType byte = 0x4d
+
+// publicKeySize is the size of a compressed secp256k1 public key.
+const publicKeySize = 33
+
+// PartialSignature is a signature of a multi-signature transaction by one of the account keys.
+type PartialSignature struct {
+	PublicKey []byte
+	Signature []byte
+}
+
+// NewMultiSigPublicKey returns the public key of a multi-signature account which requires threshold of the public keys to sign.
+// the keys are sorted so the same set of keys and threshold always give the same account.
+func NewMultiSigPublicKey(threshold int, publicKeys [][]byte) ([]byte, error) {
+	if len(publicKeys) == 0 {
+		return nil, errors.New("public keys are empty")
+	}
+
+	if len(publicKeys) > MaxMultiSigKeys {
+		return nil, fmt.Errorf("number of public keys %d is greater than %d", len(publicKeys), MaxMultiSigKeys)
+	}
+
+	if threshold < 1 || threshold > len(publicKeys) {
+		return nil, fmt.Errorf("threshold %d should be between 1 and the number of public keys %d", threshold, len(publicKeys))
+	}
+
+	keys := make([][]byte, len(publicKeys))
+	for i, publicKey := range publicKeys {
+		if len(publicKey) != publicKeySize {
+			return nil, fmt.Errorf("public key %d has an invalid size", i)
+		}
+
+		if _, err := ffgcrypto.PublicKeyFromBytes(publicKey); err != nil {
+			return nil, fmt.Errorf("failed to get public key %d: %w", i, err)
+		}
+		keys[i] = publicKey
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i], keys[j]) < 0
+	})
+
+	data := make([]byte, 0, 3+len(keys)*publicKeySize)
+	data = append(data, multiSigKeyType, byte(threshold), byte(len(keys)))
+	for i, key := range keys {
+		if i > 0 && bytes.Equal(keys[i-1], key) {
+			return nil, errors.New("public keys contain duplicates")
+		}
+		data = append(data, key...)
+	}
+
+	return data, nil
+}
+
+// ParseMultiSigPublicKey returns the threshold and the public keys of a multi-signature account.
+func ParseMultiSigPublicKey(data []byte) (int, [][]byte, error) {
+	if !IsMultiSigPublicKey(data) || len(data) < 3 {
+		return 0, nil, errors.New("not a multi-signature public key")
+	}
+
+	threshold := int(data[1])
+	count := int(data[2])
+	if len(data) != 3+count*publicKeySize {
+		return 0, nil, errors.New("multi-signature public key is malformed")
+	}
+
+	keys := make([][]byte, count)
+	for i := range keys {
+		keys[i] = data[3+i*publicKeySize : 3+(i+1)*publicKeySize]
+	}
+
+	// the keys are validated again so a hand crafted public key can't bypass the rules.
+	canonical, err := NewMultiSigPublicKey(threshold, keys)
+	if err != nil {
+		return 0, nil, fmt.Errorf("multi-signature public key is invalid: %w", err)
+	}
+
+	if !bytes.Equal(canonical, data) {
+		return 0, nil, errors.New("multi-signature public keys are not sorted")
+	}
+
+	return threshold, keys, nil
+}
+
+// IsMultiSigPublicKey checks if the public key belongs to a multi-signature account.
+func IsMultiSigPublicKey(data []byte) bool {
+	return len(data) > 0 && data[0] == multiSigKeyType
+}
+
+// MultiSigAddress returns the address of a multi-signature account.
+func MultiSigAddress(threshold int, publicKeys [][]byte) (string, error) {
+	publicKey, err := NewMultiSigPublicKey(threshold, publicKeys)
+	if err != nil {
+		return "", err
+	}
+	return ffgcrypto.RawPublicToAddress(publicKey)
+}
+
+// PartialSign signs a multi-signature transaction with one of the account keys.
+// the partial signatures are collected and combined with CombineSignatures.
+func (tx Transaction) PartialSign(key crypto.PrivKey) (PartialSignature, error) {
+	_, keys, err := ParseMultiSigPublicKey(tx.PublicKey)
+	if err != nil {
+		return PartialSignature{}, err
+	}
+
+	publicKey, err := key.GetPublic().Raw()
+	if err != nil {
+		return PartialSignature{}, fmt.Errorf("failed to get public key: %w", err)
+	}
+
+	if indexOfKey(keys, publicKey) == -1 {
+		return PartialSignature{}, errors.New("key is not part of the multi-signature account")
+	}
+
+	hash, err := tx.CalculateHash()
+	if err != nil {
+		return PartialSignature{}, fmt.Errorf("failed to get transactionHash: %w", err)
+	}
+
+	sig, err := key.Sign(hash)
+	if err != nil {
+		return PartialSignature{}, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+
+	return PartialSignature{PublicKey: publicKey, Signature: sig}, nil
+}
+
+// CombineSignatures sets the hash and the signature of a multi-signature transaction from the partial signatures.
+func (tx *Transaction) CombineSignatures(signatures []PartialSignature) error {
+	threshold, keys, err := ParseMultiSigPublicKey(tx.PublicKey)
+	if err != nil {
+		return err
+	}
+
+	hash, err := tx.CalculateHash()
+	if err != nil {
+		return fmt.Errorf("failed to get transactionHash: %w", err)
+	}
+
+	ordered := make([]*PartialSignatureProto, len(keys))
+	for _, sig := range signatures {
+		idx := indexOfKey(keys, sig.PublicKey)
+		if idx == -1 {
+			return errors.New("signer is not part of the multi-signature account")
+		}
+
+		if err := verifyPartialSignature(hash, sig.PublicKey, sig.Signature); err != nil {
+			return err
+		}
+
+		ordered[idx] = &PartialSignatureProto{PublicKey: sig.PublicKey, Signature: sig.Signature}
+	}
+
+	multiSig := MultiSignatureProto{}
+	for _, sig := range ordered {
+		if sig != nil {
+			multiSig.Signatures = append(multiSig.Signatures, sig)
+		}
+	}
+
+	if len(multiSig.Signatures) < threshold {
+		return fmt.Errorf("got %d signatures but %d are required", len(multiSig.Signatures), threshold)
+	}
+
+	data, err := proto.Marshal(&multiSig)
+	if err != nil {
+		return fmt.Errorf("failed to marshal signatures: %w", err)
+	}
+
+	tx.Hash = hash
+	tx.Signature = data
+	return nil
+}
+
+// PartialSignatures returns the partial signatures of a multi-signature transaction.
+func (tx Transaction) PartialSignatures() ([]PartialSignature, error) {
+	multiSig := MultiSignatureProto{}
+	if err := proto.Unmarshal(tx.Signature, &multiSig); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal signatures: %w", err)
+	}
+
+	signatures := make([]PartialSignature, len(multiSig.Signatures))
+	for i, sig := range multiSig.Signatures {
+		signatures[i] = PartialSignature{PublicKey: sig.PublicKey, Signature: sig.Signature}
+	}
+	return signatures, nil
+}
+
+// verifyMultiSig verifies that the transaction is signed by at least the threshold of the account keys.
+func (tx Transaction) verifyMultiSig() error {
+	threshold, keys, err := ParseMultiSigPublicKey(tx.PublicKey)
+	if err != nil {
+		return err
+	}
+
+	signatures, err := tx.PartialSignatures()
+	if err != nil {
+		return err
+	}
+
+	signed := make([]bool, len(keys))
+	for _, sig := range signatures {
+		idx := indexOfKey(keys, sig.PublicKey)
+		if idx == -1 {
+			return errors.New("signer is not part of the multi-signature account")
+		}
+
+		if signed[idx] {
+			return errors.New("duplicate signature of a multi-signature account key")
+		}
+		signed[idx] = true
+
+		if err := verifyPartialSignature(tx.Hash, sig.PublicKey, sig.Signature); err != nil {
+			return err
+		}
+	}
+
+	if len(signatures) < threshold {
+		return fmt.Errorf("got %d signatures but %d are required", len(signatures), threshold)
+	}
+
+	return nil
+}
+
+func verifyPartialSignature(hash, publicKey, signature []byte) error {
+	pubKey, err := ffgcrypto.PublicKeyFromBytes(publicKey)
+	if err != nil {
+		return fmt.Errorf("failed to get publicKey: %w", err)
+	}
+
+	ok, err := pubKey.Verify(hash, signature)
+	if err != nil {
+		return fmt.Errorf("failed to verify partial signature: %w", err)
+	}
+
+	if !ok {
+		return errors.New("failed verification of partial signature")
+	}
+	return nil
+}
+
+func indexOfKey(keys [][]byte, publicKey []byte) int {
+	for i, key := range keys {
+		if bytes.Equal(key, publicKey) {
+			return i
+		}
+	}
+	return -1
+}
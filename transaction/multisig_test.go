@@ -0,0 +1,178 @@
+package transaction
+
+import (
+	"testing"
+
+	"github.com/filefilego/filefilego/common/hexutil"
+	"github.com/filefilego/filefilego/crypto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestNewMultiSigPublicKey(t *testing.T) {
+	t.Parallel()
+
+	keys := multiSigKeys(t, 3)
+	publicKeys := rawPublicKeys(t, keys)
+
+	cases := map[string]struct {
+		threshold  int
+		publicKeys [][]byte
+		expErr     string
+	}{
+		"empty public keys": {
+			threshold: 1,
+			expErr:    "public keys are empty",
+		},
+		"zero threshold": {
+			publicKeys: publicKeys,
+			expErr:     "threshold 0 should be between 1 and the number of public keys 3",
+		},
+		"threshold bigger than keys": {
+			threshold:  4,
+			publicKeys: publicKeys,
+			expErr:     "threshold 4 should be between 1 and the number of public keys 3",
+		},
+		"invalid public key": {
+			threshold:  1,
+			publicKeys: [][]byte{{1, 2}},
+			expErr:     "public key 0 has an invalid size",
+		},
+		"duplicate public keys": {
+			threshold:  1,
+			publicKeys: [][]byte{publicKeys[0], publicKeys[0]},
+			expErr:     "public keys contain duplicates",
+		},
+		"success": {
+			threshold:  2,
+			publicKeys: publicKeys,
+		},
+	}
+
+	for name, tt := range cases {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			data, err := NewMultiSigPublicKey(tt.threshold, tt.publicKeys)
+			if tt.expErr != "" {
+				assert.EqualError(t, err, tt.expErr)
+				assert.Nil(t, data)
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, IsMultiSigPublicKey(data))
+				threshold, parsedKeys, err := ParseMultiSigPublicKey(data)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.threshold, threshold)
+				assert.ElementsMatch(t, tt.publicKeys, parsedKeys)
+			}
+		})
+	}
+
+	// the order of the keys doesn't change the address
+	addr, err := MultiSigAddress(2, publicKeys)
+	assert.NoError(t, err)
+	reversed, err := MultiSigAddress(2, [][]byte{publicKeys[2], publicKeys[1], publicKeys[0]})
+	assert.NoError(t, err)
+	assert.Equal(t, addr, reversed)
+	otherThreshold, err := MultiSigAddress(3, publicKeys)
+	assert.NoError(t, err)
+	assert.NotEqual(t, addr, otherThreshold)
+}
+
+func TestMultiSigTransaction(t *testing.T) {
+	t.Parallel()
+
+	keys := multiSigKeys(t, 3)
+	publicKeys := rawPublicKeys(t, keys)
+	multiSigPublicKey, err := NewMultiSigPublicKey(2, publicKeys)
+	assert.NoError(t, err)
+	addr, err := crypto.RawPublicToAddress(multiSigPublicKey)
+	assert.NoError(t, err)
+	mainChain, err := hexutil.Decode(ChainID)
+	assert.NoError(t, err)
+
+	tx := Transaction{
+		PublicKey:       multiSigPublicKey,
+		Nounce:          []byte{1},
+		From:            addr,
+		To:              keys[0].Address,
+		Value:           "0x1",
+		TransactionFees: "0x1",
+		Chain:           mainChain,
+	}
+
+	outsider, err := crypto.GenerateKeyPair()
+	assert.NoError(t, err)
+	_, err = tx.PartialSign(outsider.PrivateKey)
+	assert.EqualError(t, err, "key is not part of the multi-signature account")
+
+	sig0, err := tx.PartialSign(keys[0].PrivateKey)
+	assert.NoError(t, err)
+	sig2, err := tx.PartialSign(keys[2].PrivateKey)
+	assert.NoError(t, err)
+
+	// one signature is below the threshold
+	err = tx.CombineSignatures([]PartialSignature{sig0})
+	assert.EqualError(t, err, "got 1 signatures but 2 are required")
+
+	// a signature of another transaction is rejected
+	otherTx := tx
+	otherTx.Value = "0x2"
+	otherSig, err := otherTx.PartialSign(keys[1].PrivateKey)
+	assert.NoError(t, err)
+	err = tx.CombineSignatures([]PartialSignature{sig0, otherSig})
+	assert.EqualError(t, err, "failed verification of partial signature")
+
+	assert.NoError(t, tx.CombineSignatures([]PartialSignature{sig2, sig0}))
+	ok, err := tx.Validate()
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	signatures, err := tx.PartialSignatures()
+	assert.NoError(t, err)
+	assert.Len(t, signatures, 2)
+
+	// the same key can't count twice towards the threshold
+	duplicate := tx
+	multiSig := MultiSignatureProto{Signatures: []*PartialSignatureProto{
+		{PublicKey: sig0.PublicKey, Signature: sig0.Signature},
+		{PublicKey: sig0.PublicKey, Signature: sig0.Signature},
+	}}
+	duplicate.Signature, err = proto.Marshal(&multiSig)
+	assert.NoError(t, err)
+	ok, err = duplicate.Validate()
+	assert.EqualError(t, err, "failed to verify: duplicate signature of a multi-signature account key")
+	assert.False(t, ok)
+
+	// the from address has to be the multi-signature address
+	wrongFrom := tx
+	wrongFrom.From = keys[0].Address
+	wrongSig0, err := wrongFrom.PartialSign(keys[0].PrivateKey)
+	assert.NoError(t, err)
+	wrongSig1, err := wrongFrom.PartialSign(keys[1].PrivateKey)
+	assert.NoError(t, err)
+	assert.NoError(t, wrongFrom.CombineSignatures([]PartialSignature{wrongSig0, wrongSig1}))
+	ok, err = wrongFrom.Validate()
+	assert.EqualError(t, err, "from address doesn't match the public key")
+	assert.False(t, ok)
+}
+
+func multiSigKeys(t *testing.T, n int) []crypto.KeyPair {
+	keys := make([]crypto.KeyPair, n)
+	for i := range keys {
+		kp, err := crypto.GenerateKeyPair()
+		assert.NoError(t, err)
+		keys[i] = kp
+	}
+	return keys
+}
+
+func rawPublicKeys(t *testing.T, keys []crypto.KeyPair) [][]byte {
+	publicKeys := make([][]byte, len(keys))
+	for i, kp := range keys {
+		publicKey, err := kp.PublicKey.Raw()
+		assert.NoError(t, err)
+		publicKeys[i] = publicKey
+	}
+	return publicKeys
+}
@@ -3,6 +3,7 @@ package transaction
 import (
 	"bytes"
 	"crypto/sha256"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"math/big"
@@ -34,6 +35,11 @@ type Transaction struct {
 	Value           string
 	TransactionFees string
 	Chain           []byte
+
+	// optional validity window in block numbers, zero if not set.
+	// the transaction can be included in the blocks after ValidAfter up to and including ValidUntil.
+	ValidAfter uint64
+	ValidUntil uint64
 }
 
 // Serialize the transaction to bytes.
@@ -80,6 +86,14 @@ func (tx Transaction) Serialize() ([]byte, error) {
 		},
 		[]byte{},
 	)
+
+	// the validity window is only part of the hash when set, so the hashes of the other transactions don't change.
+	if tx.ValidAfter > 0 || tx.ValidUntil > 0 {
+		window := make([]byte, 16)
+		binary.BigEndian.PutUint64(window[:8], tx.ValidAfter)
+		binary.BigEndian.PutUint64(window[8:], tx.ValidUntil)
+		data = append(data, window...)
+	}
 	return data, nil
 }
 
@@ -198,6 +212,10 @@ func (tx Transaction) Validate() (bool, error) {
 		return false, errors.New("value is negative")
 	}
 
+	if tx.ValidUntil > 0 && tx.ValidAfter >= tx.ValidUntil {
+		return false, fmt.Errorf("valid after %d should be smaller than valid until %d", tx.ValidAfter, tx.ValidUntil)
+	}
+
 	valFees, err := hexutil.DecodeBig(tx.TransactionFees)
 	if err != nil {
 		return false, fmt.Errorf("failed to decode transactionFees: %w", err)
@@ -215,14 +233,21 @@ func (tx Transaction) Validate() (bool, error) {
 		return false, errors.New("transaction is altered and doesn't match the hash")
 	}
 
-	newPubKey, err := ffgcrypto.PublicKeyFromBytes(tx.PublicKey)
-	if err != nil {
-		return false, fmt.Errorf("failed to get publicKey: %w", err)
-	}
+	if IsMultiSigPublicKey(tx.PublicKey) {
+		err = tx.verifyMultiSig()
+		if err != nil {
+			return false, fmt.Errorf("failed to verify: %w", err)
+		}
+	} else {
+		newPubKey, err := ffgcrypto.PublicKeyFromBytes(tx.PublicKey)
+		if err != nil {
+			return false, fmt.Errorf("failed to get publicKey: %w", err)
+		}
 
-	err = tx.VerifyWithPublicKey(newPubKey)
-	if err != nil {
-		return false, fmt.Errorf("failed to verify: %w", err)
+		err = tx.VerifyWithPublicKey(newPubKey)
+		if err != nil {
+			return false, fmt.Errorf("failed to verify: %w", err)
+		}
 	}
 
 	fromAddr, err := ffgcrypto.RawPublicToAddress(tx.PublicKey)
@@ -237,6 +262,28 @@ func (tx Transaction) Validate() (bool, error) {
 	return true, nil
 }
 
+// ValidateValidityWindow checks that the transaction can be included in the block with the given number.
+func (tx Transaction) ValidateValidityWindow(blockNumber uint64) error {
+	if tx.ValidAfter > 0 && blockNumber <= tx.ValidAfter {
+		return fmt.Errorf("transaction is not valid before block %d", tx.ValidAfter+1)
+	}
+
+	if tx.IsExpired(blockNumber) {
+		return fmt.Errorf("transaction expired at block %d", tx.ValidUntil)
+	}
+	return nil
+}
+
+// IsExpired checks if the transaction can't be included in the block with the given number or any later block.
+func (tx Transaction) IsExpired(blockNumber uint64) bool {
+	return tx.ValidUntil > 0 && blockNumber > tx.ValidUntil
+}
+
+// Size returns the size of the serialized transaction in bytes.
+func (tx Transaction) Size() int {
+	return proto.Size(ToProtoTransaction(tx))
+}
+
 // ToProtoTransaction converts a transaction to protobuf message.
 func ToProtoTransaction(tx Transaction) *ProtoTransaction {
 	ptx := &ProtoTransaction{
@@ -250,6 +297,8 @@ func ToProtoTransaction(tx Transaction) *ProtoTransaction {
 		Value:           tx.Value,
 		TransactionFees: tx.TransactionFees,
 		Chain:           make([]byte, len(tx.Chain)),
+		ValidAfter:      tx.ValidAfter,
+		ValidUntil:      tx.ValidUntil,
 	}
 
 	copy(ptx.Hash, tx.Hash)
@@ -275,6 +324,8 @@ func ProtoTransactionToTransaction(ptx *ProtoTransaction) Transaction {
 		Value:           ptx.Value,
 		TransactionFees: ptx.TransactionFees,
 		Chain:           make([]byte, len(ptx.Chain)),
+		ValidAfter:      ptx.ValidAfter,
+		ValidUntil:      ptx.ValidUntil,
 	}
 
 	copy(tx.Hash, ptx.Hash)
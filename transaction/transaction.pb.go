@@ -2,7 +2,7 @@
 // versions:
 // 	protoc-gen-go v1.28.1
 // 	protoc        v3.17.3
-// source: internal/transaction/transaction.proto
+// source: transaction/transaction.proto
 
 package transaction
 
@@ -30,6 +30,8 @@ const (
 	DataType_UPDATE_NODE                       DataType = 3
 	DataType_DATA_CONTRACT                     DataType = 4
 	DataType_DATA_CONTRACT_RELEASE_HOSTER_FEES DataType = 5
+	DataType_DOUBLE_SIGN_EVIDENCE              DataType = 6
+	DataType_BATCH_TRANSFER                    DataType = 7
 )
 
 // Enum value maps for DataType.
@@ -41,6 +43,8 @@ var (
 		3: "UPDATE_NODE",
 		4: "DATA_CONTRACT",
 		5: "DATA_CONTRACT_RELEASE_HOSTER_FEES",
+		6: "DOUBLE_SIGN_EVIDENCE",
+		7: "BATCH_TRANSFER",
 	}
 	DataType_value = map[string]int32{
 		"UNKNOWN":                           0,
@@ -49,6 +53,8 @@ var (
 		"UPDATE_NODE":                       3,
 		"DATA_CONTRACT":                     4,
 		"DATA_CONTRACT_RELEASE_HOSTER_FEES": 5,
+		"DOUBLE_SIGN_EVIDENCE":              6,
+		"BATCH_TRANSFER":                    7,
 	}
 )
 
@@ -63,11 +69,11 @@ func (x DataType) String() string {
 }
 
 func (DataType) Descriptor() protoreflect.EnumDescriptor {
-	return file_internal_transaction_transaction_proto_enumTypes[0].Descriptor()
+	return file_transaction_transaction_proto_enumTypes[0].Descriptor()
 }
 
 func (DataType) Type() protoreflect.EnumType {
-	return &file_internal_transaction_transaction_proto_enumTypes[0]
+	return &file_transaction_transaction_proto_enumTypes[0]
 }
 
 func (x DataType) Number() protoreflect.EnumNumber {
@@ -76,7 +82,7 @@ func (x DataType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use DataType.Descriptor instead.
 func (DataType) EnumDescriptor() ([]byte, []int) {
-	return file_internal_transaction_transaction_proto_rawDescGZIP(), []int{0}
+	return file_transaction_transaction_proto_rawDescGZIP(), []int{0}
 }
 
 // ProtoTransaction is the proto representation of a transaction.
@@ -105,12 +111,16 @@ type ProtoTransaction struct {
 	TransactionFees string `protobuf:"bytes,9,opt,name=transaction_fees,json=transactionFees,proto3" json:"transaction_fees,omitempty"`
 	// chain represents the network chain.
 	Chain []byte `protobuf:"bytes,10,opt,name=chain,proto3" json:"chain,omitempty"`
+	// valid_after is the block number after which the transaction can be included in a block, zero if not set.
+	ValidAfter uint64 `protobuf:"varint,11,opt,name=valid_after,json=validAfter,proto3" json:"valid_after,omitempty"`
+	// valid_until is the last block number the transaction can be included in, zero if not set.
+	ValidUntil uint64 `protobuf:"varint,12,opt,name=valid_until,json=validUntil,proto3" json:"valid_until,omitempty"`
 }
 
 func (x *ProtoTransaction) Reset() {
 	*x = ProtoTransaction{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_transaction_transaction_proto_msgTypes[0]
+		mi := &file_transaction_transaction_proto_msgTypes[0]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -123,7 +133,7 @@ func (x *ProtoTransaction) String() string {
 func (*ProtoTransaction) ProtoMessage() {}
 
 func (x *ProtoTransaction) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_transaction_transaction_proto_msgTypes[0]
+	mi := &file_transaction_transaction_proto_msgTypes[0]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -136,7 +146,7 @@ func (x *ProtoTransaction) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProtoTransaction.ProtoReflect.Descriptor instead.
 func (*ProtoTransaction) Descriptor() ([]byte, []int) {
-	return file_internal_transaction_transaction_proto_rawDescGZIP(), []int{0}
+	return file_transaction_transaction_proto_rawDescGZIP(), []int{0}
 }
 
 func (x *ProtoTransaction) GetHash() []byte {
@@ -209,6 +219,20 @@ func (x *ProtoTransaction) GetChain() []byte {
 	return nil
 }
 
+func (x *ProtoTransaction) GetValidAfter() uint64 {
+	if x != nil {
+		return x.ValidAfter
+	}
+	return 0
+}
+
+func (x *ProtoTransaction) GetValidUntil() uint64 {
+	if x != nil {
+		return x.ValidUntil
+	}
+	return 0
+}
+
 // DataPayload is the transaction data payload.
 type DataPayload struct {
 	state         protoimpl.MessageState
@@ -224,7 +248,7 @@ type DataPayload struct {
 func (x *DataPayload) Reset() {
 	*x = DataPayload{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_transaction_transaction_proto_msgTypes[1]
+		mi := &file_transaction_transaction_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -237,7 +261,7 @@ func (x *DataPayload) String() string {
 func (*DataPayload) ProtoMessage() {}
 
 func (x *DataPayload) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_transaction_transaction_proto_msgTypes[1]
+	mi := &file_transaction_transaction_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -250,7 +274,7 @@ func (x *DataPayload) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DataPayload.ProtoReflect.Descriptor instead.
 func (*DataPayload) Descriptor() ([]byte, []int) {
-	return file_internal_transaction_transaction_proto_rawDescGZIP(), []int{1}
+	return file_transaction_transaction_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *DataPayload) GetType() DataType {
@@ -267,85 +291,332 @@ func (x *DataPayload) GetPayload() []byte {
 	return nil
 }
 
-var File_internal_transaction_transaction_proto protoreflect.FileDescriptor
-
-var file_internal_transaction_transaction_proto_rawDesc = []byte{
-	0x0a, 0x26, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x74, 0x72, 0x61, 0x6e, 0x73,
-	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x8a, 0x02, 0x0a, 0x10, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x54,
-	0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61,
-	0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x1c,
-	0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x1d, 0x0a, 0x0a,
-	0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x6e,
-	0x6f, 0x75, 0x6e, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x6e, 0x6f, 0x75,
-	0x6e, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18,
-	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74,
-	0x6f, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x12, 0x29, 0x0a, 0x10, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x5f, 0x66, 0x65, 0x65, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x74, 0x72, 0x61,
-	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x65, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05,
-	0x63, 0x68, 0x61, 0x69, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x63, 0x68, 0x61,
-	0x69, 0x6e, 0x22, 0x52, 0x0a, 0x0b, 0x44, 0x61, 0x74, 0x61, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61,
-	0x64, 0x12, 0x29, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32,
-	0x15, 0x2e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x44, 0x61,
-	0x74, 0x61, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07,
-	0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x70,
-	0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2a, 0x93, 0x01, 0x0a, 0x08, 0x44, 0x61, 0x74, 0x61, 0x54,
-	0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00,
-	0x12, 0x1e, 0x0a, 0x1a, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x5f, 0x42, 0x4c, 0x4f, 0x43, 0x4b,
-	0x43, 0x48, 0x41, 0x49, 0x4e, 0x5f, 0x53, 0x45, 0x54, 0x54, 0x49, 0x4e, 0x47, 0x53, 0x10, 0x01,
-	0x12, 0x0f, 0x0a, 0x0b, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x5f, 0x4e, 0x4f, 0x44, 0x45, 0x10,
-	0x02, 0x12, 0x0f, 0x0a, 0x0b, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x5f, 0x4e, 0x4f, 0x44, 0x45,
-	0x10, 0x03, 0x12, 0x11, 0x0a, 0x0d, 0x44, 0x41, 0x54, 0x41, 0x5f, 0x43, 0x4f, 0x4e, 0x54, 0x52,
-	0x41, 0x43, 0x54, 0x10, 0x04, 0x12, 0x25, 0x0a, 0x21, 0x44, 0x41, 0x54, 0x41, 0x5f, 0x43, 0x4f,
-	0x4e, 0x54, 0x52, 0x41, 0x43, 0x54, 0x5f, 0x52, 0x45, 0x4c, 0x45, 0x41, 0x53, 0x45, 0x5f, 0x48,
-	0x4f, 0x53, 0x54, 0x45, 0x52, 0x5f, 0x46, 0x45, 0x45, 0x53, 0x10, 0x05, 0x42, 0x37, 0x5a, 0x35,
-	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x66, 0x69, 0x6c, 0x65, 0x66,
-	0x69, 0x6c, 0x65, 0x67, 0x6f, 0x2f, 0x66, 0x69, 0x6c, 0x65, 0x66, 0x69, 0x6c, 0x65, 0x67, 0x6f,
-	0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+// PartialSignatureProto is a signature of one of the keys of a multi-signature account.
+type PartialSignatureProto struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// public_key of the signer.
+	PublicKey []byte `protobuf:"bytes,1,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	// signature of the transaction hash.
+	Signature []byte `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (x *PartialSignatureProto) Reset() {
+	*x = PartialSignatureProto{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_transaction_transaction_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PartialSignatureProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PartialSignatureProto) ProtoMessage() {}
+
+func (x *PartialSignatureProto) ProtoReflect() protoreflect.Message {
+	mi := &file_transaction_transaction_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PartialSignatureProto.ProtoReflect.Descriptor instead.
+func (*PartialSignatureProto) Descriptor() ([]byte, []int) {
+	return file_transaction_transaction_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PartialSignatureProto) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+func (x *PartialSignatureProto) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+// MultiSignatureProto is the signature of a multi-signature transaction.
+type MultiSignatureProto struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// signatures are the partial signatures ordered by the account keys.
+	Signatures []*PartialSignatureProto `protobuf:"bytes,1,rep,name=signatures,proto3" json:"signatures,omitempty"`
+}
+
+func (x *MultiSignatureProto) Reset() {
+	*x = MultiSignatureProto{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_transaction_transaction_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MultiSignatureProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MultiSignatureProto) ProtoMessage() {}
+
+func (x *MultiSignatureProto) ProtoReflect() protoreflect.Message {
+	mi := &file_transaction_transaction_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MultiSignatureProto.ProtoReflect.Descriptor instead.
+func (*MultiSignatureProto) Descriptor() ([]byte, []int) {
+	return file_transaction_transaction_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *MultiSignatureProto) GetSignatures() []*PartialSignatureProto {
+	if x != nil {
+		return x.Signatures
+	}
+	return nil
+}
+
+// BatchTransferProto is the payload of a batch transfer transaction.
+type BatchTransferProto struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// transfers are applied together, if one of them fails none is applied.
+	Transfers []*BatchTransferItemProto `protobuf:"bytes,1,rep,name=transfers,proto3" json:"transfers,omitempty"`
+}
+
+func (x *BatchTransferProto) Reset() {
+	*x = BatchTransferProto{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_transaction_transaction_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchTransferProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchTransferProto) ProtoMessage() {}
+
+func (x *BatchTransferProto) ProtoReflect() protoreflect.Message {
+	mi := &file_transaction_transaction_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchTransferProto.ProtoReflect.Descriptor instead.
+func (*BatchTransferProto) Descriptor() ([]byte, []int) {
+	return file_transaction_transaction_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BatchTransferProto) GetTransfers() []*BatchTransferItemProto {
+	if x != nil {
+		return x.Transfers
+	}
+	return nil
+}
+
+// BatchTransferItemProto is a transfer to one recipient of a batch transfer.
+type BatchTransferItemProto struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// to is the recipient address.
+	To string `protobuf:"bytes,1,opt,name=to,proto3" json:"to,omitempty"`
+	// value is the hex encoded amount.
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *BatchTransferItemProto) Reset() {
+	*x = BatchTransferItemProto{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_transaction_transaction_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchTransferItemProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchTransferItemProto) ProtoMessage() {}
+
+func (x *BatchTransferItemProto) ProtoReflect() protoreflect.Message {
+	mi := &file_transaction_transaction_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchTransferItemProto.ProtoReflect.Descriptor instead.
+func (*BatchTransferItemProto) Descriptor() ([]byte, []int) {
+	return file_transaction_transaction_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BatchTransferItemProto) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *BatchTransferItemProto) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+var File_transaction_transaction_proto protoreflect.FileDescriptor
+
+var file_transaction_transaction_proto_rawDesc = []byte{
+	0x0a, 0x1d, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x74, 0x72,
+	0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xcc, 0x02, 0x0a,
+	0x10, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65,
+	0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b,
+	0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x06, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61,
+	0x74, 0x61, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x12,
+	0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x72,
+	0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x74, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x29, 0x0a, 0x10, 0x74, 0x72, 0x61, 0x6e,
+	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x66, 0x65, 0x65, 0x73, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x46,
+	0x65, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x18, 0x0a, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x05, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x76, 0x61, 0x6c,
+	0x69, 0x64, 0x5f, 0x61, 0x66, 0x74, 0x65, 0x72, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a,
+	0x76, 0x61, 0x6c, 0x69, 0x64, 0x41, 0x66, 0x74, 0x65, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x76, 0x61,
+	0x6c, 0x69, 0x64, 0x5f, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0a, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x55, 0x6e, 0x74, 0x69, 0x6c, 0x22, 0x52, 0x0a, 0x0b, 0x44,
+	0x61, 0x74, 0x61, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x29, 0x0a, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x74, 0x72, 0x61, 0x6e, 0x73,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x54, 0x79, 0x70, 0x65, 0x52,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22,
+	0x54, 0x0a, 0x15, 0x50, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x75, 0x62, 0x6c,
+	0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x70, 0x75,
+	0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x59, 0x0a, 0x13, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x53, 0x69,
+	0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x42, 0x0a, 0x0a,
+	0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x22, 0x2e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x50,
+	0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x50,
+	0x72, 0x6f, 0x74, 0x6f, 0x52, 0x0a, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73,
+	0x22, 0x57, 0x0a, 0x12, 0x42, 0x61, 0x74, 0x63, 0x68, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65,
+	0x72, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x41, 0x0a, 0x09, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66,
+	0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x74, 0x72, 0x61, 0x6e,
+	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x54, 0x72, 0x61,
+	0x6e, 0x73, 0x66, 0x65, 0x72, 0x49, 0x74, 0x65, 0x6d, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x52, 0x09,
+	0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x73, 0x22, 0x3e, 0x0a, 0x16, 0x42, 0x61, 0x74,
+	0x63, 0x68, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x49, 0x74, 0x65, 0x6d, 0x50, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x74, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x2a, 0xc1, 0x01, 0x0a, 0x08, 0x44, 0x61,
+	0x74, 0x61, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57,
+	0x4e, 0x10, 0x00, 0x12, 0x1e, 0x0a, 0x1a, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x5f, 0x42, 0x4c,
+	0x4f, 0x43, 0x4b, 0x43, 0x48, 0x41, 0x49, 0x4e, 0x5f, 0x53, 0x45, 0x54, 0x54, 0x49, 0x4e, 0x47,
+	0x53, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x5f, 0x4e, 0x4f,
+	0x44, 0x45, 0x10, 0x02, 0x12, 0x0f, 0x0a, 0x0b, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x5f, 0x4e,
+	0x4f, 0x44, 0x45, 0x10, 0x03, 0x12, 0x11, 0x0a, 0x0d, 0x44, 0x41, 0x54, 0x41, 0x5f, 0x43, 0x4f,
+	0x4e, 0x54, 0x52, 0x41, 0x43, 0x54, 0x10, 0x04, 0x12, 0x25, 0x0a, 0x21, 0x44, 0x41, 0x54, 0x41,
+	0x5f, 0x43, 0x4f, 0x4e, 0x54, 0x52, 0x41, 0x43, 0x54, 0x5f, 0x52, 0x45, 0x4c, 0x45, 0x41, 0x53,
+	0x45, 0x5f, 0x48, 0x4f, 0x53, 0x54, 0x45, 0x52, 0x5f, 0x46, 0x45, 0x45, 0x53, 0x10, 0x05, 0x12,
+	0x18, 0x0a, 0x14, 0x44, 0x4f, 0x55, 0x42, 0x4c, 0x45, 0x5f, 0x53, 0x49, 0x47, 0x4e, 0x5f, 0x45,
+	0x56, 0x49, 0x44, 0x45, 0x4e, 0x43, 0x45, 0x10, 0x06, 0x12, 0x12, 0x0a, 0x0e, 0x42, 0x41, 0x54,
+	0x43, 0x48, 0x5f, 0x54, 0x52, 0x41, 0x4e, 0x53, 0x46, 0x45, 0x52, 0x10, 0x07, 0x42, 0x2e, 0x5a,
+	0x2c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x66, 0x69, 0x6c, 0x65,
+	0x66, 0x69, 0x6c, 0x65, 0x67, 0x6f, 0x2f, 0x66, 0x69, 0x6c, 0x65, 0x66, 0x69, 0x6c, 0x65, 0x67,
+	0x6f, 0x2f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
-	file_internal_transaction_transaction_proto_rawDescOnce sync.Once
-	file_internal_transaction_transaction_proto_rawDescData = file_internal_transaction_transaction_proto_rawDesc
+	file_transaction_transaction_proto_rawDescOnce sync.Once
+	file_transaction_transaction_proto_rawDescData = file_transaction_transaction_proto_rawDesc
 )
 
-func file_internal_transaction_transaction_proto_rawDescGZIP() []byte {
-	file_internal_transaction_transaction_proto_rawDescOnce.Do(func() {
-		file_internal_transaction_transaction_proto_rawDescData = protoimpl.X.CompressGZIP(file_internal_transaction_transaction_proto_rawDescData)
+func file_transaction_transaction_proto_rawDescGZIP() []byte {
+	file_transaction_transaction_proto_rawDescOnce.Do(func() {
+		file_transaction_transaction_proto_rawDescData = protoimpl.X.CompressGZIP(file_transaction_transaction_proto_rawDescData)
 	})
-	return file_internal_transaction_transaction_proto_rawDescData
+	return file_transaction_transaction_proto_rawDescData
 }
 
-var file_internal_transaction_transaction_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_internal_transaction_transaction_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
-var file_internal_transaction_transaction_proto_goTypes = []interface{}{
-	(DataType)(0),            // 0: transaction.DataType
-	(*ProtoTransaction)(nil), // 1: transaction.ProtoTransaction
-	(*DataPayload)(nil),      // 2: transaction.DataPayload
+var file_transaction_transaction_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_transaction_transaction_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_transaction_transaction_proto_goTypes = []interface{}{
+	(DataType)(0),                  // 0: transaction.DataType
+	(*ProtoTransaction)(nil),       // 1: transaction.ProtoTransaction
+	(*DataPayload)(nil),            // 2: transaction.DataPayload
+	(*PartialSignatureProto)(nil),  // 3: transaction.PartialSignatureProto
+	(*MultiSignatureProto)(nil),    // 4: transaction.MultiSignatureProto
+	(*BatchTransferProto)(nil),     // 5: transaction.BatchTransferProto
+	(*BatchTransferItemProto)(nil), // 6: transaction.BatchTransferItemProto
 }
-var file_internal_transaction_transaction_proto_depIdxs = []int32{
+var file_transaction_transaction_proto_depIdxs = []int32{
 	0, // 0: transaction.DataPayload.type:type_name -> transaction.DataType
-	1, // [1:1] is the sub-list for method output_type
-	1, // [1:1] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
-}
-
-func init() { file_internal_transaction_transaction_proto_init() }
-func file_internal_transaction_transaction_proto_init() {
-	if File_internal_transaction_transaction_proto != nil {
+	3, // 1: transaction.MultiSignatureProto.signatures:type_name -> transaction.PartialSignatureProto
+	6, // 2: transaction.BatchTransferProto.transfers:type_name -> transaction.BatchTransferItemProto
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_transaction_transaction_proto_init() }
+func file_transaction_transaction_proto_init() {
+	if File_transaction_transaction_proto != nil {
 		return
 	}
 	if !protoimpl.UnsafeEnabled {
-		file_internal_transaction_transaction_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+		file_transaction_transaction_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ProtoTransaction); i {
 			case 0:
 				return &v.state
@@ -357,7 +628,7 @@ func file_internal_transaction_transaction_proto_init() {
 				return nil
 			}
 		}
-		file_internal_transaction_transaction_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+		file_transaction_transaction_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*DataPayload); i {
 			case 0:
 				return &v.state
@@ -369,24 +640,72 @@ func file_internal_transaction_transaction_proto_init() {
 				return nil
 			}
 		}
+		file_transaction_transaction_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PartialSignatureProto); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_transaction_transaction_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MultiSignatureProto); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_transaction_transaction_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchTransferProto); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_transaction_transaction_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchTransferItemProto); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: file_internal_transaction_transaction_proto_rawDesc,
+			RawDescriptor: file_transaction_transaction_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   2,
+			NumMessages:   6,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
-		GoTypes:           file_internal_transaction_transaction_proto_goTypes,
-		DependencyIndexes: file_internal_transaction_transaction_proto_depIdxs,
-		EnumInfos:         file_internal_transaction_transaction_proto_enumTypes,
-		MessageInfos:      file_internal_transaction_transaction_proto_msgTypes,
+		GoTypes:           file_transaction_transaction_proto_goTypes,
+		DependencyIndexes: file_transaction_transaction_proto_depIdxs,
+		EnumInfos:         file_transaction_transaction_proto_enumTypes,
+		MessageInfos:      file_transaction_transaction_proto_msgTypes,
 	}.Build()
-	File_internal_transaction_transaction_proto = out.File
-	file_internal_transaction_transaction_proto_rawDesc = nil
-	file_internal_transaction_transaction_proto_goTypes = nil
-	file_internal_transaction_transaction_proto_depIdxs = nil
+	File_transaction_transaction_proto = out.File
+	file_transaction_transaction_proto_rawDesc = nil
+	file_transaction_transaction_proto_goTypes = nil
+	file_transaction_transaction_proto_depIdxs = nil
 }
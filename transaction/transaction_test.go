@@ -227,6 +227,23 @@ func TestValidate(t *testing.T) {
 				return tx
 			},
 		},
+		"empty validity window": {
+			expErr: "valid after 5 should be smaller than valid until 5",
+			when: func() *Transaction {
+				tx := validTransaction(t)
+				tx.ValidAfter = 5
+				tx.ValidUntil = 5
+				return tx
+			},
+		},
+		"stripped validity window": {
+			expErr: "transaction is altered and doesn't match the hash",
+			when: func() *Transaction {
+				tx := validTransaction(t)
+				tx.ValidUntil = 5
+				return tx
+			},
+		},
 		"success": {
 			when: func() *Transaction {
 				tx := validTransaction(t)
@@ -251,6 +268,52 @@ func TestValidate(t *testing.T) {
 	}
 }
 
+func TestValidateValidityWindow(t *testing.T) {
+	t.Parallel()
+	cases := map[string]struct {
+		validAfter  uint64
+		validUntil  uint64
+		blockNumber uint64
+		expErr      string
+	}{
+		"no window at genesis": {
+			blockNumber: 0,
+		},
+		"before valid after": {
+			validAfter:  5,
+			blockNumber: 5,
+			expErr:      "transaction is not valid before block 6",
+		},
+		"after valid after": {
+			validAfter:  5,
+			blockNumber: 6,
+		},
+		"at valid until": {
+			validUntil:  5,
+			blockNumber: 5,
+		},
+		"after valid until": {
+			validUntil:  5,
+			blockNumber: 6,
+			expErr:      "transaction expired at block 5",
+		},
+	}
+
+	for name, tt := range cases {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			tx := Transaction{ValidAfter: tt.validAfter, ValidUntil: tt.validUntil}
+			err := tx.ValidateValidityWindow(tt.blockNumber)
+			if tt.expErr != "" {
+				assert.EqualError(t, err, tt.expErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestProtoTransactionFunctions(t *testing.T) {
 	tx := validTransaction(t)
 	assert.NotNil(t, tx)
@@ -276,6 +339,7 @@ func TestMarshalUnmarshalProtoTransaction(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, derivedTx)
 	equalTransactions(ptx, derivedTx, t)
+	assert.Equal(t, len(ptxData), tx.Size())
 }
 
 func TestEquals(t *testing.T) {
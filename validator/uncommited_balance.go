@@ -1,9 +1,11 @@
 // Package validator implements sealing and broadcasting blocks to the network.
 //
-// The block sealing process is to go through all the transactions in the mempool
-// and order them by nounce and later on construct an uncommited balance which will
+// The block sealing process is to go through the pending transactions of the mempool,
+// which are ordered by fees and nounce, and later on construct an uncommited balance which will
 // be used to check if a transaction has enough balance and allowed to change the
-// state of the blockchain.
+// state of the blockchain. The transactions with the highest fees per byte are included first
+// until the block size or transaction count limit is reached. The selected transactions are then
+// simulated against the current state and the failing ones are evicted from the mempool.
 package validator
 
 import (
@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"sort"
 
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/protobuf/proto"
@@ -14,8 +13,8 @@ import (
 	"github.com/filefilego/filefilego/common/hexutil"
 	ffgcrypto "github.com/filefilego/filefilego/crypto"
 	"github.com/filefilego/filefilego/node/protocols/messages"
+	"github.com/filefilego/filefilego/signer"
 	"github.com/filefilego/filefilego/transaction"
-	"github.com/libp2p/go-libp2p/core/crypto"
 )
 
 // NetworkMessagePublisher is a pub sub message broadcaster.
@@ -23,17 +22,48 @@ type NetworkMessagePublisher interface {
 	PublishMessageToNetwork(ctx context.Context, data []byte) error
 }
 
+// BuilderConfig represents the limits of the sealed blocks.
+type BuilderConfig struct {
+	// MaxBlockSize is the maximum size in bytes of the transactions of a block.
+	MaxBlockSize int
+	// MaxTransactions is the maximum number of transactions of a block including the coinbase transaction.
+	MaxTransactions int
+}
+
+// DefaultBuilderConfig returns the default block limits.
+func DefaultBuilderConfig() BuilderConfig {
+	return BuilderConfig{
+		MaxBlockSize:    2 * 1024 * 1024,
+		MaxTransactions: 5000,
+	}
+}
+
+// Validate checks the limits.
+func (c BuilderConfig) Validate() error {
+	if c.MaxBlockSize <= 0 {
+		return errors.New("max block size should be greater than zero")
+	}
+
+	if c.MaxTransactions <= 1 {
+		return errors.New("max transactions should be greater than one")
+	}
+	return nil
+}
+
 // Validator struct.
 type Validator struct {
-	node       NetworkMessagePublisher
-	blockchain blockchain.Interface
-	privateKey crypto.PrivKey
+	node          NetworkMessagePublisher
+	blockchain    blockchain.Interface
+	signer        signer.Signer
+	builderConfig BuilderConfig
 
-	address string
+	publicKey []byte
+	address   string
 }
 
 // New constructs a new validator.
-func New(node NetworkMessagePublisher, bchain blockchain.Interface, privateKey crypto.PrivKey) (*Validator, error) {
+// the blocks and messages of the validator are signed by the signer, which can keep the key outside of the process.
+func New(node NetworkMessagePublisher, bchain blockchain.Interface, blockSigner signer.Signer) (*Validator, error) {
 	if node == nil {
 		return nil, errors.New("node is nil")
 	}
@@ -42,11 +72,11 @@ func New(node NetworkMessagePublisher, bchain blockchain.Interface, privateKey c
 		return nil, errors.New("blockchain is nil")
 	}
 
-	if privateKey == nil {
-		return nil, errors.New("privateKey is nil")
+	if blockSigner == nil {
+		return nil, errors.New("signer is nil")
 	}
 
-	rawPubKey, err := privateKey.GetPublic().Raw()
+	rawPubKey, err := blockSigner.PublicKey()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get public key bytes: %w", err)
 	}
@@ -57,7 +87,10 @@ func New(node NetworkMessagePublisher, bchain blockchain.Interface, privateKey c
 	}
 
 	isVerifier := false
-	allVerifiers := block.GetBlockVerifiers()
+	allVerifiers, err := block.GetBlockVerifiers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get verifiers: %w", err)
+	}
 	for _, verifier := range allVerifiers {
 		if verifier.Address == verifierAddr {
 			isVerifier = true
@@ -70,17 +103,30 @@ func New(node NetworkMessagePublisher, bchain blockchain.Interface, privateKey c
 	}
 
 	return &Validator{
-		node:       node,
-		blockchain: bchain,
-		privateKey: privateKey,
-		address:    verifierAddr,
+		node:          node,
+		blockchain:    bchain,
+		signer:        blockSigner,
+		builderConfig: DefaultBuilderConfig(),
+		publicKey:     rawPubKey,
+		address:       verifierAddr,
 	}, nil
 }
 
-func (m *Validator) prepareMempoolTransactions() []transaction.Transaction {
+// SetBuilderConfig sets the limits of the sealed blocks.
+func (m *Validator) SetBuilderConfig(config BuilderConfig) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	m.builderConfig = config
+	return nil
+}
+
+// prepareMempoolTransactions selects the pending transactions with the highest fees per byte
+// which fit in the block after the reserved bytes of the coinbase transaction.
+func (m *Validator) prepareMempoolTransactions(reservedSize int) []transaction.Transaction {
 	balances := NewUncommitedBalance()
-	mempoolTransactions := m.blockchain.GetTransactionsFromPool()
-	mempoolTransactions = sortTransactionsByNounce(mempoolTransactions)
+	// the pending transactions are ordered by fees per byte and the transactions of an address are in nounce order
+	mempoolTransactions := m.blockchain.GetPendingTransactionsFromPool()
 
 	// set the uncommited balances of addresses
 	for _, tx := range mempoolTransactions {
@@ -117,17 +163,41 @@ func (m *Validator) prepareMempoolTransactions() []transaction.Transaction {
 		balances.InitializeBalanceAndNounceFor(tx.From, balanceFrom, nounceFrom)
 	}
 
-	// we have the balances, go through the transactions again and see which are allowed
+	// the coinbase transaction is always part of the block
+	remainingSize := m.builderConfig.MaxBlockSize - reservedSize
+	remainingTransactions := m.builderConfig.MaxTransactions - 1
+
+	blockNumber := m.blockchain.GetHeight() + 1
+
+	// we have the balances, take the transactions in the order of the mempool.
+	// once a transaction of a sender is skipped, the next ones have a nounce gap and are skipped too.
+	skippedSenders := make(map[string]struct{})
 	validatedTransaction := make([]transaction.Transaction, 0)
 	for _, tx := range mempoolTransactions {
+		if remainingTransactions == 0 {
+			break
+		}
+
+		if _, ok := skippedSenders[tx.From]; ok {
+			continue
+		}
+
+		size := tx.Size()
 		amount, err := hexutil.DecodeBig(tx.Value)
-		if err != nil {
+		if err != nil || size > remainingSize || tx.ValidateValidityWindow(blockNumber) != nil {
+			skippedSenders[tx.From] = struct{}{}
 			continue
 		}
+
 		ok := balances.Subtract(tx.From, amount, hexutil.DecodeBigFromBytesToUint64(tx.Nounce))
-		if ok {
-			validatedTransaction = append(validatedTransaction, tx)
+		if !ok {
+			skippedSenders[tx.From] = struct{}{}
+			continue
 		}
+
+		validatedTransaction = append(validatedTransaction, tx)
+		remainingSize -= size
+		remainingTransactions--
 	}
 
 	return validatedTransaction
@@ -139,19 +209,14 @@ func (m *Validator) getCoinbaseTX() (*transaction.Transaction, error) {
 		return nil, fmt.Errorf("failed to decode chainID: %w", err)
 	}
 
-	publicKeyBytes, err := m.privateKey.GetPublic().Raw()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get public key bytes: %w", err)
-	}
-
-	currentBlockHeight := m.blockchain.GetHeight()
-	blockReward, err := block.GetReward(currentBlockHeight + 1)
+	blockNumber := m.blockchain.GetHeight() + 1
+	blockReward, err := block.GetReward(blockNumber)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get block reward: %w", err)
 	}
 
 	coinbaseTx := transaction.Transaction{
-		PublicKey:       make([]byte, len(publicKeyBytes)),
+		PublicKey:       make([]byte, len(m.publicKey)),
 		Nounce:          []byte{0},
 		From:            m.address,
 		To:              m.address,
@@ -159,9 +224,9 @@ func (m *Validator) getCoinbaseTX() (*transaction.Transaction, error) {
 		TransactionFees: "0x0",
 		Chain:           mainChain,
 	}
-	copy(coinbaseTx.PublicKey, publicKeyBytes)
+	copy(coinbaseTx.PublicKey, m.publicKey)
 
-	err = coinbaseTx.Sign(m.privateKey)
+	err = m.signer.SignCoinbaseTransaction(&coinbaseTx, blockNumber)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign coinbase transaction: %w", err)
 	}
@@ -184,13 +249,76 @@ func (m *Validator) BroadcastBlock(ctx context.Context, validBlock *block.Block)
 	return nil
 }
 
+// AttestCheckpoint signs and records an attestation of the latest checkpoint of the chain which isn't final yet.
+// it returns nil if there is no checkpoint to attest.
+func (m *Validator) AttestCheckpoint() (*messages.CheckpointAttestationProto, error) {
+	height := m.blockchain.GetHeight()
+	checkpoint := height - height%blockchain.CheckpointInterval
+	if m.blockchain.IsFinalized(checkpoint) {
+		return nil, nil
+	}
+
+	checkpointBlock, err := m.blockchain.GetBlockByNumber(checkpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get checkpoint block: %w", err)
+	}
+
+	sig, err := m.signer.SignCheckpoint(checkpoint, checkpointBlock.Hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign checkpoint: %w", err)
+	}
+
+	attestation := &messages.CheckpointAttestationProto{
+		BlockNumber: checkpoint,
+		BlockHash:   checkpointBlock.Hash,
+		PublicKey:   m.publicKey,
+		Signature:   sig,
+	}
+	_, err = m.blockchain.AddCheckpointAttestation(attestation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add checkpoint attestation: %w", err)
+	}
+	return attestation, nil
+}
+
+// BroadcastCheckpointAttestation broadcasts a checkpoint attestation to the network.
+func (m *Validator) BroadcastCheckpointAttestation(ctx context.Context, attestation *messages.CheckpointAttestationProto) error {
+	payload := messages.GossipPayload{
+		Message: &messages.GossipPayload_CheckpointAttestation{CheckpointAttestation: attestation},
+	}
+	data, err := proto.Marshal(&payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal checkpoint attestation: %w", err)
+	}
+	err = m.node.PublishMessageToNetwork(ctx, data)
+	if err != nil {
+		return fmt.Errorf("failed to publish checkpoint attestation to the network: %w", err)
+	}
+	return nil
+}
+
+// NextSealingTime returns the earliest timestamp at which the validator can seal the next block.
+// the in-turn verifier can seal after the schedule period and the others wait for their back-off delay.
+func (m *Validator) NextSealingTime() (int64, error) {
+	lastBlock, err := m.blockchain.GetBlockByHash(m.blockchain.GetLastBlockHash())
+	if err != nil {
+		return 0, fmt.Errorf("failed to get last block: %w", err)
+	}
+
+	delay, err := block.SealingDelay(m.address, lastBlock.Number+1)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get sealing delay: %w", err)
+	}
+	return lastBlock.Timestamp + delay, nil
+}
+
 // SealBlock seals a block.
 func (m *Validator) SealBlock(timestamp int64) (*block.Block, error) {
 	coinbaseTX, err := m.getCoinbaseTX()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get coinbase transaction: %w", err)
 	}
-	mempoolTransactions := m.prepareMempoolTransactions()
+	mempoolTransactions := m.prepareMempoolTransactions(coinbaseTX.Size())
 	mempoolTransactions = prependTransaction(mempoolTransactions, *coinbaseTX)
 
 	lastBlockHash := m.blockchain.GetLastBlockHash()
@@ -198,39 +326,81 @@ func (m *Validator) SealBlock(timestamp int64) (*block.Block, error) {
 		return nil, errors.New("failed to get last block hash from db")
 	}
 
+	blockNumber := m.blockchain.GetHeight() + 1
+	isVerifier, err := block.IsValidVerifier(m.address, blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check verifier: %w", err)
+	}
+
+	if !isVerifier {
+		return nil, fmt.Errorf("validator is not an active verifier at block %d", blockNumber)
+	}
+
+	previousBlock, err := m.blockchain.GetBlockByHash(lastBlockHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last block: %w", err)
+	}
+
+	// the signer refuses to sign another block at the same height, so the block is validated before signing.
+	if timestamp < previousBlock.Timestamp {
+		return nil, fmt.Errorf("previous block timestamp %d is bigger than the current block %d", previousBlock.Timestamp, timestamp)
+	}
+
+	delay, err := block.SealingDelay(m.address, blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sealing delay: %w", err)
+	}
+
+	if timestamp < previousBlock.Timestamp+delay {
+		return nil, fmt.Errorf("block %d can't be sealed %d seconds before its turn", blockNumber, previousBlock.Timestamp+delay-timestamp)
+	}
+
 	block := block.Block{
 		Timestamp:         timestamp,
 		PreviousBlockHash: lastBlockHash,
 		Transactions:      mempoolTransactions,
-		Number:            m.blockchain.GetHeight() + 1,
+		Number:            blockNumber,
 	}
 
-	err = block.Sign(m.privateKey)
+	// only the transactions which succeed against the current state are sealed
+	simulation, err := m.blockchain.SimulateBlock(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to simulate block: %w", err)
+	}
+	m.evictRejectedTransactions(simulation.Rejected)
+	block.Transactions = simulation.Transactions
+	block.StateRoot = simulation.StateRoot
+
+	err = m.signer.SignBlock(&block)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign block: %w", err)
 	}
 
 	err = m.blockchain.PerformStateUpdateFromBlock(block)
 	if err != nil {
+		// the block was never published, so the signer can sign another block at its height.
+		if discardErr := m.signer.DiscardBlock(&block); discardErr != nil {
+			log.Warnf("failed to discard block %d in the signer: %v", block.Number, discardErr)
+		}
 		return nil, fmt.Errorf("failed to update blockchain: %w", err)
 	}
 
 	return &block, nil
 }
 
+// evictRejectedTransactions removes the transactions which failed during the block simulation from the mempool.
+func (m *Validator) evictRejectedTransactions(rejected []blockchain.RejectedTransaction) {
+	for _, r := range rejected {
+		log.Warnf("evicting transaction %s from mempool: %v", hexutil.Encode(r.Transaction.Hash), r.Reason)
+		if err := m.blockchain.DeleteFromMemPool(r.Transaction); err != nil {
+			log.Warnf("failed to delete transaction from mempool: %v", err)
+		}
+	}
+}
+
 func prependTransaction(x []transaction.Transaction, y transaction.Transaction) []transaction.Transaction {
 	x = append(x, transaction.Transaction{})
 	copy(x[1:], x)
 	x[0] = y
 	return x
 }
-
-func sortTransactionsByNounce(transactions []transaction.Transaction) []transaction.Transaction {
-	sort.Slice(transactions, func(i, j int) bool {
-		first := hexutil.DecodeBigFromBytesToUint64(transactions[i].Nounce)
-		second := hexutil.DecodeBigFromBytesToUint64(transactions[j].Nounce)
-
-		return first < second
-	})
-	return transactions
-}
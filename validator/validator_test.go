@@ -1,6 +1,7 @@
 package validator
 
 import (
+	"errors"
 	"os"
 	"testing"
 	"time"
@@ -12,8 +13,8 @@ import (
 	"github.com/filefilego/filefilego/keystore"
 	"github.com/filefilego/filefilego/node"
 	"github.com/filefilego/filefilego/search"
+	"github.com/filefilego/filefilego/signer"
 	"github.com/filefilego/filefilego/transaction"
-	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/stretchr/testify/assert"
 	"github.com/syndtr/goleveldb/leveldb"
 )
@@ -28,10 +29,12 @@ func TestNew(t *testing.T) {
 		Address:   kp.Address,
 		PublicKey: hexutil.Encode(pubKeyBytes),
 	})
+	localSigner, err := signer.NewLocalSigner(kp.PrivateKey, "")
+	assert.NoError(t, err)
 	cases := map[string]struct {
 		node       NetworkMessagePublisher
 		blockchain blockchain.Interface
-		privateKey crypto.PrivKey
+		signer     signer.Signer
 		expErr     string
 	}{
 		"empty node": {
@@ -41,15 +44,15 @@ func TestNew(t *testing.T) {
 			node:   &node.Node{},
 			expErr: "blockchain is nil",
 		},
-		"empty privateKey": {
+		"empty signer": {
 			node:       &node.Node{},
 			blockchain: &blockchain.Blockchain{},
-			expErr:     "privateKey is nil",
+			expErr:     "signer is nil",
 		},
 		"success": {
 			node:       &node.Node{},
 			blockchain: &blockchain.Blockchain{},
-			privateKey: kp.PrivateKey,
+			signer:     localSigner,
 		},
 	}
 
@@ -57,7 +60,7 @@ func TestNew(t *testing.T) {
 		tt := tt
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
-			miner, err := New(tt.node, tt.blockchain, tt.privateKey)
+			miner, err := New(tt.node, tt.blockchain, tt.signer)
 			if tt.expErr != "" {
 				assert.Nil(t, miner)
 				assert.EqualError(t, err, tt.expErr)
@@ -96,7 +99,9 @@ func TestValidatorMethods(t *testing.T) {
 		Address:   kp.Address,
 		PublicKey: hexutil.Encode(pubKeyBytes),
 	})
-	miner, err := New(&node.Node{}, bchain, kp.PrivateKey)
+	localSigner, err := signer.NewLocalSigner(kp.PrivateKey, "")
+	assert.NoError(t, err)
+	miner, err := New(&node.Node{}, bchain, localSigner)
 	assert.NoError(t, err)
 	coinbaseTX, err := miner.getCoinbaseTX()
 	assert.NoError(t, err)
@@ -106,7 +111,7 @@ func TestValidatorMethods(t *testing.T) {
 	assert.True(t, ok)
 
 	// shouldn't have any transactions
-	transactions := miner.prepareMempoolTransactions()
+	transactions := miner.prepareMempoolTransactions(0)
 	assert.Len(t, transactions, 0)
 
 	chainID, err := hexutil.Decode(transaction.ChainID)
@@ -179,7 +184,17 @@ func TestValidatorMethods(t *testing.T) {
 	mempooltransactions := bchain.GetTransactionsFromPool()
 	assert.Len(t, mempooltransactions, 3)
 
-	preparedTransactions := miner.prepareMempoolTransactions()
+	// the block limits leave room for the coinbase and one transaction
+	assert.EqualError(t, miner.SetBuilderConfig(BuilderConfig{MaxBlockSize: 1, MaxTransactions: 1}), "max transactions should be greater than one")
+	assert.EqualError(t, miner.SetBuilderConfig(BuilderConfig{MaxTransactions: 2}), "max block size should be greater than zero")
+	assert.NoError(t, miner.SetBuilderConfig(BuilderConfig{MaxBlockSize: tx2.Size(), MaxTransactions: 2}))
+	preparedTransactions := miner.prepareMempoolTransactions(0)
+	assert.Len(t, preparedTransactions, 1)
+	assert.Equal(t, []byte{1}, preparedTransactions[0].Nounce)
+	assert.Len(t, miner.prepareMempoolTransactions(1), 0)
+
+	assert.NoError(t, miner.SetBuilderConfig(DefaultBuilderConfig()))
+	preparedTransactions = miner.prepareMempoolTransactions(0)
 	assert.Len(t, preparedTransactions, 2)
 
 	assert.Equal(t, []byte{1}, preparedTransactions[0].Nounce)
@@ -200,64 +215,85 @@ func TestValidatorMethods(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, uint64(2), bchain.GetHeight())
 
+	// the fees of the transaction are higher than the balance, so it's left out and evicted from the mempool
+	addrBytes, err := hexutil.Decode(kp.Address)
+	assert.NoError(t, err)
+	state, err := bchain.GetAddressState(addrBytes)
+	assert.NoError(t, err)
+	nounce, err := state.GetNounce()
+	assert.NoError(t, err)
+	txExpensive := transaction.Transaction{
+		PublicKey:       pubKeyBytes,
+		Nounce:          hexutil.EncodeUint64ToBytes(nounce + 1),
+		From:            kp.Address,
+		To:              kp.Address,
+		Value:           "0x0",
+		TransactionFees: "0x22b1c8c1227a000000",
+		Chain:           chainID,
+	}
+	assert.NoError(t, txExpensive.Sign(kp.PrivateKey))
+	assert.NoError(t, bchain.PutMemPool(txExpensive))
+	assert.Len(t, miner.prepareMempoolTransactions(0), 1)
+
 	// one more
-	_, err = miner.SealBlock(time.Now().Unix())
+	sealedBlock, err := miner.SealBlock(time.Now().Unix())
 	assert.NoError(t, err)
 	assert.Equal(t, uint64(3), bchain.GetHeight())
+	assert.Len(t, sealedBlock.Transactions, 1)
+	assert.Len(t, bchain.GetTransactionsFromPool(), 1)
+	assert.Equal(t, []byte{9}, bchain.GetTransactionsFromPool()[0].Nounce)
 
 	// one more with past timestamp should give error
 	_, err = miner.SealBlock(time.Now().Unix() - 10)
-	assert.ErrorContains(t, err, "failed to update blockchain: previous block timestamp")
+	assert.ErrorContains(t, err, "previous block timestamp")
 	assert.Equal(t, uint64(3), bchain.GetHeight())
-}
 
-func TestSortTransactionsByNounce(t *testing.T) {
-	transactions := []transaction.Transaction{
-		{
-			From:   "0x1",
-			Nounce: []byte{1},
-		},
-		{
-			From:   "0x2",
-			Nounce: []byte{5},
-		},
-		{
-			From:   "0x1",
-			Nounce: []byte{3},
-		},
-		{
-			From:   "0x2",
-			Nounce: []byte{4},
-		},
-		{
-			From:   "0x1",
-			Nounce: []byte{2},
-		},
-	}
+	// a block which fails to apply is discarded in the signer, so another block can be sealed at its height
+	failingMiner, err := New(&node.Node{}, &failingBlockchain{Blockchain: bchain}, localSigner)
+	assert.NoError(t, err)
+	_, err = failingMiner.SealBlock(time.Now().Unix())
+	assert.EqualError(t, err, "failed to update blockchain: apply failed")
+	assert.Equal(t, uint64(3), bchain.GetHeight())
+	_, err = miner.SealBlock(time.Now().Unix() + 1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(4), bchain.GetHeight())
 
-	sorted := sortTransactionsByNounce(transactions)
-	assert.Len(t, sorted, 5)
-	assert.Equal(t, []byte{1}, sorted[0].Nounce)
-	assert.Equal(t, []byte{2}, sorted[1].Nounce)
-	assert.Equal(t, []byte{3}, sorted[2].Nounce)
-	assert.Equal(t, []byte{4}, sorted[3].Nounce)
-	assert.Equal(t, []byte{5}, sorted[4].Nounce)
+	// the latest checkpoint is the genesis block which is already final
+	attestation, err := miner.AttestCheckpoint()
+	assert.NoError(t, err)
+	assert.Nil(t, attestation)
 
-	transactions = []transaction.Transaction{
-		{
-			From:   "0x1",
-			Nounce: []byte{1},
-		},
-		{
-			From:   "0x1",
-			Nounce: []byte{5},
-		},
-	}
+	lastBlock, err := bchain.GetBlockByHash(bchain.GetLastBlockHash())
+	assert.NoError(t, err)
+
+	// without a schedule the next block can be sealed right away
+	sealingTime, err := miner.NextSealingTime()
+	assert.NoError(t, err)
+	assert.Equal(t, lastBlock.Timestamp, sealingTime)
+
+	// with a schedule the verifier waits for its turn
+	block.SetSchedule(block.Schedule{Period: 10 * time.Second, OutOfTurnDelay: 5 * time.Second})
+	t.Cleanup(func() {
+		block.SetSchedule(block.Schedule{})
+	})
+	delay, err := block.SealingDelay(kp.Address, lastBlock.Number+1)
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, delay, int64(10))
+	sealingTime, err = miner.NextSealingTime()
+	assert.NoError(t, err)
+	assert.Equal(t, lastBlock.Timestamp+delay, sealingTime)
+	_, err = miner.SealBlock(lastBlock.Timestamp + 1)
+	assert.ErrorContains(t, err, "seconds before its turn")
+	assert.Equal(t, uint64(4), bchain.GetHeight())
+}
+
+// failingBlockchain is a blockchain which fails to apply blocks.
+type failingBlockchain struct {
+	*blockchain.Blockchain
+}
 
-	sorted = sortTransactionsByNounce(transactions)
-	assert.Len(t, sorted, 2)
-	assert.Equal(t, []byte{1}, sorted[0].Nounce)
-	assert.Equal(t, []byte{5}, sorted[1].Nounce)
+func (f *failingBlockchain) PerformStateUpdateFromBlock(block.Block) error {
+	return errors.New("apply failed")
 }
 
 func TestPrependTransaction(t *testing.T) {